@@ -24,6 +24,7 @@ import (
 	"github.com/filecoin-project/venus/app/submodule/market"
 	"github.com/filecoin-project/venus/app/submodule/mining"
 	"github.com/filecoin-project/venus/app/submodule/mpool"
+	"github.com/filecoin-project/venus/app/submodule/multisig"
 	"github.com/filecoin-project/venus/app/submodule/paych"
 	"github.com/filecoin-project/venus/app/submodule/storagenetworking"
 	"github.com/filecoin-project/venus/app/submodule/syncer"
@@ -121,6 +122,9 @@ func (b *Builder) build(ctx context.Context) (*Node, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to build node.Network")
 	}
+	if err := nd.chain.StartArchivalFallback(ctx, nd.network.Host, nd.network.Bitswap); err != nil {
+		return nil, errors.Wrap(err, "failed to start archival fallback")
+	}
 
 	nd.blockservice, err = dagservice.NewDagserviceSubmodule(ctx, (*builder)(b), nd.network)
 	if err != nil {
@@ -132,7 +136,7 @@ func (b *Builder) build(ctx context.Context) (*Node, error) {
 		return nil, errors.Wrap(err, "failed to build node.Syncer")
 	}
 
-	nd.wallet, err = wallet.NewWalletSubmodule(ctx, b.repo, nd.configModule, nd.chain, b.walletPassword)
+	nd.wallet, err = wallet.NewWalletSubmodule(ctx, b.repo, nd.configModule, nd.chain, b.journal, b.walletPassword)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to build node.wallet")
 	}
@@ -163,6 +167,7 @@ func (b *Builder) build(ctx context.Context) (*Node, error) {
 		return nil, err
 	}
 	nd.market = market.NewMarketModule(nd.chain.API(), nd.syncer.Stmgr)
+	nd.multiSig = multisig.NewMultiSigSubmodule(nd.chain.API(), nd.syncer.Stmgr)
 
 	blockDelay := b.repo.Config().NetworkParams.BlockDelay
 	nd.common = common.NewCommonModule(nd.chain, nd.network, blockDelay)
@@ -179,6 +184,11 @@ func (b *Builder) build(ctx context.Context) (*Node, error) {
 		return nil, err
 	}
 
+	// the config changes the submodules apply without a restart
+	nd.configModule.Subscribe("mpool", nd.mpool.ApplyConfig, mpool.LiveConfigKeys...)
+	nd.configModule.Subscribe("network", nd.network.ApplyConfig, network.LiveConfigKeys...)
+	nd.configModule.Subscribe("wallet", nil, wallet.LiveConfigKeys...)
+
 	apiBuilder := NewBuilder()
 	apiBuilder.NameSpace("Filecoin")
 
@@ -194,6 +204,7 @@ func (b *Builder) build(ctx context.Context) (*Node, error) {
 		nd.mpool,
 		nd.paychan,
 		nd.market,
+		nd.multiSig,
 		nd.common,
 		nd.eth,
 		nd.actorEvent,
@@ -13,6 +13,7 @@ import (
 type Env struct {
 	ctx                  context.Context
 	InspectorAPI         IInspector
+	ConfigAPI            v1api.IConfig
 	BlockStoreAPI        v1api.IBlockStore
 	ChainAPI             v1api.IChain
 	NetworkAPI           v1api.INetwork
@@ -22,11 +23,12 @@ type Env struct {
 	MingingAPI           v1api.IMining
 	MessagePoolAPI       v1api.IMessagePool
 
-	MarketAPI v1api.IMarket
-	PaychAPI  v1api.IPaychan
-	CommonAPI v1api.ICommon
-	EthAPI    v1api.IETH
-	F3API     v1api.IF3
+	MarketAPI   v1api.IMarket
+	PaychAPI    v1api.IPaychan
+	MultiSigAPI v1api.IMultiSig
+	CommonAPI   v1api.ICommon
+	EthAPI      v1api.IETH
+	F3API       v1api.IF3
 }
 
 var _ cmds.Environment = (*Env)(nil)
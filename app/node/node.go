@@ -22,6 +22,7 @@ import (
 	"github.com/filecoin-project/venus/app/submodule/market"
 	"github.com/filecoin-project/venus/app/submodule/mining"
 	"github.com/filecoin-project/venus/app/submodule/mpool"
+	"github.com/filecoin-project/venus/app/submodule/multisig"
 	network2 "github.com/filecoin-project/venus/app/submodule/network"
 	"github.com/filecoin-project/venus/app/submodule/paych"
 	"github.com/filecoin-project/venus/app/submodule/storagenetworking"
@@ -34,7 +35,9 @@ import (
 	_ "github.com/filecoin-project/venus/pkg/crypto/secp"      // enable secp signatures
 	metricsPKG "github.com/filecoin-project/venus/pkg/metrics"
 	"github.com/filecoin-project/venus/pkg/repo"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
 	"github.com/ipfs-force-community/metrics"
+	"github.com/ipfs-force-community/sophon-auth/core"
 	"github.com/ipfs-force-community/sophon-auth/jwtclient"
 	cmds "github.com/ipfs/go-ipfs-cmds"
 	cmdhttp "github.com/ipfs/go-ipfs-cmds/http"
@@ -94,9 +97,10 @@ type Node struct {
 	storageNetworking *storagenetworking.StorageNetworkingSubmodule
 	f3                *f3.F3Submodule
 
-	// paychannel and market
-	market  *market.MarketSubmodule
-	paychan *paych.PaychSubmodule
+	// paychannel, market and multisig
+	market   *market.MarketSubmodule
+	paychan  *paych.PaychSubmodule
+	multiSig *multisig.MultiSigSubmodule
 
 	common *common.CommonModule
 
@@ -366,14 +370,29 @@ func (node *Node) runRestfulAPI(ctx context.Context, handler *http.ServeMux, roo
 func (node *Node) runJsonrpcAPI(_ context.Context, handler *http.ServeMux) error { // nolint
 	handler.Handle("/rpc/v0", node.jsonRPCService)
 	handler.Handle("/rpc/v1", node.jsonRPCServiceV1)
+	handler.Handle(blockstoreutil.NetBstoreWSPath, node.blockstore.StreamHandler())
+	// the snapshot holds the whole chain, only admins may download it
+	handler.Handle("/chain/snapshot", requirePerm(core.PermAdmin, node.chain.SnapshotHandler()))
 	return nil
 }
 
+// requirePerm rejects the requests whose token doesn't grant perm, the token is verified by the AuthMux
+func requirePerm(perm core.Permission, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !core.HasPerm(r.Context(), []core.Permission{core.PermRead}, perm) {
+			http.Error(w, fmt.Sprintf("missing permission, need '%s'", perm), http.StatusForbidden)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
 // createServerEnv create server for cmd server env
 func (node *Node) createServerEnv(ctx context.Context) *Env {
 	env := Env{
 		ctx:                  ctx,
 		InspectorAPI:         NewInspectorAPI(node.repo),
+		ConfigAPI:            node.configModule.API(),
 		BlockStoreAPI:        node.blockstore.API(),
 		ChainAPI:             node.chain.API(),
 		NetworkAPI:           node.network.API(),
@@ -384,6 +403,7 @@ func (node *Node) createServerEnv(ctx context.Context) *Env {
 		MessagePoolAPI:       node.mpool.API(),
 		PaychAPI:             node.paychan.API(),
 		MarketAPI:            node.market.API(),
+		MultiSigAPI:          node.multiSig.API(),
 		CommonAPI:            node.common,
 		EthAPI:               node.eth.API(),
 		F3API:                node.f3.API(),
@@ -8,6 +8,7 @@ import (
 	"github.com/filecoin-project/venus/app/submodule/actorevent"
 	"github.com/filecoin-project/venus/app/submodule/eth"
 	"github.com/filecoin-project/venus/app/submodule/f3"
+	"github.com/filecoin-project/venus/app/submodule/multisig"
 	v0api "github.com/filecoin-project/venus/venus-shared/api/chain/v0"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/api/permission"
@@ -45,6 +46,7 @@ var skipList = []reflect.Type{
 	reflect.TypeOf(&eth.EthSubModule{}).Elem(),
 	reflect.TypeOf(&actorevent.ActorEventSubModule{}).Elem(),
 	reflect.TypeOf(&f3.F3Submodule{}).Elem(),
+	reflect.TypeOf(&multisig.MultiSigSubmodule{}).Elem(),
 }
 
 func skipV0API(in interface{}) bool {
@@ -199,6 +201,7 @@ func aliasETHAPI(rpcServer *jsonrpc.RPCServer) {
 	rpcServer.AliasMethod("eth_getCode", "Filecoin.EthGetCode")
 	rpcServer.AliasMethod("eth_getStorageAt", "Filecoin.EthGetStorageAt")
 	rpcServer.AliasMethod("eth_getBalance", "Filecoin.EthGetBalance")
+	rpcServer.AliasMethod("eth_getProof", "Filecoin.EthGetProof")
 	rpcServer.AliasMethod("eth_chainId", "Filecoin.EthChainId")
 	rpcServer.AliasMethod("eth_syncing", "Filecoin.EthSyncing")
 	rpcServer.AliasMethod("eth_feeHistory", "Filecoin.EthFeeHistory")
@@ -208,6 +211,7 @@ func aliasETHAPI(rpcServer *jsonrpc.RPCServer) {
 	rpcServer.AliasMethod("eth_sendRawTransaction", "Filecoin.EthSendRawTransaction")
 	rpcServer.AliasMethod("eth_estimateGas", "Filecoin.EthEstimateGas")
 	rpcServer.AliasMethod("eth_call", "Filecoin.EthCall")
+	rpcServer.AliasMethod("eth_simulateV1", "Filecoin.EthSimulateV1")
 
 	rpcServer.AliasMethod("eth_getLogs", "Filecoin.EthGetLogs")
 	rpcServer.AliasMethod("eth_getFilterChanges", "Filecoin.EthGetFilterChanges")
@@ -223,6 +227,16 @@ func aliasETHAPI(rpcServer *jsonrpc.RPCServer) {
 	rpcServer.AliasMethod("trace_replayBlockTransactions", "Filecoin.EthTraceReplayBlockTransactions")
 	rpcServer.AliasMethod("trace_transaction", "Filecoin.EthTraceTransaction")
 	rpcServer.AliasMethod("trace_filter", "Filecoin.EthTraceFilter")
+	rpcServer.AliasMethod("trace_call", "Filecoin.EthTraceCall")
+
+	rpcServer.AliasMethod("debug_traceTransaction", "Filecoin.EthDebugTraceTransaction")
+	rpcServer.AliasMethod("debug_traceBlockByNumber", "Filecoin.EthDebugTraceBlockByNumber")
+	rpcServer.AliasMethod("debug_traceCall", "Filecoin.EthDebugTraceCall")
+
+	rpcServer.AliasMethod("txpool_content", "Filecoin.EthTxPoolContent")
+	rpcServer.AliasMethod("txpool_contentFrom", "Filecoin.EthTxPoolContentFrom")
+	rpcServer.AliasMethod("txpool_inspect", "Filecoin.EthTxPoolInspect")
+	rpcServer.AliasMethod("txpool_status", "Filecoin.EthTxPoolStatus")
 
 	rpcServer.AliasMethod("net_version", "Filecoin.NetVersion")
 	rpcServer.AliasMethod("net_listening", "Filecoin.NetListening")
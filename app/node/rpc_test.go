@@ -12,6 +12,7 @@ import (
 	"github.com/filecoin-project/go-jsonrpc"
 	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
 	"github.com/filecoin-project/venus/venus-shared/api/permission"
+	"github.com/ipfs-force-community/sophon-auth/core"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -167,3 +168,28 @@ func mockBuild(builder *RPCBuilder) *jsonrpc.RPCServer {
 	}
 	return server
 }
+
+func TestRequirePerm(t *testing.T) {
+	tf.UnitTest(t)
+
+	h := requirePerm(core.PermAdmin, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for perm, code := range map[core.Permission]int{
+		core.PermRead:  http.StatusForbidden,
+		core.PermWrite: http.StatusForbidden,
+		core.PermAdmin: http.StatusOK,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/chain/snapshot", nil)
+		req = req.WithContext(core.CtxWithPerm(req.Context(), perm))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		assert.Equal(t, code, w.Code, perm)
+	}
+
+	// a request which didn't go through the auth mux has no permission
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/chain/snapshot", nil))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -22,6 +22,8 @@ var log = logging.Logger("actor_event")
 
 type ChainAccessor interface {
 	GetHead() *types.TipSet
+	GetTipSet(ctx context.Context, key types.TipSetKey) (*types.TipSet, error)
+	GetTipSetByHeight(ctx context.Context, ts *types.TipSet, h abi.ChainEpoch, prev bool) (*types.TipSet, error)
 }
 
 type EventFilterManager interface {
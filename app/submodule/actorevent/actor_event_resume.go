@@ -0,0 +1,361 @@
+package actorevent
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/raulk/clock"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/venus/pkg/events/filter"
+	"github.com/filecoin-project/venus/venus-shared/api"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type catchUpResult struct {
+	notifications []*types.ActorEventNotification
+	err           error
+}
+
+func (a *ActorEventHandler) SubscribeActorEventsResumable(ctx context.Context, evtFilter *types.ActorEventFilter, cursor *types.ActorEventCursor) (<-chan *types.ActorEventNotification, error) {
+	if a.eventFilterManager == nil {
+		return nil, api.ErrNotSupported
+	}
+
+	if evtFilter == nil {
+		evtFilter = &types.ActorEventFilter{}
+	}
+	params, err := a.parseFilter(*evtFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	tipSetCid, err := params.GetTipSetCid()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tipset cid: %w", err)
+	}
+
+	var from *types.TipSet
+	if cursor != nil {
+		if from, err = a.chain.GetTipSet(ctx, cursor.TipSetKey); err != nil {
+			return nil, fmt.Errorf("failed to load cursor tipset: %w", err)
+		}
+		if a.chain.GetHead().Height()-from.Height() > a.maxFilterHeightRange {
+			return nil, fmt.Errorf("invalid cursor: tipset is too far in the past (maximum: %d)", a.maxFilterHeightRange)
+		}
+	}
+
+	// The real-time events are collected right away and the events the client missed are loaded from the
+	// event index concurrently, so that no event falls in between. The real-time events are drained while
+	// catching up, a full channel would block the event filter manager, and with it the catching up.
+	fm, err := a.eventFilterManager.Install(ctx, -1, params.MaxHeight, tipSetCid, evtFilter.Addresses, evtFilter.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+	in := make(chan interface{}, 256)
+	fm.SetSubChannel(in)
+
+	catchUp := make(chan catchUpResult, 1)
+	go func() {
+		notifications, err := a.catchUp(ctx, params, tipSetCid, evtFilter, cursor, from)
+		catchUp <- catchUpResult{notifications: notifications, err: err}
+	}()
+
+	out := make(chan *types.ActorEventNotification)
+	go a.sendNotifications(ctx, fm, params.MaxHeight, in, catchUp, out)
+
+	return out, nil
+}
+
+// catchUp returns the notifications bringing a client at the cursor up to date with the event index: the
+// reverts of the events it received from the tipsets reverted since, then the events after the cursor.
+func (a *ActorEventHandler) catchUp(ctx context.Context,
+	params *filterParams,
+	tipSetCid cid.Cid,
+	evtFilter *types.ActorEventFilter,
+	cursor *types.ActorEventCursor,
+	from *types.TipSet,
+) ([]*types.ActorEventNotification, error) {
+	var (
+		out        []*types.ActorEventNotification
+		replayFrom = params.MinHeight
+		skip       = func(*filter.CollectedEvent) bool { return false }
+	)
+
+	if cursor != nil {
+		head := a.chain.GetHead()
+		ts := from
+		applied, err := a.applied(ctx, head, ts)
+		if err != nil {
+			return nil, err
+		}
+
+		if applied {
+			// resume within the tipset of the cursor
+			replayFrom = ts.Height()
+			skip = func(ce *filter.CollectedEvent) bool {
+				if !ce.TipSetKey.Equals(cursor.TipSetKey) {
+					return false
+				}
+				if cursor.Reverted {
+					return ce.EventIdx < cursor.EventIdx
+				}
+				return ce.EventIdx <= cursor.EventIdx
+			}
+		} else {
+			// revert the tipsets the client is on down to the first one still applied
+			for first := true; !applied; first = false {
+				tsCid, err := ts.Key().Cid()
+				if err != nil {
+					return nil, fmt.Errorf("failed to get tipset cid: %w", err)
+				}
+				ces, err := a.collect(ctx, 0, 0, tsCid, evtFilter, false)
+				if err != nil {
+					return nil, err
+				}
+
+				// the events are reverted from the last one the client applied, like the events of the
+				// tipsets reverted live
+				for i := len(ces) - 1; i >= 0; i-- {
+					ce := ces[i]
+					if first && (ce.EventIdx > cursor.EventIdx || cursor.Reverted && ce.EventIdx == cursor.EventIdx) {
+						continue
+					}
+					out = append(out, newNotification(types.ActorEventReverted, ce, types.ActorEventCursor{TipSetKey: ce.TipSetKey, EventIdx: ce.EventIdx, Reverted: true}))
+				}
+
+				if ts, err = a.chain.GetTipSet(ctx, ts.Parents()); err != nil {
+					return nil, fmt.Errorf("failed to load parent tipset: %w", err)
+				}
+				if applied, err = a.applied(ctx, head, ts); err != nil {
+					return nil, err
+				}
+			}
+			replayFrom = ts.Height() + 1
+		}
+
+		if params.MinHeight > replayFrom {
+			replayFrom = params.MinHeight
+		}
+	}
+
+	if replayFrom < 0 {
+		return out, nil
+	}
+	ces, err := a.collect(ctx, replayFrom, params.MaxHeight, tipSetCid, evtFilter, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, ce := range ces {
+		if skip(ce) {
+			continue
+		}
+		out = append(out, newNotification(types.ActorEventApplied, ce, types.ActorEventCursor{TipSetKey: ce.TipSetKey, EventIdx: ce.EventIdx}))
+	}
+
+	return out, nil
+}
+
+// applied reports whether the messages of ts were executed on the current chain, i.e. whether their events
+// are applied in the event index.
+func (a *ActorEventHandler) applied(ctx context.Context, head, ts *types.TipSet) (bool, error) {
+	if ts.Height() >= head.Height() {
+		return false, nil
+	}
+	canonical, err := a.chain.GetTipSetByHeight(ctx, head, ts.Height(), false)
+	if err != nil {
+		return false, fmt.Errorf("failed to load tipset at height %d: %w", ts.Height(), err)
+	}
+	return canonical.Key().Equals(ts.Key()), nil
+}
+
+// collect loads the events matching the filter from the event index, ordered by height and index
+func (a *ActorEventHandler) collect(ctx context.Context,
+	minHeight, maxHeight abi.ChainEpoch,
+	tipSetCid cid.Cid,
+	evtFilter *types.ActorEventFilter,
+	excludeReverted bool,
+) ([]*filter.CollectedEvent, error) {
+	f, err := a.eventFilterManager.Install(ctx, minHeight, maxHeight, tipSetCid, evtFilter.Addresses, evtFilter.Fields, excludeReverted)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := a.eventFilterManager.Remove(context.Background(), f.ID()); err != nil {
+			log.Warnf("failed to remove filter: %s", err)
+		}
+	}()
+
+	ces := f.TakeCollectedEvents(ctx)
+	sort.SliceStable(ces, func(i, j int) bool {
+		if ces[i].Height != ces[j].Height {
+			return ces[i].Height < ces[j].Height
+		}
+		return ces[i].EventIdx < ces[j].EventIdx
+	})
+	return ces, nil
+}
+
+// sendNotifications sends the notifications catching up, then the real-time ones, with the same limits on
+// the sending rate as SubscribeActorEventsRaw.
+func (a *ActorEventHandler) sendNotifications(ctx context.Context,
+	fm filter.EventFilter,
+	maxHeight abi.ChainEpoch,
+	in <-chan interface{},
+	catchUp <-chan catchUpResult,
+	out chan<- *types.ActorEventNotification,
+) {
+	defer func() {
+		// tell the caller we're done
+		close(out)
+		fm.ClearSubChannel()
+		if err := a.eventFilterManager.Remove(ctx, fm.ID()); err != nil {
+			log.Warnf("failed to remove filter: %s", err)
+		}
+	}()
+
+	var (
+		buffer []*types.ActorEventNotification
+		// the real-time events received while catching up
+		early []*filter.CollectedEvent
+		// the last index of the events replayed by tipset, the real-time events may have been replayed already
+		replayed = make(map[types.TipSetKey]int)
+		// the notifications catching up, and the real-time ones queued behind them, are exempt from the
+		// backlog height check. The client must keep reading them, one at least every block's time.
+		catchUpLeft    int
+		catchUpTimer   *clock.Timer
+		catchUpTimeout <-chan time.Time
+
+		minBacklogHeight        = a.chain.GetHead().Height() - 1
+		nextBacklogHeightUpdate = a.clock.Now().Add(a.blockDelay)
+	)
+
+	queue := func(ce *filter.CollectedEvent) {
+		if ce.Reverted {
+			// the events of the tipset may be applied again, they are reverted from the last one
+			delete(replayed, ce.TipSetKey)
+			buffer = append(buffer, newNotification(types.ActorEventReverted, ce, types.ActorEventCursor{TipSetKey: ce.TipSetKey, EventIdx: ce.EventIdx, Reverted: true}))
+			return
+		}
+		if idx, ok := replayed[ce.TipSetKey]; ok && ce.EventIdx <= idx {
+			return
+		}
+		buffer = append(buffer, newNotification(types.ActorEventApplied, ce, types.ActorEventCursor{TipSetKey: ce.TipSetKey, EventIdx: ce.EventIdx}))
+	}
+	enqueue := func(ce *filter.CollectedEvent) {
+		n := len(buffer)
+		queue(ce)
+		if catchUpLeft > 0 {
+			catchUpLeft += len(buffer) - n
+		}
+	}
+
+	ticker := a.clock.Ticker(a.blockDelay)
+	defer ticker.Stop()
+
+	for ctx.Err() == nil {
+		var (
+			send chan<- *types.ActorEventNotification
+			next *types.ActorEventNotification
+		)
+		if len(buffer) > 0 {
+			send, next = out, buffer[0]
+		}
+
+		select {
+		case res := <-catchUp:
+			catchUp = nil
+			if res.err != nil {
+				log.Errorf("closing event subscription, failed to catch up: %s", res.err)
+				return
+			}
+			for _, n := range res.notifications {
+				if n.Type == types.ActorEventApplied {
+					replayed[n.Cursor.TipSetKey] = n.Cursor.EventIdx
+				}
+			}
+			buffer = res.notifications
+			if catchUpLeft = len(buffer); catchUpLeft > 0 {
+				catchUpTimer = a.clock.Timer(a.blockDelay)
+				catchUpTimeout = catchUpTimer.C
+			}
+			for _, ce := range early {
+				enqueue(ce)
+			}
+			early = nil
+		case ev, ok := <-in: // incoming event
+			if !ok {
+				return
+			}
+			ce, ok := ev.(*filter.CollectedEvent)
+			if !ok {
+				log.Errorf("got unexpected value from event filter: %T", ev)
+				return
+			}
+			if catchUp != nil {
+				early = append(early, ce)
+			} else if catchUpLeft == 0 && ce.Height < minBacklogHeight {
+				log.Errorf("closing event subscription due to slow event sending rate")
+				return
+			} else {
+				enqueue(ce)
+			}
+		case send <- next: // successful send
+			buffer[0] = nil
+			buffer = buffer[1:]
+			if catchUpLeft > 0 {
+				if catchUpLeft--; catchUpLeft > 0 {
+					catchUpTimer.Reset(a.blockDelay)
+				} else {
+					// caught up, the backlog is checked from now on
+					catchUpTimer.Stop()
+					catchUpTimeout = nil
+					minBacklogHeight = a.chain.GetHead().Height() - 1
+					nextBacklogHeightUpdate = a.clock.Now().Add(a.blockDelay)
+				}
+			}
+		case <-catchUpTimeout:
+			log.Errorf("closing event subscription, the client stopped reading while catching up")
+			return
+		case <-ticker.C:
+			if catchUp != nil || catchUpLeft > 0 {
+				break
+			}
+			// check that our backlog isn't too big by looking at the oldest event
+			if len(buffer) > 0 && buffer[0].Event.Height < minBacklogHeight {
+				log.Errorf("closing event subscription due to slow event sending rate")
+				return
+			}
+			if maxHeight > 0 && len(buffer) == 0 && a.chain.GetHead().Height() > maxHeight {
+				// we've reached the filter's MaxHeight, we're done so we can close the channel
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+
+		if a.clock.Now().After(nextBacklogHeightUpdate) {
+			minBacklogHeight = a.chain.GetHead().Height() - 1
+			nextBacklogHeightUpdate = a.clock.Now().Add(a.blockDelay)
+		}
+	}
+}
+
+func newNotification(typ types.ActorEventNotificationType, ce *filter.CollectedEvent, cursor types.ActorEventCursor) *types.ActorEventNotification {
+	return &types.ActorEventNotification{
+		Type: typ,
+		Event: &types.ActorEvent{
+			Entries:   ce.Entries,
+			Emitter:   ce.EmitterAddr,
+			Reverted:  typ == types.ActorEventReverted,
+			Height:    ce.Height,
+			TipSetKey: ce.TipSetKey,
+			MsgCid:    ce.MsgCid,
+		},
+		Cursor: cursor,
+	}
+}
@@ -495,6 +495,156 @@ func TestSubscribeActorEventsRaw_OnlyHistorical(t *testing.T) {
 	}
 }
 
+func TestSubscribeActorEventsResumable(t *testing.T) {
+	const (
+		seed                 = 984651320
+		maxFilterHeightRange = 100
+		blockDelay           = 30 * time.Second
+	)
+	t.Logf("seed: %d", seed)
+	rng := pseudo.New(pseudo.NewSource(seed))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	minerAddr, err := address.NewIDAddress(uint64(rng.Int63()))
+	require.NoError(t, err)
+	forkMinerAddr, err := address.NewIDAddress(uint64(rng.Int63()))
+	require.NoError(t, err)
+
+	// the chain is 1 <- 2 <- 3 <- 4 <- 5, the client was on the fork 3 <- 4'
+	var chain []*types.TipSet
+	for h := int64(1); h <= 5; h++ {
+		chain = append(chain, newChildTipSet(t, minerAddr, h, chain))
+	}
+	fork := newChildTipSet(t, forkMinerAddr, 4, chain[:3])
+	mockChain := newMockChainAccessor(t, chain[4])
+	mockChain.addTipSets(append(chain, fork)...)
+
+	event := func(ts *types.TipSet, idx int, reverted bool) *filter.CollectedEvent {
+		ce := makeCollectedEvent(t, rng, ts.Key(), ts.Height())
+		ce.EventIdx = idx
+		ce.Reverted = reverted
+		return ce
+	}
+	applied := func(ce *filter.CollectedEvent) *types.ActorEventNotification {
+		return newNotification(types.ActorEventApplied, ce, types.ActorEventCursor{TipSetKey: ce.TipSetKey, EventIdx: ce.EventIdx})
+	}
+	reverted := func(ce *filter.CollectedEvent) *types.ActorEventNotification {
+		return newNotification(types.ActorEventReverted, ce, types.ActorEventCursor{TipSetKey: ce.TipSetKey, EventIdx: ce.EventIdx, Reverted: true})
+	}
+	receive := func(out <-chan *types.ActorEventNotification, n int) []*types.ActorEventNotification {
+		var got []*types.ActorEventNotification
+		for len(got) < n {
+			select {
+			case nt := <-out:
+				got = append(got, nt)
+			case <-time.After(time.Second):
+				t.Fatalf("received %d notifications out of %d", len(got), n)
+			}
+		}
+		return got
+	}
+
+	t.Run("resume within an applied tipset", func(t *testing.T) {
+		efm := newMockEventFilterManager(t)
+		live := newMockFilter(ctx, t, rng, nil)
+		efm.expectInstall(-1, -1, cid.Undef, nil, nil, false, live)
+		replayed := []*filter.CollectedEvent{event(chain[1], 0, false), event(chain[1], 2, false), event(chain[3], 0, false)}
+		efm.expectInstall(2, -1, cid.Undef, nil, nil, true, newMockFilter(ctx, t, rng, replayed))
+
+		handler := NewActorEventHandlerWithClock(mockChain, efm, blockDelay, maxFilterHeightRange, clock.NewMock())
+		out, err := handler.SubscribeActorEventsResumable(ctx, nil, &types.ActorEventCursor{TipSetKey: chain[1].Key(), EventIdx: 0})
+		require.NoError(t, err)
+		require.Equal(t, []*types.ActorEventNotification{applied(replayed[1]), applied(replayed[2])}, receive(out, 2))
+
+		// the real-time events replayed already are dropped
+		next := event(chain[3], 1, false)
+		revert := event(chain[3], 1, true)
+		live.sendEventToChannel(replayed[2])
+		live.sendEventToChannel(next)
+		live.sendEventToChannel(revert)
+		require.Equal(t, []*types.ActorEventNotification{applied(next), reverted(revert)}, receive(out, 2))
+	})
+
+	t.Run("resume on a reverted fork", func(t *testing.T) {
+		efm := newMockEventFilterManager(t)
+		efm.expectInstall(-1, -1, cid.Undef, nil, nil, false, newMockFilter(ctx, t, rng, nil))
+		forkCid, err := fork.Key().Cid()
+		require.NoError(t, err)
+		forked := []*filter.CollectedEvent{event(fork, 0, true), event(fork, 1, true), event(fork, 3, true)}
+		efm.expectInstall(0, 0, forkCid, nil, nil, false, newMockFilter(ctx, t, rng, forked))
+		replayed := []*filter.CollectedEvent{event(chain[3], 0, false)}
+		efm.expectInstall(4, -1, cid.Undef, nil, nil, true, newMockFilter(ctx, t, rng, replayed))
+
+		handler := NewActorEventHandlerWithClock(mockChain, efm, blockDelay, maxFilterHeightRange, clock.NewMock())
+		out, err := handler.SubscribeActorEventsResumable(ctx, nil, &types.ActorEventCursor{TipSetKey: fork.Key(), EventIdx: 1})
+		require.NoError(t, err)
+		require.Equal(t, []*types.ActorEventNotification{
+			reverted(forked[1]),
+			reverted(forked[0]),
+			applied(replayed[0]),
+		}, receive(out, 3))
+	})
+
+	t.Run("resume from a revert on a reverted fork", func(t *testing.T) {
+		efm := newMockEventFilterManager(t)
+		efm.expectInstall(-1, -1, cid.Undef, nil, nil, false, newMockFilter(ctx, t, rng, nil))
+		forkCid, err := fork.Key().Cid()
+		require.NoError(t, err)
+		forked := []*filter.CollectedEvent{event(fork, 0, true), event(fork, 1, true), event(fork, 3, true)}
+		efm.expectInstall(0, 0, forkCid, nil, nil, false, newMockFilter(ctx, t, rng, forked))
+		replayed := []*filter.CollectedEvent{event(chain[3], 0, false)}
+		efm.expectInstall(4, -1, cid.Undef, nil, nil, true, newMockFilter(ctx, t, rng, replayed))
+
+		// the client reverted the events 3 and 1 of the fork, the event 0 is still applied
+		handler := NewActorEventHandlerWithClock(mockChain, efm, blockDelay, maxFilterHeightRange, clock.NewMock())
+		out, err := handler.SubscribeActorEventsResumable(ctx, nil, &types.ActorEventCursor{TipSetKey: fork.Key(), EventIdx: 1, Reverted: true})
+		require.NoError(t, err)
+		require.Equal(t, []*types.ActorEventNotification{reverted(forked[0]), applied(replayed[0])}, receive(out, 2))
+	})
+
+	t.Run("resume from a revert on a tipset applied again", func(t *testing.T) {
+		efm := newMockEventFilterManager(t)
+		efm.expectInstall(-1, -1, cid.Undef, nil, nil, false, newMockFilter(ctx, t, rng, nil))
+		replayed := []*filter.CollectedEvent{event(chain[1], 0, false), event(chain[1], 2, false), event(chain[1], 3, false), event(chain[3], 0, false)}
+		efm.expectInstall(2, -1, cid.Undef, nil, nil, true, newMockFilter(ctx, t, rng, replayed))
+
+		// the client reverted the events of chain[1] down to the event 2 before the tipset was applied again
+		handler := NewActorEventHandlerWithClock(mockChain, efm, blockDelay, maxFilterHeightRange, clock.NewMock())
+		out, err := handler.SubscribeActorEventsResumable(ctx, nil, &types.ActorEventCursor{TipSetKey: chain[1].Key(), EventIdx: 2, Reverted: true})
+		require.NoError(t, err)
+		require.Equal(t, []*types.ActorEventNotification{applied(replayed[1]), applied(replayed[2]), applied(replayed[3])}, receive(out, 3))
+	})
+
+	t.Run("catching up takes longer than a block", func(t *testing.T) {
+		efm := newMockEventFilterManager(t)
+		live := newMockFilter(ctx, t, rng, nil)
+		efm.expectInstall(-1, -1, cid.Undef, nil, nil, false, live)
+		replayed := []*filter.CollectedEvent{event(chain[1], 1, false), event(chain[2], 0, false), event(chain[3], 0, false)}
+		efm.expectInstall(2, -1, cid.Undef, nil, nil, true, newMockFilter(ctx, t, rng, replayed))
+
+		mockClock := clock.NewMock()
+		handler := NewActorEventHandlerWithClock(mockChain, efm, blockDelay, maxFilterHeightRange, mockClock)
+		out, err := handler.SubscribeActorEventsResumable(ctx, nil, &types.ActorEventCursor{TipSetKey: chain[1].Key(), EventIdx: 0})
+		require.NoError(t, err)
+
+		// the client reads a notification every 2/3 of a block
+		for _, ce := range replayed {
+			require.Equal(t, []*types.ActorEventNotification{applied(ce)}, receive(out, 1))
+			mockClock.Add(blockDelay * 2 / 3)
+		}
+		next := event(chain[4], 0, false)
+		live.sendEventToChannel(next)
+		require.Equal(t, []*types.ActorEventNotification{applied(next)}, receive(out, 1))
+	})
+
+	t.Run("unknown cursor", func(t *testing.T) {
+		handler := NewActorEventHandlerWithClock(mockChain, newMockEventFilterManager(t), blockDelay, maxFilterHeightRange, clock.NewMock())
+		_, err := handler.SubscribeActorEventsResumable(ctx, nil, &types.ActorEventCursor{TipSetKey: types.NewTipSetKey(testCid)})
+		require.ErrorContains(t, err, "failed to load cursor tipset")
+	})
+}
+
 var (
 	_ ChainAccessor      = (*mockChainAccessor)(nil)
 	_ filter.EventFilter = (*mockFilter)(nil)
@@ -502,13 +652,22 @@ var (
 )
 
 type mockChainAccessor struct {
-	t  *testing.T
-	ts *types.TipSet
-	lk sync.Mutex
+	t       *testing.T
+	ts      *types.TipSet
+	tipsets map[types.TipSetKey]*types.TipSet
+	lk      sync.Mutex
 }
 
 func newMockChainAccessor(t *testing.T, ts *types.TipSet) *mockChainAccessor {
-	return &mockChainAccessor{t: t, ts: ts}
+	return &mockChainAccessor{t: t, ts: ts, tipsets: map[types.TipSetKey]*types.TipSet{ts.Key(): ts}}
+}
+
+func (m *mockChainAccessor) addTipSets(tss ...*types.TipSet) {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+	for _, ts := range tss {
+		m.tipsets[ts.Key()] = ts
+	}
 }
 
 func (m *mockChainAccessor) setHeaviestTipSet(ts *types.TipSet) {
@@ -523,6 +682,26 @@ func (m *mockChainAccessor) GetHead() *types.TipSet {
 	return m.ts
 }
 
+func (m *mockChainAccessor) GetTipSet(_ context.Context, key types.TipSetKey) (*types.TipSet, error) {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+	ts, ok := m.tipsets[key]
+	if !ok {
+		return nil, fmt.Errorf("tipset %s not found", key)
+	}
+	return ts, nil
+}
+
+func (m *mockChainAccessor) GetTipSetByHeight(ctx context.Context, ts *types.TipSet, h abi.ChainEpoch, _ bool) (*types.TipSet, error) {
+	for ts.Height() > h {
+		var err error
+		if ts, err = m.GetTipSet(ctx, ts.Parents()); err != nil {
+			return nil, err
+		}
+	}
+	return ts, nil
+}
+
 type mockFilter struct {
 	t                    *testing.T
 	ctx                  context.Context
@@ -724,6 +903,17 @@ func newBlockHeader(minerAddr address.Address, height int64) *types.BlockHeader
 	}
 }
 
+// newChildTipSet returns a tipset at height whose parent is the last tipset of chain
+func newChildTipSet(t *testing.T, minerAddr address.Address, height int64, chain []*types.TipSet) *types.TipSet {
+	bh := newBlockHeader(minerAddr, height)
+	if len(chain) > 0 {
+		bh.Parents = chain[len(chain)-1].Cids()
+	}
+	ts, err := types.NewTipSet([]*types.BlockHeader{bh})
+	require.NoError(t, err)
+	return ts
+}
+
 func epochPtr(i int) *abi.ChainEpoch {
 	e := abi.ChainEpoch(i)
 	return &e
@@ -20,4 +20,8 @@ func (a *ActorEventDummy) SubscribeActorEventsRaw(ctx context.Context, filter *t
 	return nil, ErrActorEventModuleDisabled
 }
 
+func (a *ActorEventDummy) SubscribeActorEventsResumable(ctx context.Context, filter *types.ActorEventFilter, cursor *types.ActorEventCursor) (<-chan *types.ActorEventNotification, error) {
+	return nil, ErrActorEventModuleDisabled
+}
+
 var _ v1api.IActorEvent = &ActorEventDummy{}
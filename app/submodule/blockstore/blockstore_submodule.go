@@ -2,6 +2,9 @@ package blockstore
 
 import (
 	"context"
+	"net/http"
+
+	"github.com/gorilla/websocket"
 
 	"github.com/filecoin-project/venus/pkg/repo"
 	v0api "github.com/filecoin-project/venus/venus-shared/api/chain/v0"
@@ -37,3 +40,16 @@ func (bsm *BlockstoreSubmodule) API() v0api.IBlockStore {
 func (bsm *BlockstoreSubmodule) V0API() v0api.IBlockStore {
 	return &blockstoreAPI{blockstore: bsm}
 }
+
+// StreamHandler serves the blockstore over websocket, read-only, to the archival fallback of other nodes
+func (bsm *BlockstoreSubmodule) StreamHandler() http.Handler {
+	upgrader := websocket.Upgrader{}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wc, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			// the upgrader replied with the error
+			return
+		}
+		blockstoreutil.HandleNetBstoreWS(context.Background(), blockstoreutil.NewReadOnlyStore(bsm.Blockstore), wc)
+	})
+}
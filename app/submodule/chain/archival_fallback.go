@@ -0,0 +1,142 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/ipfs-force-community/metrics"
+	"github.com/ipfs/boxo/exchange"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/venus-shared/api"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+)
+
+var (
+	archivalFallbackFetched = metrics.NewCounter("chain/archival_fallback_fetched", "Number of blocks fetched from the archival fallback")
+	archivalFallbackFailure = metrics.NewCounter("chain/archival_fallback_failure", "Number of blocks failed to be fetched from the archival fallback")
+)
+
+// StartArchivalFallback sets where the state missing from the blockstore is fetched from, it does nothing
+// when the archival fallback is disabled.
+func (chain *ChainSubmodule) StartArchivalFallback(ctx context.Context, h host.Host, bitswap exchange.Interface) error {
+	if chain.ArchivalFallback == nil {
+		return nil
+	}
+
+	cfg := chain.config.Repo().Config().Datastore.ArchivalFallback
+	var fetch blockstoreutil.FetchFunc
+	switch cfg.Type {
+	case config.ArchivalFallbackVenus:
+		f, err := newVenusFetcher(cfg.Upstream)
+		if err != nil {
+			return err
+		}
+		fetch = f.fetch
+	case config.ArchivalFallbackBitswap:
+		pi, err := peer.AddrInfoFromString(cfg.Upstream)
+		if err != nil {
+			return fmt.Errorf("parse archival fallback upstream: %w", err)
+		}
+		h.Peerstore().AddAddrs(pi.ID, pi.Addrs, peerstore.PermanentAddrTTL)
+		h.ConnManager().Protect(pi.ID, "archival-fallback")
+		go func() {
+			if err := h.Connect(ctx, *pi); err != nil {
+				log.Warnf("failed to connect to archival fallback %s: %v", pi.ID, err)
+			}
+		}()
+		// bitswap only hands the blocks over, they are kept in the side store of the fallback store rather
+		// than written to the repo blockstore
+		fetch = bitswap.GetBlock
+	default:
+		return fmt.Errorf("unknown archival fallback type %q", cfg.Type)
+	}
+
+	chain.ArchivalFallback.SetFallback(func(ctx context.Context, c cid.Cid) (blocks.Block, error) {
+		blk, err := fetch(ctx, c)
+		if err != nil {
+			archivalFallbackFailure.Tick(ctx)
+			return nil, err
+		}
+		archivalFallbackFetched.Tick(ctx)
+		return blk, nil
+	})
+	log.Infof("fetching the state missing from the blockstore from %s %s", cfg.Type, cfg.Upstream)
+	return nil
+}
+
+// venusFetcher reads the blocks from the blockstore of another venus node, dialing it again once the
+// connection is lost.
+type venusFetcher struct {
+	addr   string
+	header http.Header
+
+	lk sync.Mutex
+	ns *blockstoreutil.NetworkStore
+}
+
+func newVenusFetcher(upstream string) (*venusFetcher, error) {
+	info := api.ParseApiInfo(upstream)
+	addr, err := info.DialArgs("v1")
+	if err != nil {
+		return nil, fmt.Errorf("parse archival fallback upstream: %w", err)
+	}
+	u, err := url.Parse(addr)
+	if err != nil {
+		return nil, fmt.Errorf("parse archival fallback upstream: %w", err)
+	}
+	switch u.Scheme {
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	}
+	u.Path = blockstoreutil.NetBstoreWSPath
+
+	return &venusFetcher{addr: u.String(), header: info.AuthHeader()}, nil
+}
+
+func (f *venusFetcher) store(ctx context.Context) (*blockstoreutil.NetworkStore, error) {
+	f.lk.Lock()
+	defer f.lk.Unlock()
+
+	if f.ns != nil {
+		return f.ns, nil
+	}
+	wc, _, err := websocket.DefaultDialer.DialContext(ctx, f.addr, f.header)
+	if err != nil {
+		return nil, fmt.Errorf("dial %s: %w", f.addr, err)
+	}
+	ns := blockstoreutil.NewNetworkStoreWS(wc)
+	ns.OnClose(func() {
+		// the callback runs right away when the store is closed already
+		go f.reset(ns)
+	})
+	f.ns = ns
+	return ns, nil
+}
+
+func (f *venusFetcher) reset(ns *blockstoreutil.NetworkStore) {
+	f.lk.Lock()
+	defer f.lk.Unlock()
+	if f.ns == ns {
+		f.ns = nil
+	}
+}
+
+func (f *venusFetcher) fetch(ctx context.Context, c cid.Cid) (blocks.Block, error) {
+	ns, err := f.store(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ns.Get(ctx, c)
+}
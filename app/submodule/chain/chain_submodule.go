@@ -2,6 +2,10 @@ package chain
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/ipfs/go-cid"
@@ -10,6 +14,7 @@ import (
 	apiwrapper "github.com/filecoin-project/venus/app/submodule/chain/v0api"
 	"github.com/filecoin-project/venus/pkg/beacon"
 	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/chainindex"
 	"github.com/filecoin-project/venus/pkg/consensus"
 	"github.com/filecoin-project/venus/pkg/consensus/chainselector"
 	"github.com/filecoin-project/venus/pkg/consensusfault"
@@ -21,6 +26,7 @@ import (
 	"github.com/filecoin-project/venus/pkg/vmsupport"
 	v0api "github.com/filecoin-project/venus/venus-shared/api/chain/v0"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
 )
 
 // ChainSubmodule enhances the `Node` with chain capabilities.
@@ -39,6 +45,17 @@ type ChainSubmodule struct { //nolint
 	Stmgr *statemanger.Stmgr
 	// Wait for confirm message
 	Waiter *chain.Waiter
+	// Pruner compacts the splitstore, it is nil when the splitstore is disabled
+	Pruner *chain.Pruner
+	// Indexer is the persistent chain index, it is nil when the indexer is disabled
+	Indexer *chainindex.ChainIndexer
+	// Exporter writes snapshots in the background
+	Exporter *chain.SnapshotExporter
+	// Fetcher fetches chain data from peers to repair the blockstore, it is set by the syncer submodule
+	Fetcher chain.ChainFetcher
+	// ArchivalFallback reads the state missing from the blockstore from an upstream, it is nil when the
+	// archival fallback is disabled
+	ArchivalFallback *blockstoreutil.FallbackStore
 }
 
 type chainConfig interface {
@@ -53,8 +70,20 @@ func NewChainSubmodule(ctx context.Context,
 	config chainConfig,
 ) (*ChainSubmodule, error) {
 	repo := config.Repo()
+	// the chain store reads the state missing from the blockstore from the archival fallback
+	var (
+		bs               blockstoreutil.Blockstore = repo.Datastore()
+		archivalFallback *blockstoreutil.FallbackStore
+	)
+	if cfg := repo.Config().Datastore.ArchivalFallback; cfg.Type != "" {
+		fbs, err := blockstoreutil.NewFallbackStore(bs, cfg.CacheSize)
+		if err != nil {
+			return nil, fmt.Errorf("create archival fallback: %w", err)
+		}
+		bs, archivalFallback = fbs, fbs
+	}
 	// initialize chain store
-	chainStore := chain.NewStore(repo.ChainDatastore(), repo.Datastore(), config.GenesisCid(), chainselector.Weight)
+	chainStore := chain.NewStore(repo.ChainDatastore(), bs, config.GenesisCid(), chainselector.Weight)
 	// drand
 	genBlk, err := chainStore.GetGenesisBlock(context.TODO())
 	if err != nil {
@@ -90,24 +119,87 @@ func NewChainSubmodule(ctx context.Context,
 		Drand:                       drand,
 		config:                      config,
 		Waiter:                      waiter,
+		Exporter:                    chain.NewSnapshotExporter(chainStore),
+		ArchivalFallback:            archivalFallback,
 	}
 	err = store.ChainReader.Load(context.TODO())
 	if err != nil {
 		return nil, err
 	}
+
+	if ss, ok := repo.Datastore().(*blockstoreutil.SplitStore); ok {
+		store.Pruner = chain.NewPruner(chainStore, ss, repo.ChainDatastore(), repo.Config().Datastore.Splitstore)
+		if err := store.Pruner.Start(context.TODO()); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg := repo.Config().ChainIndexer; cfg != nil && cfg.EnableIndexer {
+		sqlitePath, err := repo.SqlitePath()
+		if err != nil {
+			return nil, err
+		}
+		store.Indexer, err = chainindex.NewChainIndexer(ctx, filepath.Join(sqlitePath, chainindex.DefaultDBFilename), chainStore, messageStore, *cfg)
+		if err != nil {
+			return nil, err
+		}
+		waiter.MsgIndex = store.Indexer
+	}
 	return store, nil
 }
 
 // Start loads the chain from disk.
 func (chain *ChainSubmodule) Start(ctx context.Context) error {
-	return chain.Fork.Start(ctx)
+	if err := chain.Fork.Start(ctx); err != nil {
+		return err
+	}
+	if chain.Indexer != nil {
+		return chain.Indexer.Start(ctx)
+	}
+	return nil
 }
 
 // Stop stop the chain head event
 func (chain *ChainSubmodule) Stop(ctx context.Context) {
+	if chain.Pruner != nil {
+		chain.Pruner.Stop()
+	}
+	chain.Exporter.Stop()
+	if chain.Indexer != nil {
+		if err := chain.Indexer.Close(); err != nil {
+			log.Warnf("failed to close chain index: %v", err)
+		}
+	}
 	chain.ChainReader.Stop()
 }
 
+// SnapshotHandler serves the last snapshot exported by the background export job. Range requests
+// are supported so that an interrupted download can be resumed.
+func (chain *ChainSubmodule) SnapshotHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := chain.Exporter.LastSnapshot()
+		if path == "" {
+			http.Error(w, "no snapshot has been exported", http.StatusNotFound)
+			return
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to open snapshot: %s", err), http.StatusNotFound)
+			return
+		}
+		defer f.Close() // nolint:errcheck
+
+		fi, err := f.Stat()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to stat snapshot: %s", err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filepath.Base(path)))
+		http.ServeContent(w, r, filepath.Base(path), fi.ModTime(), f)
+	})
+}
+
 // API chain module api implement
 func (chain *ChainSubmodule) API() v1api.IChain {
 	return &chainAPI{
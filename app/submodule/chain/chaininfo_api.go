@@ -104,6 +104,11 @@ func (cia *chainInfoAPI) ChainHead(ctx context.Context) (*types.TipSet, error) {
 	return cia.chain.ChainReader.GetHead(), nil
 }
 
+// ChainGetFinalizedTipSet returns the latest tipset finalized by F3, or by EC when F3 is not running
+func (cia *chainInfoAPI) ChainGetFinalizedTipSet(ctx context.Context) (*types.TipSet, error) {
+	return cia.chain.ChainReader.GetFinalizedTipSet(ctx)
+}
+
 // ChainSetHead sets `key` as the new head of this chain iff it exists in the nodes chain store.
 func (cia *chainInfoAPI) ChainSetHead(ctx context.Context, key types.TipSetKey) error {
 	ts, err := cia.chain.ChainReader.GetTipSet(ctx, key)
@@ -674,6 +679,67 @@ func (cia *chainInfoAPI) ChainExport(ctx context.Context, nroots abi.ChainEpoch,
 	return out, nil
 }
 
+// ChainPrune starts a splitstore compaction from the current head
+func (cia *chainInfoAPI) ChainPrune(ctx context.Context, opts types.PruneOpts) error {
+	if cia.chain.Pruner == nil {
+		return fmt.Errorf("splitstore is not enabled")
+	}
+	return cia.chain.Pruner.Prune(ctx, opts)
+}
+
+// ChainPruneStatus returns the progress of the running, or last, splitstore compaction
+func (cia *chainInfoAPI) ChainPruneStatus(ctx context.Context) (*types.PruneStatus, error) {
+	if cia.chain.Pruner == nil {
+		return &types.PruneStatus{Enabled: false}, nil
+	}
+	status := cia.chain.Pruner.Status()
+	return &status, nil
+}
+
+// ChainExportJobStart starts a background snapshot export
+func (cia *chainInfoAPI) ChainExportJobStart(ctx context.Context, params types.ExportJobParams) error {
+	ts, err := cia.chain.ChainReader.GetTipSet(ctx, params.TipSetKey)
+	if err != nil {
+		return fmt.Errorf("loading tipset %s: %v", params.TipSetKey, err)
+	}
+	return cia.chain.Exporter.Export(ts, params)
+}
+
+// ChainExportJobStatus returns the progress of the running, or last, background snapshot export
+func (cia *chainInfoAPI) ChainExportJobStatus(ctx context.Context) (*types.ExportJobStatus, error) {
+	status := cia.chain.Exporter.Status()
+	return &status, nil
+}
+
+// ChainExportJobCancel cancels the running background snapshot export
+func (cia *chainInfoAPI) ChainExportJobCancel(ctx context.Context) error {
+	return cia.chain.Exporter.Cancel()
+}
+
+// ChainCheckBlockstore verifies the objects of the chain are in the blockstore and hash to their cid
+func (cia *chainInfoAPI) ChainCheckBlockstore(ctx context.Context, params types.BlockstoreCheckParams) (*types.BlockstoreCheckResult, error) {
+	ts, err := cia.chain.ChainReader.GetTipSet(ctx, params.TipSetKey)
+	if err != nil {
+		return nil, fmt.Errorf("loading tipset %s: %v", params.TipSetKey, err)
+	}
+	var fetcher chain.ChainFetcher
+	if params.Repair {
+		if cia.chain.Fetcher == nil {
+			return nil, fmt.Errorf("no fetcher available to repair the blockstore")
+		}
+		fetcher = cia.chain.Fetcher
+	}
+	return cia.chain.ChainReader.CheckBlockstore(ctx, ts, params, fetcher)
+}
+
+// ChainValidateIndex checks the chain index at epoch against the canonical chain
+func (cia *chainInfoAPI) ChainValidateIndex(ctx context.Context, epoch abi.ChainEpoch, backfill bool) (*types.IndexValidation, error) {
+	if cia.chain.Indexer == nil {
+		return nil, fmt.Errorf("chain indexer is not enabled")
+	}
+	return cia.chain.Indexer.ValidateIndex(ctx, epoch, backfill)
+}
+
 // ChainGetPath returns a set of revert/apply operations needed to get from
 // one tipset to another, for example:
 // ```
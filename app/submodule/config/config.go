@@ -1,51 +1,212 @@
 package config
 
 import (
-	"context"
+	"slices"
+	"strings"
 	"sync"
 
+	logging "github.com/ipfs/go-log/v2"
+
+	"github.com/filecoin-project/venus/pkg/config"
 	repo2 "github.com/filecoin-project/venus/pkg/repo"
+	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+	"github.com/filecoin-project/venus/venus-shared/types"
 )
 
-type IConfig interface {
-	ConfigSet(ctx context.Context, dottedPath string, paramJSON string) error
-	ConfigGet(ctx context.Context, dottedPath string) (interface{}, error)
+var log = logging.Logger("config_module")
+
+// ApplyFunc applies the changed fields of cfg to a submodule without a restart, changed are the dotted keys
+// of the fields.
+type ApplyFunc func(cfg *config.Config, changed []string) error
+
+// subscriber is a submodule applying the changes of some fields of the config
+type subscriber struct {
+	name  string
+	keys  []string
+	apply ApplyFunc
+}
+
+// match returns the changed fields the subscriber applies and the keys they are under
+func (sub *subscriber) match(changed []string) ([]string, []string) {
+	var fields, keys []string
+	for _, field := range changed {
+		for _, key := range sub.keys {
+			if field == key || strings.HasPrefix(field, key+".") {
+				fields = append(fields, field)
+				if !slices.Contains(keys, key) {
+					keys = append(keys, key)
+				}
+				break
+			}
+		}
+	}
+	return fields, keys
 }
 
 // ConfigModule is plumbing implementation for setting and retrieving values from local config.
+//
+// The config of the repo is the one the node runs with, it only takes the changes applied live. The
+// config file has all the changes, kept in current, the other ones take effect after a restart.
 type ConfigModule struct { //nolint
 	repo repo2.Repo
 	lock sync.Mutex
+
+	// current is the config as written to the config file
+	current     *config.Config
+	subscribers []*subscriber
 }
 
 // NewConfigModule returns a new configModule.
 func NewConfigModule(repo repo2.Repo) *ConfigModule {
-	return &ConfigModule{repo: repo}
+	current, err := repo.Config().Clone()
+	if err != nil {
+		log.Warnf("failed to copy the config: %s", err)
+		current = repo.Config()
+	}
+	return &ConfigModule{repo: repo, current: current}
 }
 
-// Set sets a value in config
-func (s *ConfigModule) Set(dottedKey string, jsonString string) error {
+// Subscribe registers a submodule to be notified of the changes of the fields under keys, they are dotted
+// keys of fields or of whole sections, e.g. mpool.maxFee or swarm. The changed fields are applied live
+// when apply succeeds, the other changes take effect after a restart. apply is nil when the submodule reads
+// the fields from the config each time it uses them.
+func (s *ConfigModule) Subscribe(name string, apply ApplyFunc, keys ...string) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	cfg := s.repo.Config()
-	if err := cfg.Set(dottedKey, jsonString); err != nil {
+	s.subscribers = append(s.subscribers, &subscriber{name: name, keys: keys, apply: apply})
+}
+
+// Set sets a value in config and writes it to the config file, the changed fields are validated and then
+// passed to the subscribers applying them. Only the fields applied are changed in the config the node runs
+// with.
+func (s *ConfigModule) Set(dottedKey string, jsonString string) (*types.ConfigSetResult, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	before, err := s.current.Fields()
+	if err != nil {
+		return nil, err
+	}
+
+	// check the change on a copy so that an invalid one leaves the config untouched
+	next, err := s.current.Clone()
+	if err != nil {
+		return nil, err
+	}
+	if err := next.Set(dottedKey, jsonString); err != nil {
+		return nil, err
+	}
+	if err := next.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.WriteConfig(next); err != nil {
+		return nil, err
+	}
+	s.current = next
+
+	after, err := next.Fields()
+	if err != nil {
+		return nil, err
+	}
+	res := &types.ConfigSetResult{Changed: config.DiffFields(before, after)}
+	if len(res.Changed) == 0 {
+		return res, nil
+	}
+
+	running := s.repo.Config()
+	for _, sub := range s.subscribers {
+		fields, keys := sub.match(res.Changed)
+		if len(fields) == 0 {
+			continue
+		}
+		if err := s.apply(sub, running, next, keys, fields); err != nil {
+			log.Warnf("%s failed to apply the changes of %v, they take effect after a restart: %s", sub.name, fields, err)
+			continue
+		}
+		res.Applied = append(res.Applied, fields...)
+	}
+
+	// a field set back to the value the node runs with doesn't need a restart
+	runningFields, err := running.Fields()
+	if err != nil {
+		return nil, err
+	}
+	pending := make(map[string]struct{})
+	for _, field := range config.DiffFields(runningFields, after) {
+		pending[field] = struct{}{}
+	}
+	for _, field := range res.Changed {
+		if _, ok := pending[field]; ok {
+			res.RestartRequired = append(res.RestartRequired, field)
+		}
+	}
+
+	return res, nil
+}
+
+// apply copies the fields under keys from next to the config the node runs with and passes them to sub, the
+// running config is restored when sub fails to apply them.
+func (s *ConfigModule) apply(sub *subscriber, running, next *config.Config, keys, fields []string) error {
+	prev, err := running.Clone()
+	if err != nil {
 		return err
 	}
+	for _, key := range keys {
+		if err := running.CopyField(next, key); err != nil {
+			return err
+		}
+	}
 
-	return s.repo.ReplaceConfig(cfg)
+	if sub.apply == nil {
+		log.Debugf("%s reads %v from the config when used", sub.name, fields)
+		return nil
+	}
+	if err := sub.apply(running, fields); err != nil {
+		for _, key := range keys {
+			if err := running.CopyField(prev, key); err != nil {
+				log.Errorf("failed to restore the running value of %s: %s", key, err)
+			}
+		}
+		return err
+	}
+	return nil
 }
 
-// Get gets a value from config
+// Get gets a value from config, the changes waiting for a restart included
 func (s *ConfigModule) Get(dottedKey string) (interface{}, error) {
-	return s.repo.Config().Get(dottedKey)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.current.Get(dottedKey)
+}
+
+// Diff returns the fields of the config which differ from the ones the node runs with
+func (s *ConfigModule) Diff() ([]types.ConfigChange, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	running, err := s.repo.Config().Fields()
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.current.Fields()
+	if err != nil {
+		return nil, err
+	}
+	keys := config.DiffFields(running, current)
+	changes := make([]types.ConfigChange, 0, len(keys))
+	for _, key := range keys {
+		changes = append(changes, types.ConfigChange{Key: key, Running: running[key], Current: current[key]})
+	}
+	return changes, nil
 }
 
 // API create a new config api implement
-func (s *ConfigModule) API() IConfig {
+func (s *ConfigModule) API() v1api.IConfig {
 	return &configAPI{config: s}
 }
 
-func (s *ConfigModule) V0API() IConfig {
+func (s *ConfigModule) V0API() v1api.IConfig {
 	return &configAPI{config: s}
 }
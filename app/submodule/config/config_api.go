@@ -2,9 +2,12 @@ package config
 
 import (
 	"context"
+
+	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+	"github.com/filecoin-project/venus/venus-shared/types"
 )
 
-var _ IConfig = &configAPI{}
+var _ v1api.IConfig = &configAPI{}
 
 type configAPI struct { //nolint
 	config *ConfigModule
@@ -16,7 +19,8 @@ type configAPI struct { //nolint
 // For example:
 // ConfigSet("datastore.path", "dev/null") and ConfigSet("datastore", "{\"path\":\"dev/null\"}")
 // are the same operation.
-func (ca *configAPI) ConfigSet(ctx context.Context, dottedPath string, paramJSON string) error {
+// The result lists the changed fields which were applied live and the ones requiring a restart.
+func (ca *configAPI) ConfigSet(ctx context.Context, dottedPath string, paramJSON string) (*types.ConfigSetResult, error) {
 	return ca.config.Set(dottedPath, paramJSON)
 }
 
@@ -25,3 +29,9 @@ func (ca *configAPI) ConfigSet(ctx context.Context, dottedPath string, paramJSON
 func (ca *configAPI) ConfigGet(ctx context.Context, dottedPath string) (interface{}, error) {
 	return ca.config.Get(dottedPath)
 }
+
+// ConfigDiff lists the fields of the local config which differ from the ones the node runs with,
+// they take effect after a restart.
+func (ca *configAPI) ConfigDiff(ctx context.Context) ([]types.ConfigChange, error) {
+	return ca.config.Diff()
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/filecoin-project/venus/pkg/testhelpers"
@@ -12,6 +13,7 @@ import (
 	"github.com/filecoin-project/venus/pkg/config"
 	repo2 "github.com/filecoin-project/venus/pkg/repo"
 	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
 )
 
 func TestConfigGet(t *testing.T) {
@@ -54,7 +56,7 @@ func TestConfigSet(t *testing.T) {
 
 		jsonBlob := `{"addresses": ["bootup1", "bootup2"]}`
 
-		err := cfgAPI.Set("bootstrap", jsonBlob)
+		_, err := cfgAPI.Set("bootstrap", jsonBlob)
 		require.NoError(t, err)
 		out, err := cfgAPI.Get("bootstrap")
 		require.NoError(t, err)
@@ -65,27 +67,33 @@ func TestConfigSet(t *testing.T) {
 		assert.Equal(t, expected, out)
 
 		// validate config write
-		cfg := repo.Config()
+		cfg := cfgAPI.current
 		assert.Equal(t, expected, cfg.Bootstrap)
 		assert.Equal(t, defaultCfg.Datastore, cfg.Datastore)
 
-		err = cfgAPI.Set("api.apiAddress", ":1234")
+		_, err = cfgAPI.Set("api.apiAddress", ":1234")
 		require.NoError(t, err)
-		assert.Equal(t, ":1234", cfg.API.APIAddress)
+		assert.Equal(t, ":1234", cfgAPI.current.API.APIAddress)
 
 		testAddr := testhelpers.RequireIDAddress(t, 100).String()
-		err = cfgAPI.Set("walletModule.defaultAddress", testAddr)
+		_, err = cfgAPI.Set("walletModule.defaultAddress", testAddr)
 		require.NoError(t, err)
-		assert.Equal(t, testAddr, cfg.Wallet.DefaultAddress.String())
+		assert.Equal(t, testAddr, cfgAPI.current.Wallet.DefaultAddress.String())
 
 		testSwarmAddr := "/ip4/0.0.0.0/tcp/0"
-		err = cfgAPI.Set("swarm.address", testSwarmAddr)
+		_, err = cfgAPI.Set("swarm.address", testSwarmAddr)
 		require.NoError(t, err)
-		assert.Equal(t, testSwarmAddr, cfg.Swarm.Address)
+		assert.Equal(t, testSwarmAddr, cfgAPI.current.Swarm.Address)
 
-		err = cfgAPI.Set("datastore.path", "/dev/null")
+		_, err = cfgAPI.Set("datastore.path", "/dev/null")
 		require.NoError(t, err)
-		assert.Equal(t, "/dev/null", cfg.Datastore.Path)
+		assert.Equal(t, "/dev/null", cfgAPI.current.Datastore.Path)
+
+		// the node keeps running with the values it started with
+		running := repo.Config()
+		assert.Equal(t, defaultCfg.Bootstrap, running.Bootstrap)
+		assert.Equal(t, defaultCfg.API.APIAddress, running.API.APIAddress)
+		assert.Equal(t, defaultCfg.Datastore, running.Datastore)
 	})
 
 	t.Run("failure cases fail", func(t *testing.T) {
@@ -95,23 +103,112 @@ func TestConfigSet(t *testing.T) {
 		// bad key
 		jsonBlob := `{"addresses": ["bootup1", "bootup2"]}`
 
-		err := cfgAPI.Set("botstrap", jsonBlob)
+		_, err := cfgAPI.Set("botstrap", jsonBlob)
 		assert.EqualError(t, err, "json: unknown field \"botstrap\"")
 
 		// bad value type (bootstrap is a struct not a list)
 		jsonBlobBadType := `["bootup1", "bootup2"]`
-		err = cfgAPI.Set("bootstrap", jsonBlobBadType)
+		_, err = cfgAPI.Set("bootstrap", jsonBlobBadType)
 		assert.Error(t, err)
 
 		// bad JSON
 		jsonBlobInvalid := `{"addresses": [bootup1, "bootup2"]}`
 
-		err = cfgAPI.Set("bootstrap", jsonBlobInvalid)
+		_, err = cfgAPI.Set("bootstrap", jsonBlobInvalid)
 		assert.EqualError(t, err, "json: cannot unmarshal string into Go struct field Config.bootstrap of type config.BootstrapConfig")
 
 		// bad address
 		jsonBlobBadAddr := "f4cqnyc0muxjajygqavu645m8ja04vckk2kcorrupt"
-		err = cfgAPI.Set("walletModule.defaultAddress", jsonBlobBadAddr)
+		_, err = cfgAPI.Set("walletModule.defaultAddress", jsonBlobBadAddr)
 		assert.EqualError(t, err, address.ErrInvalidPayload.Error())
 	})
 }
+
+func TestConfigSubscribe(t *testing.T) {
+	tf.UnitTest(t)
+
+	repo := repo2.NewInMemoryRepo()
+	cfgAPI := NewConfigModule(repo)
+
+	var applied [][]string
+	cfgAPI.Subscribe("mpool", func(cfg *config.Config, changed []string) error {
+		applied = append(applied, changed)
+		return nil
+	}, "mpool.maxFee", "mpool.feeBump")
+	cfgAPI.Subscribe("broken", func(cfg *config.Config, changed []string) error {
+		return errors.New("can't apply")
+	}, "fevm.event")
+
+	res, err := cfgAPI.Set("mpool", `{"maxFee": "1 FIL", "feeBump": {"enable": true}, "maxNonceGap": 10}`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mpool.feeBump.enable", "mpool.maxFee", "mpool.maxNonceGap"}, res.Changed)
+	assert.Equal(t, []string{"mpool.feeBump.enable", "mpool.maxFee"}, res.Applied)
+	assert.Equal(t, []string{"mpool.maxNonceGap"}, res.RestartRequired)
+	assert.Equal(t, [][]string{{"mpool.feeBump.enable", "mpool.maxFee"}}, applied)
+
+	res, err = cfgAPI.Set("fevm.event.maxFilters", `50`)
+	require.NoError(t, err)
+	assert.Empty(t, res.Applied)
+	assert.Equal(t, []string{"fevm.event.maxFilters"}, res.RestartRequired)
+
+	t.Log("the diff lists the changes waiting for a restart")
+	diff, err := cfgAPI.Diff()
+	require.NoError(t, err)
+	require.Len(t, diff, 2)
+	assert.Equal(t, "fevm.event.maxFilters", diff[0].Key)
+	assert.Equal(t, `50`, string(diff[0].Current))
+	assert.Equal(t, "mpool.maxNonceGap", diff[1].Key)
+
+	t.Log("setting a field back to the running value doesn't need a restart")
+	res, err = cfgAPI.Set("mpool.maxNonceGap", `100`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mpool.maxNonceGap"}, res.Changed)
+	assert.Empty(t, res.RestartRequired)
+
+	t.Log("the fields read when used need no restart")
+	cfgAPI.Subscribe("wallet", nil, "walletModule.defaultAddress")
+	res, err = cfgAPI.Set("walletModule.defaultAddress", "f01000")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"walletModule.defaultAddress"}, res.Applied)
+	assert.Empty(t, res.RestartRequired)
+
+	t.Log("the conn manager limits, the fevm caches and the event options are reported as restart required")
+	for key, value := range map[string]string{
+		"swarm.connMgrHigh":       `300`,
+		"fevm.EthBlkCacheSize":    `100`,
+		"fevm.event.filterTTL":    `"1h0m0s"`,
+		"fevm.event.maxFilters":   `60`,
+		"fevm.event.databasePath": `"/tmp/events.db"`,
+	} {
+		res, err := cfgAPI.Set(key, value)
+		require.NoError(t, err)
+		assert.Empty(t, res.Applied, key)
+		assert.Equal(t, []string{key}, res.RestartRequired, key)
+	}
+	diff, err = cfgAPI.Diff()
+	require.NoError(t, err)
+	var keys []string
+	for _, change := range diff {
+		keys = append(keys, change.Key)
+	}
+	assert.Equal(t, []string{"fevm.EthBlkCacheSize", "fevm.event.databasePath", "fevm.event.filterTTL", "fevm.event.maxFilters", "swarm.connMgrHigh"}, keys)
+
+	t.Log("invalid changes leave the config untouched")
+	_, err = cfgAPI.Set("mpool.maxFee", `"-1 FIL"`)
+	require.Error(t, err)
+	_, err = cfgAPI.Set("swarm.connMgrLow", `1000`)
+	require.Error(t, err)
+	assert.Equal(t, uint(150), repo.Config().Swarm.ConnMgrLow)
+	assert.Equal(t, types.MustParseFIL("1"), repo.Config().Mpool.MaxFee)
+	assert.Len(t, applied, 1)
+
+	t.Log("the node runs with the applied fields only, the other ones are written to the config file")
+	running := repo.Config()
+	assert.True(t, running.Mpool.FeeBump.Enable)
+	assert.Equal(t, "f01000", running.Wallet.DefaultAddress.String())
+	assert.Equal(t, config.NewDefaultConfig().Swarm.ConnMgrHigh, running.Swarm.ConnMgrHigh)
+	assert.Equal(t, config.NewDefaultConfig().FevmConfig.Event.MaxFilters, running.FevmConfig.Event.MaxFilters)
+	value, err := cfgAPI.Get("swarm.connMgrHigh")
+	require.NoError(t, err)
+	assert.Equal(t, uint(300), value)
+}
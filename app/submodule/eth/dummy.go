@@ -101,6 +101,10 @@ func (e *ethAPIDummy) EthGetStorageAt(ctx context.Context, address types.EthAddr
 	return nil, ErrModuleDisabled
 }
 
+func (e *ethAPIDummy) EthGetProof(ctx context.Context, address types.EthAddress, storageKeys []types.EthBytes, blkParam types.EthBlockNumberOrHash) (*types.EthProof, error) {
+	return nil, ErrModuleDisabled
+}
+
 func (e *ethAPIDummy) EthGetBalance(ctx context.Context, address types.EthAddress, blkParam types.EthBlockNumberOrHash) (types.EthBigInt, error) {
 	return types.EthBigIntZero, ErrModuleDisabled
 }
@@ -149,6 +153,22 @@ func (e *ethAPIDummy) EthSendRawTransaction(ctx context.Context, rawTx types.Eth
 	return types.EthHash{}, ErrModuleDisabled
 }
 
+func (e *ethAPIDummy) EthTxPoolContent(ctx context.Context) (*types.EthTxPoolContent, error) {
+	return nil, ErrModuleDisabled
+}
+
+func (e *ethAPIDummy) EthTxPoolContentFrom(ctx context.Context, address types.EthAddress) (*types.EthTxPoolContentFrom, error) {
+	return nil, ErrModuleDisabled
+}
+
+func (e *ethAPIDummy) EthTxPoolInspect(ctx context.Context) (*types.EthTxPoolInspect, error) {
+	return nil, ErrModuleDisabled
+}
+
+func (e *ethAPIDummy) EthTxPoolStatus(ctx context.Context) (types.EthTxPoolStatus, error) {
+	return types.EthTxPoolStatus{}, ErrModuleDisabled
+}
+
 func (e *ethAPIDummy) Web3ClientVersion(ctx context.Context) (string, error) {
 	return "", ErrModuleDisabled
 }
@@ -169,6 +189,26 @@ func (e *ethAPIDummy) EthTraceFilter(ctx context.Context, filter types.EthTraceF
 	return nil, ErrModuleDisabled
 }
 
+func (e *ethAPIDummy) EthDebugTraceTransaction(ctx context.Context, p jsonrpc.RawParams) (*types.EthDebugTrace, error) {
+	return nil, ErrModuleDisabled
+}
+
+func (e *ethAPIDummy) EthDebugTraceBlockByNumber(ctx context.Context, p jsonrpc.RawParams) ([]*types.EthDebugTraceBlockResult, error) {
+	return nil, ErrModuleDisabled
+}
+
+func (e *ethAPIDummy) EthTraceCall(ctx context.Context, p jsonrpc.RawParams) (*types.EthTraceResults, error) {
+	return nil, ErrModuleDisabled
+}
+
+func (e *ethAPIDummy) EthSimulateV1(ctx context.Context, p jsonrpc.RawParams) ([]types.EthSimulatedBlock, error) {
+	return nil, ErrModuleDisabled
+}
+
+func (e *ethAPIDummy) EthDebugTraceCall(ctx context.Context, p jsonrpc.RawParams) (*types.EthDebugTrace, error) {
+	return nil, ErrModuleDisabled
+}
+
 func (e *ethAPIDummy) start(_ context.Context) error {
 	return nil
 }
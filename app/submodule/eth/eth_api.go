@@ -18,6 +18,7 @@ import (
 	"github.com/filecoin-project/go-state-types/builtin/v10/evm"
 	"github.com/filecoin-project/go-state-types/exitcode"
 	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/chainindex"
 	"github.com/filecoin-project/venus/pkg/constants"
 	"github.com/filecoin-project/venus/pkg/crypto"
 	"github.com/filecoin-project/venus/pkg/ethhashlookup"
@@ -70,7 +71,9 @@ func newEthAPI(em *EthSubModule) (*ethAPI, error) {
 		TransactionHashLookup: transactionHashLookup,
 	}
 
-	if !dbAlreadyExists {
+	// the chain index maps the hashes of the messages included on chain, the lookup table
+	// then only has to record the pending ones.
+	if !dbAlreadyExists && em.chainModule.Indexer == nil {
 		err = a.ethTxHashManager.PopulateExistingMappings(em.ctx, 0)
 		if err != nil {
 			return nil, err
@@ -107,14 +110,15 @@ type ethAPI struct {
 }
 
 func (a *ethAPI) start(ctx context.Context) error {
-	ev, err := events.NewEvents(ctx, a.chain)
-	if err != nil {
-		return err
+	// Tipset listener, the chain index maps the hashes of the applied messages when it is enabled
+	if a.em.chainModule.Indexer == nil {
+		ev, err := events.NewEvents(ctx, a.chain)
+		if err != nil {
+			return err
+		}
+		_ = ev.Observe(a.ethTxHashManager)
 	}
 
-	// Tipset listener
-	_ = ev.Observe(a.ethTxHashManager)
-
 	ch, err := a.em.mpoolModule.MPool.Updates(ctx)
 	if err != nil {
 		return err
@@ -292,7 +296,7 @@ func (a *ethAPI) EthGetTransactionByHashLimited(ctx context.Context, txHash *typ
 		return nil, nil
 	}
 
-	c, err := a.ethTxHashManager.TransactionHashLookup.GetCidFromHash(*txHash)
+	c, err := a.getCidFromHash(ctx, *txHash)
 	if err != nil {
 		log.Debug("could not find transaction hash %s in lookup table", txHash.String())
 	}
@@ -345,13 +349,25 @@ func (a *ethAPI) EthGetTransactionByHashLimited(ctx context.Context, txHash *typ
 	return nil, nil
 }
 
+// getCidFromHash looks up the message of an eth transaction hash in the chain index when it is enabled,
+// the lookup table is only consulted for the transactions the index doesn't have, e.g. the pending ones.
+func (a *ethAPI) getCidFromHash(ctx context.Context, txHash types.EthHash) (cid.Cid, error) {
+	if a.em.chainModule.Indexer != nil {
+		c, err := a.em.chainModule.Indexer.GetCidFromHash(ctx, txHash)
+		if !errors.Is(err, chainindex.ErrNotFound) {
+			return c, err
+		}
+	}
+	return a.ethTxHashManager.TransactionHashLookup.GetCidFromHash(txHash)
+}
+
 func (a *ethAPI) EthGetMessageCidByTransactionHash(ctx context.Context, txHash *types.EthHash) (*cid.Cid, error) {
 	// Ethereum's behavior is to return null when the txHash is invalid, so we use nil to check if txHash is valid
 	if txHash == nil {
 		return nil, nil
 	}
 
-	c, err := a.ethTxHashManager.TransactionHashLookup.GetCidFromHash(*txHash)
+	c, err := a.getCidFromHash(ctx, *txHash)
 	// We fall out of the first condition and continue
 	if errors.Is(err, ethhashlookup.ErrNotFound) {
 		log.Debug("could not find transaction hash %s in lookup table", txHash.String())
@@ -445,7 +461,7 @@ func (a *ethAPI) EthGetTransactionReceipt(ctx context.Context, txHash types.EthH
 }
 
 func (a *ethAPI) EthGetTransactionReceiptLimited(ctx context.Context, txHash types.EthHash, limit abi.ChainEpoch) (*types.EthTxReceipt, error) {
-	c, err := a.ethTxHashManager.TransactionHashLookup.GetCidFromHash(txHash)
+	c, err := a.getCidFromHash(ctx, txHash)
 	if err != nil {
 		log.Debug("could not find transaction hash %s in lookup table", txHash.String())
 	}
@@ -1396,9 +1412,17 @@ func (a *ethAPI) EthTraceFilter(ctx context.Context, filter types.EthTraceFilter
 			}
 			return types.EthUint64(parent.Height()), nil
 		case "safe":
-			latestHeight := head.Height() - 1
-			safeHeight := latestHeight - types.SafeEpochDelay
-			return types.EthUint64(safeHeight), nil
+			ts, err := getSafeTipSet(ctx, a.em.chainModule.ChainReader, head)
+			if err != nil {
+				return 0, err
+			}
+			return types.EthUint64(ts.Height()), nil
+		case "finalized":
+			ts, err := getFinalizedTipSet(ctx, a.em.chainModule.ChainReader)
+			if err != nil {
+				return 0, err
+			}
+			return types.EthUint64(ts.Height()), nil
 		default:
 			blockNum, err := types.EthUint64FromHex(blockValue)
 			if err != nil {
@@ -0,0 +1,541 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-jsonrpc"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/venus/pkg/fork"
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	"github.com/filecoin-project/venus/pkg/statemanger"
+	"github.com/filecoin-project/venus/venus-shared/actors/adt"
+	builtinactors "github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	builtinevm "github.com/filecoin-project/venus/venus-shared/actors/builtin/evm"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// The debug_trace* methods replay the messages with statemanger.ReplayWithStates, the traces are built from
+// the execution traces like the trace_* ones and the prestates are read from the states around the messages.
+//
+// Limitations (for now):
+//
+// 1. The struct logger of geth is not supported, a tracer must be specified.
+// 2. The prestateTracer only reports the storage slots written by the transaction, the FVM doesn't trace
+//    the reads of the EVM storage.
+// 3. The messages of a block are replayed without the block rewards and the cron of the null rounds
+//    before the block.
+
+// EthDebugTraceTransaction implements geth-compatible API method debug_traceTransaction
+func (a *ethAPI) EthDebugTraceTransaction(ctx context.Context, p jsonrpc.RawParams) (*types.EthDebugTrace, error) {
+	params, err := jsonrpc.DecodeParams[types.EthDebugTraceTransactionParams](p)
+	if err != nil {
+		return nil, fmt.Errorf("decoding params: %w", err)
+	}
+	config, err := checkTraceConfig(params.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := a.EthGetTransactionByHash(ctx, &params.TxHash)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get transaction by hash: %w", err)
+	}
+	if tx == nil {
+		return nil, errors.New("transaction not found")
+	}
+	// tx.BlockNumber is nil when the transaction is still in the mpool/pending
+	if tx.BlockNumber == nil {
+		return nil, errors.New("no trace for pending transactions")
+	}
+
+	msgCid, err := a.EthGetMessageCidByTransactionHash(ctx, &params.TxHash)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get message cid: %w", err)
+	}
+	if msgCid == nil {
+		return nil, errors.New("transaction not found")
+	}
+
+	ts, err := getTipsetByBlockNumber(ctx, a.em.chainModule.ChainReader, strconv.FormatUint(uint64(*tx.BlockNumber), 10), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tipset: %w", err)
+	}
+
+	traces, err := a.debugTraceTipSet(ctx, ts, config, *msgCid)
+	if err != nil {
+		return nil, err
+	}
+	if len(traces) == 0 {
+		return nil, fmt.Errorf("message %s not found in tipset %s", msgCid, ts.Key())
+	}
+
+	return &traces[0].Result, nil
+}
+
+// EthDebugTraceBlockByNumber implements geth-compatible API method debug_traceBlockByNumber
+func (a *ethAPI) EthDebugTraceBlockByNumber(ctx context.Context, p jsonrpc.RawParams) ([]*types.EthDebugTraceBlockResult, error) {
+	params, err := jsonrpc.DecodeParams[types.EthDebugTraceBlockParams](p)
+	if err != nil {
+		return nil, fmt.Errorf("decoding params: %w", err)
+	}
+	config, err := checkTraceConfig(params.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := getTipsetByBlockNumber(ctx, a.em.chainModule.ChainReader, params.BlkNum, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tipset: %w", err)
+	}
+
+	return a.debugTraceTipSet(ctx, ts, config, cid.Undef)
+}
+
+// EthDebugTraceCall implements geth-compatible API method debug_traceCall
+func (a *ethAPI) EthDebugTraceCall(ctx context.Context, p jsonrpc.RawParams) (*types.EthDebugTrace, error) {
+	params, err := jsonrpc.DecodeParams[types.EthDebugTraceCallParams](p)
+	if err != nil {
+		return nil, fmt.Errorf("decoding params: %w", err)
+	}
+	config, err := checkTraceConfig(params.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := params.Tx.ToFilecoinMessage()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert ethcall to filecoin message: %w", err)
+	}
+	ts, err := getTipsetByEthBlockNumberOrHash(ctx, a.em.chainModule.ChainReader, params.BlkParam)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process block param: %v, %w", params.BlkParam, err)
+	}
+
+	if ts.Height() > 0 {
+		pts, err := a.chain.ChainGetTipSet(ctx, ts.Parents())
+		if err != nil {
+			return nil, fmt.Errorf("failed to find a non-forking epoch: %w", err)
+		}
+		// Check for expensive forks from the parents to the tipset, including nil tipsets
+		if a.em.chainModule.Fork.HasExpensiveForkBetween(pts.Height(), ts.Height()+1) {
+			return nil, fork.ErrExpensiveFork
+		}
+	}
+
+	st, err := a.em.chainModule.ChainReader.GetTipSetStateRoot(ctx, ts)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get tipset state: %w", err)
+	}
+	res, states, err := a.em.chainModule.Stmgr.ApplyOnStateWithStates(ctx, st, msg, ts)
+	if err != nil {
+		return nil, fmt.Errorf("ApplyOnStateWithStates failed: %w", err)
+	}
+
+	return debugTrace(ctx, res, states, config)
+}
+
+func checkTraceConfig(config *types.EthTraceConfig) (types.EthTraceConfig, error) {
+	if config == nil || config.Tracer == "" {
+		return types.EthTraceConfig{}, fmt.Errorf("a tracer is required, supported tracers: %s, %s", types.EthCallTracer, types.EthPrestateTracer)
+	}
+	switch config.Tracer {
+	case types.EthCallTracer, types.EthPrestateTracer:
+		return *config, nil
+	}
+	return types.EthTraceConfig{}, fmt.Errorf("unsupported tracer %q, supported tracers: %s, %s", config.Tracer, types.EthCallTracer, types.EthPrestateTracer)
+}
+
+// debugTraceTipSet traces the messages of ts, or only the message msgCid when it is defined.
+func (a *ethAPI) debugTraceTipSet(ctx context.Context, ts *types.TipSet, config types.EthTraceConfig, msgCid cid.Cid) ([]*types.EthDebugTraceBlockResult, error) {
+	var traces []*types.EthDebugTraceBlockResult
+	err := a.em.chainModule.Stmgr.ReplayWithStates(ctx, ts, func(msg types.ChainMsg, res *types.InvocResult, states *statemanger.CallStates) error {
+		if msgCid.Defined() && !msgCid.Equals(res.MsgCid) {
+			return nil
+		}
+
+		txHash, err := a.EthGetTransactionHashByCid(ctx, res.MsgCid)
+		if err != nil {
+			return fmt.Errorf("failed to get transaction hash by cid: %w", err)
+		}
+		if txHash == nil {
+			return fmt.Errorf("cannot find transaction hash for cid %s", res.MsgCid)
+		}
+
+		trace, err := debugTrace(ctx, res, states, config)
+		if err != nil {
+			return fmt.Errorf("failed to trace message %s: %w", res.MsgCid, err)
+		}
+		traces = append(traces, &types.EthDebugTraceBlockResult{TxHash: *txHash, Result: *trace})
+
+		if msgCid.Defined() {
+			return statemanger.ErrStopReplay
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return traces, nil
+}
+
+func debugTrace(ctx context.Context, res *types.InvocResult, states *statemanger.CallStates, config types.EthTraceConfig) (*types.EthDebugTrace, error) {
+	pre, err := tree.LoadState(ctx, states.Store, states.Pre)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state before the message: %w", err)
+	}
+
+	if config.Tracer == types.EthCallTracer {
+		frame, err := callFrame(ctx, res, pre)
+		if err != nil {
+			return nil, err
+		}
+		if config.TracerConfig.OnlyTopCall {
+			frame.Calls = nil
+		}
+		return &types.EthDebugTrace{CallFrame: frame}, nil
+	}
+
+	post, err := tree.LoadState(ctx, states.Store, states.Post)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state after the message: %w", err)
+	}
+	if config.TracerConfig.DiffMode {
+		diff, err := prestateDiff(ctx, res, pre, post)
+		if err != nil {
+			return nil, err
+		}
+		return &types.EthDebugTrace{Diff: diff}, nil
+	}
+	prestate, err := prestate(ctx, res, pre, post)
+	if err != nil {
+		return nil, err
+	}
+	return &types.EthDebugTrace{Prestate: prestate}, nil
+}
+
+// callFrame builds the call tree of the callTracer from the traces of buildTraces
+func callFrame(ctx context.Context, res *types.InvocResult, state tree.Tree) (*types.EthCallFrame, error) {
+	env, err := baseEnvironment(ctx, res.Msg.From, state)
+	if err != nil {
+		return nil, fmt.Errorf("when processing message %s: %w", res.MsgCid, err)
+	}
+	if err := buildTraces(env, []int{}, &res.ExecutionTrace); err != nil {
+		return nil, fmt.Errorf("failed building traces for msg %s: %w", res.MsgCid, err)
+	}
+
+	if len(env.traces) == 0 {
+		// the message failed before invoking the receiver
+		frame := &types.EthCallFrame{
+			Type:    "CALL",
+			From:    env.caller,
+			Gas:     types.EthUint64(res.Msg.GasLimit),
+			GasUsed: types.EthUint64(res.MsgRct.GasUsed),
+			Input:   res.Msg.Params,
+			Error:   res.Error,
+		}
+		if to, err := lookupEthAddress(ctx, res.Msg.To, state); err == nil {
+			frame.To = &to
+		}
+		value := types.EthBigInt(res.Msg.Value)
+		frame.Value = &value
+		if frame.Error == "" {
+			frame.Error = res.MsgRct.ExitCode.String()
+		}
+		return frame, nil
+	}
+
+	return callFrameTree(env.traces), nil
+}
+
+// callFrameTree nests the traces, which are ordered depth first, by their trace address
+func callFrameTree(traces []*types.EthTrace) *types.EthCallFrame {
+	var root *types.EthCallFrame
+	stack := make([]*types.EthCallFrame, 0)
+	for _, trace := range traces {
+		frame := callFrameFromTrace(trace)
+		depth := len(trace.TraceAddress)
+		if depth == 0 {
+			root = frame
+			stack = append(stack[:0], frame)
+			continue
+		}
+		if depth > len(stack) {
+			// the parent was skipped by buildTraces, attach the frame to the closest ancestor
+			depth = len(stack)
+		}
+		parent := stack[depth-1]
+		parent.Calls = append(parent.Calls, frame)
+		stack = append(stack[:depth], frame)
+	}
+	return root
+}
+
+func callFrameFromTrace(trace *types.EthTrace) *types.EthCallFrame {
+	frame := &types.EthCallFrame{Error: trace.Error}
+	switch action := trace.Action.(type) {
+	case *types.EthCallTraceAction:
+		frame.Type = strings.ToUpper(action.CallType)
+		frame.From = action.From
+		to := action.To
+		frame.To = &to
+		frame.Gas = action.Gas
+		frame.Input = action.Input
+		// the value of a delegate call is the one of its parent
+		if action.CallType != "delegatecall" {
+			value := action.Value
+			frame.Value = &value
+		}
+	case *types.EthCreateTraceAction:
+		frame.Type = "CREATE"
+		frame.From = action.From
+		frame.Gas = action.Gas
+		frame.Input = action.Init
+		value := action.Value
+		frame.Value = &value
+	}
+	switch result := trace.Result.(type) {
+	case *types.EthCallTraceResult:
+		frame.GasUsed = result.GasUsed
+		frame.Output = result.Output
+	case *types.EthCreateTraceResult:
+		frame.To = result.Address
+		frame.GasUsed = result.GasUsed
+		frame.Output = result.Code
+	}
+	return frame
+}
+
+// touchedAccount is an actor touched by a message, with its state before and after the message
+type touchedAccount struct {
+	addr        types.EthAddress
+	pre, post   *types.Actor
+	preStorage  map[types.EthHash]types.EthHash
+	postStorage map[types.EthHash]types.EthHash
+}
+
+func prestate(ctx context.Context, res *types.InvocResult, pre, post tree.Tree) (types.EthPrestate, error) {
+	accounts, err := touchedAccounts(ctx, res, pre, post)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(types.EthPrestate, len(accounts))
+	for _, acc := range accounts {
+		if acc.pre == nil {
+			out[acc.addr] = &types.EthPrestateAccount{}
+			continue
+		}
+		account, err := prestateAccount(ctx, pre, acc.pre)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read account %s: %w", acc.addr, err)
+		}
+		// the slots written by the message, the ones which didn't exist are zero
+		for k := range acc.postStorage {
+			if _, ok := acc.preStorage[k]; !ok {
+				acc.preStorage[k] = types.EthHash{}
+			}
+		}
+		if len(acc.preStorage) > 0 {
+			account.Storage = acc.preStorage
+		}
+		out[acc.addr] = account
+	}
+
+	return out, nil
+}
+
+func prestateDiff(ctx context.Context, res *types.InvocResult, pre, post tree.Tree) (*types.EthPrestateDiff, error) {
+	accounts, err := touchedAccounts(ctx, res, pre, post)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &types.EthPrestateDiff{Pre: types.EthPrestate{}, Post: types.EthPrestate{}}
+	for _, acc := range accounts {
+		var preAccount, postAccount *types.EthPrestateAccount
+		if acc.pre != nil {
+			if preAccount, err = prestateAccount(ctx, pre, acc.pre); err != nil {
+				return nil, fmt.Errorf("failed to read account %s: %w", acc.addr, err)
+			}
+		}
+		if acc.post != nil {
+			if postAccount, err = prestateAccount(ctx, post, acc.post); err != nil {
+				return nil, fmt.Errorf("failed to read account %s: %w", acc.addr, err)
+			}
+		}
+
+		// post only holds the fields modified by the message
+		changed := &types.EthPrestateAccount{}
+		modified := len(acc.preStorage) > 0 || len(acc.postStorage) > 0
+		switch {
+		case postAccount == nil:
+			modified = modified || preAccount != nil
+		case preAccount == nil:
+			changed = postAccount
+			modified = true
+		default:
+			if big.Cmp(big.Int(*preAccount.Balance), big.Int(*postAccount.Balance)) != 0 {
+				changed.Balance = postAccount.Balance
+				modified = true
+			}
+			if preAccount.Nonce != postAccount.Nonce {
+				changed.Nonce = postAccount.Nonce
+				modified = true
+			}
+			if !bytes.Equal(preAccount.Code, postAccount.Code) {
+				changed.Code = postAccount.Code
+				modified = true
+			}
+		}
+		if !modified {
+			continue
+		}
+
+		if preAccount != nil {
+			if len(acc.preStorage) > 0 {
+				preAccount.Storage = acc.preStorage
+			}
+			diff.Pre[acc.addr] = preAccount
+		}
+		if postAccount != nil {
+			if len(acc.postStorage) > 0 {
+				changed.Storage = acc.postStorage
+			}
+			diff.Post[acc.addr] = changed
+		}
+	}
+
+	return diff, nil
+}
+
+// touchedAccounts returns the sender and receiver of the message and the actors invoked by it, with the
+// storage slots of the contracts modified by the message.
+func touchedAccounts(ctx context.Context, res *types.InvocResult, pre, post tree.Tree) ([]*touchedAccount, error) {
+	addrs := []address.Address{res.Msg.From, res.Msg.To}
+	var walk func(et *types.ExecutionTrace)
+	walk = func(et *types.ExecutionTrace) {
+		if et.InvokedActor != nil {
+			addrs = append(addrs, mustIDAddress(et.InvokedActor.Id))
+		}
+		for i := range et.Subcalls {
+			walk(&et.Subcalls[i])
+		}
+	}
+	walk(&res.ExecutionTrace)
+
+	seen := make(map[address.Address]struct{})
+	var accounts []*touchedAccount
+	for _, addr := range addrs {
+		state := pre
+		id, err := pre.LookupID(addr)
+		if err != nil {
+			// the actor is created by the message
+			state = post
+			if id, err = post.LookupID(addr); err != nil {
+				continue
+			}
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		acc := &touchedAccount{}
+		if acc.addr, err = lookupEthAddress(ctx, id, state); err != nil {
+			return nil, fmt.Errorf("failed to lookup eth address of %s: %w", addr, err)
+		}
+		if acc.pre, err = getActor(ctx, pre, id); err != nil {
+			return nil, err
+		}
+		if acc.post, err = getActor(ctx, post, id); err != nil {
+			return nil, err
+		}
+		preRoot, err := contractStateRoot(ctx, pre, acc.pre)
+		if err != nil {
+			return nil, err
+		}
+		postRoot, err := contractStateRoot(ctx, post, acc.post)
+		if err != nil {
+			return nil, err
+		}
+		if acc.preStorage, acc.postStorage, err = builtinevm.DiffStorage(ctx, post.GetStore(), preRoot, postRoot); err != nil {
+			return nil, fmt.Errorf("failed to diff the storage of %s: %w", acc.addr, err)
+		}
+		accounts = append(accounts, acc)
+	}
+
+	return accounts, nil
+}
+
+func mustIDAddress(id abi.ActorID) address.Address {
+	addr, _ := address.NewIDAddress(uint64(id))
+	return addr
+}
+
+func getActor(ctx context.Context, state tree.Tree, addr address.Address) (*types.Actor, error) {
+	act, found, err := state.GetActor(ctx, addr)
+	if err != nil && !errors.Is(err, types.ErrActorNotFound) {
+		return nil, fmt.Errorf("failed to load actor %s: %w", addr, err)
+	}
+	if !found {
+		return nil, nil
+	}
+	return act, nil
+}
+
+func loadEvmState(ctx context.Context, state tree.Tree, act *types.Actor) (builtinevm.State, error) {
+	if act == nil || !builtinactors.IsEvmActor(act.Code) {
+		return nil, nil
+	}
+	evmState, err := builtinevm.Load(adt.WrapStore(ctx, state.GetStore()), act)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load evm state: %w", err)
+	}
+	return evmState, nil
+}
+
+func contractStateRoot(ctx context.Context, state tree.Tree, act *types.Actor) (cid.Cid, error) {
+	evmState, err := loadEvmState(ctx, state, act)
+	if err != nil || evmState == nil {
+		return cid.Undef, err
+	}
+	return evmState.GetContractStateCID()
+}
+
+// prestateAccount reads the balance, the nonce and the code of act, the nonce of a contract is the one of
+// the EVM.
+func prestateAccount(ctx context.Context, state tree.Tree, act *types.Actor) (*types.EthPrestateAccount, error) {
+	balance := types.EthBigInt(act.Balance)
+	account := &types.EthPrestateAccount{
+		Balance: &balance,
+		Nonce:   types.EthUint64(act.Nonce),
+	}
+
+	evmState, err := loadEvmState(ctx, state, act)
+	if err != nil || evmState == nil {
+		return account, err
+	}
+	nonce, err := evmState.Nonce()
+	if err != nil {
+		return nil, err
+	}
+	account.Nonce = types.EthUint64(nonce)
+	if alive, err := evmState.IsAlive(); err != nil || !alive {
+		return account, err
+	}
+	if account.Code, err = evmState.GetBytecode(); err != nil {
+		return nil, fmt.Errorf("failed to load bytecode: %w", err)
+	}
+
+	return account, nil
+}
@@ -0,0 +1,57 @@
+package eth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestCallFrameTree(t *testing.T) {
+	addr := func(b byte) types.EthAddress { return types.EthAddress{b} }
+	call := func(callType string, from, to byte, traceAddr ...int) *types.EthTrace {
+		return &types.EthTrace{
+			Type:         "call",
+			TraceAddress: traceAddr,
+			Action: &types.EthCallTraceAction{
+				CallType: callType,
+				From:     addr(from),
+				To:       addr(to),
+				Value:    types.EthBigInt(big.NewInt(int64(to))),
+			},
+			Result: &types.EthCallTraceResult{GasUsed: types.EthUint64(to)},
+		}
+	}
+	created := addr(4)
+	traces := []*types.EthTrace{
+		call("call", 0, 1),
+		call("staticcall", 1, 2, 0),
+		call("delegatecall", 2, 3, 0, 0),
+		{
+			Type:         "create",
+			TraceAddress: []int{1},
+			Action:       &types.EthCreateTraceAction{From: addr(1), Init: types.EthBytes{1}},
+			Result:       &types.EthCreateTraceResult{Address: &created, Code: types.EthBytes{2}},
+		},
+	}
+
+	root := callFrameTree(traces)
+	require.Equal(t, "CALL", root.Type)
+	require.Equal(t, addr(1), *root.To)
+	require.Len(t, root.Calls, 2)
+
+	static := root.Calls[0]
+	require.Equal(t, "STATICCALL", static.Type)
+	require.Equal(t, types.EthUint64(2), static.GasUsed)
+	require.Len(t, static.Calls, 1)
+	require.Equal(t, "DELEGATECALL", static.Calls[0].Type)
+	require.Nil(t, static.Calls[0].Value)
+
+	create := root.Calls[1]
+	require.Equal(t, "CREATE", create.Type)
+	require.Equal(t, created, *create.To)
+	require.Equal(t, types.EthBytes{1}, create.Input)
+	require.Equal(t, types.EthBytes{2}, create.Output)
+}
@@ -2,6 +2,7 @@ package eth
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -16,6 +17,7 @@ import (
 	"github.com/filecoin-project/venus/pkg/chain"
 	"github.com/filecoin-project/venus/pkg/events"
 	"github.com/filecoin-project/venus/pkg/events/filter"
+	"github.com/filecoin-project/venus/pkg/events/filter/sqlite"
 	"github.com/filecoin-project/venus/pkg/statemanger"
 	"github.com/filecoin-project/venus/venus-shared/api"
 	v1 "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
@@ -24,6 +26,8 @@ import (
 	"github.com/ipfs/go-cid"
 	"github.com/multiformats/go-multicodec"
 	"github.com/zyedidia/generic/queue"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
 )
 
 var (
@@ -55,7 +59,6 @@ func newEthEventAPI(ctx context.Context, em *EthSubModule) (*ethEventAPI, error)
 		stmgr:        ee.em.chainModule.Stmgr,
 		messageStore: ee.em.chainModule.MessageStore,
 	}
-	ee.FilterStore = filter.NewMemFilterStore(cfg.Event.MaxFilters)
 
 	// Enable indexing of actor events
 	var eventIndex *filter.EventIndex
@@ -102,9 +105,52 @@ func newEthEventAPI(ctx context.Context, em *EthSubModule) (*ethEventAPI, error)
 		MaxFilterResults: cfg.Event.MaxFilterResults,
 	}
 
+	var err error
+	if ee.FilterStore, err = ee.newFilterStore(ctx); err != nil {
+		return nil, fmt.Errorf("failed to setup filter store: %w", err)
+	}
+
 	return ee, nil
 }
 
+// newFilterStore returns the configured store of the filters, the filters are only kept in memory by default
+func (e *ethEventAPI) newFilterStore(ctx context.Context) (filter.FilterStore, error) {
+	cfg := e.em.cfg.FevmConfig.Event
+	switch cfg.FilterStore.Type {
+	case "", "memory":
+		return filter.NewMemFilterStore(cfg.MaxFilters), nil
+	case "sqlite":
+		dbPath := cfg.FilterStore.DatabasePath
+		if len(dbPath) == 0 {
+			dbPath = filepath.Join(e.em.sqlitePath, "filters.db")
+		}
+		db, _, err := sqlite.Open(dbPath)
+		if err != nil {
+			return nil, err
+		}
+		e.filterDB = db
+	case "mysql":
+		mysqlCfg := cfg.FilterStore.MySQL
+		gormDB, err := gorm.Open(mysql.Open(mysqlCfg.ConnectionString))
+		if err != nil {
+			return nil, fmt.Errorf("[db connection failed] Connection : %s %w", mysqlCfg.ConnectionString, err)
+		}
+		db, err := gormDB.DB()
+		if err != nil {
+			return nil, err
+		}
+		db.SetMaxIdleConns(mysqlCfg.MaxIdleConn)
+		db.SetMaxOpenConns(mysqlCfg.MaxOpenConn)
+		db.SetConnMaxLifetime(time.Second * mysqlCfg.ConnMaxLifeTime)
+		e.filterDB = db
+	default:
+		return nil, fmt.Errorf("unknown filter store type %q", cfg.FilterStore.Type)
+	}
+
+	return filter.NewSQLFilterStore(ctx, e.filterDB, cfg.MaxFilters, e.em.chainModule.ChainReader, e.EventFilterManager,
+		e.TipSetFilterManager, e.MemPoolFilterManager)
+}
+
 type ethEventAPI struct {
 	em                   *EthSubModule
 	ChainAPI             v1.IChain
@@ -116,7 +162,8 @@ type ethEventAPI struct {
 	MaxFilterHeightRange abi.ChainEpoch
 	SubscribtionCtx      context.Context
 
-	disable bool
+	filterDB *sql.DB // the database of FilterStore, if persistent
+	disable  bool
 }
 
 func (e *ethEventAPI) Start(ctx context.Context) error {
@@ -145,6 +192,11 @@ func (e *ethEventAPI) Start(ctx context.Context) error {
 }
 
 func (e *ethEventAPI) Close(ctx context.Context) error {
+	if e.filterDB != nil {
+		if err := e.filterDB.Close(); err != nil {
+			return err
+		}
+	}
 	if e.EventFilterManager != nil && e.EventFilterManager.EventIndex != nil {
 		return e.EventFilterManager.EventIndex.Close()
 	}
@@ -307,17 +359,28 @@ func (e *ethEventAPI) EthGetFilterChanges(ctx context.Context, id types.EthFilte
 	if err != nil {
 		return nil, err
 	}
+	// read before taking the results, a result collected in between is taken again rather than missed
+	cursor := e.em.chainModule.ChainReader.GetHead().Height()
 
+	var res *types.EthFilterResult
 	switch fc := f.(type) {
 	case filterEventCollector:
-		return ethFilterResultFromEvents(ctx, fc.TakeCollectedEvents(ctx), e.em.chainModule.MessageStore)
+		res, err = ethFilterResultFromEvents(ctx, fc.TakeCollectedEvents(ctx), e.em.chainModule.MessageStore)
 	case filterTipSetCollector:
-		return ethFilterResultFromTipSets(fc.TakeCollectedTipSets(ctx))
+		res, err = ethFilterResultFromTipSets(fc.TakeCollectedTipSets(ctx))
 	case filterMessageCollector:
-		return ethFilterResultFromMessages(fc.TakeCollectedMessages(ctx))
+		res, err = ethFilterResultFromMessages(fc.TakeCollectedMessages(ctx))
+	default:
+		return nil, fmt.Errorf("unknown filter type")
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, fmt.Errorf("unknown filter type")
+	if err := e.FilterStore.MarkTaken(ctx, f.ID(), cursor); err != nil {
+		return nil, err
+	}
+	return res, nil
 }
 
 func (e *ethEventAPI) EthGetFilterLogs(ctx context.Context, id types.EthFilterID) (*types.EthFilterResult, error) {
@@ -329,10 +392,18 @@ func (e *ethEventAPI) EthGetFilterLogs(ctx context.Context, id types.EthFilterID
 	if err != nil {
 		return nil, err
 	}
+	cursor := e.em.chainModule.ChainReader.GetHead().Height()
 
 	switch fc := f.(type) {
 	case filterEventCollector:
-		return ethFilterResultFromEvents(ctx, fc.TakeCollectedEvents(ctx), e.em.chainModule.MessageStore)
+		res, err := ethFilterResultFromEvents(ctx, fc.TakeCollectedEvents(ctx), e.em.chainModule.MessageStore)
+		if err != nil {
+			return nil, err
+		}
+		if err := e.FilterStore.MarkTaken(ctx, f.ID(), cursor); err != nil {
+			return nil, err
+		}
+		return res, nil
 	}
 
 	return nil, fmt.Errorf("wrong filter type")
@@ -0,0 +1,38 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/filecoin-project/venus/venus-shared/utils/ethproof"
+)
+
+// EthGetProof proves the account and the storage slots against the parent state root of the tipset, like
+// EthGetStorageAt and EthGetCode read them, so that the proof can be checked with ethproof.Verify and the
+// parent state of the tipset returned by ChainGetTipSet.
+func (a *ethAPI) EthGetProof(ctx context.Context, address types.EthAddress, storageKeys []types.EthBytes, blkParam types.EthBlockNumberOrHash) (*types.EthProof, error) {
+	ts, err := getTipsetByEthBlockNumberOrHash(ctx, a.em.chainModule.ChainReader, blkParam)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process block param: %v, %w", blkParam, err)
+	}
+
+	keys := make([]types.EthHash, 0, len(storageKeys))
+	for _, position := range storageKeys {
+		l := len(position)
+		if l > 32 {
+			return nil, errors.New("supplied storage key is too long")
+		}
+		var key types.EthHash
+		copy(key[32-l:], position)
+		keys = append(keys, key)
+	}
+
+	// make sure the parent state of the tipset is the one computed by this node
+	if _, _, err := a.em.chainModule.Stmgr.ParentState(ctx, ts); err != nil {
+		return nil, fmt.Errorf("failed to load parent state of %s: %w", ts.Key(), err)
+	}
+
+	return ethproof.Prove(ctx, a.em.chainModule.ChainReader.Blockstore(), ts.ParentState(), address, keys)
+}
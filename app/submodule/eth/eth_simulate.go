@@ -0,0 +1,432 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-jsonrpc"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/manifest"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	mh "github.com/multiformats/go-multihash"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	"github.com/filecoin-project/venus/pkg/statemanger"
+	"github.com/filecoin-project/venus/pkg/vm/vmcontext"
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/actors/adt"
+	builtinactors "github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	builtinevm "github.com/filecoin-project/venus/venus-shared/actors/builtin/evm"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// the error codes of the failed calls of eth_simulateV1
+const (
+	simulateErrReverted  = 3
+	simulateErrExecution = -32015
+)
+
+// the limits of a request of eth_simulateV1, like geth
+const (
+	maxSimulateBlocks = 256
+	maxSimulateCalls  = 1000
+)
+
+// bytecodePrefix is the prefix of the CIDs of the EVM bytecode, stored as raw blocks
+var bytecodePrefix = cid.Prefix{
+	Version:  1,
+	Codec:    cid.Raw,
+	MhType:   uint64(mh.BLAKE2B_MIN + 31),
+	MhLength: 32,
+}
+
+func (a *ethAPI) EthTraceCall(ctx context.Context, p jsonrpc.RawParams) (*types.EthTraceResults, error) {
+	params, err := jsonrpc.DecodeParams[types.EthTraceCallParams](p)
+	if err != nil {
+		return nil, fmt.Errorf("decoding params: %w", err)
+	}
+	if len(params.TraceTypes) != 1 || params.TraceTypes[0] != "trace" {
+		return nil, errors.New("only 'trace' is supported")
+	}
+
+	blkParam := types.NewEthBlockNumberOrHashFromPredefined("latest")
+	if params.BlkParam != nil {
+		blkParam = *params.BlkParam
+	}
+	ts, err := getTipsetByEthBlockNumberOrHash(ctx, a.em.chainModule.ChainReader, blkParam)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process block param: %v, %w", blkParam, err)
+	}
+
+	results, err := a.simulate(ctx, ts, []types.EthBlockStateCalls{{
+		StateOverrides: params.StateOverrides,
+		Calls:          []types.EthCall{params.Tx},
+	}})
+	if err != nil {
+		return nil, err
+	}
+	traces, err := simulatedTraces(ctx, results[0][0])
+	if err != nil {
+		return nil, err
+	}
+
+	var output []byte
+	if len(traces) > 0 {
+		switch r := traces[0].Result.(type) {
+		case *types.EthCallTraceResult:
+			output = r.Output
+		case *types.EthCreateTraceResult:
+			output = r.Code
+		}
+	}
+
+	return &types.EthTraceResults{
+		Output: output,
+		Trace:  traces,
+	}, nil
+}
+
+func (a *ethAPI) EthSimulateV1(ctx context.Context, p jsonrpc.RawParams) ([]types.EthSimulatedBlock, error) {
+	params, err := jsonrpc.DecodeParams[types.EthSimulateParams](p)
+	if err != nil {
+		return nil, fmt.Errorf("decoding params: %w", err)
+	}
+	if err := checkSimulateLimits(params.Payload.BlockStateCalls); err != nil {
+		return nil, err
+	}
+
+	blkParam := types.NewEthBlockNumberOrHashFromPredefined("latest")
+	if params.BlkParam != nil {
+		blkParam = *params.BlkParam
+	}
+	ts, err := getTipsetByEthBlockNumberOrHash(ctx, a.em.chainModule.ChainReader, blkParam)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process block param: %v, %w", blkParam, err)
+	}
+
+	results, err := a.simulate(ctx, ts, params.Payload.BlockStateCalls)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]types.EthSimulatedBlock, 0, len(results))
+	for i, calls := range results {
+		// the blocks are applied at the epochs following ts, see Stmgr.Simulate
+		blk := types.EthSimulatedBlock{
+			Number: types.EthUint64(ts.Height()) + 1 + types.EthUint64(i),
+			Calls:  make([]types.EthSimulatedCall, 0, len(calls)),
+		}
+		logIndex := 0
+		for i, call := range calls {
+			simulated, err := simulatedCall(ctx, call, i, &logIndex, blk.Number)
+			if err != nil {
+				return nil, fmt.Errorf("call %d: %w", i, err)
+			}
+			blk.Calls = append(blk.Calls, *simulated)
+		}
+		out = append(out, blk)
+	}
+
+	return out, nil
+}
+
+// checkSimulateLimits refuses the requests of eth_simulateV1 with too many blocks or calls
+func checkSimulateLimits(blocks []types.EthBlockStateCalls) error {
+	if len(blocks) > maxSimulateBlocks {
+		return fmt.Errorf("too many blocks: %d, the maximum is %d", len(blocks), maxSimulateBlocks)
+	}
+	calls := 0
+	for _, blk := range blocks {
+		calls += len(blk.Calls)
+	}
+	if calls > maxSimulateCalls {
+		return fmt.Errorf("too many calls: %d, the maximum is %d", calls, maxSimulateCalls)
+	}
+	return nil
+}
+
+// simulate applies the calls of the blocks on the state of ts, like EthCall, after their state overrides.
+func (a *ethAPI) simulate(ctx context.Context, ts *types.TipSet, blockStateCalls []types.EthBlockStateCalls) ([][]*statemanger.SimulatedCall, error) {
+	stateRoot, err := a.em.chainModule.ChainReader.GetTipSetStateRoot(ctx, ts)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get tipset state: %w", err)
+	}
+	av, err := actorstypes.VersionForNetwork(a.em.chainModule.Stmgr.GetNetworkVersion(ctx, ts.Height()))
+	if err != nil {
+		return nil, err
+	}
+
+	simBlocks := make([]statemanger.SimulationBlock, 0, len(blockStateCalls))
+	for i, blk := range blockStateCalls {
+		simBlock := statemanger.SimulationBlock{Msgs: make([]*types.Message, 0, len(blk.Calls))}
+		for j, call := range blk.Calls {
+			msg, err := call.ToFilecoinMessage()
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert call %d of block %d to filecoin message: %w", j, i, err)
+			}
+			simBlock.Msgs = append(simBlock.Msgs, msg)
+		}
+		if overrides := blk.StateOverrides; len(overrides) > 0 {
+			simBlock.Override = func(ctx context.Context, bs blockstoreutil.Blockstore, st *tree.State) error {
+				return applyStateOverride(ctx, bs, st, av, overrides)
+			}
+		}
+		simBlocks = append(simBlocks, simBlock)
+	}
+
+	return a.em.chainModule.Stmgr.Simulate(ctx, ts, stateRoot, a.em.cfg.NetworkParams.BlockDelay, simBlocks)
+}
+
+// applyStateOverride applies the overrides to st, the accounts which don't exist are created as EthAccount
+// actors, or EVM actors when their code is overridden.
+func applyStateOverride(ctx context.Context, bs blockstoreutil.Blockstore, st *tree.State, av actorstypes.Version, overrides types.EthStateOverride) error {
+	addrs := make([]types.EthAddress, 0, len(overrides))
+	for addr := range overrides {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i][:], addrs[j][:]) < 0
+	})
+
+	for _, addr := range addrs {
+		if err := applyAccountOverride(ctx, bs, st, av, addr, overrides[addr]); err != nil {
+			return fmt.Errorf("account %s: %w", addr, err)
+		}
+	}
+	return nil
+}
+
+func applyAccountOverride(ctx context.Context, bs blockstoreutil.Blockstore, st *tree.State, av actorstypes.Version, addr types.EthAddress, override types.EthAccountOverride) error {
+	if override.State != nil && override.StateDiff != nil {
+		return errors.New("state and stateDiff can't be both set")
+	}
+	codeID := func(name string) (cid.Cid, error) {
+		c, ok := actors.GetActorCodeID(av, name)
+		if !ok {
+			return cid.Undef, fmt.Errorf("failed to get %s actor code ID for actors version %d", name, av)
+		}
+		return c, nil
+	}
+
+	filAddr, err := addr.ToFilecoinAddress()
+	if err != nil {
+		return fmt.Errorf("cannot get Filecoin address: %w", err)
+	}
+	act, found, err := st.GetActor(ctx, filAddr)
+	if err != nil {
+		return fmt.Errorf("failed to get actor: %w", err)
+	}
+	if found {
+		if filAddr, err = st.LookupID(filAddr); err != nil {
+			return err
+		}
+	} else {
+		if filAddr.Protocol() != address.Delegated {
+			return errors.New("only accounts with an Ethereum address can be created")
+		}
+		ethAccount, err := codeID(manifest.EthAccountKey)
+		if err != nil {
+			return err
+		}
+		delegated := filAddr
+		act = &types.Actor{
+			Code:             ethAccount,
+			Head:             vmcontext.EmptyObjectCid,
+			Balance:          big.Zero(),
+			DelegatedAddress: &delegated,
+		}
+		if filAddr, err = st.RegisterNewAddress(delegated); err != nil {
+			return fmt.Errorf("failed to register address: %w", err)
+		}
+	}
+
+	if override.Balance != nil {
+		act.Balance = big.Int(*override.Balance)
+	}
+
+	adtStore := adt.WrapStore(ctx, st.GetStore())
+	var evmState builtinevm.State
+	if builtinactors.IsEvmActor(act.Code) {
+		if evmState, err = builtinevm.Load(adtStore, act); err != nil {
+			return fmt.Errorf("failed to load evm state: %w", err)
+		}
+	}
+
+	if override.Code != nil {
+		if evmState == nil && !builtinactors.IsEthAccountActor(act.Code) && !builtinactors.IsPlaceholderActor(act.Code) {
+			return errors.New("only the code of the accounts with an Ethereum address can be overridden")
+		}
+
+		code := *override.Code
+		bytecode, err := bytecodePrefix.Sum(code)
+		if err != nil {
+			return err
+		}
+		blk, err := blocks.NewBlockWithCid(code, bytecode)
+		if err != nil {
+			return err
+		}
+		if err := bs.Put(ctx, blk); err != nil {
+			return fmt.Errorf("failed to store code: %w", err)
+		}
+		var hash [32]byte
+		hasher := sha3.NewLegacyKeccak256()
+		hasher.Write(code)
+		copy(hash[:], hasher.Sum(nil))
+
+		if evmState == nil {
+			if act.Code, err = codeID(manifest.EvmKey); err != nil {
+				return err
+			}
+			if evmState, err = builtinevm.MakeState(adtStore, av, bytecode); err != nil {
+				return fmt.Errorf("failed to make evm state: %w", err)
+			}
+			// the nonce of a contract is kept in its state
+			if err := evmState.SetNonce(act.Nonce); err != nil {
+				return err
+			}
+		}
+		if err := evmState.SetBytecode(bytecode, hash); err != nil {
+			return err
+		}
+	}
+
+	if override.Nonce != nil {
+		if evmState != nil {
+			err = evmState.SetNonce(uint64(*override.Nonce))
+		} else {
+			act.Nonce = uint64(*override.Nonce)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if override.State != nil || override.StateDiff != nil {
+		if evmState == nil {
+			return errors.New("only the storage of contracts can be overridden")
+		}
+		slots := override.State
+		if override.StateDiff != nil {
+			root, err := evmState.GetContractStateCID()
+			if err != nil {
+				return err
+			}
+			if slots, err = builtinevm.ReadStorage(ctx, adtStore, root); err != nil {
+				return fmt.Errorf("failed to read storage: %w", err)
+			}
+			for k, v := range override.StateDiff {
+				slots[k] = v
+			}
+		}
+		root, err := builtinevm.PutStorage(ctx, adtStore, slots)
+		if err != nil {
+			return fmt.Errorf("failed to store storage: %w", err)
+		}
+		if err := evmState.SetContractStateCID(root); err != nil {
+			return err
+		}
+	}
+
+	if evmState != nil {
+		if act.Head, err = adtStore.Put(ctx, evmState); err != nil {
+			return fmt.Errorf("failed to store evm state: %w", err)
+		}
+	}
+
+	return st.SetActor(ctx, filAddr, act)
+}
+
+// simulatedTraces returns the traces of a simulated call, in the format of trace_call.
+func simulatedTraces(ctx context.Context, call *statemanger.SimulatedCall) ([]*types.EthTrace, error) {
+	st, err := tree.LoadState(ctx, call.States.Store, call.States.Post)
+	if err != nil {
+		return nil, fmt.Errorf("loading state: %w", err)
+	}
+	env, err := baseEnvironment(ctx, call.Result.Msg.From, st)
+	if err != nil {
+		return nil, err
+	}
+	if err := buildTraces(env, []int{}, &call.Result.ExecutionTrace); err != nil {
+		return nil, fmt.Errorf("failed building traces: %w", err)
+	}
+	return env.traces, nil
+}
+
+// simulatedCall returns the result of the txIndex call of a simulated block, logIndex being the index of its
+// first log in the block.
+func simulatedCall(ctx context.Context, call *statemanger.SimulatedCall, txIndex int, logIndex *int, blockNumber types.EthUint64) (*types.EthSimulatedCall, error) {
+	st, err := tree.LoadState(ctx, call.States.Store, call.States.Post)
+	if err != nil {
+		return nil, fmt.Errorf("loading state: %w", err)
+	}
+	traces, err := simulatedTraces(ctx, call)
+	if err != nil {
+		return nil, err
+	}
+
+	rct := call.Result.MsgRct
+	res := &types.EthSimulatedCall{
+		ReturnData: types.EthBytes{},
+		Logs:       []types.EthLog{},
+		GasUsed:    types.EthUint64(rct.GasUsed),
+		Trace:      traces,
+	}
+	// the return of a contract creation isn't EVM return data, nothing is returned like EthCall does
+	if ret, err := cbg.ReadByteArray(bytes.NewReader(rct.Return), uint64(len(rct.Return))); err == nil && len(ret) > 0 {
+		res.ReturnData = ret
+	}
+
+	if rct.ExitCode.IsError() {
+		if rct.ExitCode == builtinevm.ErrReverted {
+			res.Error = &types.EthSimulateError{
+				Code:    simulateErrReverted,
+				Message: fmt.Sprintf("execution reverted: %s", parseEthRevert(rct.Return)),
+				Data:    res.ReturnData,
+			}
+		} else {
+			res.Error = &types.EthSimulateError{
+				Code:    simulateErrExecution,
+				Message: fmt.Sprintf("message execution failed: exit %s, vm error: %s", rct.ExitCode, call.Result.Error),
+			}
+		}
+		// the state changes of a failed call are reverted, so are its events
+		return res, nil
+	}
+	res.Status = 1
+
+	for _, ev := range call.Events {
+		data, topics, ok := ethLogFromEvent(ev.Entries)
+		if !ok {
+			continue
+		}
+		emitter, err := address.NewIDAddress(uint64(ev.Emitter))
+		if err != nil {
+			return nil, err
+		}
+		emitterAddr, err := lookupEthAddress(ctx, emitter, st)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve emitter %s: %w", emitter, err)
+		}
+		res.Logs = append(res.Logs, types.EthLog{
+			Address:          emitterAddr,
+			Data:             data,
+			Topics:           topics,
+			LogIndex:         types.EthUint64(*logIndex),
+			TransactionIndex: types.EthUint64(txIndex),
+			BlockNumber:      blockNumber,
+		})
+		*logIndex++
+	}
+
+	return res, nil
+}
@@ -0,0 +1,110 @@
+package eth
+
+import (
+	"context"
+	"testing"
+
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+	init16 "github.com/filecoin-project/go-state-types/builtin/v16/init"
+	"github.com/filecoin-project/go-state-types/manifest"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/state/tree"
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/actors/adt"
+	builtinactors "github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	builtinevm "github.com/filecoin-project/venus/venus-shared/actors/builtin/evm"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestApplyStateOverride(t *testing.T) {
+	ctx := context.Background()
+	bs := blockstoreutil.NewTemporarySync()
+	store := cbor.NewCborStore(bs)
+	adtStore := adt.WrapStore(ctx, store)
+	av := actorstypes.Version16
+
+	st, err := tree.NewState(store, tree.StateTreeVersion5)
+	require.NoError(t, err)
+	initState, err := init16.ConstructState(adtStore, "test")
+	require.NoError(t, err)
+	initHead, err := store.Put(ctx, initState)
+	require.NoError(t, err)
+	initCode, _ := actors.GetActorCodeID(av, manifest.InitKey)
+	require.NoError(t, st.SetActor(ctx, builtinactors.InitActorAddr, &types.Actor{Code: initCode, Head: initHead, Balance: big.Zero()}))
+
+	account := types.EthAddress{19: 1}
+	contract := types.EthAddress{19: 2}
+	balance := types.EthBigInt(big.NewInt(10))
+	nonce := types.EthUint64(2)
+	code := types.EthBytes{0x60, 0x00}
+	slot1, slot2 := types.EthHash{31: 1}, types.EthHash{31: 2}
+	require.NoError(t, applyStateOverride(ctx, bs, st, av, types.EthStateOverride{
+		account:  {Balance: &balance, Nonce: &nonce},
+		contract: {Code: &code, Nonce: &nonce, State: map[types.EthHash]types.EthHash{slot1: {31: 1}}},
+	}))
+
+	getActor := func(addr types.EthAddress) *types.Actor {
+		filAddr, err := addr.ToFilecoinAddress()
+		require.NoError(t, err)
+		act, found, err := st.GetActor(ctx, filAddr)
+		require.NoError(t, err)
+		require.True(t, found)
+		return act
+	}
+	act := getActor(account)
+	require.True(t, builtinactors.IsEthAccountActor(act.Code))
+	require.Equal(t, big.NewInt(10), act.Balance)
+	require.Equal(t, uint64(2), act.Nonce)
+
+	storage := func() map[types.EthHash]types.EthHash {
+		evmState, err := builtinevm.Load(adtStore, getActor(contract))
+		require.NoError(t, err)
+		bytecode, err := evmState.GetBytecode()
+		require.NoError(t, err)
+		require.Equal(t, []byte(code), bytecode)
+		evmNonce, err := evmState.Nonce()
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), evmNonce)
+		root, err := evmState.GetContractStateCID()
+		require.NoError(t, err)
+		slots, err := builtinevm.ReadStorage(ctx, adtStore, root)
+		require.NoError(t, err)
+		return slots
+	}
+	require.True(t, builtinactors.IsEvmActor(getActor(contract).Code))
+	require.Equal(t, map[types.EthHash]types.EthHash{slot1: {31: 1}}, storage())
+
+	// the diff only changes the given slots, a zero value deletes the slot
+	require.NoError(t, applyStateOverride(ctx, bs, st, av, types.EthStateOverride{
+		contract: {StateDiff: map[types.EthHash]types.EthHash{slot1: {}, slot2: {31: 2}}},
+	}))
+	require.Equal(t, map[types.EthHash]types.EthHash{slot2: {31: 2}}, storage())
+
+	require.ErrorContains(t, applyStateOverride(ctx, bs, st, av, types.EthStateOverride{
+		contract: {State: map[types.EthHash]types.EthHash{}, StateDiff: map[types.EthHash]types.EthHash{}},
+	}), "can't be both set")
+	require.ErrorContains(t, applyStateOverride(ctx, bs, st, av, types.EthStateOverride{
+		account: {StateDiff: map[types.EthHash]types.EthHash{slot1: {31: 1}}},
+	}), "only the storage of contracts")
+	require.ErrorContains(t, applyStateOverride(ctx, bs, st, av, types.EthStateOverride{
+		types.EthAddressFromActorID(1234): {Balance: &balance},
+	}), "can be created")
+}
+
+func TestCheckSimulateLimits(t *testing.T) {
+	blocks := make([]types.EthBlockStateCalls, maxSimulateBlocks)
+	require.NoError(t, checkSimulateLimits(blocks))
+	require.ErrorContains(t, checkSimulateLimits(append(blocks, types.EthBlockStateCalls{})), "too many blocks")
+
+	blocks = []types.EthBlockStateCalls{
+		{Calls: make([]types.EthCall, maxSimulateCalls/2)},
+		{Calls: make([]types.EthCall, maxSimulateCalls/2)},
+	}
+	require.NoError(t, checkSimulateLimits(blocks))
+	blocks[1].Calls = append(blocks[1].Calls, types.EthCall{})
+	require.ErrorContains(t, checkSimulateLimits(blocks), "too many calls")
+}
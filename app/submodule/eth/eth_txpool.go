@@ -0,0 +1,104 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/crypto"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func (a *ethAPI) EthTxPoolContent(ctx context.Context) (*types.EthTxPoolContent, error) {
+	pending, queued := a.txPool(ctx)
+	return &types.EthTxPoolContent{Pending: pending, Queued: queued}, nil
+}
+
+func (a *ethAPI) EthTxPoolContentFrom(ctx context.Context, address types.EthAddress) (*types.EthTxPoolContentFrom, error) {
+	pending, queued := a.txPool(ctx)
+	content := &types.EthTxPoolContentFrom{Pending: pending[address], Queued: queued[address]}
+	if content.Pending == nil {
+		content.Pending = map[string]types.EthTx{}
+	}
+	if content.Queued == nil {
+		content.Queued = map[string]types.EthTx{}
+	}
+	return content, nil
+}
+
+func (a *ethAPI) EthTxPoolInspect(ctx context.Context) (*types.EthTxPoolInspect, error) {
+	pending, queued := a.txPool(ctx)
+	return &types.EthTxPoolInspect{Pending: inspectTxPool(pending), Queued: inspectTxPool(queued)}, nil
+}
+
+func (a *ethAPI) EthTxPoolStatus(ctx context.Context) (types.EthTxPoolStatus, error) {
+	pending, queued := a.txPool(ctx)
+	return types.EthTxPoolStatus{Pending: countTxPool(pending), Queued: countTxPool(queued)}, nil
+}
+
+// txPool returns the pending and queued messages of the message pool as Ethereum transactions, grouped by
+// sender and keyed by nonce.
+func (a *ethAPI) txPool(ctx context.Context) (pending, queued map[types.EthAddress]map[string]types.EthTx) {
+	pendingMsgs, queuedMsgs := a.em.mpoolModule.MPool.PendingWithGaps(ctx)
+	return groupTxPool(pendingMsgs), groupTxPool(queuedMsgs)
+}
+
+// groupTxPool converts the messages signed with delegated signatures, the only ones whose addresses are
+// guaranteed to be convertible to Ethereum addresses, and groups them by sender.
+func groupTxPool(msgs []*types.SignedMessage) map[types.EthAddress]map[string]types.EthTx {
+	out := make(map[types.EthAddress]map[string]types.EthTx)
+	for _, msg := range msgs {
+		if msg.Signature.Type != crypto.SigTypeDelegated {
+			continue
+		}
+		ethTx, err := types.EthTransactionFromSignedFilecoinMessage(msg)
+		if err != nil {
+			log.Warnf("could not convert Filecoin message %s into tx: %s", msg.Cid(), err)
+			continue
+		}
+		tx, err := ethTx.ToEthTx(msg)
+		if err != nil {
+			log.Warnf("could not convert Eth transaction of message %s to EthTx: %s", msg.Cid(), err)
+			continue
+		}
+
+		txs, ok := out[tx.From]
+		if !ok {
+			txs = make(map[string]types.EthTx)
+			out[tx.From] = txs
+		}
+		txs[strconv.FormatUint(uint64(tx.Nonce), 10)] = tx
+	}
+	return out
+}
+
+// inspectTxPool summarizes the transactions the way geth does, "to: value wei + gas gas × fee cap wei"
+func inspectTxPool(content map[types.EthAddress]map[string]types.EthTx) map[types.EthAddress]map[string]string {
+	out := make(map[types.EthAddress]map[string]string, len(content))
+	for from, txs := range content {
+		summaries := make(map[string]string, len(txs))
+		for nonce, tx := range txs {
+			to := "contract creation"
+			if tx.To != nil {
+				to = tx.To.String()
+			}
+			feeCap, err := tx.GasFeeCap()
+			if err != nil {
+				feeCap = types.EthBigIntZero
+			}
+			summaries[nonce] = fmt.Sprintf("%s: %s wei + %d gas × %s wei", to, big.Int(tx.Value), tx.Gas, big.Int(feeCap))
+		}
+		out[from] = summaries
+	}
+	return out
+}
+
+func countTxPool(content map[types.EthAddress]map[string]types.EthTx) types.EthUint64 {
+	count := 0
+	for _, txs := range content {
+		count += len(txs)
+	}
+	return types.EthUint64(count)
+}
@@ -0,0 +1,64 @@
+package eth
+
+import (
+	"testing"
+
+	gocrypto "github.com/filecoin-project/go-crypto"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+
+	types2 "github.com/filecoin-project/venus/venus-shared/actors/types"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func signedEthMessage(t *testing.T, priv []byte, nonce int, to *types.EthAddress) *types.SignedMessage {
+	tx := &types.Eth1559TxArgs{
+		ChainID:              types2.Eip155ChainID,
+		Nonce:                nonce,
+		To:                   to,
+		Value:                big.NewInt(10),
+		MaxFeePerGas:         big.NewInt(100),
+		MaxPriorityFeePerGas: big.NewInt(1),
+		GasLimit:             21000,
+	}
+	unsigned, err := tx.ToRlpUnsignedMsg()
+	require.NoError(t, err)
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(unsigned)
+	sig, err := gocrypto.Sign(priv, hasher.Sum(nil))
+	require.NoError(t, err)
+	require.NoError(t, tx.InitialiseSignature(crypto.Signature{Type: crypto.SigTypeDelegated, Data: sig}))
+
+	smsg, err := types.ToSignedFilecoinMessage(tx)
+	require.NoError(t, err)
+	return smsg
+}
+
+func TestTxPool(t *testing.T) {
+	priv, err := gocrypto.GenerateKey()
+	require.NoError(t, err)
+	to := types.EthAddress{19: 1}
+	msgs := []*types.SignedMessage{
+		signedEthMessage(t, priv, 0, &to),
+		signedEthMessage(t, priv, 1, nil),
+		// native messages are left out
+		{Message: types.Message{From: builtin.SystemActorAddr}, Signature: crypto.Signature{Type: crypto.SigTypeSecp256k1}},
+	}
+	from, err := types.EthAddressFromFilecoinAddress(msgs[0].Message.From)
+	require.NoError(t, err)
+
+	content := groupTxPool(msgs)
+	require.Len(t, content, 1)
+	require.Len(t, content[from], 2)
+	require.Equal(t, types.EthUint64(1), content[from]["1"].Nonce)
+	require.Equal(t, &to, content[from]["0"].To)
+	require.Equal(t, types.EthUint64(2), countTxPool(content))
+
+	require.Equal(t, map[types.EthAddress]map[string]string{from: {
+		"0": to.String() + ": 10 wei + 21000 gas × 100 wei",
+		"1": "contract creation: 10 wei + 21000 gas × 100 wei",
+	}}, inspectTxPool(content))
+}
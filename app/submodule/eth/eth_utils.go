@@ -18,7 +18,6 @@ import (
 	"github.com/filecoin-project/go-state-types/crypto"
 	"github.com/filecoin-project/go-state-types/exitcode"
 	"github.com/filecoin-project/venus/pkg/chain"
-	"github.com/filecoin-project/venus/pkg/constants"
 	"github.com/filecoin-project/venus/pkg/state/tree"
 	"github.com/filecoin-project/venus/pkg/statemanger"
 	"github.com/filecoin-project/venus/pkg/vm/gas"
@@ -54,21 +53,9 @@ func getTipsetByBlockNumber(ctx context.Context, store *chain.Store, blkParam st
 		}
 		return parent, nil
 	case "safe":
-		latestHeight := head.Height() - 1
-		safeHeight := latestHeight - types.SafeEpochDelay
-		ts, err := store.GetTipSetByHeight(ctx, head, safeHeight, true)
-		if err != nil {
-			return nil, fmt.Errorf("cannot get tipset at height: %v", safeHeight)
-		}
-		return ts, nil
+		return getSafeTipSet(ctx, store, head)
 	case "finalized":
-		latestHeight := head.Height() - 1
-		safeHeight := latestHeight - constants.Finality
-		ts, err := store.GetTipSetByHeight(ctx, head, safeHeight, true)
-		if err != nil {
-			return nil, fmt.Errorf("cannot get tipset at height: %v", safeHeight)
-		}
-		return ts, nil
+		return getFinalizedTipSet(ctx, store)
 	default:
 		var num types.EthUint64
 		err := num.UnmarshalJSON([]byte(`"` + blkParam + `"`))
@@ -89,6 +76,38 @@ func getTipsetByBlockNumber(ctx context.Context, store *chain.Store, blkParam st
 	}
 }
 
+// getSafeTipSet returns the tipset SafeEpochDelay epochs behind the latest one, or the finalized tipset
+// when it is more recent, F3 finalizes the tipsets faster than the safe delay.
+func getSafeTipSet(ctx context.Context, store *chain.Store, head *types.TipSet) (*types.TipSet, error) {
+	latestHeight := head.Height() - 1
+	safeHeight := latestHeight - types.SafeEpochDelay
+	if safeHeight < 0 {
+		safeHeight = 0
+	}
+	ts, err := store.GetTipSetByHeight(ctx, head, safeHeight, true)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get tipset at height: %v", safeHeight)
+	}
+
+	finalized, err := getFinalizedTipSet(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+	if finalized.Height() > ts.Height() {
+		return finalized, nil
+	}
+	return ts, nil
+}
+
+// getFinalizedTipSet returns the tipset finalized by F3, or by EC when F3 is not running.
+func getFinalizedTipSet(ctx context.Context, store *chain.Store) (*types.TipSet, error) {
+	ts, err := store.GetFinalizedTipSet(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get finalized tipset: %v", err)
+	}
+	return ts, nil
+}
+
 func getTipsetByEthBlockNumberOrHash(ctx context.Context, store *chain.Store, blkParam types.EthBlockNumberOrHash) (*types.TipSet, error) {
 	head := store.GetHead()
 
@@ -104,6 +123,10 @@ func getTipsetByEthBlockNumberOrHash(ctx context.Context, store *chain.Store, bl
 				return nil, errors.New("cannot get parent tipset")
 			}
 			return parent, nil
+		} else if *predefined == "safe" {
+			return getSafeTipSet(ctx, store, head)
+		} else if *predefined == "finalized" {
+			return getFinalizedTipSet(ctx, store)
 		} else {
 			return nil, fmt.Errorf("unknown predefined block %s", *predefined)
 		}
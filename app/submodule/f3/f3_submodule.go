@@ -57,6 +57,8 @@ func NewF3Submodule(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	// the head selection never reverts the tipsets finalized by F3
+	chain.ChainReader.SetF3Finality(m)
 
 	return &F3Submodule{m}, nil
 }
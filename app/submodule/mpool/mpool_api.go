@@ -59,6 +59,7 @@ func (a *MessagePoolAPI) MpoolGetConfig(context.Context) (*types.MpoolConfig, er
 		ReplaceByFeeRatio:      cfg.ReplaceByFeeRatio,
 		PruneCooldown:          cfg.PruneCooldown,
 		GasLimitOverestimation: cfg.GasLimitOverestimation,
+		PriorityLanes:          cfg.PriorityLanes,
 	}, nil
 }
 
@@ -71,6 +72,7 @@ func (a *MessagePoolAPI) MpoolSetConfig(ctx context.Context, cfg *types.MpoolCon
 		ReplaceByFeeRatio:      cfg.ReplaceByFeeRatio,
 		PruneCooldown:          cfg.PruneCooldown,
 		GasLimitOverestimation: cfg.GasLimitOverestimation,
+		PriorityLanes:          cfg.PriorityLanes,
 	})
 }
 
@@ -343,6 +345,17 @@ func (a *MessagePoolAPI) GasEstimateGasPremium(ctx context.Context, nblocksincl
 	return a.mp.MPool.GasEstimateGasPremium(ctx, nblocksincl, sender, gaslimit, tsk, a.mp.MPool.PriceCache)
 }
 
+// GasEstimateFeeHistory returns the parent base fee and the percentiles of the gas premiums of the
+// messages included in recent tipsets.
+func (a *MessagePoolAPI) GasEstimateFeeHistory(ctx context.Context, epochs uint64, percentiles []float64, tsk types.TipSetKey) (*types.FeeHistory, error) {
+	return a.mp.MPool.GasEstimateFeeHistory(ctx, epochs, percentiles, tsk)
+}
+
+// MpoolFeeBumpStatus returns the fee bump status of the local pending messages
+func (a *MessagePoolAPI) MpoolFeeBumpStatus(ctx context.Context) (*types.MpoolFeeBumpStatus, error) {
+	return a.mp.MPool.FeeBumpStatus(), nil
+}
+
 func (a *MessagePoolAPI) MpoolCheckMessages(ctx context.Context, protos []*types.MessagePrototype) ([][]types.MessageCheckStatus, error) {
 	return a.mp.MPool.CheckMessages(ctx, protos)
 }
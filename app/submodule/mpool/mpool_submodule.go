@@ -90,13 +90,16 @@ func NewMpoolSubmodule(ctx context.Context,
 		return nil, fmt.Errorf("constructing mpool: %s", err)
 	}
 
+	msgSigner := messagepool.NewMessageSigner(wallet.WalletIntersection(), mp, cfg.Repo().MetaDatastore())
+	mp.SetFeeBumpSigner(msgSigner)
+
 	return &MessagePoolSubmodule{
 		MPool:        mp,
 		chain:        chain,
 		walletAPI:    wallet.API(),
 		network:      network,
 		networkCfg:   cfg.Repo().Config().NetworkParams,
-		msgSigner:    messagepool.NewMessageSigner(wallet.WalletIntersection(), mp, cfg.Repo().MetaDatastore()),
+		msgSigner:    msgSigner,
 		bootstrapper: cfg.Repo().Config().PubsubConfig.Bootstrapper,
 	}, nil
 }
@@ -284,3 +287,12 @@ func (mp *MessagePoolSubmodule) V0API() v0api.IMessagePool {
 	pushLocks := messagepool.NewMpoolLocker()
 	return &MessagePoolAPI{mp: mp, pushLocks: pushLocks}
 }
+
+// LiveConfigKeys are the fields of the config the message pool applies without a restart
+var LiveConfigKeys = []string{"mpool.maxFee", "mpool.addressFees", "mpool.feeBump"}
+
+// ApplyConfig applies the fee options of the message pool config
+func (mp *MessagePoolSubmodule) ApplyConfig(cfg *config.Config, _ []string) error {
+	mp.MPool.UpdateFeeConfig(cfg.Mpool)
+	return nil
+}
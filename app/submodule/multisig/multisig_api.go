@@ -0,0 +1,428 @@
+package multisig
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+	msig16 "github.com/filecoin-project/go-state-types/builtin/v16/multisig"
+
+	"github.com/filecoin-project/venus/pkg/statemanger"
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/multisig"
+	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type msigProposeResponse int
+
+const (
+	msigApprove msigProposeResponse = iota
+	msigCancel
+)
+
+var _ v1api.IMultiSig = (*multiSigAPI)(nil)
+
+type multiSigAPI struct {
+	chain v1api.IChain
+	stmgr *statemanger.Stmgr
+}
+
+func newMultiSigAPI(c v1api.IChain, stmgr *statemanger.Stmgr) v1api.IMultiSig {
+	return &multiSigAPI{chain: c, stmgr: stmgr}
+}
+
+func (a *multiSigAPI) loadState(ctx context.Context, addr address.Address, ts *types.TipSet) (*types.Actor, multisig.State, error) {
+	_, view, err := a.stmgr.ParentStateView(ctx, ts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading tipset %s parent state view: %w", ts.Key(), err)
+	}
+	act, err := view.LoadActor(ctx, addr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load multisig actor: %w", err)
+	}
+	msas, err := view.LoadMultisigState(ctx, act)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load multisig actor state: %w", err)
+	}
+	return act, msas, nil
+}
+
+// MsigGetAvailableBalance returns the portion of a multisig's balance that can be withdrawn or spent
+func (a *multiSigAPI) MsigGetAvailableBalance(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.BigInt, error) {
+	ts, err := a.chain.ChainGetTipSet(ctx, tsk)
+	if err != nil {
+		return types.EmptyInt, fmt.Errorf("loading tipset %s: %w", tsk, err)
+	}
+	act, msas, err := a.loadState(ctx, addr, ts)
+	if err != nil {
+		return types.EmptyInt, err
+	}
+	locked, err := msas.LockedBalance(ts.Height())
+	if err != nil {
+		return types.EmptyInt, fmt.Errorf("failed to compute locked multisig balance: %w", err)
+	}
+	return types.BigSub(act.Balance, locked), nil
+}
+
+// MsigGetVestingSchedule returns the vesting details of a given multisig.
+func (a *multiSigAPI) MsigGetVestingSchedule(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.MsigVesting, error) {
+	ts, err := a.chain.ChainGetTipSet(ctx, tsk)
+	if err != nil {
+		return types.EmptyVesting, fmt.Errorf("loading tipset %s: %w", tsk, err)
+	}
+	_, msas, err := a.loadState(ctx, addr, ts)
+	if err != nil {
+		return types.EmptyVesting, err
+	}
+
+	ib, err := msas.InitialBalance()
+	if err != nil {
+		return types.EmptyVesting, fmt.Errorf("failed to load multisig initial balance: %w", err)
+	}
+	se, err := msas.StartEpoch()
+	if err != nil {
+		return types.EmptyVesting, fmt.Errorf("failed to load multisig start epoch: %w", err)
+	}
+	ud, err := msas.UnlockDuration()
+	if err != nil {
+		return types.EmptyVesting, fmt.Errorf("failed to load multisig unlock duration: %w", err)
+	}
+
+	return types.MsigVesting{
+		InitialBalance: ib,
+		StartEpoch:     se,
+		UnlockDuration: ud,
+	}, nil
+}
+
+// MsigGetVested returns the amount of FIL that vested in a multisig in a certain period.
+func (a *multiSigAPI) MsigGetVested(ctx context.Context, addr address.Address, start types.TipSetKey, end types.TipSetKey) (types.BigInt, error) {
+	startTS, err := a.chain.ChainGetTipSet(ctx, start)
+	if err != nil {
+		return types.EmptyInt, fmt.Errorf("loading start tipset %s: %w", start, err)
+	}
+	endTS, err := a.chain.ChainGetTipSet(ctx, end)
+	if err != nil {
+		return types.EmptyInt, fmt.Errorf("loading end tipset %s: %w", end, err)
+	}
+
+	if startTS.Height() > endTS.Height() {
+		return types.EmptyInt, fmt.Errorf("start tipset %d is after end tipset %d", startTS.Height(), endTS.Height())
+	} else if startTS.Height() == endTS.Height() {
+		return big.Zero(), nil
+	}
+
+	_, msas, err := a.loadState(ctx, addr, endTS)
+	if err != nil {
+		return types.EmptyInt, err
+	}
+	startLk, err := msas.LockedBalance(startTS.Height())
+	if err != nil {
+		return types.EmptyInt, fmt.Errorf("failed to compute locked balance at start height: %w", err)
+	}
+	endLk, err := msas.LockedBalance(endTS.Height())
+	if err != nil {
+		return types.EmptyInt, fmt.Errorf("failed to compute locked balance at end height: %w", err)
+	}
+
+	return types.BigSub(startLk, endLk), nil
+}
+
+// MsigGetPending returns pending transactions for the given multisig wallet.
+func (a *multiSigAPI) MsigGetPending(ctx context.Context, addr address.Address, tsk types.TipSetKey) ([]*types.MsigTransaction, error) {
+	ts, err := a.chain.ChainGetTipSet(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("loading tipset %s: %w", tsk, err)
+	}
+	_, msas, err := a.loadState(ctx, addr, ts)
+	if err != nil {
+		return nil, err
+	}
+	return pendingTxns(msas)
+}
+
+// pendingTxns lists the pending transactions of a multisig state
+func pendingTxns(msas multisig.State) ([]*types.MsigTransaction, error) {
+	out := []*types.MsigTransaction{}
+	if err := msas.ForEachPendingTxn(func(id int64, txn multisig.Transaction) error {
+		out = append(out, &types.MsigTransaction{
+			ID:       id,
+			To:       txn.To,
+			Value:    txn.Value,
+			Method:   txn.Method,
+			Params:   txn.Params,
+			Approved: txn.Approved,
+		})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (a *multiSigAPI) messageBuilder(ctx context.Context, from address.Address) (multisig.MessageBuilder, error) {
+	nv, err := a.chain.StateNetworkVersion(ctx, types.EmptyTSK)
+	if err != nil {
+		return nil, err
+	}
+	av, err := actorstypes.VersionForNetwork(nv)
+	if err != nil {
+		return nil, err
+	}
+	return multisig.Message(av, from), nil
+}
+
+// MsigCreate creates a multisig wallet
+// TODO: remove gp (gasPrice) from arguments
+func (a *multiSigAPI) MsigCreate(ctx context.Context, req uint64, addrs []address.Address, duration abi.ChainEpoch, val types.BigInt, src address.Address, gp types.BigInt) (*types.MessagePrototype, error) {
+	mb, err := a.messageBuilder(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := mb.Create(addrs, req, 0, duration, val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MessagePrototype{
+		Message:    *msg,
+		ValidNonce: false,
+	}, nil
+}
+
+// MsigPropose proposes a multisig message
+func (a *multiSigAPI) MsigPropose(ctx context.Context, msig address.Address, to address.Address, amt types.BigInt, src address.Address, method uint64, params []byte) (*types.MessagePrototype, error) {
+	mb, err := a.messageBuilder(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := mb.Propose(msig, to, amt, abi.MethodNum(method), params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create proposal: %w", err)
+	}
+
+	return &types.MessagePrototype{
+		Message:    *msg,
+		ValidNonce: false,
+	}, nil
+}
+
+// MsigApprove approves a previously-proposed multisig message by transaction ID
+func (a *multiSigAPI) MsigApprove(ctx context.Context, msig address.Address, txID uint64, src address.Address) (*types.MessagePrototype, error) {
+	return a.msigApproveOrCancelSimple(ctx, msigApprove, msig, txID, src)
+}
+
+// MsigApproveTxnHash approves a previously-proposed multisig message, specified
+// using both transaction ID and a hash of the parameters used in the proposal.
+func (a *multiSigAPI) MsigApproveTxnHash(ctx context.Context, msig address.Address, txID uint64, proposer address.Address, to address.Address, amt types.BigInt, src address.Address, method uint64, params []byte) (*types.MessagePrototype, error) {
+	return a.msigApproveOrCancelTxnHash(ctx, msigApprove, msig, txID, proposer, to, amt, src, method, params)
+}
+
+// MsigCancel cancels a previously-proposed multisig message
+func (a *multiSigAPI) MsigCancel(ctx context.Context, msig address.Address, txID uint64, src address.Address) (*types.MessagePrototype, error) {
+	return a.msigApproveOrCancelSimple(ctx, msigCancel, msig, txID, src)
+}
+
+// MsigCancelTxnHash cancels a previously-proposed multisig message
+func (a *multiSigAPI) MsigCancelTxnHash(ctx context.Context, msig address.Address, txID uint64, to address.Address, amt types.BigInt, src address.Address, method uint64, params []byte) (*types.MessagePrototype, error) {
+	return a.msigApproveOrCancelTxnHash(ctx, msigCancel, msig, txID, src, to, amt, src, method, params)
+}
+
+// MsigAddPropose proposes adding a signer in the multisig
+func (a *multiSigAPI) MsigAddPropose(ctx context.Context, msig address.Address, src address.Address, newAdd address.Address, inc bool) (*types.MessagePrototype, error) {
+	enc, err := serializeAddParams(newAdd, inc)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.MsigPropose(ctx, msig, msig, big.Zero(), src, uint64(multisig.Methods.AddSigner), enc)
+}
+
+// MsigAddApprove approves a previously proposed AddSigner message
+func (a *multiSigAPI) MsigAddApprove(ctx context.Context, msig address.Address, src address.Address, txID uint64, proposer address.Address, newAdd address.Address, inc bool) (*types.MessagePrototype, error) {
+	enc, err := serializeAddParams(newAdd, inc)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.MsigApproveTxnHash(ctx, msig, txID, proposer, msig, big.Zero(), src, uint64(multisig.Methods.AddSigner), enc)
+}
+
+// MsigAddCancel cancels a previously proposed AddSigner message
+func (a *multiSigAPI) MsigAddCancel(ctx context.Context, msig address.Address, src address.Address, txID uint64, newAdd address.Address, inc bool) (*types.MessagePrototype, error) {
+	enc, err := serializeAddParams(newAdd, inc)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.MsigCancelTxnHash(ctx, msig, txID, msig, big.Zero(), src, uint64(multisig.Methods.AddSigner), enc)
+}
+
+// MsigSwapPropose proposes swapping 2 signers in the multisig
+func (a *multiSigAPI) MsigSwapPropose(ctx context.Context, msig address.Address, src address.Address, oldAdd address.Address, newAdd address.Address) (*types.MessagePrototype, error) {
+	enc, err := serializeSwapParams(oldAdd, newAdd)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.MsigPropose(ctx, msig, msig, big.Zero(), src, uint64(multisig.Methods.SwapSigner), enc)
+}
+
+// MsigSwapApprove approves a previously proposed SwapSigner
+func (a *multiSigAPI) MsigSwapApprove(ctx context.Context, msig address.Address, src address.Address, txID uint64, proposer address.Address, oldAdd address.Address, newAdd address.Address) (*types.MessagePrototype, error) {
+	enc, err := serializeSwapParams(oldAdd, newAdd)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.MsigApproveTxnHash(ctx, msig, txID, proposer, msig, big.Zero(), src, uint64(multisig.Methods.SwapSigner), enc)
+}
+
+// MsigSwapCancel cancels a previously proposed SwapSigner message
+func (a *multiSigAPI) MsigSwapCancel(ctx context.Context, msig address.Address, src address.Address, txID uint64, oldAdd address.Address, newAdd address.Address) (*types.MessagePrototype, error) {
+	enc, err := serializeSwapParams(oldAdd, newAdd)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.MsigCancelTxnHash(ctx, msig, txID, msig, big.Zero(), src, uint64(multisig.Methods.SwapSigner), enc)
+}
+
+// MsigRemoveSigner proposes the removal of a signer from the multisig.
+func (a *multiSigAPI) MsigRemoveSigner(ctx context.Context, msig address.Address, proposer address.Address, toRemove address.Address, decrease bool) (*types.MessagePrototype, error) {
+	enc, err := serializeRemoveParams(toRemove, decrease)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.MsigPropose(ctx, msig, msig, big.Zero(), proposer, uint64(multisig.Methods.RemoveSigner), enc)
+}
+
+func (a *multiSigAPI) msigApproveOrCancelSimple(ctx context.Context, operation msigProposeResponse, msig address.Address, txID uint64, src address.Address) (*types.MessagePrototype, error) {
+	if msig == address.Undef {
+		return nil, fmt.Errorf("must provide multisig address")
+	}
+	if src == address.Undef {
+		return nil, fmt.Errorf("must provide source address")
+	}
+
+	mb, err := a.messageBuilder(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+
+	var msg *types.Message
+	switch operation {
+	case msigApprove:
+		msg, err = mb.Approve(msig, txID, nil)
+	case msigCancel:
+		msg, err = mb.Cancel(msig, txID, nil)
+	default:
+		return nil, fmt.Errorf("invalid operation for msigApproveOrCancel")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MessagePrototype{
+		Message:    *msg,
+		ValidNonce: false,
+	}, nil
+}
+
+func (a *multiSigAPI) msigApproveOrCancelTxnHash(ctx context.Context,
+	operation msigProposeResponse,
+	msig address.Address,
+	txID uint64,
+	proposer address.Address,
+	to address.Address,
+	amt types.BigInt,
+	src address.Address,
+	method uint64,
+	params []byte,
+) (*types.MessagePrototype, error) {
+	if msig == address.Undef {
+		return nil, fmt.Errorf("must provide multisig address")
+	}
+	if src == address.Undef {
+		return nil, fmt.Errorf("must provide source address")
+	}
+
+	if proposer.Protocol() != address.ID {
+		proposerID, err := a.chain.StateLookupID(ctx, proposer, types.EmptyTSK)
+		if err != nil {
+			return nil, err
+		}
+		proposer = proposerID
+	}
+
+	p := multisig.ProposalHashData{
+		Requester: proposer,
+		To:        to,
+		Value:     amt,
+		Method:    abi.MethodNum(method),
+		Params:    params,
+	}
+
+	mb, err := a.messageBuilder(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+
+	var msg *types.Message
+	switch operation {
+	case msigApprove:
+		msg, err = mb.Approve(msig, txID, &p)
+	case msigCancel:
+		msg, err = mb.Cancel(msig, txID, &p)
+	default:
+		return nil, fmt.Errorf("invalid operation for msigApproveOrCancel")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MessagePrototype{
+		Message:    *msg,
+		ValidNonce: false,
+	}, nil
+}
+
+func serializeAddParams(newAdd address.Address, inc bool) ([]byte, error) {
+	enc, err := actors.SerializeParams(&msig16.AddSignerParams{
+		Signer:   newAdd,
+		Increase: inc,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return enc, nil
+}
+
+func serializeSwapParams(old address.Address, newAdd address.Address) ([]byte, error) {
+	enc, err := actors.SerializeParams(&msig16.SwapSignerParams{
+		From: old,
+		To:   newAdd,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return enc, nil
+}
+
+func serializeRemoveParams(rem address.Address, dec bool) ([]byte, error) {
+	enc, err := actors.SerializeParams(&msig16.RemoveSignerParams{
+		Signer:   rem,
+		Decrease: dec,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return enc, nil
+}
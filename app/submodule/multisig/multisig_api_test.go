@@ -0,0 +1,150 @@
+package multisig
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	msig16 "github.com/filecoin-project/go-state-types/builtin/v16/multisig"
+	"github.com/filecoin-project/go-state-types/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/multisig"
+	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// fakeChain answers the chain queries of the message builders
+type fakeChain struct {
+	v1api.IChain
+	ids map[address.Address]address.Address
+}
+
+func (c *fakeChain) StateNetworkVersion(context.Context, types.TipSetKey) (network.Version, error) {
+	return network.Version25, nil
+}
+
+func (c *fakeChain) StateLookupID(_ context.Context, addr address.Address, _ types.TipSetKey) (address.Address, error) {
+	return c.ids[addr], nil
+}
+
+func mustAddr(t *testing.T, s string) address.Address {
+	addr, err := address.NewFromString(s)
+	require.NoError(t, err)
+	return addr
+}
+
+func TestMsigMessages(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	msig := mustAddr(t, "f01000")
+	src := mustAddr(t, "f01001")
+	proposer, err := address.NewSecp256k1Address([]byte("proposer"))
+	require.NoError(t, err)
+	proposerID := mustAddr(t, "f01002")
+	to := mustAddr(t, "f01003")
+	api := newMultiSigAPI(&fakeChain{ids: map[address.Address]address.Address{proposer: proposerID}}, nil)
+
+	t.Run("propose", func(t *testing.T) {
+		proto, err := api.MsigPropose(ctx, msig, to, big.NewInt(10), src, 2, []byte{1, 2})
+		require.NoError(t, err)
+		assert.Equal(t, msig, proto.Message.To)
+		assert.Equal(t, src, proto.Message.From)
+		assert.Equal(t, multisig.Methods.Propose, proto.Message.Method)
+
+		var params msig16.ProposeParams
+		require.NoError(t, params.UnmarshalCBOR(bytes.NewReader(proto.Message.Params)))
+		assert.Equal(t, msig16.ProposeParams{To: to, Value: big.NewInt(10), Method: 2, Params: []byte{1, 2}}, params)
+	})
+
+	t.Run("add signer", func(t *testing.T) {
+		proto, err := api.MsigAddPropose(ctx, msig, src, proposer, true)
+		require.NoError(t, err)
+
+		var params msig16.ProposeParams
+		require.NoError(t, params.UnmarshalCBOR(bytes.NewReader(proto.Message.Params)))
+		assert.Equal(t, msig, params.To)
+		assert.Equal(t, multisig.Methods.AddSigner, params.Method)
+
+		var add msig16.AddSignerParams
+		require.NoError(t, add.UnmarshalCBOR(bytes.NewReader(params.Params)))
+		assert.Equal(t, msig16.AddSignerParams{Signer: proposer, Increase: true}, add)
+	})
+
+	t.Run("approve and cancel by id", func(t *testing.T) {
+		for method, build := range map[abi.MethodNum]func() (*types.MessagePrototype, error){
+			multisig.Methods.Approve: func() (*types.MessagePrototype, error) { return api.MsigApprove(ctx, msig, 7, src) },
+			multisig.Methods.Cancel:  func() (*types.MessagePrototype, error) { return api.MsigCancel(ctx, msig, 7, src) },
+		} {
+			proto, err := build()
+			require.NoError(t, err)
+			assert.Equal(t, method, proto.Message.Method)
+
+			var params msig16.TxnIDParams
+			require.NoError(t, params.UnmarshalCBOR(bytes.NewReader(proto.Message.Params)))
+			assert.Equal(t, msig16.TxnID(7), params.ID)
+			assert.Empty(t, params.ProposalHash)
+		}
+
+		_, err := api.MsigApprove(ctx, address.Undef, 7, src)
+		require.Error(t, err)
+	})
+
+	t.Run("approve with the proposal hash", func(t *testing.T) {
+		proto, err := api.MsigApproveTxnHash(ctx, msig, 3, proposer, to, big.NewInt(10), src, 2, []byte{1, 2})
+		require.NoError(t, err)
+		assert.Equal(t, multisig.Methods.Approve, proto.Message.Method)
+
+		var params msig16.TxnIDParams
+		require.NoError(t, params.UnmarshalCBOR(bytes.NewReader(proto.Message.Params)))
+		assert.Equal(t, msig16.TxnID(3), params.ID)
+
+		// the proposer is resolved to its id address before hashing
+		data := multisig.ProposalHashData{Requester: proposerID, To: to, Value: big.NewInt(10), Method: 2, Params: []byte{1, 2}}
+		ser, err := data.Serialize()
+		require.NoError(t, err)
+		hash := blake2b.Sum256(ser)
+		assert.Equal(t, hash[:], params.ProposalHash)
+	})
+}
+
+// pendingState is a multisig state holding some pending transactions
+type pendingState struct {
+	multisig.State
+	txns map[int64]multisig.Transaction
+}
+
+func (s *pendingState) ForEachPendingTxn(cb func(id int64, txn multisig.Transaction) error) error {
+	for id, txn := range s.txns {
+		if err := cb(id, txn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestPendingTxns(t *testing.T) {
+	tf.UnitTest(t)
+
+	signer := mustAddr(t, "f01001")
+	to := mustAddr(t, "f01003")
+
+	out, err := pendingTxns(&pendingState{})
+	require.NoError(t, err)
+	assert.NotNil(t, out)
+	assert.Empty(t, out)
+
+	out, err = pendingTxns(&pendingState{txns: map[int64]multisig.Transaction{
+		4: {To: to, Value: big.NewInt(5), Method: 0, Params: nil, Approved: []address.Address{signer}},
+	}})
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	assert.Equal(t, &types.MsigTransaction{ID: 4, To: to, Value: big.NewInt(5), Approved: []address.Address{signer}}, out[0])
+}
@@ -0,0 +1,22 @@
+package multisig
+
+import (
+	"github.com/filecoin-project/venus/pkg/statemanger"
+	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
+)
+
+// MultiSigSubmodule enhances the `Node` with multisig capabilities.
+type MultiSigSubmodule struct { //nolint
+	c     v1api.IChain
+	stmgr *statemanger.Stmgr
+}
+
+// NewMultiSigSubmodule create new multisig module
+func NewMultiSigSubmodule(c v1api.IChain, stmgr *statemanger.Stmgr) *MultiSigSubmodule {
+	return &MultiSigSubmodule{c: c, stmgr: stmgr}
+}
+
+// API create a new multisig implement
+func (ms *MultiSigSubmodule) API() v1api.IMultiSig {
+	return newMultiSigAPI(ms.c, ms.stmgr)
+}
@@ -3,9 +3,12 @@ package network
 import (
 	"context"
 	"crypto/rand"
+	"fmt"
+	"math"
 
 	"github.com/go-errors/errors"
 	"github.com/jbenet/goprocess"
+	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p/core/connmgr"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/event"
@@ -15,9 +18,68 @@ import (
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/libp2p/go-libp2p/p2p/host/eventbus"
 	"github.com/libp2p/go-libp2p/p2p/host/peerstore/pstoremem"
+	rcmgr "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
 	"github.com/multiformats/go-multiaddr"
+	"github.com/pbnjay/memory"
+
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/util/ulimit"
+)
+
+const (
+	// defaultResourceMgrMaxFD is the number of file descriptors libp2p may use when the limit of the
+	// process can't be read or is unlimited
+	defaultResourceMgrMaxFD = 8 << 10
+	// maxResourceMgrMaxFD caps the file descriptors given to libp2p, the limits scaled to a larger
+	// number would be meaningless
+	maxResourceMgrMaxFD = 1 << 20
 )
 
+// resourceManager builds the libp2p resource manager from the swarm config, the default limits of
+// libp2p are scaled to the memory and the file descriptors libp2p may use. The null resource manager
+// is returned when it is disabled, libp2p would otherwise use its own default limits.
+func resourceManager(cfg *config.SwarmConfig) (net.ResourceManager, error) {
+	if !cfg.ResourceMgr {
+		networkLogger.Info("libp2p resource manager is disabled")
+		return &net.NullResourceManager{}, nil
+	}
+
+	limits := rcmgr.DefaultLimits
+	libp2p.SetDefaultServiceLimits(&limits)
+
+	maxMemory := cfg.ResourceMgrMaxMemory
+	if maxMemory <= 0 {
+		maxMemory = int64(memory.TotalMemory() / 8)
+	}
+
+	maxFD := cfg.ResourceMgrMaxFD
+	if maxFD <= 0 {
+		maxFD = defaultResourceMgrMaxFD
+		if soft, _, err := ulimit.GetLimit(); err == nil {
+			maxFD = libp2pMaxFD(soft)
+		} else {
+			networkLogger.Warnf("failed to get file descriptor limit, using %d for libp2p: %v", maxFD, err)
+		}
+	}
+
+	networkLogger.Infow("libp2p resource manager limits scaled", "memory", maxMemory, "fd", maxFD)
+
+	rm, err := rcmgr.NewResourceManager(rcmgr.NewFixedLimiter(limits.Scale(maxMemory, maxFD)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource manager: %w", err)
+	}
+	return rm, nil
+}
+
+// libp2pMaxFD returns the file descriptors libp2p may use out of the soft limit of the process, half of
+// them up to maxResourceMgrMaxFD. The default is used when the limit is unset or unlimited.
+func libp2pMaxFD(soft uint64) int {
+	if soft == 0 || soft == math.MaxUint64 {
+		return defaultResourceMgrMaxFD
+	}
+	return int(min(soft/2, maxResourceMgrMaxFD))
+}
+
 type noopLibP2PHost struct {
 	peerId peer.ID //nolint
 }
@@ -0,0 +1,20 @@
+package network
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestLibp2pMaxFD(t *testing.T) {
+	tf.UnitTest(t)
+
+	require.Equal(t, defaultResourceMgrMaxFD, libp2pMaxFD(0))
+	require.Equal(t, defaultResourceMgrMaxFD, libp2pMaxFD(math.MaxUint64))
+	require.Equal(t, 512, libp2pMaxFD(1024))
+	require.Equal(t, maxResourceMgrMaxFD, libp2pMaxFD(math.MaxUint64-1))
+	require.Equal(t, maxResourceMgrMaxFD, libp2pMaxFD(1<<40))
+}
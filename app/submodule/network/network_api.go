@@ -120,6 +120,36 @@ func (na *networkAPI) NetProtectList(ctx context.Context) ([]peer.ID, error) {
 	return na.network.Network.ProtectList()
 }
 
+// NetBlockAdd blocks the given peers, IP addresses and subnets
+func (na *networkAPI) NetBlockAdd(ctx context.Context, acl types.NetBlockList) error {
+	return na.network.Network.BlockAdd(acl)
+}
+
+// NetBlockRemove unblocks the given peers, IP addresses and subnets
+func (na *networkAPI) NetBlockRemove(ctx context.Context, acl types.NetBlockList) error {
+	return na.network.Network.BlockRemove(acl)
+}
+
+// NetBlockList returns the blocked peers, IP addresses and subnets
+func (na *networkAPI) NetBlockList(ctx context.Context) (types.NetBlockList, error) {
+	return na.network.Network.BlockList()
+}
+
+// NetStat returns the usage of a scope of the libp2p resource manager
+func (na *networkAPI) NetStat(ctx context.Context, scope string) (types.NetStat, error) {
+	return na.network.Network.Stat(scope)
+}
+
+// NetLimit returns the limit of a scope of the libp2p resource manager
+func (na *networkAPI) NetLimit(ctx context.Context, scope string) (types.NetLimit, error) {
+	return na.network.Network.Limit(scope)
+}
+
+// NetSetLimit replaces the limit of a scope of the libp2p resource manager
+func (na *networkAPI) NetSetLimit(ctx context.Context, scope string, limit types.NetLimit) error {
+	return na.network.Network.SetLimit(scope, limit)
+}
+
 // NetConnectedness returns a state signaling connection capabilities
 func (na *networkAPI) NetConnectedness(ctx context.Context, p peer.ID) (network.Connectedness, error) {
 	return na.network.Network.Connectedness(p)
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/dchest/blake2b"
@@ -32,6 +33,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/routing"
 	routedhost "github.com/libp2p/go-libp2p/p2p/host/routed"
 	yamux "github.com/libp2p/go-libp2p/p2p/muxer/yamux"
+	"github.com/libp2p/go-libp2p/p2p/net/conngater"
 	"github.com/libp2p/go-libp2p/p2p/net/connmgr"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/pkg/errors"
@@ -90,6 +92,35 @@ type NetworkSubmodule struct { //nolint
 
 	cfg   networkConfig
 	F3Cfg *vf3.Config
+
+	protectLk      sync.Mutex
+	protectedPeers []peer.ID
+}
+
+// LiveConfigKeys are the fields of the config the network applies without a restart. The limits of the
+// connection manager aren't, its watermarks are fixed once the host is created.
+var LiveConfigKeys = []string{"swarm.protectedPeers"}
+
+// ApplyConfig protects the connections to the peers of swarm.protectedPeers from being trimmed by the
+// connection manager, the peers removed from the list aren't protected anymore.
+func (networkSubmodule *NetworkSubmodule) ApplyConfig(cfg *config.Config, _ []string) error {
+	pids, err := parseProtectedPeers(cfg.Swarm.ProtectedPeers)
+	if err != nil {
+		return err
+	}
+
+	networkSubmodule.protectLk.Lock()
+	defer networkSubmodule.protectLk.Unlock()
+
+	cm := networkSubmodule.Host.ConnManager()
+	for _, pid := range networkSubmodule.protectedPeers {
+		cm.Unprotect(pid, configProtectTag)
+	}
+	for _, pid := range pids {
+		cm.Protect(pid, configProtectTag)
+	}
+	networkSubmodule.protectedPeers = pids
+	return nil
 }
 
 // API create a new network implement
@@ -158,12 +189,29 @@ func NewNetworkSubmodule(ctx context.Context,
 	}
 
 	swarmCfg := cfg.Swarm
-	cm, err := connectionManager(swarmCfg.ConnMgrLow, swarmCfg.ConnMgrHigh, time.Duration(swarmCfg.ConnMgrGrace), swarmCfg.ProtectedPeers, bootNodes)
+	protectedPeers, err := parseProtectedPeers(swarmCfg.ProtectedPeers)
+	if err != nil {
+		return nil, err
+	}
+	cm, err := connectionManager(swarmCfg.ConnMgrLow, swarmCfg.ConnMgrHigh, time.Duration(swarmCfg.ConnMgrGrace), protectedPeers, bootNodes)
 	if err != nil {
 		return nil, err
 	}
 	libP2pOpts = append(libP2pOpts, libp2p.ConnectionManager(cm))
 
+	// the blocked peers, addresses and subnets are persisted in the metadata datastore
+	gater, err := conngater.NewBasicConnectionGater(config.Repo().MetaDatastore())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create connection gater")
+	}
+	libP2pOpts = append(libP2pOpts, libp2p.ConnectionGater(gater))
+
+	rm, err := resourceManager(swarmCfg)
+	if err != nil {
+		return nil, err
+	}
+	libP2pOpts = append(libP2pOpts, libp2p.ResourceManager(rm))
+
 	// set up host
 	rawHost, err := buildHost(ctx, config, libP2pOpts, cfg)
 	if err != nil {
@@ -218,7 +266,7 @@ func NewNetworkSubmodule(ctx context.Context,
 		return nil, err
 	}
 	// build network
-	network := net.New(peerHost, rawHost, net.NewRouter(router), bandwidthTracker)
+	network := net.New(peerHost, rawHost, gater, net.NewRouter(router), bandwidthTracker)
 	exchangeClient := filexchange.NewClient(peerHost, peerMgr)
 	exchangeServer := filexchange.NewServer(chainStore, messageStore, peerHost)
 	helloHandler := helloprotocol.NewHelloProtocolHandler(peerHost, peerMgr, exchangeClient, chainStore, messageStore, config.GenesisCid(), time.Duration(config.Repo().Config().NetworkParams.BlockDelay)*time.Second)
@@ -241,6 +289,7 @@ func NewNetworkSubmodule(ctx context.Context,
 		cfg:              config,
 		ScoreKeeper:      sk,
 		F3Cfg:            f3Cfg,
+		protectedPeers:   protectedPeers,
 	}, nil
 }
 
@@ -437,19 +486,29 @@ func HashMsgId(m *pubsub_pb.Message) string {
 	return string(hash[:])
 }
 
-func connectionManager(low, high uint, grace time.Duration, protected []string, bootstrapNodes []peer.AddrInfo) (*connmgr.BasicConnMgr, error) {
-	cm, err := connmgr.NewConnManager(int(low), int(high), connmgr.WithGracePeriod(grace))
-	if err != nil {
-		return nil, err
-	}
+// configProtectTag is the tag of the connections to the protected peers of the config
+const configProtectTag = "config-prot"
 
+func parseProtectedPeers(protected []string) ([]peer.ID, error) {
+	pids := make([]peer.ID, 0, len(protected))
 	for _, p := range protected {
 		pid, err := peer.Decode(p)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse peer ID in protected peers array: %w", err)
 		}
+		pids = append(pids, pid)
+	}
+	return pids, nil
+}
+
+func connectionManager(low, high uint, grace time.Duration, protected []peer.ID, bootstrapNodes []peer.AddrInfo) (*connmgr.BasicConnMgr, error) {
+	cm, err := connmgr.NewConnManager(int(low), int(high), connmgr.WithGracePeriod(grace))
+	if err != nil {
+		return nil, err
+	}
 
-		cm.Protect(pid, "config-prot")
+	for _, pid := range protected {
+		cm.Protect(pid, configProtectTag)
 	}
 
 	for _, inf := range bootstrapNodes {
@@ -12,6 +12,7 @@ import (
 	"github.com/filecoin-project/venus/pkg/fvm"
 	v1api "github.com/filecoin-project/venus/venus-shared/api/chain/v1"
 	"github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/ipfs/go-cid"
 	logging "github.com/ipfs/go-log/v2"
 )
 
@@ -218,3 +219,23 @@ func (sa *syncerAPI) SyncIncomingBlocks(ctx context.Context) (<-chan *types.Bloc
 func (sa *syncerAPI) SyncCheckpoint(ctx context.Context, tsk types.TipSetKey) error {
 	return sa.syncer.SyncProvider.SyncCheckpoint(ctx, tsk)
 }
+
+// SyncMarkBad marks a block as bad, the syncer refuses it and the chains including it.
+func (sa *syncerAPI) SyncMarkBad(ctx context.Context, bcid cid.Cid) error {
+	return sa.syncer.ChainSyncManager.BlockProposer().SyncMarkBad(ctx, bcid)
+}
+
+// SyncUnmarkBad removes a block from the bad block cache.
+func (sa *syncerAPI) SyncUnmarkBad(ctx context.Context, bcid cid.Cid) error {
+	return sa.syncer.ChainSyncManager.BlockProposer().SyncUnmarkBad(ctx, bcid)
+}
+
+// SyncUnmarkAllBad purges the bad block cache.
+func (sa *syncerAPI) SyncUnmarkAllBad(ctx context.Context) error {
+	return sa.syncer.ChainSyncManager.BlockProposer().SyncUnmarkAllBad(ctx)
+}
+
+// SyncCheckBad returns the reason a block is bad, or an empty string if it is not.
+func (sa *syncerAPI) SyncCheckBad(ctx context.Context, bcid cid.Cid) (string, error) {
+	return sa.syncer.ChainSyncManager.BlockProposer().SyncCheckBad(bcid), nil
+}
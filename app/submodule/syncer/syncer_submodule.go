@@ -26,6 +26,7 @@ import (
 	"github.com/filecoin-project/venus/pkg/chain"
 	"github.com/filecoin-project/venus/pkg/chainsync"
 	"github.com/filecoin-project/venus/pkg/chainsync/slashfilter"
+	syncer2 "github.com/filecoin-project/venus/pkg/chainsync/syncer"
 	"github.com/filecoin-project/venus/pkg/consensus"
 	"github.com/filecoin-project/venus/pkg/net/blocksub"
 	"github.com/filecoin-project/venus/pkg/net/pubsub"
@@ -124,9 +125,15 @@ func NewSyncerSubmodule(ctx context.Context,
 	blkValid.Stmgr = stmgr
 	chn.Stmgr = stmgr
 	chn.Waiter.Stmgr = stmgr
+	chn.Fetcher = network.ExchangeClient
+
+	badBlocks, err := syncer2.NewBadBlockCache(ctx, config.Repo().MetaDatastore(), syncer2.DefaultBadBlockCacheSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load bad block cache")
+	}
 
 	chainSyncManager, err := chainsync.NewManager(stmgr, blkValid, chn,
-		blockstore.Blockstore, network.ExchangeClient, config.ChainClock(), chn.Fork)
+		blockstore.Blockstore, network.ExchangeClient, config.ChainClock(), chn.Fork, badBlocks)
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +53,7 @@ func (walletAPI *WalletAPI) WalletDefaultAddress(ctx context.Context) (address.A
 	// No default is set; pick the 0th and make it the default.
 	if len(walletAPI.WalletAddresses(ctx)) > 0 {
 		addr := walletAPI.WalletAddresses(ctx)[0]
-		err := walletAPI.walletModule.Config.Set("walletModule.defaultAddress", addr.String())
+		_, err := walletAPI.walletModule.Config.Set("walletModule.defaultAddress", addr.String())
 		if err != nil {
 			return address.Undef, err
 		}
@@ -74,7 +74,7 @@ func (walletAPI *WalletAPI) WalletSetDefault(ctx context.Context, addr address.A
 	localAddrs := walletAPI.WalletAddresses(ctx)
 	for _, localAddr := range localAddrs {
 		if localAddr == addr {
-			err := walletAPI.walletModule.Config.Set("walletModule.defaultAddress", addr.String())
+			_, err := walletAPI.walletModule.Config.Set("walletModule.defaultAddress", addr.String())
 			if err != nil {
 				return err
 			}
@@ -167,3 +167,129 @@ func (walletAPI *WalletAPI) HasPassword(ctx context.Context) bool {
 func (walletAPI *WalletAPI) WalletState(ctx context.Context) int {
 	return walletAPI.walletModule.Wallet.WalletState(ctx)
 }
+
+// WalletSignMessageBytes signs msg prefixed with types.SignedMessageBytes, so that the signature proves the
+// ownership of the address but can't be used for anything else
+func (walletAPI *WalletAPI) WalletSignMessageBytes(ctx context.Context, k address.Address, msg []byte) (*crypto.Signature, error) {
+	keyAddr, err := walletAPI.walletModule.Chain.Stmgr.ResolveToDeterministicAddress(ctx, k, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ResolveTokeyAddress failed:%v", err)
+	}
+	return walletAPI.adapter.WalletSign(ctx, keyAddr, types.SignedMessageBytes(keyAddr.Protocol(), msg), types.MsgMeta{Type: types.MTMessageBytes, Extra: msg})
+}
+
+// WalletVerify returns whether sig is a signature of msg by the key of k
+func (walletAPI *WalletAPI) WalletVerify(ctx context.Context, k address.Address, msg []byte, sig *crypto.Signature) (bool, error) {
+	keyAddr, err := walletAPI.walletModule.Chain.Stmgr.ResolveToDeterministicAddress(ctx, k, nil)
+	if err != nil {
+		return false, fmt.Errorf("ResolveTokeyAddress failed:%v", err)
+	}
+	return verifySignature(keyAddr, msg, sig), nil
+}
+
+// verifySignature returns whether sig is a signature of msg by keyAddr, the signatures of the ethereum wallets
+// are accepted too
+func verifySignature(keyAddr address.Address, msg []byte, sig *crypto.Signature) bool {
+	if sig.Type == crypto.SigTypeDelegated && len(sig.Data) == 65 && sig.Data[64] >= 27 {
+		// the recovery id of the ethereum wallets is offset by 27
+		data := append([]byte(nil), sig.Data...)
+		data[64] -= 27
+		sig = &crypto.Signature{Type: sig.Type, Data: data}
+	}
+	return crypto.Verify(sig, keyAddr, msg) == nil
+}
+
+// WalletNewHDAddress derives the next address of protocol from the seed of the HD wallet, a seed is
+// created first if the wallet has none and its mnemonic is returned along
+func (walletAPI *WalletAPI) WalletNewHDAddress(ctx context.Context, protocol address.Protocol) (*types.WalletHDAddress, error) {
+	hd, err := walletAPI.walletModule.Wallet.HDBacked()
+	if err != nil {
+		return nil, err
+	}
+
+	var mnemonic string
+	has, err := hd.HasSeed(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		if mnemonic, err = wallet.NewMnemonic(); err != nil {
+			return nil, err
+		}
+		if err := hd.SetMnemonic(ctx, mnemonic); err != nil {
+			return nil, err
+		}
+	}
+
+	addr, err := hd.NewAddress(ctx, protocol)
+	if err != nil {
+		return nil, err
+	}
+	return walletAPI.hdAddress(hd, addr, mnemonic)
+}
+
+// WalletImportMnemonic sets the seed of the HD wallet from mnemonic and derives the first address of protocol
+func (walletAPI *WalletAPI) WalletImportMnemonic(ctx context.Context, mnemonic string, protocol address.Protocol) (*types.WalletHDAddress, error) {
+	hd, err := walletAPI.walletModule.Wallet.HDBacked()
+	if err != nil {
+		return nil, err
+	}
+	if err := hd.SetMnemonic(ctx, mnemonic); err != nil {
+		return nil, err
+	}
+
+	addr, err := hd.Derive(ctx, protocol, 0)
+	if err != nil {
+		return nil, err
+	}
+	return walletAPI.hdAddress(hd, addr, "")
+}
+
+// WalletDeriveHDAddress derives the index-th address of protocol from the seed of the HD wallet
+func (walletAPI *WalletAPI) WalletDeriveHDAddress(ctx context.Context, protocol address.Protocol, index uint32) (*types.WalletHDAddress, error) {
+	hd, err := walletAPI.walletModule.Wallet.HDBacked()
+	if err != nil {
+		return nil, err
+	}
+
+	addr, err := hd.Derive(ctx, protocol, index)
+	if err != nil {
+		return nil, err
+	}
+	return walletAPI.hdAddress(hd, addr, "")
+}
+
+func (walletAPI *WalletAPI) hdAddress(hd *wallet.HDBackend, addr address.Address, mnemonic string) (*types.WalletHDAddress, error) {
+	path, err := hd.Path(addr)
+	if err != nil {
+		return nil, err
+	}
+	return &types.WalletHDAddress{Address: addr, Path: path, Mnemonic: mnemonic}, nil
+}
+
+// WalletPolicySet sets the signing policy of an address, replacing the previous one. The policy covers
+// everything the node signs with the address: the wallet API, the message pool, the block signer and
+// the wallet gateway.
+func (walletAPI *WalletAPI) WalletPolicySet(ctx context.Context, policy *types.WalletPolicy) error {
+	keyAddr, err := walletAPI.walletModule.Chain.Stmgr.ResolveToDeterministicAddress(ctx, policy.Address, nil)
+	if err != nil {
+		return fmt.Errorf("ResolveTokeyAddress failed:%v", err)
+	}
+	cpy := *policy
+	cpy.Address = keyAddr
+	return walletAPI.walletModule.Policies.SetPolicy(ctx, &cpy)
+}
+
+// WalletPolicyGet returns the signing policy of an address
+func (walletAPI *WalletAPI) WalletPolicyGet(ctx context.Context, addr address.Address) (*types.WalletPolicy, error) {
+	keyAddr, err := walletAPI.walletModule.Chain.Stmgr.ResolveToDeterministicAddress(ctx, addr, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ResolveTokeyAddress failed:%v", err)
+	}
+	return walletAPI.walletModule.Policies.Policy(keyAddr)
+}
+
+// WalletPolicyList returns the signing policies of all the addresses
+func (walletAPI *WalletAPI) WalletPolicyList(ctx context.Context) ([]*types.WalletPolicy, error) {
+	return walletAPI.walletModule.Policies.Policies(), nil
+}
@@ -0,0 +1,84 @@
+package wallet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/crypto"
+	_ "github.com/filecoin-project/venus/pkg/crypto/delegated"
+	_ "github.com/filecoin-project/venus/pkg/crypto/secp"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/pkg/wallet/key"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func signWith(t *testing.T, ki key.KeyInfo, msg []byte) (address.Address, *crypto.Signature) {
+	addr, err := ki.Address()
+	require.NoError(t, err)
+	var sig *crypto.Signature
+	require.NoError(t, ki.UsePrivateKey(func(pk []byte) error {
+		sig, err = crypto.Sign(msg, pk, ki.SigType)
+		return err
+	}))
+	return addr, sig
+}
+
+func TestVerifySignature(t *testing.T) {
+	tf.UnitTest(t)
+
+	seed := bytes.NewReader(bytes.Repeat([]byte{7}, 1024))
+	other, err := key.NewSecpKeyFromSeed(seed)
+	require.NoError(t, err)
+	otherAddr, err := other.Address()
+	require.NoError(t, err)
+
+	t.Run("secp", func(t *testing.T) {
+		ki, err := key.NewSecpKeyFromSeed(seed)
+		require.NoError(t, err)
+		msg := types.SignedMessageBytes(address.SECP256K1, []byte("hello"))
+		addr, sig := signWith(t, ki, msg)
+
+		require.True(t, verifySignature(addr, msg, sig))
+		require.False(t, verifySignature(addr, []byte("hello"), sig))
+		require.False(t, verifySignature(otherAddr, msg, sig))
+	})
+
+	t.Run("bls", func(t *testing.T) {
+		ki, err := key.NewBLSKeyFromSeed(seed)
+		if err != nil {
+			t.Skipf("bls isn't available: %v", err)
+		}
+		msg := types.SignedMessageBytes(address.BLS, []byte("hello"))
+		addr, sig := signWith(t, ki, msg)
+		if err := crypto.Verify(sig, addr, msg); err != nil {
+			t.Skipf("bls can't verify its signatures: %v", err)
+		}
+
+		require.True(t, verifySignature(addr, msg, sig))
+		require.False(t, verifySignature(addr, []byte("hello"), sig))
+	})
+
+	t.Run("delegated personal_sign", func(t *testing.T) {
+		ki, err := key.NewDelegatedKeyFromSeed(seed)
+		require.NoError(t, err)
+		msg := types.SignedMessageBytes(address.Delegated, []byte("hello"))
+		addr, sig := signWith(t, ki, msg)
+		require.Equal(t, address.Delegated, addr.Protocol())
+		require.Len(t, sig.Data, 65)
+		require.True(t, verifySignature(addr, msg, sig))
+
+		// the ethereum wallets offset the recovery id by 27
+		eth := &crypto.Signature{Type: sig.Type, Data: append([]byte(nil), sig.Data...)}
+		eth.Data[64] += 27
+		require.True(t, verifySignature(addr, msg, eth))
+		require.Equal(t, sig.Data[64]+27, eth.Data[64], "the signature of the caller isn't modified")
+
+		// a wrong recovery id doesn't verify
+		eth.Data[64] ^= 1
+		require.False(t, verifySignature(addr, msg, eth))
+		require.False(t, verifySignature(addr, []byte("hello"), sig))
+	})
+}
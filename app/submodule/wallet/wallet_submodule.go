@@ -15,6 +15,7 @@ import (
 	"github.com/filecoin-project/venus/app/submodule/config"
 	"github.com/filecoin-project/venus/app/submodule/wallet/remotewallet"
 	pconfig "github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/journal"
 	"github.com/filecoin-project/venus/pkg/repo"
 	"github.com/filecoin-project/venus/pkg/state"
 	"github.com/filecoin-project/venus/pkg/wallet"
@@ -32,11 +33,14 @@ type WalletSubmodule struct { // nolint
 	Signer        types.Signer
 	Config        *config.ConfigModule
 	WalletGateway *gateway.WalletGateway
+	// Policies checks what the addresses sign against their policies
+	Policies *wallet.PolicyWallet
 }
 
 type walletRepo interface {
 	Config() *pconfig.Config
 	WalletDatastore() repo.Datastore
+	MetaDatastore() repo.Datastore
 }
 
 // NewWalletSubmodule creates a new storage protocol submodule.
@@ -44,17 +48,23 @@ func NewWalletSubmodule(ctx context.Context,
 	repo walletRepo,
 	cfgModule *config.ConfigModule,
 	chain *chain.ChainSubmodule,
+	jrnl journal.Journal,
 	password []byte,
 ) (*WalletSubmodule, error) {
 	passphraseCfg, err := getPassphraseConfig(repo.Config())
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get passphrase config")
 	}
+	// the hd backend keeps the password intact, unlike the ds backend
+	hdBackend, err := wallet.NewHDBackend(ctx, repo.MetaDatastore(), passphraseCfg, password)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set up HD wallet backend")
+	}
 	backend, err := wallet.NewDSBackend(ctx, repo.WalletDatastore(), passphraseCfg, password)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to set up walletModule backend")
 	}
-	fcWallet := wallet.New(backend)
+	fcWallet := wallet.New(backend, hdBackend)
 	headSigner := state.NewHeadSignView(chain.ChainReader)
 
 	var adapter wallet.WalletIntersection
@@ -70,6 +80,11 @@ func NewWalletSubmodule(ctx context.Context,
 	} else {
 		adapter = fcWallet
 	}
+	policies, err := wallet.NewPolicyWallet(ctx, adapter, repo.MetaDatastore(), chain.API(), jrnl)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load wallet policies")
+	}
+	adapter = policies
 
 	var wg *gateway.WalletGateway
 	if len(repo.Config().Wallet.GatewayBacked) != 0 {
@@ -87,8 +102,9 @@ func NewWalletSubmodule(ctx context.Context,
 		Chain:         chain,
 		Wallet:        fcWallet,
 		adapter:       adapter,
-		Signer:        state.NewSigner(headSigner, fcWallet),
+		Signer:        state.NewSigner(headSigner, fcWallet, policies.Enforce(fcWallet.WalletSign)),
 		WalletGateway: wg,
+		Policies:      policies,
 	}, nil
 }
 
@@ -115,7 +131,7 @@ func (wallet *WalletSubmodule) GetWalletSign() wallet.WalletSignFunc {
 	if wallet.WalletGateway == nil {
 		return wallet.adapter.WalletSign
 	}
-	return wallet.WalletGateway.WalletSign
+	return wallet.Policies.Enforce(wallet.WalletGateway.WalletSign)
 }
 
 func getPassphraseConfig(cfg *pconfig.Config) (pconfig.PassphraseConfig, error) {
@@ -124,3 +140,6 @@ func getPassphraseConfig(cfg *pconfig.Config) (pconfig.PassphraseConfig, error)
 		ScryptP: cfg.Wallet.PassphraseConfig.ScryptP,
 	}, nil
 }
+
+// LiveConfigKeys are the fields of the config the wallet reads each time it uses them, they need no restart
+var LiveConfigKeys = []string{"walletModule.defaultAddress"}
@@ -4,9 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/hex"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"strings"
 	"time"
@@ -21,6 +22,7 @@ import (
 
 	"github.com/filecoin-project/venus/app/node"
 	"github.com/filecoin-project/venus/cmd/tablewriter"
+	"github.com/filecoin-project/venus/pkg/crypto"
 	"github.com/filecoin-project/venus/pkg/wallet"
 	"github.com/filecoin-project/venus/pkg/wallet/key"
 	"github.com/filecoin-project/venus/venus-shared/types"
@@ -38,6 +40,9 @@ var walletCmd = &cmds.Command{
 	Subcommands: map[string]*cmds.Command{
 		"balance":      balanceCmd,
 		"import":       walletImportCmd,
+		"derive":       walletDeriveCmd,
+		"sign":         walletSignCmd,
+		"verify":       walletVerifyCmd,
 		"export":       walletExportCmd,
 		"ls":           addrsLsCmd,
 		"new":          addrsNewCmd,
@@ -59,22 +64,28 @@ type AddressLsResult struct {
 	Addresses []address.Address
 }
 
+func parseProtocol(protocolName string) (address.Protocol, error) {
+	switch protocolName {
+	case "secp256k1":
+		return address.SECP256K1, nil
+	case "bls":
+		return address.BLS, nil
+	case "delegated":
+		return address.Delegated, nil
+	default:
+		return 0, fmt.Errorf("unrecognized address protocol %s", protocolName)
+	}
+}
+
 var addrsNewCmd = &cmds.Command{
 	Options: []cmds.Option{
 		cmds.StringOption("type", "The type of address to create: bls (default) or secp256k1 or delegated").WithDefault("bls"),
+		cmds.BoolOption("hd", "Derive the address from the seed of the HD wallet, only secp256k1 and delegated addresses can be derived").WithDefault(false),
 	},
 	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
-		protocolName := req.Options["type"].(string)
-		var protocol address.Protocol
-		switch protocolName {
-		case "secp256k1":
-			protocol = address.SECP256K1
-		case "bls":
-			protocol = address.BLS
-		case "delegated":
-			protocol = address.Delegated
-		default:
-			return fmt.Errorf("unrecognized address protocol %s", protocolName)
+		protocol, err := parseProtocol(req.Options["type"].(string))
+		if err != nil {
+			return err
 		}
 
 		if !env.(*node.Env).WalletAPI.HasPassword(req.Context) {
@@ -84,6 +95,14 @@ var addrsNewCmd = &cmds.Command{
 			return errWalletLocked
 		}
 
+		if req.Options["hd"].(bool) {
+			hdAddr, err := env.(*node.Env).WalletAPI.WalletNewHDAddress(req.Context, protocol)
+			if err != nil {
+				return err
+			}
+			return printHDAddress(re, hdAddr)
+		}
+
 		addr, err := env.(*node.Env).WalletAPI.WalletNewAddress(req.Context, protocol)
 		if err != nil {
 			return err
@@ -93,6 +112,55 @@ var addrsNewCmd = &cmds.Command{
 	},
 }
 
+var walletDeriveCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Derive an address from the seed of the HD wallet",
+	},
+	Options: []cmds.Option{
+		cmds.StringOption("type", "The type of address to derive: secp256k1 (default) or delegated").WithDefault("secp256k1"),
+		cmds.Uint64Option("index", "The index of the address in its BIP-44 path"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		protocol, err := parseProtocol(req.Options["type"].(string))
+		if err != nil {
+			return err
+		}
+		index, ok := req.Options["index"].(uint64)
+		if !ok {
+			return errors.New("--index is required")
+		}
+		if index > math.MaxUint32 {
+			return fmt.Errorf("index %d is out of range", index)
+		}
+
+		if !env.(*node.Env).WalletAPI.HasPassword(req.Context) {
+			return errMissPassword
+		}
+		if env.(*node.Env).WalletAPI.WalletState(req.Context) == wallet.Lock {
+			return errWalletLocked
+		}
+
+		hdAddr, err := env.(*node.Env).WalletAPI.WalletDeriveHDAddress(req.Context, protocol, uint32(index))
+		if err != nil {
+			return err
+		}
+		return printHDAddress(re, hdAddr)
+	},
+}
+
+func printHDAddress(re cmds.ResponseEmitter, hdAddr *types.WalletHDAddress) error {
+	buf := new(bytes.Buffer)
+	writer := NewSilentWriter(buf)
+	writer.Printf("%s\t%s\n", hdAddr.Address, hdAddr.Path)
+	if len(hdAddr.Mnemonic) != 0 {
+		writer.Println()
+		writer.Println("A new seed was created for the HD wallet, write down its mnemonic and keep it safe,")
+		writer.Println("it is the only way to restore the addresses derived from it:")
+		writer.Println(hdAddr.Mnemonic)
+	}
+	return re.Emit(buf)
+}
+
 var addrsDeleteCmd = &cmds.Command{
 	Arguments: []cmds.Argument{
 		cmds.StringArg("address", true, false, "wallet address"),
@@ -300,6 +368,28 @@ var walletImportCmd = &cmds.Command{
 	Arguments: []cmds.Argument{
 		cmds.FileArg("walletFile", true, false, "File containing wallet data to import").EnableStdin(),
 	},
+	Options: []cmds.Option{
+		cmds.BoolOption("mnemonic", "The file contains the BIP-39 mnemonic to set the seed of the HD wallet from").WithDefault(false),
+		cmds.StringOption("format", "The format of the file: hex-lotus, json-lotus, eth-keystore or raw-hex").WithDefault(string(wallet.KeyFormatHexLotus)),
+		cmds.StringOption("type", "The type of the key of a raw-hex file or of the first address derived from the mnemonic: "+
+			"secp256k1 (default) or delegated, or bls for a raw-hex file. The key of an eth-keystore file is delegated by default"),
+		cmds.StringOption("keystore-password", "The password of the eth-keystore file, prompted if not given"),
+	},
+	PreRun: func(req *cmds.Request, env cmds.Environment) error {
+		if req.Options["format"].(string) != string(wallet.KeyFormatEthKeystore) {
+			return nil
+		}
+		if _, ok := req.Options["keystore-password"]; ok {
+			return nil
+		}
+		pw, err := gopass.GetPasswdPrompt("Keystore password:", true, os.Stdin, os.Stdout)
+		if err != nil {
+			return err
+		}
+		req.Options["keystore-password"] = string(pw)
+
+		return nil
+	},
 	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
 		if !env.(*node.Env).WalletAPI.HasPassword(req.Context) {
 			return errMissPassword
@@ -317,13 +407,42 @@ var walletImportCmd = &cmds.Command{
 			return fmt.Errorf("given file was not a files.File")
 		}
 
-		var key types.KeyInfo
-		err := json.NewDecoder(hex.NewDecoder(fi)).Decode(&key)
+		format := wallet.KeyFormat(req.Options["format"].(string))
+		keyType, _ := req.Options["type"].(string)
+		if len(keyType) == 0 {
+			keyType = string(types.KTSecp256k1)
+			if format == wallet.KeyFormatEthKeystore {
+				keyType = string(types.KTDelegated)
+			}
+		}
+
+		if req.Options["mnemonic"].(bool) {
+			protocol, err := parseProtocol(keyType)
+			if err != nil {
+				return err
+			}
+			mnemonic, err := io.ReadAll(fi)
+			if err != nil {
+				return err
+			}
+			hdAddr, err := env.(*node.Env).WalletAPI.WalletImportMnemonic(req.Context, strings.Join(strings.Fields(string(mnemonic)), " "), protocol)
+			if err != nil {
+				return err
+			}
+			return printHDAddress(re, hdAddr)
+		}
+
+		data, err := io.ReadAll(fi)
+		if err != nil {
+			return err
+		}
+		keystorePassword, _ := req.Options["keystore-password"].(string)
+		key, err := wallet.DecodeKeyFile(data, format, types.KeyType(keyType), []byte(keystorePassword))
 		if err != nil {
 			return err
 		}
 
-		addr, err := env.(*node.Env).WalletAPI.WalletImport(req.Context, &key)
+		addr, err := env.(*node.Env).WalletAPI.WalletImport(req.Context, key)
 		if err != nil {
 			return err
 		}
@@ -337,7 +456,28 @@ var walletExportCmd = &cmds.Command{
 		cmds.StringArg("addr", true, true, "address of key to export"),
 		cmds.StringArg("password", false, false, "Password to be locked"),
 	},
+	Options: []cmds.Option{
+		cmds.StringOption("format", "The format of the exported key: hex-lotus, json-lotus, eth-keystore or raw-hex").WithDefault(string(wallet.KeyFormatHexLotus)),
+		cmds.StringOption("keystore-password", "The password to encrypt the eth-keystore file with, prompted if not given"),
+	},
 	PreRun: func(req *cmds.Request, env cmds.Environment) error {
+		if req.Options["format"].(string) == string(wallet.KeyFormatEthKeystore) {
+			if _, ok := req.Options["keystore-password"]; !ok {
+				pw, err := gopass.GetPasswdPrompt("Keystore password:", true, os.Stdin, os.Stdout)
+				if err != nil {
+					return err
+				}
+				pw2, err := gopass.GetPasswdPrompt("Enter keystore password again:", true, os.Stdin, os.Stdout)
+				if err != nil {
+					return err
+				}
+				if !bytes.Equal(pw, pw2) {
+					return errors.New("the input passwords are inconsistent")
+				}
+				req.Options["keystore-password"] = string(pw)
+			}
+		}
+
 		// for testing, skip manual password entry
 		if len(req.Arguments) == 2 && len(req.Arguments[1]) != 0 {
 			return nil
@@ -369,12 +509,123 @@ var walletExportCmd = &cmds.Command{
 			return err
 		}
 
-		kiBytes, err := json.Marshal(ki)
+		keystorePassword, _ := req.Options["keystore-password"].(string)
+		data, err := wallet.EncodeKeyFile(ki, wallet.KeyFormat(req.Options["format"].(string)), []byte(keystorePassword))
+		if err != nil {
+			return err
+		}
+
+		return printOneString(re, string(data))
+	},
+}
+
+// decodeSignData returns the data to sign or verify, hex encoded unless utf8 is set
+func decodeSignData(data string, utf8 bool) ([]byte, error) {
+	if utf8 {
+		return []byte(data), nil
+	}
+	b, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("decode hex data: %w", err)
+	}
+	return b, nil
+}
+
+var walletSignCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Sign a message with an address",
+		ShortDescription: `The message is prefixed with "\x19Filecoin Signed Message:\n" and its length before being signed,
+or with the prefix of EIP-191 personal_sign for a delegated address, so that the signature proves the ownership
+of the address but can't be taken for the signature of a chain message. The signature is printed hex encoded,
+starting with its type.`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("address", true, false, "The address signing the message"),
+		cmds.StringArg("message", true, false, "The hex encoded message"),
+	},
+	Options: []cmds.Option{
+		cmds.BoolOption("utf8", "The message is a UTF-8 string rather than hex encoded").WithDefault(false),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		addr, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		msg, err := decodeSignData(req.Arguments[1], req.Options["utf8"].(bool))
+		if err != nil {
+			return err
+		}
+
+		if env.(*node.Env).WalletAPI.WalletState(req.Context) == wallet.Lock {
+			return errWalletLocked
+		}
+
+		sig, err := env.(*node.Env).WalletAPI.WalletSignMessageBytes(req.Context, addr, msg)
+		if err != nil {
+			return err
+		}
+		sigBytes, err := sig.MarshalBinary()
+		if err != nil {
+			return err
+		}
+
+		return printOneString(re, hex.EncodeToString(sigBytes))
+	},
+}
+
+var walletVerifyCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Verify the signature of a message",
+		ShortDescription: `The message is prefixed the way 'venus wallet sign' does before being verified, unless --raw is set.
+The signature is hex encoded and starts with its type, like the ones of 'venus wallet sign' and 'lotus wallet sign'.`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("address", true, false, "The address which signed the message"),
+		cmds.StringArg("message", true, false, "The hex encoded message"),
+		cmds.StringArg("signature", true, false, "The hex encoded signature"),
+	},
+	Options: []cmds.Option{
+		cmds.BoolOption("utf8", "The message is a UTF-8 string rather than hex encoded").WithDefault(false),
+		cmds.BoolOption("raw", "The message was signed as is, without prefix").WithDefault(false),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		addr, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		msg, err := decodeSignData(req.Arguments[1], req.Options["utf8"].(bool))
+		if err != nil {
+			return err
+		}
+		sigBytes, err := hex.DecodeString(strings.TrimPrefix(req.Arguments[2], "0x"))
+		if err != nil {
+			return fmt.Errorf("decode hex signature: %w", err)
+		}
+		var sig crypto.Signature
+		if err := sig.UnmarshalBinary(sigBytes); err != nil {
+			return err
+		}
+
+		if !req.Options["raw"].(bool) {
+			// the signer is resolved to its key address by the node, an ID address doesn't tell the prefix
+			keyAddr := addr
+			if addr.Protocol() == address.ID {
+				if keyAddr, err = env.(*node.Env).ChainAPI.StateAccountKey(req.Context, addr, types.EmptyTSK); err != nil {
+					return err
+				}
+			}
+			msg = types.SignedMessageBytes(keyAddr.Protocol(), msg)
+		}
+
+		ok, err := env.(*node.Env).WalletAPI.WalletVerify(req.Context, addr, msg, &sig)
 		if err != nil {
 			return err
 		}
+		if !ok {
+			return errors.New("invalid signature")
+		}
 
-		return printOneString(re, hex.EncodeToString(kiBytes))
+		return printOneString(re, "valid")
 	},
 }
 
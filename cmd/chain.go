@@ -8,9 +8,11 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/docker/go-units"
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
@@ -37,7 +39,11 @@ var chainCmd = &cmds.Command{
 		"get-receipts":       chainGetReceiptsCmd,
 		"disputer":           chainDisputeSetCmd,
 		"export":             chainExportCmd,
+		"export-snapshot":    chainExportSnapshotCmd,
 		"read-obj":           chainReadObjCmd,
+		"prune":              chainPruneCmd,
+		"validate-index":     chainValidateIndexCmd,
+		"check-blockstore":   chainCheckBlockstoreCmd,
 	},
 }
 
@@ -410,6 +416,335 @@ var chainExportCmd = &cmds.Command{
 	},
 }
 
+var chainExportSnapshotCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Export a snapshot to a file on the node in the background",
+		ShortDescription: `Start a background job writing a CARv1 snapshot, zstd compressed by default, to a file
+on the node. The last snapshot exported successfully is served over http at /chain/snapshot to
+clients with an admin token, and can be imported with 'venus daemon --import-snapshot'.`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("outputPath", true, false, "path of the snapshot file on the node"),
+	},
+	Options: []cmds.Option{
+		cmds.StringOption("tipset").WithDefault(""),
+		cmds.Int64Option("recent-stateroots", "specify the number of recent state roots to include in the export").WithDefault(int64(0)),
+		cmds.BoolOption("skip-old-msgs").WithDefault(false),
+		cmds.BoolOption("compress", "compress the snapshot with zstd").WithDefault(true),
+		cmds.IntOption("workers", "number of goroutines walking the chain DAG").WithDefault(1),
+		cmds.BoolOption("wait", "wait for the export to finish").WithDefault(false),
+	},
+	Subcommands: map[string]*cmds.Command{
+		"status": chainExportSnapshotStatusCmd,
+		"cancel": chainExportSnapshotCancelCmd,
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		api := env.(*node.Env).ChainAPI
+
+		path, err := filepath.Abs(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		ts, err := LoadTipSet(req.Context, req, api)
+		if err != nil {
+			return err
+		}
+
+		params := types.ExportJobParams{
+			Path:             path,
+			TipSetKey:        ts.Key(),
+			RecentStateRoots: abi.ChainEpoch(req.Options["recent-stateroots"].(int64)),
+			SkipOldMsgs:      req.Options["skip-old-msgs"].(bool),
+			Compress:         req.Options["compress"].(bool),
+			Workers:          req.Options["workers"].(int),
+		}
+		if err := api.ChainExportJobStart(req.Context, params); err != nil {
+			return err
+		}
+
+		if !req.Options["wait"].(bool) {
+			return printOneString(re, fmt.Sprintf("snapshot export of tipset %d started", ts.Height()))
+		}
+
+		for {
+			select {
+			case <-req.Context.Done():
+				return req.Context.Err()
+			case <-time.After(5 * time.Second):
+			}
+
+			status, err := api.ChainExportJobStatus(req.Context)
+			if err != nil {
+				return err
+			}
+			if status.Running {
+				continue
+			}
+			if status.LastError != "" {
+				return fmt.Errorf("snapshot export failed: %s", status.LastError)
+			}
+			return printExportJobStatus(re, status)
+		}
+	},
+}
+
+var chainExportSnapshotStatusCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Show the progress of the running, or last, snapshot export",
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		status, err := env.(*node.Env).ChainAPI.ChainExportJobStatus(req.Context)
+		if err != nil {
+			return err
+		}
+		return printExportJobStatus(re, status)
+	},
+}
+
+var chainExportSnapshotCancelCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Cancel the running snapshot export",
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		if err := env.(*node.Env).ChainAPI.ChainExportJobCancel(req.Context); err != nil {
+			return err
+		}
+		return printOneString(re, "snapshot export canceled")
+	},
+}
+
+func printExportJobStatus(re cmds.ResponseEmitter, status *types.ExportJobStatus) error {
+	buf := new(bytes.Buffer)
+	writer := NewSilentWriter(buf)
+
+	if status.StartTime.IsZero() {
+		writer.Println("no snapshot has been exported")
+		return re.Emit(buf)
+	}
+
+	writer.Printf("Running:       %t\n", status.Running)
+	writer.Printf("Path:          %s\n", status.Path)
+	writer.Printf("Compressed:    %t\n", status.Compressed)
+	writer.Printf("Height:        %d\n", status.Height)
+	writer.Printf("State roots:   %d\n", status.RecentStateRoots)
+	writer.Printf("Current epoch: %d\n", status.CurrentEpoch)
+	writer.Printf("Blocks:        %d\n", status.BlocksWritten)
+	writer.Printf("Size:          %s\n", units.BytesSize(float64(status.BytesWritten)))
+	writer.Printf("Started:       %s\n", status.StartTime.Format("2006-01-02 15:04:05"))
+	if !status.Running {
+		writer.Printf("Finished:      %s\n", status.EndTime.Format("2006-01-02 15:04:05"))
+	}
+	if status.Canceled {
+		writer.Println("Canceled:      true")
+	}
+	if status.LastError != "" {
+		writer.Printf("Error:         %s\n", status.LastError)
+	}
+
+	return re.Emit(buf)
+}
+
+var chainPruneCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Prune the hot store of the splitstore",
+		ShortDescription: `Start a splitstore compaction from the current head. The state and messages
+older than the retained finality windows are moved to the cold store, or discarded,
+depending on the configured splitstore mode.`,
+	},
+	Options: []cmds.Option{
+		cmds.Int64Option("retain-finalities", "number of finality windows to keep in the hot store, 0 uses the configured value").WithDefault(int64(0)),
+		cmds.BoolOption("wait", "wait for the compaction to finish").WithDefault(false),
+	},
+	Subcommands: map[string]*cmds.Command{
+		"status": chainPruneStatusCmd,
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		api := env.(*node.Env).ChainAPI
+		opts := types.PruneOpts{
+			RetainFinalities: req.Options["retain-finalities"].(int64),
+			Wait:             req.Options["wait"].(bool),
+		}
+		if err := api.ChainPrune(req.Context, opts); err != nil {
+			return err
+		}
+
+		if !opts.Wait {
+			return printOneString(re, "compaction started")
+		}
+
+		status, err := api.ChainPruneStatus(req.Context)
+		if err != nil {
+			return err
+		}
+		return printPruneStatus(re, status)
+	},
+}
+
+var chainPruneStatusCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Show the progress of the running, or last, splitstore compaction",
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		status, err := env.(*node.Env).ChainAPI.ChainPruneStatus(req.Context)
+		if err != nil {
+			return err
+		}
+		return printPruneStatus(re, status)
+	},
+}
+
+func printPruneStatus(re cmds.ResponseEmitter, status *types.PruneStatus) error {
+	buf := new(bytes.Buffer)
+	writer := NewSilentWriter(buf)
+
+	if !status.Enabled {
+		writer.Println("splitstore is not enabled")
+		return re.Emit(buf)
+	}
+
+	writer.Printf("Mode:       %s\n", status.Mode)
+	writer.Printf("Compacting: %t\n", status.Compacting)
+	writer.Printf("Stage:      %s\n", status.Stage)
+	if !status.StartTime.IsZero() {
+		writer.Printf("Base epoch: %d\n", status.BaseEpoch)
+		writer.Printf("Boundary:   %d\n", status.Boundary)
+		writer.Printf("Started:    %s\n", status.StartTime.Format("2006-01-02 15:04:05"))
+		if !status.Compacting {
+			writer.Printf("Finished:   %s\n", status.EndTime.Format("2006-01-02 15:04:05"))
+		}
+	}
+	writer.Printf("Marked:     %d\n", status.Marked)
+	writer.Printf("Scanned:    %d\n", status.Scanned)
+	writer.Printf("Moved:      %d\n", status.Moved)
+	writer.Printf("Removed:    %d\n", status.Removed)
+	writer.Printf("Mark set:   %s\n", units.BytesSize(float64(status.MarkSetSize)))
+	if status.LastError != "" {
+		writer.Printf("Error:      %s\n", status.LastError)
+	}
+
+	return re.Emit(buf)
+}
+
+var chainValidateIndexCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Validate the chain index over a range of epochs",
+		ShortDescription: `Walk backwards from epoch --from to epoch --to and check that the chain index
+matches the canonical chain at each epoch. With --backfill the tipsets missing in the index or not
+matching the chain are re-indexed.`,
+	},
+	Options: []cmds.Option{
+		cmds.Int64Option("from", "epoch to start the validation from, default to the parent of the head"),
+		cmds.Int64Option("to", "epoch to stop the validation at").WithDefault(int64(0)),
+		cmds.BoolOption("backfill", "re-index the tipsets missing in the index or not matching the chain").WithDefault(false),
+		cmds.BoolOption("quiet", "only print the epochs that failed the validation").WithDefault(false),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		ctx := req.Context
+		api := env.(*node.Env).ChainAPI
+
+		from, ok := req.Options["from"].(int64)
+		if !ok {
+			head, err := api.ChainHead(ctx)
+			if err != nil {
+				return err
+			}
+			from = int64(head.Height()) - 1
+		}
+		to := req.Options["to"].(int64)
+		if from < to {
+			return fmt.Errorf("from epoch %d must not be smaller than to epoch %d", from, to)
+		}
+		backfill := req.Options["backfill"].(bool)
+		quiet := req.Options["quiet"].(bool)
+
+		buf := new(bytes.Buffer)
+		writer := NewSilentWriter(buf)
+		var failed int
+		for epoch := from; epoch >= to; epoch-- {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			res, err := api.ChainValidateIndex(ctx, abi.ChainEpoch(epoch), backfill)
+			if err != nil {
+				failed++
+				writer.Printf("epoch %d: error: %v\n", epoch, err)
+				continue
+			}
+			if quiet {
+				continue
+			}
+			switch {
+			case res.IsNullRound:
+				writer.Printf("epoch %d: null round\n", epoch)
+			case res.Backfilled:
+				writer.Printf("epoch %d: backfilled, messages: %d\n", epoch, res.IndexedMessagesCount)
+			default:
+				writer.Printf("epoch %d: messages: %d\n", epoch, res.IndexedMessagesCount)
+			}
+		}
+		writer.Printf("validated %d epochs, %d failed\n", from-to+1, failed)
+
+		return re.Emit(buf)
+	},
+}
+
+var chainCheckBlockstoreCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Check the integrity of the chain objects in the blockstore",
+		ShortDescription: `Walk the chain from the head, or --tipset, through headers, messages and the receipts and
+state trees of the recent state roots, and report the objects missing in the blockstore or not hashing to their
+cid. With --repair the missing or corrupt headers and messages are refetched from peers.`,
+	},
+	Options: []cmds.Option{
+		cmds.StringOption("tipset", "tipset to start the check from, default to the head"),
+		cmds.Int64Option("epochs", "number of epochs to check below the tipset, 0 checks to genesis").WithDefault(int64(constants.Finality)),
+		cmds.Int64Option("recent-stateroots", "number of recent epochs whose state trees and receipts are checked").WithDefault(int64(1)),
+		cmds.BoolOption("repair", "refetch missing or corrupt headers and messages from peers").WithDefault(false),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		api := env.(*node.Env).ChainAPI
+
+		ts, err := LoadTipSet(req.Context, req, api)
+		if err != nil {
+			return err
+		}
+
+		res, err := api.ChainCheckBlockstore(req.Context, types.BlockstoreCheckParams{
+			TipSetKey:        ts.Key(),
+			Epochs:           abi.ChainEpoch(req.Options["epochs"].(int64)),
+			RecentStateRoots: abi.ChainEpoch(req.Options["recent-stateroots"].(int64)),
+			Repair:           req.Options["repair"].(bool),
+		})
+		if err != nil {
+			return err
+		}
+
+		buf := new(bytes.Buffer)
+		writer := NewSilentWriter(buf)
+		var repaired int
+		for _, f := range res.Faults {
+			state := "missing"
+			if f.Corrupt {
+				state = "corrupt"
+			}
+			if f.Repaired {
+				repaired++
+				state += ", repaired"
+			}
+			if f.Error != "" {
+				writer.Printf("%s %s at epoch %d: %s: %s\n", f.Object, f.Cid, f.Epoch, state, f.Error)
+			} else {
+				writer.Printf("%s %s at epoch %d: %s\n", f.Object, f.Cid, f.Epoch, state)
+			}
+		}
+		writer.Printf("checked %d blocks from epoch %d to %d, %d faults, %d repaired\n",
+			res.BlocksChecked, res.Height, res.LowestEpoch, len(res.Faults), repaired)
+
+		return re.Emit(buf)
+	},
+}
+
 // LoadTipSet gets the tipset from the context, or the head from the API.
 //
 // It always gets the head from the API so commands use a consistent tipset even if time pases.
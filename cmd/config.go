@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	cmds "github.com/ipfs/go-ipfs-cmds"
+
+	"github.com/filecoin-project/venus/app/node"
+)
+
+var configCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Get and set the config of the venus node",
+		ShortDescription: `
+The fields of the config are referenced by their dotted keys, e.g. mpool.maxFee, or by the key
+of a whole section, e.g. mpool. Some changes are applied by the running node, the others take
+effect after a restart, 'venus config diff' lists them.
+`,
+	},
+	Subcommands: map[string]*cmds.Command{
+		"get":  configGetCmd,
+		"set":  configSetCmd,
+		"diff": configDiffCmd,
+	},
+}
+
+var configGetCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Print the value of a field of the config",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("key", true, false, "dotted key of the field, e.g. mpool.maxFee"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		value, err := env.(*node.Env).ConfigAPI.ConfigGet(req.Context, req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		return re.Emit(value)
+	},
+}
+
+var configSetCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Set the value of a field of the config",
+		ShortDescription: `
+The value is json, a plain string doesn't need to be quoted:
+  venus config set mpool.maxFee "0.5 FIL"
+  venus config set swarm.protectedPeers '["12D3KooW..."]'
+
+mpool.maxFee, mpool.addressFees, mpool.feeBump, swarm.protectedPeers and walletModule.defaultAddress are
+applied without a restart. The other fields, e.g. the connection manager limits, the fevm cache sizes or the
+event options, are saved and reported as restart required, 'venus config diff' lists them until the restart.
+`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("key", true, false, "dotted key of the field, e.g. mpool.maxFee"),
+		cmds.StringArg("value", true, false, "json value of the field"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		res, err := env.(*node.Env).ConfigAPI.ConfigSet(req.Context, req.Arguments[0], req.Arguments[1])
+		if err != nil {
+			return err
+		}
+
+		buf := new(bytes.Buffer)
+		writer := NewSilentWriter(buf)
+		if len(res.Changed) == 0 {
+			writer.Println("nothing changed")
+		}
+		if len(res.Applied) > 0 {
+			writer.Printf("applied: %s\n", strings.Join(res.Applied, ", "))
+		}
+		if len(res.RestartRequired) > 0 {
+			writer.Printf("restart required: %s\n", strings.Join(res.RestartRequired, ", "))
+		}
+		return re.Emit(buf)
+	},
+}
+
+var configDiffCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline:          "List the changes of the config waiting for a restart",
+		ShortDescription: "Lists the fields of the config file which differ from the ones the node runs with.",
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		changes, err := env.(*node.Env).ConfigAPI.ConfigDiff(req.Context)
+		if err != nil {
+			return err
+		}
+
+		buf := new(bytes.Buffer)
+		writer := NewSilentWriter(buf)
+		if len(changes) == 0 {
+			writer.Println("the node runs with the current config")
+		} else {
+			writer.Println("restart required:")
+		}
+		for _, change := range changes {
+			writer.Printf("  %s: %s -> %s\n", change.Key, configValue(change.Running), configValue(change.Current))
+		}
+		return re.Emit(buf)
+	},
+}
+
+func configValue(v json.RawMessage) string {
+	if len(v) == 0 {
+		return "<none>"
+	}
+	return string(v)
+}
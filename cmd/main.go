@@ -128,6 +128,9 @@ State COMMANDS
 Paych COMMANDS 
   paych                  - Manage payment channels
 
+Multisig COMMANDS
+  msig                   - Interact with a multisig wallet
+
 Cid COMMANDS
   manifest-cid-from-car  - Get the manifest CID from a car file
 
@@ -139,6 +142,7 @@ F3 COMMANDS
 
 TOOL COMMANDS
   inspect                - Show info about the venus node
+  config                 - Get and set the config of the venus node
   log                    - Interact with the daemon event log output
   version                - Show venus version information
   seed                   - Seal sectors for genesis miner
@@ -178,6 +182,7 @@ var rootSubcmdsDaemon = map[string]*cmds.Command{
 	"sync":    syncCmd,
 	"drand":   drandCmd,
 	"inspect": inspectCmd,
+	"config":  configCmd,
 	"log":     logCmd,
 	"send":    msgSendCmd,
 	"mpool":   mpoolCmd,
@@ -187,6 +192,7 @@ var rootSubcmdsDaemon = map[string]*cmds.Command{
 	"state":   stateCmd,
 	"miner":   minerCmd,
 	"paych":   paychCmd,
+	"msig":    multisigCmd,
 	"info":    infoCmd,
 	"evm":     evmCmd,
 	"f3":      f3Cmd,
@@ -0,0 +1,890 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	init16 "github.com/filecoin-project/go-state-types/builtin/v16/init"
+	msig16 "github.com/filecoin-project/go-state-types/builtin/v16/multisig"
+	cmds "github.com/ipfs/go-ipfs-cmds"
+
+	"github.com/filecoin-project/venus/app/node"
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var (
+	msigFromOption       = cmds.StringOption("from", "account to send the message from, defaults to the wallet default address")
+	msigConfidenceOption = cmds.Uint64Option("confidence", "number of block confirmations to wait for").WithDefault(constants.MessageConfidence)
+)
+
+var multisigCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Interact with a multisig wallet",
+	},
+	Subcommands: map[string]*cmds.Command{
+		"create":         msigCreateCmd,
+		"inspect":        msigInspectCmd,
+		"propose":        msigProposeCmd,
+		"propose-remove": msigRemoveProposeCmd,
+		"approve":        msigApproveCmd,
+		"cancel":         msigCancelCmd,
+		"add-propose":    msigAddProposeCmd,
+		"add-approve":    msigAddApproveCmd,
+		"add-cancel":     msigAddCancelCmd,
+		"swap-propose":   msigSwapProposeCmd,
+		"swap-approve":   msigSwapApproveCmd,
+		"swap-cancel":    msigSwapCancelCmd,
+		"vested":         msigVestedCmd,
+	},
+}
+
+var msigCreateCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Create a new multisig wallet",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("addresses", true, true, "addresses of the signers"),
+	},
+	Options: []cmds.Option{
+		cmds.Uint64Option("required", "number of required approvals (defaults to the number of signers)"),
+		cmds.StringOption("value", "initial funds to give to multisig (FIL)").WithDefault("0"),
+		cmds.Int64Option("duration", "length of the period over which funds unlock").WithDefault(int64(0)),
+		msigFromOption,
+		msigConfidenceOption,
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		ctx := req.Context
+		api := getEnv(env)
+
+		var addrs []address.Address
+		for _, a := range req.Arguments {
+			addr, err := address.NewFromString(a)
+			if err != nil {
+				return err
+			}
+			addrs = append(addrs, addr)
+		}
+
+		required, _ := req.Options["required"].(uint64)
+		if required == 0 {
+			required = uint64(len(addrs))
+		}
+
+		value, err := types.ParseFIL(req.Options["value"].(string))
+		if err != nil {
+			return err
+		}
+		duration := abi.ChainEpoch(req.Options["duration"].(int64))
+
+		from, err := fromAddrOrDefault(req, env)
+		if err != nil {
+			return err
+		}
+
+		proto, err := api.MultiSigAPI.MsigCreate(ctx, required, addrs, duration, types.BigInt(value), from, types.NewInt(0))
+		if err != nil {
+			return err
+		}
+
+		wait, err := msigPushAndWait(req, api, proto)
+		if err != nil {
+			return err
+		}
+
+		var execreturn init16.ExecReturn
+		if err := execreturn.UnmarshalCBOR(bytes.NewReader(wait.Receipt.Return)); err != nil {
+			return err
+		}
+
+		return printOneString(re, fmt.Sprintf("Created new multisig: %s %s", execreturn.IDAddress, execreturn.RobustAddress))
+	},
+}
+
+// msigState is the json view of the multisig actor state returned by StateReadState
+type msigState struct {
+	Signers               []address.Address
+	NumApprovalsThreshold uint64
+	NextTxnID             int64
+	InitialBalance        abi.TokenAmount
+	StartEpoch            abi.ChainEpoch
+	UnlockDuration        abi.ChainEpoch
+}
+
+var msigInspectCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Inspect a multisig wallet",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("address", true, false, "address of the multisig"),
+	},
+	Options: []cmds.Option{
+		cmds.BoolOption("vesting", "include vesting details"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		ctx := req.Context
+		api := getEnv(env)
+
+		maddr, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+
+		head, err := api.ChainAPI.ChainHead(ctx)
+		if err != nil {
+			return err
+		}
+
+		actState, err := api.ChainAPI.StateReadState(ctx, maddr, head.Key())
+		if err != nil {
+			return err
+		}
+		if !builtin.IsMultisigActor(actState.Code) {
+			return fmt.Errorf("actor %s is not a multisig actor", maddr)
+		}
+
+		raw, err := json.Marshal(actState.State)
+		if err != nil {
+			return err
+		}
+		var st msigState
+		if err := json.Unmarshal(raw, &st); err != nil {
+			return fmt.Errorf("decoding multisig state: %w", err)
+		}
+
+		available, err := api.MultiSigAPI.MsigGetAvailableBalance(ctx, maddr, head.Key())
+		if err != nil {
+			return err
+		}
+
+		pending, err := api.MultiSigAPI.MsigGetPending(ctx, maddr, head.Key())
+		if err != nil {
+			return err
+		}
+
+		buf := new(bytes.Buffer)
+		writer := NewSilentWriter(buf)
+		writer.Printf("Balance: %s\n", types.FIL(actState.Balance))
+		writer.Printf("Spendable: %s\n", types.FIL(available))
+
+		if vesting, _ := req.Options["vesting"].(bool); vesting {
+			writer.Printf("InitialBalance: %s\n", types.FIL(st.InitialBalance))
+			writer.Printf("StartEpoch: %d\n", st.StartEpoch)
+			writer.Printf("UnlockDuration: %d\n", st.UnlockDuration)
+		}
+
+		writer.Printf("Threshold: %d / %d\n", st.NumApprovalsThreshold, len(st.Signers))
+		writer.Println("Signers:")
+		tw := tabwriter.NewWriter(buf, 8, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintf(tw, "ID\tAddress\n")
+		for _, s := range st.Signers {
+			signerActor, err := api.ChainAPI.StateAccountKey(ctx, s, head.Key())
+			if err != nil {
+				_, _ = fmt.Fprintf(tw, "%s\t%s\n", s, "N/A")
+				continue
+			}
+			_, _ = fmt.Fprintf(tw, "%s\t%s\n", s, signerActor)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+
+		writer.Printf("Transactions: %d\n", len(pending))
+		if len(pending) > 0 {
+			tw = tabwriter.NewWriter(buf, 8, 4, 2, ' ', 0)
+			_, _ = fmt.Fprintf(tw, "ID\tState\tApprovals\tTo\tValue\tMethod\tParams\n")
+			for _, txn := range pending {
+				state := "pending"
+				if len(txn.Approved) >= int(st.NumApprovalsThreshold) {
+					state = "approved"
+				}
+				_, _ = fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\t%d\t%x\n", txn.ID, state, len(txn.Approved), txn.To, types.FIL(txn.Value), txn.Method, txn.Params)
+			}
+			if err := tw.Flush(); err != nil {
+				return err
+			}
+		}
+
+		return re.Emit(buf)
+	},
+}
+
+var msigProposeCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Propose a multisig transaction",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("multisig", true, false, "address of the multisig"),
+		cmds.StringArg("destination", true, false, "recipient of the proposed transaction"),
+		cmds.StringArg("value", true, false, "value to transfer (FIL)"),
+		cmds.StringArg("method", false, false, "method number to call"),
+		cmds.StringArg("params", false, false, "hex encoded method params"),
+	},
+	Options: []cmds.Option{
+		msigFromOption,
+		msigConfidenceOption,
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		ctx := req.Context
+		api := getEnv(env)
+
+		if len(req.Arguments) == 4 {
+			return errors.New("must either pass both method and params, or neither")
+		}
+
+		msig, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		dest, err := address.NewFromString(req.Arguments[1])
+		if err != nil {
+			return err
+		}
+		value, err := types.ParseFIL(req.Arguments[2])
+		if err != nil {
+			return err
+		}
+		method, params, err := parseMsigMethodParams(req.Arguments[3:])
+		if err != nil {
+			return err
+		}
+
+		from, err := fromAddrOrDefault(req, env)
+		if err != nil {
+			return err
+		}
+
+		act, err := api.ChainAPI.StateGetActor(ctx, msig, types.EmptyTSK)
+		if err != nil {
+			return fmt.Errorf("failed to look up multisig %s: %w", msig, err)
+		}
+		if !builtin.IsMultisigActor(act.Code) {
+			return fmt.Errorf("actor %s is not a multisig actor", msig)
+		}
+
+		proto, err := api.MultiSigAPI.MsigPropose(ctx, msig, dest, types.BigInt(value), from, method, params)
+		if err != nil {
+			return err
+		}
+
+		return msigProposeResult(req, re, api, proto)
+	},
+}
+
+var msigRemoveProposeCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Propose to remove a signer",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("multisig", true, false, "address of the multisig"),
+		cmds.StringArg("signer", true, false, "signer to remove"),
+	},
+	Options: []cmds.Option{
+		cmds.BoolOption("decrease-threshold", "whether the number of required signers should be decreased"),
+		msigFromOption,
+		msigConfidenceOption,
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		api := getEnv(env)
+
+		msig, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		signer, err := address.NewFromString(req.Arguments[1])
+		if err != nil {
+			return err
+		}
+		from, err := fromAddrOrDefault(req, env)
+		if err != nil {
+			return err
+		}
+		decrease, _ := req.Options["decrease-threshold"].(bool)
+
+		proto, err := api.MultiSigAPI.MsigRemoveSigner(req.Context, msig, from, signer, decrease)
+		if err != nil {
+			return err
+		}
+
+		return msigProposeResult(req, re, api, proto)
+	},
+}
+
+var msigApproveCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Approve a multisig message",
+		ShortDescription: `
+Approve by transaction ID only, or pass the proposer, destination and value (and optionally
+method and params) to make sure the approved transaction is exactly the expected one.
+`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("multisig", true, false, "address of the multisig"),
+		cmds.StringArg("txid", true, false, "id of the proposed transaction"),
+		cmds.StringArg("proposer", false, false, "address of the proposer"),
+		cmds.StringArg("destination", false, false, "recipient of the proposed transaction"),
+		cmds.StringArg("value", false, false, "value of the proposed transaction (FIL)"),
+		cmds.StringArg("method", false, false, "method number of the proposed transaction"),
+		cmds.StringArg("params", false, false, "hex encoded params of the proposed transaction"),
+	},
+	Options: []cmds.Option{
+		msigFromOption,
+		msigConfidenceOption,
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		ctx := req.Context
+		api := getEnv(env)
+
+		argc := len(req.Arguments)
+		if argc > 2 && argc < 5 {
+			return errors.New("usage: approve <multisig> <txid> [<proposer> <destination> <value> [<method> <params>]]")
+		}
+		if argc == 6 {
+			return errors.New("must either pass both method and params, or neither")
+		}
+
+		msig, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		txid, err := strconv.ParseUint(req.Arguments[1], 10, 64)
+		if err != nil {
+			return err
+		}
+		from, err := fromAddrOrDefault(req, env)
+		if err != nil {
+			return err
+		}
+
+		var proto *types.MessagePrototype
+		if argc == 2 {
+			proto, err = api.MultiSigAPI.MsigApprove(ctx, msig, txid, from)
+		} else {
+			var proposer, dest address.Address
+			if proposer, err = address.NewFromString(req.Arguments[2]); err != nil {
+				return err
+			}
+			if dest, err = address.NewFromString(req.Arguments[3]); err != nil {
+				return err
+			}
+			value, err := types.ParseFIL(req.Arguments[4])
+			if err != nil {
+				return err
+			}
+			method, params, err := parseMsigMethodParams(req.Arguments[5:])
+			if err != nil {
+				return err
+			}
+			proto, err = api.MultiSigAPI.MsigApproveTxnHash(ctx, msig, txid, proposer, dest, types.BigInt(value), from, method, params)
+			if err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		return msigApproveResult(req, re, api, proto)
+	},
+}
+
+var msigCancelCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Cancel a multisig message",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("multisig", true, false, "address of the multisig"),
+		cmds.StringArg("txid", true, false, "id of the proposed transaction"),
+		cmds.StringArg("destination", false, false, "recipient of the proposed transaction"),
+		cmds.StringArg("value", false, false, "value of the proposed transaction (FIL)"),
+		cmds.StringArg("method", false, false, "method number of the proposed transaction"),
+		cmds.StringArg("params", false, false, "hex encoded params of the proposed transaction"),
+	},
+	Options: []cmds.Option{
+		msigFromOption,
+		msigConfidenceOption,
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		ctx := req.Context
+		api := getEnv(env)
+
+		argc := len(req.Arguments)
+		if argc == 3 {
+			return errors.New("usage: cancel <multisig> <txid> [<destination> <value> [<method> <params>]]")
+		}
+		if argc == 5 {
+			return errors.New("must either pass both method and params, or neither")
+		}
+
+		msig, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		txid, err := strconv.ParseUint(req.Arguments[1], 10, 64)
+		if err != nil {
+			return err
+		}
+		from, err := fromAddrOrDefault(req, env)
+		if err != nil {
+			return err
+		}
+
+		var proto *types.MessagePrototype
+		if argc == 2 {
+			proto, err = api.MultiSigAPI.MsigCancel(ctx, msig, txid, from)
+		} else {
+			dest, err := address.NewFromString(req.Arguments[2])
+			if err != nil {
+				return err
+			}
+			value, err := types.ParseFIL(req.Arguments[3])
+			if err != nil {
+				return err
+			}
+			method, params, err := parseMsigMethodParams(req.Arguments[4:])
+			if err != nil {
+				return err
+			}
+			proto, err = api.MultiSigAPI.MsigCancelTxnHash(ctx, msig, txid, dest, types.BigInt(value), from, method, params)
+			if err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, err := msigPushAndWait(req, api, proto); err != nil {
+			return err
+		}
+		return printOneString(re, fmt.Sprintf("Transaction %d cancelled", txid))
+	},
+}
+
+var msigAddProposeCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Propose to add a signer",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("multisig", true, false, "address of the multisig"),
+		cmds.StringArg("signer", true, false, "signer to add"),
+	},
+	Options: []cmds.Option{
+		cmds.BoolOption("increase-threshold", "whether the number of required signers should be increased"),
+		msigFromOption,
+		msigConfidenceOption,
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		api := getEnv(env)
+
+		msig, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		signer, err := address.NewFromString(req.Arguments[1])
+		if err != nil {
+			return err
+		}
+		from, err := fromAddrOrDefault(req, env)
+		if err != nil {
+			return err
+		}
+		increase, _ := req.Options["increase-threshold"].(bool)
+
+		proto, err := api.MultiSigAPI.MsigAddPropose(req.Context, msig, from, signer, increase)
+		if err != nil {
+			return err
+		}
+
+		return msigProposeResult(req, re, api, proto)
+	},
+}
+
+var msigAddApproveCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Approve a message to add a signer",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("multisig", true, false, "address of the multisig"),
+		cmds.StringArg("proposer", true, false, "address of the proposer"),
+		cmds.StringArg("txid", true, false, "id of the proposed transaction"),
+		cmds.StringArg("signer", true, false, "signer to add"),
+		cmds.StringArg("increase-threshold", true, false, "whether the number of required signers should be increased (true/false)"),
+	},
+	Options: []cmds.Option{
+		msigFromOption,
+		msigConfidenceOption,
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		api := getEnv(env)
+
+		msig, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		proposer, err := address.NewFromString(req.Arguments[1])
+		if err != nil {
+			return err
+		}
+		txid, err := strconv.ParseUint(req.Arguments[2], 10, 64)
+		if err != nil {
+			return err
+		}
+		signer, err := address.NewFromString(req.Arguments[3])
+		if err != nil {
+			return err
+		}
+		increase, err := strconv.ParseBool(req.Arguments[4])
+		if err != nil {
+			return err
+		}
+		from, err := fromAddrOrDefault(req, env)
+		if err != nil {
+			return err
+		}
+
+		proto, err := api.MultiSigAPI.MsigAddApprove(req.Context, msig, from, txid, proposer, signer, increase)
+		if err != nil {
+			return err
+		}
+
+		return msigApproveResult(req, re, api, proto)
+	},
+}
+
+var msigAddCancelCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Cancel a message to add a signer",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("multisig", true, false, "address of the multisig"),
+		cmds.StringArg("txid", true, false, "id of the proposed transaction"),
+		cmds.StringArg("signer", true, false, "signer to add"),
+		cmds.StringArg("increase-threshold", true, false, "whether the number of required signers should be increased (true/false)"),
+	},
+	Options: []cmds.Option{
+		msigFromOption,
+		msigConfidenceOption,
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		api := getEnv(env)
+
+		msig, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		txid, err := strconv.ParseUint(req.Arguments[1], 10, 64)
+		if err != nil {
+			return err
+		}
+		signer, err := address.NewFromString(req.Arguments[2])
+		if err != nil {
+			return err
+		}
+		increase, err := strconv.ParseBool(req.Arguments[3])
+		if err != nil {
+			return err
+		}
+		from, err := fromAddrOrDefault(req, env)
+		if err != nil {
+			return err
+		}
+
+		proto, err := api.MultiSigAPI.MsigAddCancel(req.Context, msig, from, txid, signer, increase)
+		if err != nil {
+			return err
+		}
+
+		if _, err := msigPushAndWait(req, api, proto); err != nil {
+			return err
+		}
+		return printOneString(re, fmt.Sprintf("Transaction %d cancelled", txid))
+	},
+}
+
+var msigSwapProposeCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Propose to swap signers",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("multisig", true, false, "address of the multisig"),
+		cmds.StringArg("old", true, false, "signer to be replaced"),
+		cmds.StringArg("new", true, false, "new signer"),
+	},
+	Options: []cmds.Option{
+		msigFromOption,
+		msigConfidenceOption,
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		api := getEnv(env)
+
+		msig, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		oldAddr, err := address.NewFromString(req.Arguments[1])
+		if err != nil {
+			return err
+		}
+		newAddr, err := address.NewFromString(req.Arguments[2])
+		if err != nil {
+			return err
+		}
+		from, err := fromAddrOrDefault(req, env)
+		if err != nil {
+			return err
+		}
+
+		proto, err := api.MultiSigAPI.MsigSwapPropose(req.Context, msig, from, oldAddr, newAddr)
+		if err != nil {
+			return err
+		}
+
+		return msigProposeResult(req, re, api, proto)
+	},
+}
+
+var msigSwapApproveCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Approve a message to swap signers",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("multisig", true, false, "address of the multisig"),
+		cmds.StringArg("proposer", true, false, "address of the proposer"),
+		cmds.StringArg("txid", true, false, "id of the proposed transaction"),
+		cmds.StringArg("old", true, false, "signer to be replaced"),
+		cmds.StringArg("new", true, false, "new signer"),
+	},
+	Options: []cmds.Option{
+		msigFromOption,
+		msigConfidenceOption,
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		api := getEnv(env)
+
+		msig, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		proposer, err := address.NewFromString(req.Arguments[1])
+		if err != nil {
+			return err
+		}
+		txid, err := strconv.ParseUint(req.Arguments[2], 10, 64)
+		if err != nil {
+			return err
+		}
+		oldAddr, err := address.NewFromString(req.Arguments[3])
+		if err != nil {
+			return err
+		}
+		newAddr, err := address.NewFromString(req.Arguments[4])
+		if err != nil {
+			return err
+		}
+		from, err := fromAddrOrDefault(req, env)
+		if err != nil {
+			return err
+		}
+
+		proto, err := api.MultiSigAPI.MsigSwapApprove(req.Context, msig, from, txid, proposer, oldAddr, newAddr)
+		if err != nil {
+			return err
+		}
+
+		return msigApproveResult(req, re, api, proto)
+	},
+}
+
+var msigSwapCancelCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Cancel a message to swap signers",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("multisig", true, false, "address of the multisig"),
+		cmds.StringArg("txid", true, false, "id of the proposed transaction"),
+		cmds.StringArg("old", true, false, "signer to be replaced"),
+		cmds.StringArg("new", true, false, "new signer"),
+	},
+	Options: []cmds.Option{
+		msigFromOption,
+		msigConfidenceOption,
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		api := getEnv(env)
+
+		msig, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+		txid, err := strconv.ParseUint(req.Arguments[1], 10, 64)
+		if err != nil {
+			return err
+		}
+		oldAddr, err := address.NewFromString(req.Arguments[2])
+		if err != nil {
+			return err
+		}
+		newAddr, err := address.NewFromString(req.Arguments[3])
+		if err != nil {
+			return err
+		}
+		from, err := fromAddrOrDefault(req, env)
+		if err != nil {
+			return err
+		}
+
+		proto, err := api.MultiSigAPI.MsigSwapCancel(req.Context, msig, from, txid, oldAddr, newAddr)
+		if err != nil {
+			return err
+		}
+
+		if _, err := msigPushAndWait(req, api, proto); err != nil {
+			return err
+		}
+		return printOneString(re, fmt.Sprintf("Transaction %d cancelled", txid))
+	},
+}
+
+var msigVestedCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Gets the amount vested in an msig between two epochs",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("multisig", true, false, "address of the multisig"),
+	},
+	Options: []cmds.Option{
+		cmds.Int64Option("start-epoch", "start epoch to measure vesting from").WithDefault(int64(0)),
+		cmds.Int64Option("end-epoch", "end epoch to stop measure vesting at, defaults to the chain head").WithDefault(int64(-1)),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		ctx := req.Context
+		api := getEnv(env)
+
+		msig, err := address.NewFromString(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+
+		head, err := api.ChainAPI.ChainHead(ctx)
+		if err != nil {
+			return err
+		}
+
+		start, err := api.ChainAPI.ChainGetTipSetByHeight(ctx, abi.ChainEpoch(req.Options["start-epoch"].(int64)), head.Key())
+		if err != nil {
+			return err
+		}
+
+		end := head
+		if endEpoch := req.Options["end-epoch"].(int64); endEpoch >= 0 {
+			end, err = api.ChainAPI.ChainGetTipSetByHeight(ctx, abi.ChainEpoch(endEpoch), head.Key())
+			if err != nil {
+				return err
+			}
+		}
+
+		amt, err := api.MultiSigAPI.MsigGetVested(ctx, msig, start.Key(), end.Key())
+		if err != nil {
+			return err
+		}
+
+		return printOneString(re, fmt.Sprintf("Vested: %s between %d and %d", types.FIL(amt), start.Height(), end.Height()))
+	},
+}
+
+func parseMsigMethodParams(args []string) (uint64, []byte, error) {
+	if len(args) < 2 {
+		return 0, nil, nil
+	}
+	method, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to parse method number: %w", err)
+	}
+	params, err := hex.DecodeString(args[1])
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to decode params: %w", err)
+	}
+	return method, params, nil
+}
+
+// msigPushAndWait pushes the message prototype to the message pool and waits for it to be executed successfully
+func msigPushAndWait(req *cmds.Request, api *node.Env, proto *types.MessagePrototype) (*types.MsgLookup, error) {
+	ctx := req.Context
+	if proto.ValidNonce {
+		return nil, errors.New("multisig message prototype must not carry a nonce")
+	}
+
+	smsg, err := api.MessagePoolAPI.MpoolPushMessage(ctx, &proto.Message, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	confidence, _ := req.Options["confidence"].(uint64)
+	wait, err := api.ChainAPI.StateWaitMsg(ctx, smsg.Cid(), confidence, constants.LookbackNoLimit, true)
+	if err != nil {
+		return nil, err
+	}
+	if wait.Receipt.ExitCode.IsError() {
+		return nil, fmt.Errorf("message %s failed to execute: exit code %d", smsg.Cid(), wait.Receipt.ExitCode)
+	}
+
+	return wait, nil
+}
+
+func msigProposeResult(req *cmds.Request, re cmds.ResponseEmitter, api *node.Env, proto *types.MessagePrototype) error {
+	wait, err := msigPushAndWait(req, api, proto)
+	if err != nil {
+		return err
+	}
+
+	var ret msig16.ProposeReturn
+	if err := ret.UnmarshalCBOR(bytes.NewReader(wait.Receipt.Return)); err != nil {
+		return fmt.Errorf("decoding propose return: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+	writer := NewSilentWriter(buf)
+	writer.Printf("Message CID: %s\n", wait.Message)
+	writer.Printf("Transaction ID: %d\n", ret.TxnID)
+	if ret.Applied {
+		writer.Printf("Transaction was executed during propose\n")
+		writer.Printf("Exit Code: %d\n", ret.Code)
+		writer.Printf("Return Value: %x\n", ret.Ret)
+	}
+
+	return re.Emit(buf)
+}
+
+func msigApproveResult(req *cmds.Request, re cmds.ResponseEmitter, api *node.Env, proto *types.MessagePrototype) error {
+	wait, err := msigPushAndWait(req, api, proto)
+	if err != nil {
+		return err
+	}
+
+	var ret msig16.ApproveReturn
+	if err := ret.UnmarshalCBOR(bytes.NewReader(wait.Receipt.Return)); err != nil {
+		return fmt.Errorf("decoding approve return: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+	writer := NewSilentWriter(buf)
+	writer.Printf("Message CID: %s\n", wait.Message)
+	if ret.Applied {
+		writer.Printf("Transaction was executed with the approve\n")
+		writer.Printf("Exit Code: %d\n", ret.Code)
+		writer.Printf("Return Value: %x\n", ret.Ret)
+	} else {
+		writer.Printf("Transaction approved, waiting for more approvals\n")
+	}
+
+	return re.Emit(buf)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	gonet "net"
 	"sort"
 	"strings"
 	"text/tabwriter"
@@ -22,6 +23,7 @@ import (
 
 	"github.com/filecoin-project/venus/app/node"
 	"github.com/filecoin-project/venus/pkg/net"
+	"github.com/filecoin-project/venus/venus-shared/types"
 )
 
 const (
@@ -56,6 +58,11 @@ libp2p peers on the internet.
 		"unprotect":      protectRemoveCmd,
 		"list-protected": protectListCmd,
 		"scores":         swarmScoresCmd,
+		"block":          blockAddCmd,
+		"unblock":        blockRemoveCmd,
+		"blocklist":      blockListCmd,
+		"stat":           swarmStatCmd,
+		"limit":          swarmLimitCmd,
 	},
 }
 
@@ -640,6 +647,204 @@ var protectListCmd = &cmds.Command{
 	},
 }
 
+var blockAddCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Block connections with peers, IP addresses or CIDR subnets",
+		ShortDescription: `
+Each target is a peer ID, an IP address or a CIDR subnet. The existing connections to
+the targets are closed, the block list is persisted and survives a restart.
+`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("targets", true, true, "peer IDs, IP addresses or CIDR subnets to block"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		acl, err := decodeNetBlockListFromArgs(req)
+		if err != nil {
+			return err
+		}
+
+		if err := env.(*node.Env).NetworkAPI.NetBlockAdd(req.Context, acl); err != nil {
+			return err
+		}
+
+		return printOneString(re, fmt.Sprintf("blocked %d targets", len(req.Arguments)))
+	},
+}
+
+var blockRemoveCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Unblock connections with peers, IP addresses or CIDR subnets",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("targets", true, true, "peer IDs, IP addresses or CIDR subnets to unblock"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		acl, err := decodeNetBlockListFromArgs(req)
+		if err != nil {
+			return err
+		}
+
+		if err := env.(*node.Env).NetworkAPI.NetBlockRemove(req.Context, acl); err != nil {
+			return err
+		}
+
+		return printOneString(re, fmt.Sprintf("unblocked %d targets", len(req.Arguments)))
+	},
+}
+
+var blockListCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "List the blocked peers, IP addresses and CIDR subnets",
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		acl, err := env.(*node.Env).NetworkAPI.NetBlockList(req.Context)
+		if err != nil {
+			return err
+		}
+
+		buf := new(bytes.Buffer)
+		writer := NewSilentWriter(buf)
+
+		if len(acl.Peers) > 0 {
+			writer.Println("Blocked Peers:")
+			for _, p := range acl.Peers {
+				writer.Printf("\t%s\n", p)
+			}
+		}
+		if len(acl.IPAddrs) > 0 {
+			writer.Println("Blocked IPs:")
+			for _, a := range acl.IPAddrs {
+				writer.Printf("\t%s\n", a)
+			}
+		}
+		if len(acl.IPSubnets) > 0 {
+			writer.Println("Blocked Subnets:")
+			for _, n := range acl.IPSubnets {
+				writer.Printf("\t%s\n", n)
+			}
+		}
+
+		return re.Emit(buf)
+	},
+}
+
+// decodeNetBlockListFromArgs sorts the arguments into CIDR subnets, IP addresses and peer IDs.
+func decodeNetBlockListFromArgs(req *cmds.Request) (types.NetBlockList, error) {
+	var acl types.NetBlockList
+	for _, arg := range req.Arguments {
+		if _, _, err := gonet.ParseCIDR(arg); err == nil {
+			acl.IPSubnets = append(acl.IPSubnets, arg)
+			continue
+		}
+		if ip := gonet.ParseIP(arg); ip != nil {
+			acl.IPAddrs = append(acl.IPAddrs, arg)
+			continue
+		}
+		p, err := peer.Decode(arg)
+		if err != nil {
+			return acl, fmt.Errorf("%s is not a peer ID, an IP address or a CIDR subnet", arg)
+		}
+		acl.Peers = append(acl.Peers, p)
+	}
+	return acl, nil
+}
+
+var swarmStatCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Print the resource usage of the libp2p resource manager",
+		ShortDescription: `
+The scope is one of:
+  all              the usage of all the scopes (default)
+  system           the usage of the whole libp2p stack
+  transient        the usage of the streams and connections not yet attached to a peer or protocol
+  svc:<service>    the usage of a service
+  proto:<protocol> the usage of a protocol
+  peer:<peer id>   the usage of a peer
+`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("scope", false, false, "the scope to print the usage of"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		scope := net.ScopeAll
+		if len(req.Arguments) > 0 {
+			scope = req.Arguments[0]
+		}
+
+		stat, err := env.(*node.Env).NetworkAPI.NetStat(req.Context, scope)
+		if err != nil {
+			return err
+		}
+
+		out, err := json.MarshalIndent(stat, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		buf := new(bytes.Buffer)
+		writer := NewSilentWriter(buf)
+		writer.Println(string(out))
+
+		return re.Emit(buf)
+	},
+}
+
+var swarmLimitCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Print or set the limit of a scope of the libp2p resource manager",
+		ShortDescription: `
+The scope is one of system, transient, svc:<service>, proto:<protocol> or peer:<peer id>.
+With --set the limit is replaced with the JSON limit given, like the one printed, until the
+node restarts.
+`,
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("scope", true, false, "the scope of the limit"),
+		cmds.StringArg("limit", false, false, "the new limit in JSON"),
+	},
+	Options: []cmds.Option{
+		cmds.BoolOption("set", "set the limit of the scope"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		netAPI := env.(*node.Env).NetworkAPI
+		scope := req.Arguments[0]
+
+		if set, _ := req.Options["set"].(bool); set {
+			if len(req.Arguments) != 2 {
+				return fmt.Errorf("expected the scope and the limit")
+			}
+
+			var limit types.NetLimit
+			if err := json.Unmarshal([]byte(req.Arguments[1]), &limit); err != nil {
+				return fmt.Errorf("failed to decode limit: %w", err)
+			}
+
+			if err := netAPI.NetSetLimit(req.Context, scope, limit); err != nil {
+				return err
+			}
+
+			return printOneString(re, fmt.Sprintf("limit of %s set", scope))
+		}
+
+		limit, err := netAPI.NetLimit(req.Context, scope)
+		if err != nil {
+			return err
+		}
+
+		out, err := json.MarshalIndent(limit, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		buf := new(bytes.Buffer)
+		writer := NewSilentWriter(buf)
+		writer.Println(string(out))
+
+		return re.Emit(buf)
+	},
+}
+
 var swarmScoresCmd = &cmds.Command{
 	Helptext: cmds.HelpText{
 		Tagline: "Print peers' pubsub scores",
@@ -3,8 +3,11 @@ package cmd
 
 import (
 	"bytes"
+	"fmt"
 	"strconv"
 
+	"github.com/ipfs/go-cid"
+
 	"github.com/filecoin-project/venus/venus-shared/types"
 
 	cmds "github.com/ipfs/go-ipfs-cmds"
@@ -21,6 +24,9 @@ var syncCmd = &cmds.Command{
 		"history":        historyCmd,
 		"concurrent":     getConcurrent,
 		"set-concurrent": setConcurrent,
+		"mark-bad":       markBadCmd,
+		"unmark-bad":     unmarkBadCmd,
+		"check-bad":      checkBadCmd,
 	},
 }
 
@@ -159,3 +165,68 @@ var historyCmd = &cmds.Command{
 		return re.Emit(w)
 	},
 }
+
+var markBadCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Mark the given block as bad, will prevent syncing to a chain that contains it",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("blockCid", true, false, "cid of the bad block"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		bcid, err := cid.Decode(req.Arguments[0])
+		if err != nil {
+			return fmt.Errorf("failed to decode input as a cid: %w", err)
+		}
+		return env.(*node.Env).SyncerAPI.SyncMarkBad(req.Context, bcid)
+	},
+}
+
+var unmarkBadCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Unmark the given block as bad, makes it possible to sync to a chain containing it",
+	},
+	Options: []cmds.Option{
+		cmds.BoolOption("all", "drop the entire bad block cache"),
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("blockCid", false, false, "cid of the block to unmark"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		api := env.(*node.Env).SyncerAPI
+		if all, _ := req.Options["all"].(bool); all {
+			return api.SyncUnmarkAllBad(req.Context)
+		}
+		if len(req.Arguments) != 1 {
+			return fmt.Errorf("must specify a block cid or --all")
+		}
+		bcid, err := cid.Decode(req.Arguments[0])
+		if err != nil {
+			return fmt.Errorf("failed to decode input as a cid: %w", err)
+		}
+		return api.SyncUnmarkBad(req.Context, bcid)
+	},
+}
+
+var checkBadCmd = &cmds.Command{
+	Helptext: cmds.HelpText{
+		Tagline: "Check if the given block was marked bad, and for what reason",
+	},
+	Arguments: []cmds.Argument{
+		cmds.StringArg("blockCid", true, false, "cid of the block to check"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		bcid, err := cid.Decode(req.Arguments[0])
+		if err != nil {
+			return fmt.Errorf("failed to decode input as a cid: %w", err)
+		}
+		reason, err := env.(*node.Env).SyncerAPI.SyncCheckBad(req.Context, bcid)
+		if err != nil {
+			return err
+		}
+		if reason == "" {
+			return printOneString(re, "block was not marked as bad")
+		}
+		return printOneString(re, reason)
+	},
+}
@@ -0,0 +1,312 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/multiformats/go-multicodec"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/libp2p/exchange"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+const (
+	objectHeader   = "header"
+	objectMessages = "messages"
+	objectReceipts = "receipts"
+	objectState    = "state"
+)
+
+// ChainFetcher fetches chain data from peers, it is implemented by the exchange client.
+type ChainFetcher interface {
+	GetBlocks(ctx context.Context, tsk types.TipSetKey, count int) ([]*types.TipSet, error)
+	GetChainMessages(ctx context.Context, tipsets []*types.TipSet) ([]*exchange.CompactedMessages, error)
+}
+
+type blockstoreChecker struct {
+	store *Store
+	// fetcher is nil when the faults are not repaired
+	fetcher ChainFetcher
+	walked  *cid.Set
+	res     *types.BlockstoreCheckResult
+}
+
+// CheckBlockstore walks the chain from ts like WalkSnapshot does, through headers, messages and, for the
+// recent state roots, receipts and state trees, and verifies that every block reached is in the blockstore
+// and hashes to its cid. Unlike WalkSnapshot the walk goes on past missing or corrupt blocks, they are
+// reported in the result. Missing or corrupt headers and messages are refetched with fetcher when it is not nil.
+func (store *Store) CheckBlockstore(ctx context.Context, ts *types.TipSet, params types.BlockstoreCheckParams, fetcher ChainFetcher) (*types.BlockstoreCheckResult, error) {
+	if ts == nil {
+		ts = store.GetHead()
+	}
+
+	var minHeight abi.ChainEpoch
+	if params.Epochs > 0 && ts.Height() > params.Epochs {
+		minHeight = ts.Height() - params.Epochs
+	}
+
+	c := &blockstoreChecker{
+		store:   store,
+		fetcher: fetcher,
+		walked:  cid.NewSet(),
+		res: &types.BlockstoreCheckResult{
+			TipSetKey:   ts.Key(),
+			Height:      ts.Height(),
+			LowestEpoch: ts.Height(),
+		},
+	}
+
+	log.Infow("blockstore check started", "height", ts.Height(), "epochs", params.Epochs, "repair", fetcher != nil)
+
+	tsk := ts.Key()
+	epoch := ts.Height()
+	for {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		headers := c.checkHeaders(ctx, tsk, epoch)
+		if len(headers) == 0 {
+			// none of the headers can be read, the chain can't be walked further
+			break
+		}
+
+		c.checkMessages(ctx, headers)
+		for _, b := range headers {
+			if b.Height == 0 || b.Height > ts.Height()-params.RecentStateRoots {
+				c.res.Faults = append(c.res.Faults, c.checkLinks(ctx, b.ParentStateRoot, objectState, b.Height)...)
+				c.res.Faults = append(c.res.Faults, c.checkLinks(ctx, b.ParentMessageReceipts, objectReceipts, b.Height)...)
+			}
+		}
+
+		epoch = headers[0].Height
+		c.res.LowestEpoch = epoch
+		if epoch%1000 == 0 {
+			log.Infow("blockstore check", "height", epoch, "blocks", c.res.BlocksChecked, "faults", len(c.res.Faults))
+		}
+		if epoch == 0 || epoch <= minHeight {
+			break
+		}
+		tsk = types.NewTipSetKey(headers[0].Parents...)
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	log.Infow("blockstore check finished", "lowest", c.res.LowestEpoch, "blocks", c.res.BlocksChecked, "faults", len(c.res.Faults))
+
+	return c.res, nil
+}
+
+// checkHeaders checks the headers of the tipset tsk, reached from a header at epoch, and returns the
+// ones that can be read.
+func (c *blockstoreChecker) checkHeaders(ctx context.Context, tsk types.TipSetKey, epoch abi.ChainEpoch) []*types.BlockHeader {
+	var headers []*types.BlockHeader
+	var faults []types.BlockstoreFault
+	for _, bcid := range tsk.Cids() {
+		if !c.walked.Visit(bcid) {
+			continue
+		}
+		data, fault := c.get(ctx, bcid, objectHeader, epoch)
+		if fault != nil {
+			faults = append(faults, *fault)
+			continue
+		}
+		var b types.BlockHeader
+		if err := b.UnmarshalCBOR(bytes.NewReader(data)); err != nil {
+			faults = append(faults, types.BlockstoreFault{Cid: bcid, Object: objectHeader, Epoch: epoch, Corrupt: true, Error: err.Error()})
+			continue
+		}
+		headers = append(headers, &b)
+	}
+
+	if len(faults) > 0 && c.fetcher != nil {
+		ts, err := c.repairHeaders(ctx, tsk, faults)
+		if err == nil {
+			headers = ts.Blocks()
+		}
+		for i := range faults {
+			faults[i].Repaired = err == nil
+			if err != nil {
+				faults[i].Error = err.Error()
+			}
+		}
+	}
+	c.res.Faults = append(c.res.Faults, faults...)
+
+	return headers
+}
+
+func (c *blockstoreChecker) repairHeaders(ctx context.Context, tsk types.TipSetKey, faults []types.BlockstoreFault) (*types.TipSet, error) {
+	tss, err := c.fetcher.GetBlocks(ctx, tsk, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tipset %s: %w", tsk, err)
+	}
+	if len(tss) != 1 || !tss[0].Key().Equals(tsk) {
+		return nil, fmt.Errorf("peers didn't return tipset %s", tsk)
+	}
+
+	if err := c.deleteCorrupt(ctx, faults); err != nil {
+		return nil, err
+	}
+	for _, b := range tss[0].Blocks() {
+		if _, err := c.store.PutObject(ctx, b); err != nil {
+			return nil, fmt.Errorf("failed to store header %s: %w", b.Cid(), err)
+		}
+	}
+	return tss[0], nil
+}
+
+// checkMessages checks the messages of the headers of a tipset, they are refetched as a whole when one
+// of them is missing or corrupt.
+func (c *blockstoreChecker) checkMessages(ctx context.Context, headers []*types.BlockHeader) {
+	var faults []types.BlockstoreFault
+	for _, b := range headers {
+		faults = append(faults, c.checkLinks(ctx, b.Messages, objectMessages, b.Height)...)
+	}
+
+	if len(faults) > 0 && c.fetcher != nil {
+		err := c.repairMessages(ctx, headers, faults)
+		for i := range faults {
+			if err == nil {
+				_, fault := c.get(ctx, faults[i].Cid, objectMessages, faults[i].Epoch)
+				faults[i].Repaired = fault == nil
+				continue
+			}
+			faults[i].Error = err.Error()
+		}
+	}
+	c.res.Faults = append(c.res.Faults, faults...)
+}
+
+func (c *blockstoreChecker) repairMessages(ctx context.Context, headers []*types.BlockHeader, faults []types.BlockstoreFault) error {
+	ts, err := types.NewTipSet(headers)
+	if err != nil {
+		return fmt.Errorf("cannot fetch messages of an incomplete tipset: %w", err)
+	}
+	msgs, err := c.fetcher.GetChainMessages(ctx, []*types.TipSet{ts})
+	if err != nil {
+		return fmt.Errorf("failed to fetch messages of tipset %s: %w", ts.Key(), err)
+	}
+	if len(msgs) != 1 {
+		return fmt.Errorf("peers didn't return the messages of tipset %s", ts.Key())
+	}
+
+	bs := blockstoreutil.NewTemporary()
+	cst := cbor.NewCborStore(bs)
+	for _, m := range msgs[0].Bls {
+		if _, err := cst.Put(ctx, m); err != nil {
+			return err
+		}
+	}
+	for _, m := range msgs[0].Secpk {
+		if _, err := cst.Put(ctx, m); err != nil {
+			return err
+		}
+	}
+	if len(msgs[0].BlsIncludes) != ts.Len() || len(msgs[0].SecpkIncludes) != ts.Len() {
+		return fmt.Errorf("message includes don't match the size of tipset %s", ts.Key())
+	}
+	for i, b := range ts.Blocks() {
+		bmsgCids := make([]cid.Cid, 0, len(msgs[0].BlsIncludes[i]))
+		for _, idx := range msgs[0].BlsIncludes[i] {
+			bmsgCids = append(bmsgCids, msgs[0].Bls[idx].Cid())
+		}
+		smsgCids := make([]cid.Cid, 0, len(msgs[0].SecpkIncludes[i]))
+		for _, idx := range msgs[0].SecpkIncludes[i] {
+			smsgCids = append(smsgCids, msgs[0].Secpk[idx].Cid())
+		}
+		root, err := ComputeMsgMeta(bs, bmsgCids, smsgCids)
+		if err != nil {
+			return err
+		}
+		if root != b.Messages {
+			return fmt.Errorf("fetched messages don't match message root of header %s", b.Cid())
+		}
+	}
+
+	if err := c.deleteCorrupt(ctx, faults); err != nil {
+		return err
+	}
+	return blockstoreutil.CopyBlockstore(ctx, bs, c.store.bsstore)
+}
+
+// deleteCorrupt removes the corrupt blocks so they are replaced by the refetched ones.
+func (c *blockstoreChecker) deleteCorrupt(ctx context.Context, faults []types.BlockstoreFault) error {
+	for _, f := range faults {
+		if !f.Corrupt {
+			continue
+		}
+		if err := c.store.bsstore.DeleteBlock(ctx, f.Cid); err != nil {
+			return fmt.Errorf("failed to delete corrupt block %s: %w", f.Cid, err)
+		}
+	}
+	return nil
+}
+
+// checkLinks checks root and the blocks it links to, in the order recurseLinks walks them, and returns
+// the missing or corrupt ones. It doesn't reuse recurseLinks since that one stops at the first block it
+// can't read and trusts the data of the blockstore, while the check verifies the hash of every block and
+// carries on with the siblings of a missing or corrupt block to report all the faults of the dag.
+func (c *blockstoreChecker) checkLinks(ctx context.Context, root cid.Cid, object string, epoch abi.ChainEpoch) []types.BlockstoreFault {
+	if ctx.Err() != nil || !c.walked.Visit(root) {
+		return nil
+	}
+
+	data, fault := c.get(ctx, root, object, epoch)
+	if fault != nil {
+		return []types.BlockstoreFault{*fault}
+	}
+	if data == nil || multicodec.Code(root.Prefix().Codec) != multicodec.DagCbor {
+		return nil
+	}
+
+	var links []cid.Cid
+	if err := cbg.ScanForLinks(bytes.NewReader(data), func(l cid.Cid) {
+		links = append(links, l)
+	}); err != nil {
+		return []types.BlockstoreFault{{Cid: root, Object: object, Epoch: epoch, Corrupt: true, Error: err.Error()}}
+	}
+
+	var faults []types.BlockstoreFault
+	for _, l := range links {
+		faults = append(faults, c.checkLinks(ctx, l, object, epoch)...)
+	}
+	return faults
+}
+
+// get reads a block and verifies it hashes to its cid. Identity cids are not read, nil is returned for them.
+func (c *blockstoreChecker) get(ctx context.Context, bcid cid.Cid, object string, epoch abi.ChainEpoch) ([]byte, *types.BlockstoreFault) {
+	prefix := bcid.Prefix()
+	if multicodec.Code(prefix.MhType) == multicodec.Identity {
+		return nil, nil
+	}
+	switch multicodec.Code(prefix.Codec) {
+	case multicodec.FilCommitmentSealed, multicodec.FilCommitmentUnsealed:
+		return nil, nil
+	}
+
+	c.res.BlocksChecked++
+	blk, err := c.store.bsstore.Get(ctx, bcid)
+	if err != nil {
+		fault := &types.BlockstoreFault{Cid: bcid, Object: object, Epoch: epoch}
+		if !ipld.IsNotFound(err) {
+			fault.Corrupt = true
+			fault.Error = err.Error()
+		}
+		return nil, fault
+	}
+
+	sum, err := prefix.Sum(blk.RawData())
+	if err != nil || !sum.Equals(bcid) {
+		return nil, &types.BlockstoreFault{Cid: bcid, Object: object, Epoch: epoch, Corrupt: true}
+	}
+	return blk.RawData(), nil
+}
@@ -0,0 +1,89 @@
+package chain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/libp2p/exchange"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// fakeFetcher serves the tipsets it was given, the blocks of the tests don't include messages.
+type fakeFetcher struct {
+	tipsets map[types.TipSetKey]*types.TipSet
+}
+
+func (f *fakeFetcher) GetBlocks(ctx context.Context, tsk types.TipSetKey, count int) ([]*types.TipSet, error) {
+	return []*types.TipSet{f.tipsets[tsk]}, nil
+}
+
+func (f *fakeFetcher) GetChainMessages(ctx context.Context, tipsets []*types.TipSet) ([]*exchange.CompactedMessages, error) {
+	var out []*exchange.CompactedMessages
+	for _, ts := range tipsets {
+		out = append(out, &exchange.CompactedMessages{
+			BlsIncludes:   make([][]uint64, ts.Len()),
+			SecpkIncludes: make([][]uint64, ts.Len()),
+		})
+	}
+	return out, nil
+}
+
+func TestCheckBlockstore(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	builder := chain.NewBuilder(t, address.Undef)
+	head := builder.AppendManyOn(ctx, 10, builder.Genesis())
+	bs := builder.BlockStore()
+
+	res, err := builder.Store().CheckBlockstore(ctx, head, types.BlockstoreCheckParams{RecentStateRoots: 1}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, res.Faults)
+	assert.Equal(t, head.Height(), res.Height)
+	assert.Equal(t, builder.Genesis().Height(), res.LowestEpoch)
+	assert.Greater(t, res.BlocksChecked, int64(10))
+
+	fetcher := &fakeFetcher{tipsets: map[types.TipSetKey]*types.TipSet{}}
+	mid := head
+	for mid.Height() > 5 {
+		mid, err = builder.Store().GetTipSet(ctx, mid.Parents())
+		require.NoError(t, err)
+		fetcher.tipsets[mid.Key()] = mid
+	}
+
+	// lose a header and corrupt the message root of the tipset
+	missing := mid.At(0).Cid()
+	require.NoError(t, bs.DeleteBlock(ctx, missing))
+	corrupt := mid.At(0).Messages
+	require.NoError(t, bs.DeleteBlock(ctx, corrupt))
+	blk, err := blocks.NewBlockWithCid([]byte("garbage"), corrupt)
+	require.NoError(t, err)
+	require.NoError(t, bs.Put(ctx, blk))
+
+	res, err = builder.Store().CheckBlockstore(ctx, head, types.BlockstoreCheckParams{}, nil)
+	require.NoError(t, err)
+	require.Len(t, res.Faults, 2)
+	assert.Equal(t, types.BlockstoreFault{Cid: corrupt, Object: "messages", Epoch: head.Height(), Corrupt: true}, res.Faults[0])
+	assert.Equal(t, types.BlockstoreFault{Cid: missing, Object: "header", Epoch: mid.Height() + 1}, res.Faults[1])
+	// the walk stops at the missing header
+	assert.Equal(t, mid.Height()+1, res.LowestEpoch)
+
+	// the message root is shared by all the blocks, it is repaired from the first tipset fetched
+	res, err = builder.Store().CheckBlockstore(ctx, head, types.BlockstoreCheckParams{Epochs: 10}, fetcher)
+	require.NoError(t, err)
+	require.Len(t, res.Faults, 2)
+	for _, f := range res.Faults {
+		assert.True(t, f.Repaired, "%s %s not repaired: %s", f.Object, f.Cid, f.Error)
+	}
+	assert.Equal(t, builder.Genesis().Height(), res.LowestEpoch)
+
+	res, err = builder.Store().CheckBlockstore(ctx, head, types.BlockstoreCheckParams{}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, res.Faults)
+}
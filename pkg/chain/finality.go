@@ -0,0 +1,90 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/filecoin-project/go-f3/certs"
+
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// F3Finality provides the finality certificates of F3, it is implemented by vf3.F3.
+type F3Finality interface {
+	IsRunning() bool
+	GetLatestCert(ctx context.Context) (*certs.FinalityCertificate, error)
+}
+
+// SetF3Finality sets the source of the F3 finality, the tipsets it finalizes are never reverted by the
+// head selection, and GetFinalizedTipSet returns them instead of the EC finalized ones.
+func (store *Store) SetF3Finality(f3 F3Finality) {
+	store.f3Lk.Lock()
+	defer store.f3Lk.Unlock()
+	store.f3 = f3
+}
+
+// GetFinalizedTipSet returns the latest tipset finalized by F3 when F3 is running, and falls back to
+// the tipset finalized by EC, `constants.Finality` epochs behind the head, otherwise or when F3 lags
+// behind EC.
+func (store *Store) GetFinalizedTipSet(ctx context.Context) (*types.TipSet, error) {
+	head := store.GetHead()
+	ecFinalized, err := store.ecFinalizedTipSet(ctx, head)
+	if err != nil {
+		return nil, err
+	}
+
+	f3Finalized, err := store.f3FinalizedTipSet(ctx)
+	if err != nil {
+		log.Warnf("failed to get the F3 finalized tipset, falling back to EC finality: %s", err)
+		return ecFinalized, nil
+	}
+	if f3Finalized == nil || f3Finalized.Height() < ecFinalized.Height() {
+		return ecFinalized, nil
+	}
+
+	return f3Finalized, nil
+}
+
+func (store *Store) ecFinalizedTipSet(ctx context.Context, head *types.TipSet) (*types.TipSet, error) {
+	height := head.Height() - constants.Finality
+	if height < 0 {
+		height = 0
+	}
+	ts, err := store.GetTipSetByHeight(ctx, head, height, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the EC finalized tipset at height %d: %w", height, err)
+	}
+	return ts, nil
+}
+
+// f3FinalizedTipSet returns the head of the latest finality certificate of F3, nil when F3 is not
+// running or hasn't finalized anything yet.
+func (store *Store) f3FinalizedTipSet(ctx context.Context) (*types.TipSet, error) {
+	store.f3Lk.RLock()
+	f3 := store.f3
+	store.f3Lk.RUnlock()
+
+	if f3 == nil || !f3.IsRunning() {
+		return nil, nil
+	}
+
+	cert, err := f3.GetLatestCert(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the latest F3 certificate: %w", err)
+	}
+	if cert == nil || cert.ECChain.IsZero() {
+		return nil, nil
+	}
+
+	tsk, err := types.TipSetKeyFromBytes(cert.ECChain.Head().Key)
+	if err != nil {
+		return nil, fmt.Errorf("decoding the key of the F3 finalized tipset: %w", err)
+	}
+	ts, err := store.GetTipSet(ctx, tsk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the F3 finalized tipset %s: %w", tsk, err)
+	}
+
+	return ts, nil
+}
@@ -0,0 +1,131 @@
+// stm: #unit
+package chain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-f3/certs"
+	"github.com/filecoin-project/go-f3/gpbft"
+	"github.com/filecoin-project/go-state-types/big"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type fakeF3Finality struct {
+	running   bool
+	finalized *types.TipSet
+	err       error
+}
+
+func (f *fakeF3Finality) IsRunning() bool {
+	return f.running
+}
+
+func (f *fakeF3Finality) GetLatestCert(ctx context.Context) (*certs.FinalityCertificate, error) {
+	if f.err != nil || f.finalized == nil {
+		return nil, f.err
+	}
+	ecChain, err := gpbft.NewChain(&gpbft.TipSet{Epoch: int64(f.finalized.Height()), Key: f.finalized.Key().Bytes(), PowerTable: f.finalized.At(0).Cid()})
+	if err != nil {
+		return nil, err
+	}
+	return &certs.FinalityCertificate{ECChain: ecChain}, nil
+}
+
+// heightWeight makes the longest chain the heaviest one
+func heightWeight(_ context.Context, _ cbor.IpldStore, ts *types.TipSet) (big.Int, error) {
+	return big.NewInt(int64(ts.Height())*10 + int64(ts.Len())), nil
+}
+
+func TestGetFinalizedTipSet(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	builder := chain.NewBuilder(t, address.Undef)
+	genesis := builder.Genesis()
+	cs := newChainStore(builder.Repo(), genesis)
+
+	link1 := builder.AppendOn(ctx, genesis, 1)
+	link2 := builder.AppendOn(ctx, link1, 1)
+	require.NoError(t, cs.Store.SetHead(ctx, link2))
+
+	// the EC finality is behind the genesis
+	ts, err := cs.Store.GetFinalizedTipSet(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, genesis.Key(), ts.Key())
+
+	f3 := &fakeF3Finality{finalized: link1}
+	cs.Store.SetF3Finality(f3)
+	ts, err = cs.Store.GetFinalizedTipSet(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, genesis.Key(), ts.Key())
+
+	f3.running = true
+	ts, err = cs.Store.GetFinalizedTipSet(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, link1.Key(), ts.Key())
+
+	// the node falls back to EC finality when F3 fails
+	f3.err = errors.New("boom")
+	ts, err = cs.Store.GetFinalizedTipSet(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, genesis.Key(), ts.Key())
+}
+
+func TestRefreshHeaviestTipSetF3Finality(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	builder := chain.NewBuilder(t, address.Undef)
+	genesis := builder.Genesis()
+	cs := chain.NewStore(builder.Repo().ChainDatastore(), builder.Repo().Datastore(), genesis.At(0).Cid(), heightWeight)
+
+	link1 := builder.AppendOn(ctx, genesis, 1)
+	link2 := builder.AppendOn(ctx, link1, 1)
+	require.NoError(t, cs.SetHead(ctx, link2))
+
+	// a longer fork from the genesis
+	fork := builder.AppendManyOn(ctx, 3, genesis)
+	for _, blk := range append(link2.Blocks(), fork.Blocks()...) {
+		require.NoError(t, cs.AddToTipSetTracker(ctx, blk))
+	}
+
+	// link1 is finalized by F3, the fork is refused
+	cs.SetF3Finality(&fakeF3Finality{running: true, finalized: link1})
+	require.NoError(t, cs.RefreshHeaviestTipSet(ctx, fork.Height()))
+	assert.Equal(t, link2.Key(), cs.GetHead().Key())
+
+	// without F3 the fork is short enough to be taken
+	cs.SetF3Finality(&fakeF3Finality{})
+	require.NoError(t, cs.RefreshHeaviestTipSet(ctx, fork.Height()))
+	assert.Equal(t, fork.Key(), cs.GetHead().Key())
+}
+
+func TestRefreshHeaviestTipSetLeavesLosingFork(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	builder := chain.NewBuilder(t, address.Undef)
+	genesis := builder.Genesis()
+	cs := chain.NewStore(builder.Repo().ChainDatastore(), builder.Repo().Datastore(), genesis.At(0).Cid(), heightWeight)
+
+	// the head is on a fork which doesn't include the F3 finalized tipset
+	fork := builder.AppendManyOn(ctx, 2, genesis)
+	require.NoError(t, cs.SetHead(ctx, fork))
+
+	link1 := builder.AppendOn(ctx, genesis, 1)
+	link3 := builder.AppendManyOn(ctx, 2, link1)
+	for _, blk := range append(fork.Blocks(), link3.Blocks()...) {
+		require.NoError(t, cs.AddToTipSetTracker(ctx, blk))
+	}
+
+	// moving onto the finalized chain doesn't revert link1
+	cs.SetF3Finality(&fakeF3Finality{running: true, finalized: link1})
+	require.NoError(t, cs.RefreshHeaviestTipSet(ctx, link3.Height()))
+	assert.Equal(t, link3.Key(), cs.GetHead().Key())
+}
@@ -0,0 +1,260 @@
+package chain
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/pkg/repo"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// lastCompactionKey is the key at which the base epoch of the last compaction is written in the datastore.
+var lastCompactionKey = datastore.NewKey("/splitstore/lastCompaction")
+
+// Pruner drives the splitstore compaction. It keeps the state and messages of the most
+// recent finality windows in the hot store and sweeps the rest out of it, either when
+// the head advanced far enough since the last compaction or on demand.
+type Pruner struct {
+	store *Store
+	ss    *blockstoreutil.SplitStore
+	ds    repo.Datastore
+	cfg   config.SplitstoreConfig
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	lk             sync.Mutex
+	lastCompaction abi.ChainEpoch
+	// running is set from the start of a compaction until its status is final, it reserves
+	// the compaction before the status is touched.
+	running bool
+	status  types.PruneStatus
+}
+
+// NewPruner creates a pruner compacting ss from the chain tracked by store.
+func NewPruner(store *Store, ss *blockstoreutil.SplitStore, ds repo.Datastore, cfg config.SplitstoreConfig) *Pruner {
+	return &Pruner{
+		store: store,
+		ss:    ss,
+		ds:    ds,
+		cfg:   cfg,
+		status: types.PruneStatus{
+			Enabled: true,
+			Mode:    cfg.Mode,
+			Stage:   blockstoreutil.CompactionIdle,
+		},
+	}
+}
+
+// Start loads the epoch of the last compaction and starts watching head changes.
+func (p *Pruner) Start(ctx context.Context) error {
+	p.ctx, p.cancel = context.WithCancel(ctx)
+
+	val, err := p.ds.Get(ctx, lastCompactionKey)
+	switch err {
+	case nil:
+		p.lastCompaction = abi.ChainEpoch(binary.BigEndian.Uint64(val))
+	case datastore.ErrNotFound:
+		// never compacted, count from the current head so that a node does not start
+		// with a compaction of its whole history.
+		p.lastCompaction = p.store.GetHead().Height()
+	default:
+		return fmt.Errorf("failed to load last compaction epoch: %w", err)
+	}
+
+	if p.cfg.CompactionFinalities > 0 {
+		p.store.SubscribeHeadChanges(p.onHeadChange)
+	}
+	return nil
+}
+
+// Stop cancels a running compaction and waits for it to exit.
+func (p *Pruner) Stop() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.wg.Wait()
+}
+
+// Prune starts a compaction from the current head, it returns once the compaction started, or once it
+// finished when opts.Wait is set, cancelling ctx then cancels the compaction.
+func (p *Pruner) Prune(ctx context.Context, opts types.PruneOpts) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	retain := p.cfg.RetainFinalities
+	if opts.RetainFinalities > 0 {
+		retain = opts.RetainFinalities
+	}
+	done, cancel, err := p.compact(p.store.GetHead(), retain)
+	if err != nil || !opts.Wait {
+		return err
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// Status returns the state of the running, or last, compaction.
+func (p *Pruner) Status() types.PruneStatus {
+	p.lk.Lock()
+	status := p.status
+	p.lk.Unlock()
+
+	progress := p.ss.Progress()
+	status.Compacting = p.ss.Compacting()
+	status.Stage = progress.Stage
+	status.Marked = progress.Marked
+	status.Scanned = progress.Scanned
+	status.Moved = progress.Moved
+	status.Removed = progress.Removed
+	status.MarkSetSize = progress.MarkSetSize
+	return status
+}
+
+func (p *Pruner) onHeadChange(_, app []*types.TipSet) error {
+	if p.ctx.Err() != nil {
+		return ErrNotifeeDone
+	}
+	if len(app) == 0 {
+		return nil
+	}
+
+	head := app[len(app)-1]
+	p.lk.Lock()
+	due := head.Height()-p.lastCompaction >= abi.ChainEpoch(p.cfg.CompactionFinalities)*constants.Finality
+	running := p.running
+	p.lk.Unlock()
+	if !due || running {
+		return nil
+	}
+
+	if _, _, err := p.compact(head, p.cfg.RetainFinalities); err != nil && err != blockstoreutil.ErrCompactionInProgress {
+		log.Warnf("failed to start compaction: %s", err)
+	}
+	return nil
+}
+
+// compact runs a compaction in the background, keeping retain finality windows behind head. The
+// result of the compaction is sent on the returned channel, and the returned function cancels it.
+func (p *Pruner) compact(head *types.TipSet, retain int64) (<-chan error, context.CancelFunc, error) {
+	if retain <= 0 {
+		return nil, nil, fmt.Errorf("at least one finality window has to be retained, got %d", retain)
+	}
+
+	inclRecentRoots := abi.ChainEpoch(retain) * constants.Finality
+
+	p.lk.Lock()
+	if p.running || p.ss.Compacting() {
+		p.lk.Unlock()
+		return nil, nil, blockstoreutil.ErrCompactionInProgress
+	}
+	p.running = true
+	p.status.BaseEpoch = head.Height()
+	p.status.Boundary = head.Height() - inclRecentRoots
+	p.status.StartTime = constants.Clock.Now()
+	p.status.LastError = ""
+	p.lk.Unlock()
+
+	ctx, cancel := context.WithCancel(p.ctx)
+	done := make(chan error, 1)
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer cancel()
+
+		log.Infow("compaction started", "head", head.Height(), "retain", inclRecentRoots)
+		err := p.ss.Compact(ctx, func(ctx context.Context, visit func(cid.Cid) error) error {
+			return p.mark(ctx, head, inclRecentRoots, visit)
+		})
+		defer func() { done <- err }()
+
+		p.lk.Lock()
+		defer p.lk.Unlock()
+		defer func() { p.running = false }()
+		p.status.EndTime = constants.Clock.Now()
+		if err != nil {
+			log.Errorf("compaction failed: %s", err)
+			p.status.LastError = err.Error()
+			return
+		}
+
+		p.lastCompaction = head.Height()
+		buf := make([]byte, 8)
+		binary.BigEndian.PutUint64(buf, uint64(head.Height()))
+		if err := p.ds.Put(p.ctx, lastCompactionKey, buf); err != nil {
+			log.Warnf("failed to persist last compaction epoch: %s", err)
+		}
+		log.Infow("compaction finished", "head", head.Height(), "duration", p.status.EndTime.Sub(p.status.StartTime))
+	}()
+
+	return done, cancel, nil
+}
+
+// mark visits all the objects that have to stay in the hot store: every block header,
+// the messages, receipts and states of the last inclRecentRoots epochs, and the
+// tipset keys of those epochs. The objects are read from the splitstore itself, not
+// through the archival fallback, those missing locally have nothing to keep.
+func (p *Pruner) mark(ctx context.Context, head *types.TipSet, inclRecentRoots abi.ChainEpoch, visit func(cid.Cid) error) error {
+	if err := p.store.walkSnapshot(ctx, p.ss, head, inclRecentRoots, true, false, true, visit); err != nil {
+		return err
+	}
+
+	// the state computed on top of the head is only referenced by its tipset metadata.
+	var roots []cid.Cid
+	if tsm, err := p.store.GetTipsetMetadata(ctx, head); err == nil {
+		roots = append(roots, tsm.TipSetStateRoot, tsm.TipSetReceipts)
+	}
+
+	walked := cid.NewSet()
+	for _, root := range roots {
+		if !walked.Visit(root) {
+			continue
+		}
+		cids, err := recurseLinksFrom(ctx, p.ss, true, walked, root, []cid.Cid{root})
+		if err != nil {
+			return fmt.Errorf("recursing head state failed: %w", err)
+		}
+		for _, c := range cids {
+			if err := visit(c); err != nil {
+				return err
+			}
+		}
+	}
+
+	for ts := head; ts.Height() > head.Height()-inclRecentRoots; {
+		tskBlk, err := ts.Key().ToStorageBlock()
+		if err != nil {
+			return err
+		}
+		if err := visit(tskBlk.Cid()); err != nil {
+			return err
+		}
+
+		if ts.Height() == 0 {
+			break
+		}
+		if ts, err = p.store.GetTipSet(ctx, ts.Parents()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+)
+
+func TestCompactionWalkSkipsMissingObjects(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	builder := NewBuilder(t, address.Undef)
+	head := builder.AppendManyOn(ctx, 5, builder.Genesis())
+
+	// a local view missing the messages of the head
+	local := blockstoreutil.NewMemory()
+	keys, err := builder.BlockStore().AllKeysChan(ctx)
+	require.NoError(t, err)
+	for c := range keys {
+		blk, err := builder.BlockStore().Get(ctx, c)
+		require.NoError(t, err)
+		require.NoError(t, local.Put(ctx, blk))
+	}
+	missing := head.At(0).Messages
+	require.NoError(t, local.DeleteBlock(ctx, missing))
+
+	visit := func(cid.Cid) error { return nil }
+	require.Error(t, builder.Store().walkSnapshot(ctx, local, head, 2, true, false, false, visit))
+
+	visited := cid.NewSet()
+	require.NoError(t, builder.Store().walkSnapshot(ctx, local, head, 2, true, false, true, func(c cid.Cid) error {
+		visited.Add(c)
+		return nil
+	}))
+	require.True(t, visited.Has(head.At(0).ParentStateRoot))
+}
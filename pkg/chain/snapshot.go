@@ -0,0 +1,411 @@
+package chain
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	"github.com/ipld/go-car"
+	carutil "github.com/ipld/go-car/util"
+	"github.com/klauspost/compress/zstd"
+	"github.com/multiformats/go-multicodec"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// ErrExportInProgress is returned when a snapshot export is started while another one is running.
+var ErrExportInProgress = errors.New("a snapshot export is already running")
+
+// WalkSnapshotOpts configures WalkSnapshotParallel.
+type WalkSnapshotOpts struct {
+	InclRecentRoots abi.ChainEpoch
+	SkipOldMsgs     bool
+	SkipMsgReceipts bool
+	// Workers is the number of goroutines walking the messages, receipts and states, at least one is used.
+	Workers int
+	// OnHeight is called each time the walk reaches a lower epoch, it may be nil.
+	OnHeight func(abi.ChainEpoch)
+}
+
+// WalkSnapshotParallel visits the same objects as WalkSnapshot, the block headers are walked in the same
+// order while the messages, receipts and states they link to are walked by opts.Workers goroutines.
+// cb is never called concurrently.
+func (store *Store) WalkSnapshotParallel(ctx context.Context, ts *types.TipSet, opts WalkSnapshotOpts, cb func(cid.Cid) error) error {
+	if ts == nil {
+		ts = store.GetHead()
+	}
+	workers := opts.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	var seenLk sync.Mutex
+	seen := cid.NewSet()
+	visit := func(c cid.Cid) bool {
+		seenLk.Lock()
+		defer seenLk.Unlock()
+		return seen.Visit(c)
+	}
+
+	var cbLk sync.Mutex
+	emit := func(c cid.Cid) error {
+		prefix := c.Prefix()
+		// Don't include identity CIDs.
+		if multicodec.Code(prefix.MhType) == multicodec.Identity {
+			return nil
+		}
+		// We only include raw, cbor, and dagcbor, for now.
+		switch multicodec.Code(prefix.Codec) {
+		case multicodec.Cbor, multicodec.DagCbor, multicodec.Raw:
+		default:
+			return nil
+		}
+
+		cbLk.Lock()
+		defer cbLk.Unlock()
+		return cb(c)
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	roots := make(chan cid.Cid, workers*16)
+	for i := 0; i < workers; i++ {
+		g.Go(func() error {
+			for root := range roots {
+				if err := store.walkLinks(gctx, root, visit, emit); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	g.Go(func() error {
+		defer close(roots)
+
+		queue := func(c cid.Cid) error {
+			if !visit(c) {
+				return nil
+			}
+			select {
+			case roots <- c:
+				return nil
+			case <-gctx.Done():
+				return gctx.Err()
+			}
+		}
+
+		blocksToWalk := ts.Cids()
+		currentMinHeight := ts.Height()
+		for len(blocksToWalk) > 0 {
+			blk := blocksToWalk[0]
+			blocksToWalk = blocksToWalk[1:]
+			if !visit(blk) {
+				continue
+			}
+			if err := emit(blk); err != nil {
+				return err
+			}
+
+			data, err := store.bsstore.Get(gctx, blk)
+			if err != nil {
+				return fmt.Errorf("getting block: %w", err)
+			}
+			var b types.BlockHeader
+			if err := b.UnmarshalCBOR(bytes.NewBuffer(data.RawData())); err != nil {
+				return fmt.Errorf("unmarshaling block header (cid=%s): %w", blk, err)
+			}
+
+			if currentMinHeight > b.Height {
+				currentMinHeight = b.Height
+				if opts.OnHeight != nil {
+					opts.OnHeight(currentMinHeight)
+				}
+			}
+
+			if !opts.SkipOldMsgs || b.Height > ts.Height()-opts.InclRecentRoots {
+				if err := queue(b.Messages); err != nil {
+					return err
+				}
+			}
+
+			if b.Height > 0 {
+				blocksToWalk = append(blocksToWalk, b.Parents...)
+			} else {
+				// include the genesis block
+				for _, c := range b.Parents {
+					if visit(c) {
+						if err := emit(c); err != nil {
+							return err
+						}
+					}
+				}
+			}
+
+			if b.Height == 0 || b.Height > ts.Height()-opts.InclRecentRoots {
+				if err := queue(b.ParentStateRoot); err != nil {
+					return err
+				}
+				if !opts.SkipMsgReceipts {
+					if err := queue(b.ParentMessageReceipts); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	})
+
+	return g.Wait()
+}
+
+// walkLinks emits root and the objects it links to recursively, skipping the ones already visited.
+// root must have been visited by the caller.
+func (store *Store) walkLinks(ctx context.Context, root cid.Cid, visit func(cid.Cid) bool, emit func(cid.Cid) error) error {
+	if err := emit(root); err != nil {
+		return err
+	}
+	if multicodec.Code(root.Prefix().Codec) != multicodec.DagCbor {
+		return nil
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	data, err := store.bsstore.Get(ctx, root)
+	if err != nil {
+		return fmt.Errorf("recurse links get (%s) failed: %w", root, err)
+	}
+
+	var links []cid.Cid
+	if err := cbg.ScanForLinks(bytes.NewReader(data.RawData()), func(c cid.Cid) {
+		if visit(c) {
+			links = append(links, c)
+		}
+	}); err != nil {
+		return fmt.Errorf("scanning for links failed: %w", err)
+	}
+
+	for _, c := range links {
+		if err := store.walkLinks(ctx, c, visit, emit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SnapshotExporter runs snapshot exports in the background, one at a time.
+type SnapshotExporter struct {
+	store *Store
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	lk        sync.Mutex
+	jobCancel context.CancelFunc
+	status    types.ExportJobStatus
+	// lastSnapshot is the path of the last snapshot that was written successfully
+	lastSnapshot string
+
+	currentEpoch  atomic.Int64
+	blocksWritten atomic.Int64
+	bytesWritten  atomic.Int64
+}
+
+// NewSnapshotExporter creates an exporter of the chain tracked by store.
+func NewSnapshotExporter(store *Store) *SnapshotExporter {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &SnapshotExporter{
+		store:  store,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Stop cancels a running export and waits for it to exit.
+func (e *SnapshotExporter) Stop() {
+	e.cancel()
+	e.wg.Wait()
+}
+
+// Export starts writing the snapshot of ts to params.Path, it returns once the export started.
+// The snapshot is written to a temporary file which is renamed to params.Path when it is complete.
+func (e *SnapshotExporter) Export(ts *types.TipSet, params types.ExportJobParams) error {
+	if params.Path == "" {
+		return fmt.Errorf("an output path is required")
+	}
+	if params.SkipOldMsgs && params.RecentStateRoots == 0 {
+		return fmt.Errorf("must pass recent stateroots along with skip-old-msgs")
+	}
+	if params.RecentStateRoots > 0 && params.RecentStateRoots < constants.Finality {
+		return fmt.Errorf("recent state roots has to be greater than %d", constants.Finality)
+	}
+
+	e.lk.Lock()
+	defer e.lk.Unlock()
+	if e.status.Running {
+		return ErrExportInProgress
+	}
+
+	tmpPath := params.Path + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+
+	var ctx context.Context
+	ctx, e.jobCancel = context.WithCancel(e.ctx)
+	e.status = types.ExportJobStatus{
+		Running:          true,
+		Path:             params.Path,
+		Compressed:       params.Compress,
+		TipSetKey:        ts.Key(),
+		Height:           ts.Height(),
+		RecentStateRoots: params.RecentStateRoots,
+		StartTime:        constants.Clock.Now(),
+	}
+	e.currentEpoch.Store(int64(ts.Height()))
+	e.blocksWritten.Store(0)
+	e.bytesWritten.Store(0)
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+
+		log.Infow("snapshot export started", "height", ts.Height(), "path", params.Path)
+		err := e.export(ctx, ts, params, f)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err == nil {
+			err = os.Rename(tmpPath, params.Path)
+		} else {
+			_ = os.Remove(tmpPath)
+		}
+
+		e.lk.Lock()
+		defer e.lk.Unlock()
+		e.jobCancel()
+		e.status.Running = false
+		e.status.EndTime = constants.Clock.Now()
+		switch {
+		case err == nil:
+			e.lastSnapshot = params.Path
+			log.Infow("snapshot export finished", "height", ts.Height(), "path", params.Path, "duration", e.status.EndTime.Sub(e.status.StartTime))
+		case errors.Is(err, context.Canceled):
+			e.status.Canceled = true
+			log.Warnw("snapshot export canceled", "height", ts.Height(), "path", params.Path)
+		default:
+			e.status.LastError = err.Error()
+			log.Errorf("snapshot export failed: %s", err)
+		}
+	}()
+
+	return nil
+}
+
+func (e *SnapshotExporter) export(ctx context.Context, ts *types.TipSet, params types.ExportJobParams, f io.Writer) error {
+	cw := &countingWriter{w: f, n: &e.bytesWritten}
+	bw := bufio.NewWriterSize(cw, 1<<20)
+
+	var w io.Writer = bw
+	var zw *zstd.Encoder
+	if params.Compress {
+		var err error
+		if zw, err = zstd.NewWriter(bw); err != nil {
+			return fmt.Errorf("failed to create zstd writer: %w", err)
+		}
+		w = zw
+	}
+
+	h := &car.CarHeader{
+		Roots:   ts.Cids(),
+		Version: 1,
+	}
+	if err := car.WriteHeader(h, w); err != nil {
+		return fmt.Errorf("failed to write car header: %s", err)
+	}
+
+	opts := WalkSnapshotOpts{
+		InclRecentRoots: params.RecentStateRoots,
+		SkipOldMsgs:     params.SkipOldMsgs,
+		SkipMsgReceipts: true,
+		Workers:         params.Workers,
+		OnHeight: func(h abi.ChainEpoch) {
+			e.currentEpoch.Store(int64(h))
+		},
+	}
+	if err := e.store.WalkSnapshotParallel(ctx, ts, opts, func(c cid.Cid) error {
+		blk, err := e.store.bsstore.Get(ctx, c)
+		if err != nil {
+			return fmt.Errorf("writing object to car, bs.Get: %w", err)
+		}
+		if err := carutil.LdWrite(w, c.Bytes(), blk.RawData()); err != nil {
+			return fmt.Errorf("failed to write block to car output: %w", err)
+		}
+		e.blocksWritten.Add(1)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if zw != nil {
+		if err := zw.Close(); err != nil {
+			return fmt.Errorf("failed to close zstd writer: %w", err)
+		}
+	}
+	return bw.Flush()
+}
+
+// Cancel cancels the running export.
+func (e *SnapshotExporter) Cancel() error {
+	e.lk.Lock()
+	defer e.lk.Unlock()
+	if !e.status.Running {
+		return fmt.Errorf("no snapshot export is running")
+	}
+	e.jobCancel()
+	return nil
+}
+
+// Status returns the progress of the running, or last, export.
+func (e *SnapshotExporter) Status() types.ExportJobStatus {
+	e.lk.Lock()
+	status := e.status
+	e.lk.Unlock()
+
+	status.CurrentEpoch = abi.ChainEpoch(e.currentEpoch.Load())
+	status.BlocksWritten = e.blocksWritten.Load()
+	status.BytesWritten = e.bytesWritten.Load()
+	return status
+}
+
+// LastSnapshot returns the path of the last snapshot that was exported successfully, it is empty
+// when no export completed yet.
+func (e *SnapshotExporter) LastSnapshot() string {
+	e.lk.Lock()
+	defer e.lk.Unlock()
+	return e.lastSnapshot
+}
+
+type countingWriter struct {
+	w io.Writer
+	n *atomic.Int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n.Add(int64(n))
+	return n, err
+}
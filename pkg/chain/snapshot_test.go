@@ -0,0 +1,96 @@
+package chain_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+	carv2 "github.com/ipld/go-car/v2"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestWalkSnapshotParallel(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	builder := chain.NewBuilder(t, address.Undef)
+	head := builder.AppendManyOn(ctx, 20, builder.Genesis())
+
+	expected := cid.NewSet()
+	require.NoError(t, builder.Store().WalkSnapshot(ctx, head, 0, false, true, func(c cid.Cid) error {
+		expected.Add(c)
+		return nil
+	}))
+
+	for _, workers := range []int{1, 4} {
+		var lk sync.Mutex
+		walked := cid.NewSet()
+		require.NoError(t, builder.Store().WalkSnapshotParallel(ctx, head, chain.WalkSnapshotOpts{SkipMsgReceipts: true, Workers: workers}, func(c cid.Cid) error {
+			lk.Lock()
+			defer lk.Unlock()
+			assert.True(t, walked.Visit(c), "%s visited twice", c)
+			return nil
+		}))
+		assert.Equal(t, expected.Len(), walked.Len())
+		require.NoError(t, expected.ForEach(func(c cid.Cid) error {
+			assert.True(t, walked.Has(c), "%s not visited", c)
+			return nil
+		}))
+	}
+}
+
+func TestSnapshotExporter(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	builder := chain.NewBuilder(t, address.Undef)
+	head := builder.AppendManyOn(ctx, 20, builder.Genesis())
+
+	exporter := chain.NewSnapshotExporter(builder.Store())
+	defer exporter.Stop()
+
+	path := filepath.Join(t.TempDir(), "snapshot.car.zst")
+	require.NoError(t, exporter.Export(head, types.ExportJobParams{Path: path, Compress: true, Workers: 2}))
+
+	require.Eventually(t, func() bool { return !exporter.Status().Running }, 10*time.Second, 10*time.Millisecond)
+	status := exporter.Status()
+	require.Empty(t, status.LastError)
+	assert.Equal(t, head.Height(), status.Height)
+	assert.Equal(t, path, exporter.LastSnapshot())
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close() // nolint:errcheck
+	fi, err := f.Stat()
+	require.NoError(t, err)
+	assert.Equal(t, fi.Size(), status.BytesWritten)
+
+	zr, err := zstd.NewReader(f)
+	require.NoError(t, err)
+	defer zr.Close()
+	br, err := carv2.NewBlockReader(zr)
+	require.NoError(t, err)
+	assert.Equal(t, head.Cids(), br.Roots)
+
+	var blocks int64
+	for {
+		blk, err := br.Next()
+		if err != nil {
+			break
+		}
+		has, err := builder.BlockStore().Has(ctx, blk.Cid())
+		require.NoError(t, err)
+		assert.True(t, has)
+		blocks++
+	}
+	assert.Equal(t, status.BlocksWritten, blocks)
+}
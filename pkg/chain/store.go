@@ -25,6 +25,7 @@ import (
 	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-datastore"
 	cbor "github.com/ipfs/go-ipld-cbor"
+	ipld "github.com/ipfs/go-ipld-format"
 	logging "github.com/ipfs/go-log/v2"
 
 	"github.com/filecoin-project/go-address"
@@ -128,6 +129,10 @@ type Store struct {
 	tipsets map[abi.ChainEpoch][]cid.Cid
 
 	weight WeightFunc
+
+	f3Lk sync.RWMutex
+	// f3 provides the tipsets finalized by F3, nil when F3 is disabled
+	f3 F3Finality
 }
 
 // NewStore constructs a new default store.
@@ -809,11 +814,20 @@ func (store *Store) GenesisRootCid() cid.Cid {
 }
 
 func recurseLinks(ctx context.Context, bs blockstore.Blockstore, walked *cid.Set, root cid.Cid, in []cid.Cid) ([]cid.Cid, error) {
+	return recurseLinksFrom(ctx, bs, false, walked, root, in)
+}
+
+// recurseLinksFrom recurses the links like recurseLinks, the objects missing from bs are not recursed
+// when skipMissing is set.
+func recurseLinksFrom(ctx context.Context, bs blockstore.Blockstore, skipMissing bool, walked *cid.Set, root cid.Cid, in []cid.Cid) ([]cid.Cid, error) {
 	if multicodec.Code(root.Prefix().Codec) != multicodec.DagCbor {
 		return in, nil
 	}
 
 	data, err := bs.Get(ctx, root)
+	if skipMissing && ipld.IsNotFound(err) {
+		return in, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("recurse links get (%s) failed: %w", root, err)
 	}
@@ -832,7 +846,7 @@ func recurseLinks(ctx context.Context, bs blockstore.Blockstore, walked *cid.Set
 
 		in = append(in, c)
 		var err error
-		in, err = recurseLinks(ctx, bs, walked, c, in)
+		in, err = recurseLinksFrom(ctx, bs, skipMissing, walked, c, in)
 		if err != nil {
 			rerr = err
 		}
@@ -869,6 +883,13 @@ func (store *Store) Export(ctx context.Context, ts *types.TipSet, inclRecentRoot
 }
 
 func (store *Store) WalkSnapshot(ctx context.Context, ts *types.TipSet, inclRecentRoots abi.ChainEpoch, skipOldMsgs, skipMsgReceipts bool, cb func(cid.Cid) error) error {
+	return store.walkSnapshot(ctx, store.bsstore, ts, inclRecentRoots, skipOldMsgs, skipMsgReceipts, false, cb)
+}
+
+// walkSnapshot walks the snapshot like WalkSnapshot reading the objects from bs. When forCompaction is
+// set, the whole receipts amts are walked instead of their roots, the splitstore compaction needs them,
+// and the objects missing from bs are skipped rather than failing the walk.
+func (store *Store) walkSnapshot(ctx context.Context, bs blockstoreutil.Blockstore, ts *types.TipSet, inclRecentRoots abi.ChainEpoch, skipOldMsgs, skipMsgReceipts, forCompaction bool, cb func(cid.Cid) error) error {
 	if ts == nil {
 		ts = store.GetHead()
 	}
@@ -888,7 +909,10 @@ func (store *Store) WalkSnapshot(ctx context.Context, ts *types.TipSet, inclRece
 			return err
 		}
 
-		data, err := store.bsstore.Get(ctx, blk)
+		data, err := bs.Get(ctx, blk)
+		if forCompaction && ipld.IsNotFound(err) {
+			return nil
+		}
 		if err != nil {
 			return fmt.Errorf("getting block: %w", err)
 		}
@@ -908,7 +932,7 @@ func (store *Store) WalkSnapshot(ctx context.Context, ts *types.TipSet, inclRece
 		var cids []cid.Cid
 		if !skipOldMsgs || b.Height > ts.Height()-inclRecentRoots {
 			if walked.Visit(b.Messages) {
-				mcids, err := recurseLinks(ctx, store.bsstore, walked, b.Messages, []cid.Cid{b.Messages})
+				mcids, err := recurseLinksFrom(ctx, bs, forCompaction, walked, b.Messages, []cid.Cid{b.Messages})
 				if err != nil {
 					return fmt.Errorf("recursing messages failed: %w", err)
 				}
@@ -927,7 +951,7 @@ func (store *Store) WalkSnapshot(ctx context.Context, ts *types.TipSet, inclRece
 
 		if b.Height == 0 || b.Height > ts.Height()-inclRecentRoots {
 			if walked.Visit(b.ParentStateRoot) {
-				cids, err := recurseLinks(ctx, store.bsstore, walked, b.ParentStateRoot, []cid.Cid{b.ParentStateRoot})
+				cids, err := recurseLinksFrom(ctx, bs, forCompaction, walked, b.ParentStateRoot, []cid.Cid{b.ParentStateRoot})
 				if err != nil {
 					return fmt.Errorf("recursing genesis state failed: %w", err)
 				}
@@ -936,7 +960,16 @@ func (store *Store) WalkSnapshot(ctx context.Context, ts *types.TipSet, inclRece
 			}
 
 			if !skipMsgReceipts && walked.Visit(b.ParentMessageReceipts) {
-				out = append(out, b.ParentMessageReceipts)
+				if !forCompaction {
+					out = append(out, b.ParentMessageReceipts)
+				} else {
+					cids, err := recurseLinksFrom(ctx, bs, forCompaction, walked, b.ParentMessageReceipts, []cid.Cid{b.ParentMessageReceipts})
+					if err != nil {
+						return fmt.Errorf("recursing message receipts failed: %w", err)
+					}
+
+					out = append(out, cids...)
+				}
 			}
 		}
 
@@ -1695,6 +1728,9 @@ func breakWeightTie(ts1, ts2 *types.TipSet) bool {
 //
 //	`syncFork()` counts the length on both sides of the fork at the moment (we
 //	need to settle on that) but here we just enforce it on the `synced` side.
+//
+//	A fork reverting the tipset finalized by F3, or one of its ancestors, is
+//	never allowed whatever its length.
 func (store *Store) exceedsForkLength(ctx context.Context, synced, external *types.TipSet) (bool, error) {
 	if synced == nil || external == nil {
 		// FIXME: If `cs.heaviest` is nil we should just bypass the entire
@@ -1703,7 +1739,28 @@ func (store *Store) exceedsForkLength(ctx context.Context, synced, external *typ
 		return false, nil
 	}
 
-	var err error
+	f3Finalized, err := store.f3FinalizedTipSet(ctx)
+	if err != nil {
+		log.Warnf("failed to get the F3 finalized tipset, checking the fork length only: %s", err)
+	}
+	if f3Finalized != nil {
+		// Only a switch from a chain including the finalized tipset to one which
+		// doesn't reverts it, a node on a losing fork must be able to move onto
+		// the finalized chain.
+		onSynced, err := store.isOnChain(ctx, f3Finalized, synced)
+		if err != nil {
+			return false, err
+		}
+		onExternal, err := store.isOnChain(ctx, f3Finalized, external)
+		if err != nil {
+			return false, err
+		}
+		if onSynced && !onExternal {
+			log.Warnf("refusing a fork reverting the F3 finalized tipset %s at height %d", f3Finalized.Key(), f3Finalized.Height())
+			return true, nil
+		}
+	}
+
 	// `forkLength`: number of tipsets we need to walk back from the our `synced`
 	// chain to the common ancestor with the new `external` head in order to
 	// adopt the fork.
@@ -1752,3 +1809,15 @@ func (store *Store) exceedsForkLength(ctx context.Context, synced, external *typ
 	// We traversed the fork length allowed without finding a common ancestor.
 	return true, nil
 }
+
+// isOnChain returns whether ts is head or one of its ancestors.
+func (store *Store) isOnChain(ctx context.Context, ts, head *types.TipSet) (bool, error) {
+	if ts.Height() > head.Height() {
+		return false, nil
+	}
+	target, err := store.GetTipSetByHeight(ctx, head, ts.Height(), true)
+	if err != nil {
+		return false, err
+	}
+	return target.Equals(ts), nil
+}
@@ -183,6 +183,8 @@ func (f *Builder) CirculatingSupplyCalcualtor() ICirculatingSupplyCalcualtor {
 
 func (f *Builder) RemovePeer(peer peer.ID) {}
 
+func (f *Builder) SetBadBlockChecker(checker aexchange.BadBlockChecker) {}
+
 func (f *Builder) GenMiners(str string) []address.Address {
 	var miners []address.Address
 	for i := 0; i < defaultMinerCount; i++ {
@@ -27,12 +27,32 @@ type MsgLookup struct {
 type waiterChainReader interface {
 	GetHead() *types.TipSet
 	GetTipSet(context.Context, types.TipSetKey) (*types.TipSet, error)
+	GetTipSetByHeight(context.Context, *types.TipSet, abi.ChainEpoch, bool) (*types.TipSet, error)
 	LookupID(context.Context, *types.TipSet, address.Address) (address.Address, error)
 	GetActorAt(context.Context, *types.TipSet, address.Address) (*types.Actor, error)
 	GetTipSetReceiptsRoot(context.Context, *types.TipSet) (cid.Cid, error)
 	SubHeadChanges(context.Context) chan []*types.HeadChange
 }
 
+// MsgInfo describes where a message was included on chain.
+type MsgInfo struct {
+	// Message is the cid of the message
+	Message cid.Cid
+	// TipSet is the cid of the key of the tipset which includes the message
+	TipSet cid.Cid
+	// Epoch is the height of the tipset which includes the message
+	Epoch abi.ChainEpoch
+}
+
+// MsgIndex is a persistent index of message inclusion used to avoid walking the chain.
+type MsgIndex interface {
+	// GetMsgInfo returns the inclusion info of a message, ErrMsgNotIndexed is returned when the message is unknown.
+	GetMsgInfo(ctx context.Context, m cid.Cid) (*MsgInfo, error)
+}
+
+// ErrMsgNotIndexed is returned by MsgIndex when the message is not indexed
+var ErrMsgNotIndexed = errors.New("message not found in index")
+
 type IStmgr interface {
 	GetActorAt(context.Context, address.Address, *types.TipSet) (*types.Actor, error)
 	RunStateTransition(context.Context, *types.TipSet, vm.ExecCallBack, bool) (root cid.Cid, receipts cid.Cid, err error)
@@ -45,6 +65,8 @@ type Waiter struct {
 	cst             cbor.IpldStore
 	bs              bstore.Blockstore
 	Stmgr           IStmgr
+	// MsgIndex is consulted before searching backwards through the chain, it may be nil
+	MsgIndex MsgIndex
 }
 
 // WaitPredicate is a function that identifies a message and returns true when found.
@@ -108,6 +130,19 @@ func (w *Waiter) findMessage(ctx context.Context, from *types.TipSet, m types.Ch
 	limitHeight := from.Height() - lookback
 	noLimit := lookback == constants.LookbackNoLimit
 
+	if w.MsgIndex != nil {
+		msg, found, err := w.findIndexedMessage(ctx, from, m, allowReplaced)
+		switch {
+		case err == nil && found:
+			if noLimit || msg.TS.Height() > limitHeight {
+				return msg, true, nil
+			}
+			return nil, false, nil
+		case err != nil && !errors.Is(err, ErrMsgNotIndexed):
+			log.Warnf("failed to look up message %s in the chain index, fall back to searching the chain: %v", m.Cid(), err)
+		}
+	}
+
 	cur := from
 	curActor, err := w.Stmgr.GetActorAt(ctx, m.VMMessage().From, cur)
 	if err != nil {
@@ -164,6 +199,34 @@ func (w *Waiter) findMessage(ctx context.Context, from *types.TipSet, m types.Ch
 	}
 }
 
+// findIndexedMessage looks up the message in the message index and checks that
+// the tipset including it is part of the chain of from.
+func (w *Waiter) findIndexedMessage(ctx context.Context, from *types.TipSet, m types.ChainMsg, allowReplaced bool) (*types.ChainMessage, bool, error) {
+	info, err := w.MsgIndex.GetMsgInfo(ctx, m.Cid())
+	if err != nil {
+		return nil, false, err
+	}
+	// the message is executed in the next non-null tipset
+	if info.Epoch >= from.Height() {
+		return nil, false, nil
+	}
+
+	executionTS, err := w.chainReader.GetTipSetByHeight(ctx, from, info.Epoch+1, false)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load execution tipset at %d: %w", info.Epoch+1, err)
+	}
+	parentCid, err := executionTS.Parents().Cid()
+	if err != nil {
+		return nil, false, err
+	}
+	if parentCid != info.TipSet {
+		// the indexed tipset is not on the chain of from, the index may be stale
+		return nil, false, ErrMsgNotIndexed
+	}
+
+	return w.receiptForTipset(ctx, executionTS, m, allowReplaced)
+}
+
 // waitForMessage looks for a matching message in a channel of tipsets and returns
 // the message, block and receipt, when it is found. Reads until the channel is
 // closed or the context done. Returns the found message/block (or nil if the
@@ -0,0 +1,77 @@
+package chainindex
+
+import "database/sql"
+
+var ddls = []string{
+	// one row per message included by a tipset, a tipset without messages is recorded
+	// with a NULL message_cid so that it is known to be indexed.
+	`CREATE TABLE IF NOT EXISTS tipset_message (
+		id INTEGER PRIMARY KEY,
+		tipset_key_cid BLOB NOT NULL,
+		height INTEGER NOT NULL,
+		reverted INTEGER NOT NULL,
+		message_cid BLOB,
+		message_index INTEGER,
+		UNIQUE (tipset_key_cid, message_cid)
+	)`,
+
+	`CREATE TABLE IF NOT EXISTS eth_tx_hash (
+		tx_hash TEXT PRIMARY KEY NOT NULL,
+		message_cid BLOB NOT NULL,
+		inserted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+	)`,
+
+	`CREATE INDEX IF NOT EXISTS tipset_message_tipset_key_cid ON tipset_message (tipset_key_cid)`,
+	`CREATE INDEX IF NOT EXISTS tipset_message_height ON tipset_message (height)`,
+	`CREATE INDEX IF NOT EXISTS tipset_message_message_cid ON tipset_message (message_cid)`,
+	`CREATE INDEX IF NOT EXISTS eth_tx_hash_message_cid ON eth_tx_hash (message_cid)`,
+}
+
+type preparedStatements struct {
+	hasTipset                 *sql.Stmt
+	hasNonRevertedTipset      *sql.Stmt
+	insertTipsetMessage       *sql.Stmt
+	updateTipsetToReverted    *sql.Stmt
+	updateTipsetToNonReverted *sql.Stmt
+	revertOtherTipsetsAt      *sql.Stmt
+	revertTipsetsAt           *sql.Stmt
+	revertTipsetsAbove        *sql.Stmt
+
+	insertEthTxHash *sql.Stmt
+
+	getMsgInfo              *sql.Stmt
+	getMsgCidFromEthHash    *sql.Stmt
+	getNonRevertedTipsetsAt *sql.Stmt
+	countMessagesAtTipset   *sql.Stmt
+	hasNonRevertedAtHeight  *sql.Stmt
+	removeTipset            *sql.Stmt
+
+	gcTipsets     *sql.Stmt
+	gcEthTxHashes *sql.Stmt
+}
+
+// preparedStatementMapping returns the query of each prepared statement.
+func preparedStatementMapping(ps *preparedStatements) map[**sql.Stmt]string {
+	return map[**sql.Stmt]string{
+		&ps.hasTipset:                 `SELECT EXISTS(SELECT 1 FROM tipset_message WHERE tipset_key_cid = ?)`,
+		&ps.hasNonRevertedTipset:      `SELECT EXISTS(SELECT 1 FROM tipset_message WHERE tipset_key_cid = ? AND reverted = 0)`,
+		&ps.insertTipsetMessage:       `INSERT INTO tipset_message (tipset_key_cid, height, reverted, message_cid, message_index) VALUES (?, ?, 0, ?, ?) ON CONFLICT (tipset_key_cid, message_cid) DO UPDATE SET reverted = 0`,
+		&ps.updateTipsetToReverted:    `UPDATE tipset_message SET reverted = 1 WHERE tipset_key_cid = ?`,
+		&ps.updateTipsetToNonReverted: `UPDATE tipset_message SET reverted = 0 WHERE tipset_key_cid = ?`,
+		&ps.revertOtherTipsetsAt:      `UPDATE tipset_message SET reverted = 1 WHERE height = ? AND tipset_key_cid != ?`,
+		&ps.revertTipsetsAt:           `UPDATE tipset_message SET reverted = 1 WHERE height = ?`,
+		&ps.revertTipsetsAbove:        `UPDATE tipset_message SET reverted = 1 WHERE height > ?`,
+
+		&ps.insertEthTxHash: `INSERT INTO eth_tx_hash (tx_hash, message_cid) VALUES (?, ?) ON CONFLICT (tx_hash) DO UPDATE SET inserted_at = CURRENT_TIMESTAMP`,
+
+		&ps.getMsgInfo:              `SELECT tipset_key_cid, height FROM tipset_message WHERE message_cid = ? AND reverted = 0 ORDER BY height DESC LIMIT 1`,
+		&ps.getMsgCidFromEthHash:    `SELECT message_cid FROM eth_tx_hash WHERE tx_hash = ?`,
+		&ps.getNonRevertedTipsetsAt: `SELECT DISTINCT tipset_key_cid FROM tipset_message WHERE height = ? AND reverted = 0`,
+		&ps.countMessagesAtTipset:   `SELECT COUNT(*) FROM tipset_message WHERE tipset_key_cid = ? AND reverted = 0 AND message_cid IS NOT NULL`,
+		&ps.hasNonRevertedAtHeight:  `SELECT EXISTS(SELECT 1 FROM tipset_message WHERE height = ? AND reverted = 0)`,
+		&ps.removeTipset:            `DELETE FROM tipset_message WHERE tipset_key_cid = ?`,
+
+		&ps.gcTipsets:     `DELETE FROM tipset_message WHERE height < ?`,
+		&ps.gcEthTxHashes: `DELETE FROM eth_tx_hash WHERE message_cid NOT IN (SELECT message_cid FROM tipset_message WHERE message_cid IS NOT NULL)`,
+	}
+}
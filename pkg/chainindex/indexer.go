@@ -0,0 +1,345 @@
+package chainindex
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/crypto"
+	logging "github.com/ipfs/go-log/v2"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/events/filter/sqlite"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+var log = logging.Logger("chainindex")
+
+// DefaultDBFilename is the name of the chain index database in the sqlite directory of the repo
+const DefaultDBFilename = "chainindex.db"
+
+// gcInterval is how often entries older than the retention window are removed
+const gcInterval = 4 * time.Hour
+
+// ErrNotFound is returned when an eth transaction hash is not indexed
+var ErrNotFound = errors.New("not found in chain index")
+
+var _ chain.MsgIndex = (*ChainIndexer)(nil)
+
+// ChainIndexer maintains a persistent index of the messages included by each tipset and
+// the eth transaction hashes of delegated messages. It follows the chain head and keeps
+// reverted tipsets marked as such. When it is enabled, the eth transaction hash lookup
+// only records the pending messages. Actor events are out of its scope, they are
+// indexed by filter.EventIndex.
+type ChainIndexer struct {
+	db    *sql.DB
+	stmts *preparedStatements
+
+	cs  *chain.Store
+	ms  *chain.MessageStore
+	cfg config.ChainIndexerConfig
+
+	// lk serializes writes to the index
+	lk sync.Mutex
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewChainIndexer opens, or creates, the chain index database at path
+func NewChainIndexer(ctx context.Context, path string, cs *chain.Store, ms *chain.MessageStore, cfg config.ChainIndexerConfig) (*ChainIndexer, error) {
+	db, _, err := sqlite.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open chain index db: %w", err)
+	}
+
+	if err := sqlite.InitDb(ctx, "chain index", db, ddls, []sqlite.MigrationFunc{}); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to setup chain index db: %w", err)
+	}
+
+	ci := &ChainIndexer{
+		db:    db,
+		stmts: &preparedStatements{},
+		cs:    cs,
+		ms:    ms,
+		cfg:   cfg,
+	}
+	for stmtPointer, query := range preparedStatementMapping(ci.stmts) {
+		if *stmtPointer, err = db.Prepare(query); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("prepare statement [%s]: %w", query, err)
+		}
+	}
+
+	return ci, nil
+}
+
+// Start indexes the tipsets applied while the node was offline and subscribes to head changes.
+func (ci *ChainIndexer) Start(ctx context.Context) error {
+	ctx, ci.cancel = context.WithCancel(ctx)
+
+	if err := ci.reconcile(ctx, ci.cs.GetHead()); err != nil {
+		return fmt.Errorf("failed to reconcile chain index: %w", err)
+	}
+
+	ci.cs.SubscribeHeadChanges(func(rev, app []*types.TipSet) error {
+		return ci.headChange(ctx, rev, app)
+	})
+
+	if ci.cfg.GCRetentionEpochs > 0 {
+		ci.wg.Add(1)
+		go ci.gcLoop(ctx)
+	}
+	return nil
+}
+
+// Close stops the background work and closes the database
+func (ci *ChainIndexer) Close() error {
+	if ci.cancel != nil {
+		ci.cancel()
+	}
+	ci.wg.Wait()
+
+	ci.lk.Lock()
+	defer ci.lk.Unlock()
+	return ci.db.Close()
+}
+
+func (ci *ChainIndexer) headChange(ctx context.Context, rev, app []*types.TipSet) error {
+	if ctx.Err() != nil {
+		return chain.ErrNotifeeDone
+	}
+
+	return ci.withTx(ctx, func(tx *sql.Tx) error {
+		for _, ts := range rev {
+			if err := ci.revert(ctx, tx, ts); err != nil {
+				return fmt.Errorf("revert tipset %d: %w", ts.Height(), err)
+			}
+		}
+		for _, ts := range app {
+			if err := ci.apply(ctx, tx, ts); err != nil {
+				return fmt.Errorf("apply tipset %d: %w", ts.Height(), err)
+			}
+		}
+		return nil
+	})
+}
+
+// Backfill indexes the tipsets of the canonical chain between from and to, both inclusive.
+func (ci *ChainIndexer) Backfill(ctx context.Context, from, to abi.ChainEpoch) error {
+	head := ci.cs.GetHead()
+	if to > head.Height() {
+		to = head.Height()
+	}
+
+	ts, err := ci.cs.GetTipSetByHeight(ctx, head, to, true)
+	if err != nil {
+		return err
+	}
+	for ts.Height() >= from {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := ci.withTx(ctx, func(tx *sql.Tx) error {
+			return ci.apply(ctx, tx, ts)
+		}); err != nil {
+			return fmt.Errorf("backfill tipset %d: %w", ts.Height(), err)
+		}
+		if ts.Height() == 0 {
+			break
+		}
+		if ts, err = ci.cs.GetTipSet(ctx, ts.Parents()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcile walks back from head to the last indexed tipset and indexes everything in between,
+// tipsets above head and tipsets off the canonical chain are marked reverted.
+func (ci *ChainIndexer) reconcile(ctx context.Context, head *types.TipSet) error {
+	return ci.withTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.Stmt(ci.stmts.revertTipsetsAbove).ExecContext(ctx, head.Height()); err != nil {
+			return err
+		}
+
+		var missing []*types.TipSet
+		cur := head
+		for uint64(len(missing)) < ci.cfg.MaxReconcileTipsets && cur.Height() > 0 {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			indexed, err := ci.isIndexed(ctx, tx, cur)
+			if err != nil {
+				return err
+			}
+			if indexed {
+				break
+			}
+			missing = append(missing, cur)
+
+			if cur, err = ci.cs.GetTipSet(ctx, cur.Parents()); err != nil {
+				return err
+			}
+		}
+
+		if len(missing) > 0 {
+			log.Infof("reconciling chain index, indexing %d tipsets from %d to %d", len(missing), missing[len(missing)-1].Height(), head.Height())
+		}
+		for i := len(missing) - 1; i >= 0; i-- {
+			ts := missing[i]
+			tsKeyCid, err := ts.Key().Cid()
+			if err != nil {
+				return err
+			}
+			if _, err := tx.Stmt(ci.stmts.revertOtherTipsetsAt).ExecContext(ctx, ts.Height(), tsKeyCid.Bytes()); err != nil {
+				return err
+			}
+			if err := ci.apply(ctx, tx, ts); err != nil {
+				return fmt.Errorf("apply tipset %d: %w", ts.Height(), err)
+			}
+		}
+		return nil
+	})
+}
+
+func (ci *ChainIndexer) isIndexed(ctx context.Context, tx *sql.Tx, ts *types.TipSet) (bool, error) {
+	tsKeyCid, err := ts.Key().Cid()
+	if err != nil {
+		return false, err
+	}
+	var exists bool
+	if err := tx.Stmt(ci.stmts.hasNonRevertedTipset).QueryRowContext(ctx, tsKeyCid.Bytes()).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// apply indexes the messages included by ts
+func (ci *ChainIndexer) apply(ctx context.Context, tx *sql.Tx, ts *types.TipSet) error {
+	return ci.indexTipset(ctx, tx, ts)
+}
+
+// revert marks the messages included by ts as reverted.
+func (ci *ChainIndexer) revert(ctx context.Context, tx *sql.Tx, ts *types.TipSet) error {
+	tsKeyCid, err := ts.Key().Cid()
+	if err != nil {
+		return err
+	}
+	_, err = tx.Stmt(ci.stmts.updateTipsetToReverted).ExecContext(ctx, tsKeyCid.Bytes())
+	return err
+}
+
+func (ci *ChainIndexer) indexTipset(ctx context.Context, tx *sql.Tx, ts *types.TipSet) error {
+	tsKeyCid, err := ts.Key().Cid()
+	if err != nil {
+		return err
+	}
+
+	var exists bool
+	if err := tx.Stmt(ci.stmts.hasTipset).QueryRowContext(ctx, tsKeyCid.Bytes()).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		_, err := tx.Stmt(ci.stmts.updateTipsetToNonReverted).ExecContext(ctx, tsKeyCid.Bytes())
+		return err
+	}
+
+	msgs, err := ci.ms.MessagesForTipset(ts)
+	if err != nil {
+		return fmt.Errorf("failed to load messages of tipset: %w", err)
+	}
+
+	insertMsg := tx.Stmt(ci.stmts.insertTipsetMessage)
+	if len(msgs) == 0 {
+		_, err := insertMsg.ExecContext(ctx, tsKeyCid.Bytes(), ts.Height(), nil, -1)
+		return err
+	}
+
+	insertHash := tx.Stmt(ci.stmts.insertEthTxHash)
+	for i, msg := range msgs {
+		msgCid := msg.Cid()
+		if _, err := insertMsg.ExecContext(ctx, tsKeyCid.Bytes(), ts.Height(), msgCid.Bytes(), i); err != nil {
+			return err
+		}
+
+		smsg, ok := msg.(*types.SignedMessage)
+		if !ok || smsg.Signature.Type != crypto.SigTypeDelegated {
+			continue
+		}
+		ethTx, err := types.EthTransactionFromSignedFilecoinMessage(smsg)
+		if err != nil {
+			log.Warnf("failed to convert message %s to eth transaction: %v", msgCid, err)
+			continue
+		}
+		hash, err := ethTx.TxHash()
+		if err != nil {
+			log.Warnf("failed to compute eth transaction hash of %s: %v", msgCid, err)
+			continue
+		}
+		if _, err := insertHash.ExecContext(ctx, hash.String(), msgCid.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ci *ChainIndexer) gcLoop(ctx context.Context) {
+	defer ci.wg.Done()
+
+	ticker := time.NewTicker(gcInterval)
+	defer ticker.Stop()
+
+	for {
+		ci.gc(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (ci *ChainIndexer) gc(ctx context.Context) {
+	removeBefore := ci.cs.GetHead().Height() - abi.ChainEpoch(ci.cfg.GCRetentionEpochs)
+	if removeBefore <= 0 {
+		return
+	}
+
+	err := ci.withTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.Stmt(ci.stmts.gcTipsets).ExecContext(ctx, removeBefore); err != nil {
+			return err
+		}
+		_, err := tx.Stmt(ci.stmts.gcEthTxHashes).ExecContext(ctx)
+		return err
+	})
+	if err != nil {
+		log.Errorf("failed to gc chain index before epoch %d: %v", removeBefore, err)
+		return
+	}
+	log.Infof("removed chain index entries before epoch %d", removeBefore)
+}
+
+func (ci *ChainIndexer) withTx(ctx context.Context, f func(tx *sql.Tx) error) error {
+	ci.lk.Lock()
+	defer ci.lk.Unlock()
+
+	tx, err := ci.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	// rollback the transaction (a no-op if the transaction was already committed)
+	defer func() { _ = tx.Rollback() }()
+
+	if err := f(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
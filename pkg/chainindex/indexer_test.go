@@ -0,0 +1,186 @@
+package chainindex
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/testhelpers"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type testChain struct {
+	builder *chain.Builder
+	msgs    []*types.SignedMessage
+	// ts1 includes msgs, their receipts are in ts2
+	ts1, ts2, ts3 *types.TipSet
+}
+
+func newTestChain(ctx context.Context, t *testing.T) *testChain {
+	builder := chain.NewBuilder(t, address.Undef)
+	mm := testhelpers.NewMessageMaker(t, testhelpers.MustGenerateKeyInfo(1, 42))
+	alice := mm.Addresses()[0]
+	msgs := []*types.SignedMessage{mm.NewSignedMessage(alice, 0), mm.NewSignedMessage(alice, 1)}
+
+	receipts, err := builder.MessageStore().StoreReceipts(ctx, []types.MessageReceipt{
+		types.NewMessageReceiptV1(0, nil, 1, nil),
+		types.NewMessageReceiptV1(0, nil, 1, nil),
+	})
+	require.NoError(t, err)
+
+	msgMeta, err := builder.MessageStore().StoreMessages(ctx, msgs, nil)
+	require.NoError(t, err)
+
+	// the builder computes fake state roots for tipsets with messages which can't be loaded,
+	// so the tipsets are built on the genesis state.
+	tc := &testChain{builder: builder, msgs: msgs}
+	genesis := builder.Genesis().At(0)
+	tc.ts1 = putTipSet(ctx, t, builder, builder.Genesis(), msgMeta, genesis.ParentMessageReceipts)
+	tc.ts2 = putTipSet(ctx, t, builder, tc.ts1, genesis.Messages, receipts)
+	tc.ts3 = putTipSet(ctx, t, builder, tc.ts2, genesis.Messages, genesis.ParentMessageReceipts)
+	require.NoError(t, builder.Store().SetHead(ctx, tc.ts3))
+
+	return tc
+}
+
+func putTipSet(ctx context.Context, t *testing.T, builder *chain.Builder, parent *types.TipSet, msgs, receipts cid.Cid) *types.TipSet {
+	genesis := builder.Genesis().At(0)
+	blk := &types.BlockHeader{
+		Ticket:                &types.Ticket{VRFProof: []byte{byte(parent.Height() + 1)}},
+		Miner:                 genesis.Miner,
+		ParentWeight:          genesis.ParentWeight,
+		Parents:               parent.Key().Cids(),
+		Height:                parent.Height() + 1,
+		ParentStateRoot:       genesis.ParentStateRoot,
+		ParentMessageReceipts: receipts,
+		Messages:              msgs,
+		BLSAggregate:          genesis.BLSAggregate,
+		BlockSig:              &crypto.Signature{Type: crypto.SigTypeSecp256k1, Data: []byte{}},
+		ElectionProof:         &types.ElectionProof{VRFProof: []byte{0x0c, 0x0d}, WinCount: 10},
+	}
+	_, err := builder.Cstore().Put(ctx, blk)
+	require.NoError(t, err)
+	require.NoError(t, builder.Store().AddToTipSetTracker(ctx, blk))
+	return testhelpers.RequireNewTipSet(t, blk)
+}
+
+func newTestIndexer(ctx context.Context, t *testing.T, tc *testChain) *ChainIndexer {
+	ci, err := NewChainIndexer(ctx, filepath.Join(t.TempDir(), DefaultDBFilename), tc.builder.Store(), tc.builder.MessageStore(), config.ChainIndexerConfig{
+		EnableIndexer:       true,
+		MaxReconcileTipsets: 100,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ci.Close()) })
+	return ci
+}
+
+func TestIndexerApplyAndRevert(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	tc := newTestChain(ctx, t)
+	ci := newTestIndexer(ctx, t, tc)
+
+	require.NoError(t, ci.reconcile(ctx, tc.ts3))
+
+	ts1KeyCid, err := tc.ts1.Key().Cid()
+	require.NoError(t, err)
+	for _, msg := range tc.msgs {
+		info, err := ci.GetMsgInfo(ctx, msg.Cid())
+		require.NoError(t, err)
+		assert.Equal(t, ts1KeyCid, info.TipSet)
+		assert.Equal(t, tc.ts1.Height(), info.Epoch)
+	}
+
+	res, err := ci.ValidateIndex(ctx, tc.ts1.Height(), false)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), res.IndexedMessagesCount)
+	assert.False(t, res.Backfilled)
+
+	require.NoError(t, ci.headChange(ctx, []*types.TipSet{tc.ts3, tc.ts2}, nil))
+	_, err = ci.GetMsgInfo(ctx, tc.msgs[0].Cid())
+	require.NoError(t, err)
+
+	require.NoError(t, ci.headChange(ctx, []*types.TipSet{tc.ts1}, nil))
+	_, err = ci.GetMsgInfo(ctx, tc.msgs[0].Cid())
+	assert.True(t, errors.Is(err, chain.ErrMsgNotIndexed))
+
+	// applying again restores the existing entries
+	require.NoError(t, ci.headChange(ctx, nil, []*types.TipSet{tc.ts1, tc.ts2, tc.ts3}))
+	res, err = ci.ValidateIndex(ctx, tc.ts1.Height(), false)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), res.IndexedMessagesCount)
+}
+
+func TestValidateIndexBackfill(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	tc := newTestChain(ctx, t)
+	ci := newTestIndexer(ctx, t, tc)
+
+	_, err := ci.ValidateIndex(ctx, tc.ts3.Height()+1, false)
+	assert.Error(t, err)
+
+	_, err = ci.ValidateIndex(ctx, tc.ts1.Height(), false)
+	assert.Error(t, err)
+
+	res, err := ci.ValidateIndex(ctx, tc.ts1.Height(), true)
+	require.NoError(t, err)
+	assert.True(t, res.Backfilled)
+	assert.Equal(t, tc.ts1.Key(), res.TipSetKey)
+	assert.Equal(t, uint64(2), res.IndexedMessagesCount)
+
+	res, err = ci.ValidateIndex(ctx, tc.ts1.Height(), false)
+	require.NoError(t, err)
+	assert.False(t, res.Backfilled)
+}
+
+func TestValidateIndexRepair(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	tc := newTestChain(ctx, t)
+	ci := newTestIndexer(ctx, t, tc)
+
+	require.NoError(t, ci.reconcile(ctx, tc.ts3))
+	ts1KeyCid, err := tc.ts1.Key().Cid()
+	require.NoError(t, err)
+
+	// an indexed row of ts1 went missing
+	_, err = ci.db.ExecContext(ctx, "DELETE FROM tipset_message WHERE tipset_key_cid = ? AND message_index = 1", ts1KeyCid.Bytes())
+	require.NoError(t, err)
+	_, err = ci.ValidateIndex(ctx, tc.ts1.Height(), false)
+	assert.Error(t, err)
+
+	res, err := ci.ValidateIndex(ctx, tc.ts1.Height(), true)
+	require.NoError(t, err)
+	assert.True(t, res.Backfilled)
+	assert.Equal(t, uint64(2), res.IndexedMessagesCount)
+
+	// a tipset off the chain is indexed as canonical at the height of ts1
+	_, err = ci.db.ExecContext(ctx, "INSERT INTO tipset_message (tipset_key_cid, height, reverted, message_cid, message_index) VALUES (?, ?, 0, NULL, -1)", []byte("fork"), tc.ts1.Height())
+	require.NoError(t, err)
+	_, err = ci.ValidateIndex(ctx, tc.ts1.Height(), false)
+	assert.Error(t, err)
+
+	res, err = ci.ValidateIndex(ctx, tc.ts1.Height(), true)
+	require.NoError(t, err)
+	assert.True(t, res.Backfilled)
+
+	res, err = ci.ValidateIndex(ctx, tc.ts1.Height(), false)
+	require.NoError(t, err)
+	assert.False(t, res.Backfilled)
+	for _, msg := range tc.msgs {
+		info, err := ci.GetMsgInfo(ctx, msg.Cid())
+		require.NoError(t, err)
+		assert.Equal(t, ts1KeyCid, info.TipSet)
+	}
+}
@@ -0,0 +1,52 @@
+package chainindex
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/venus/pkg/chain"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// GetMsgInfo returns the canonical tipset that included the message m and its height
+func (ci *ChainIndexer) GetMsgInfo(ctx context.Context, m cid.Cid) (*chain.MsgInfo, error) {
+	var tsKeyCidBytes []byte
+	var height int64
+	err := ci.stmts.getMsgInfo.QueryRowContext(ctx, m.Bytes()).Scan(&tsKeyCidBytes, &height)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("message %s: %w", m, chain.ErrMsgNotIndexed)
+		}
+		return nil, fmt.Errorf("failed to query message info: %w", err)
+	}
+
+	tsKeyCid, err := cid.Cast(tsKeyCidBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cast tipset key cid: %w", err)
+	}
+
+	return &chain.MsgInfo{
+		Message: m,
+		TipSet:  tsKeyCid,
+		Epoch:   abi.ChainEpoch(height),
+	}, nil
+}
+
+// GetCidFromHash returns the cid of the message of the eth transaction hash
+func (ci *ChainIndexer) GetCidFromHash(ctx context.Context, hash types.EthHash) (cid.Cid, error) {
+	var msgCidBytes []byte
+	err := ci.stmts.getMsgCidFromEthHash.QueryRowContext(ctx, hash.String()).Scan(&msgCidBytes)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return cid.Undef, ErrNotFound
+		}
+		return cid.Undef, fmt.Errorf("failed to query message cid: %w", err)
+	}
+
+	return cid.Cast(msgCidBytes)
+}
@@ -0,0 +1,142 @@
+package chainindex
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// ValidateIndex checks that the index at epoch matches the canonical chain: the indexed tipset is the
+// canonical one and the number of indexed messages matches the chain. A tipset missing in the index or
+// not matching the chain is re-indexed when backfill is set, otherwise an error is returned.
+func (ci *ChainIndexer) ValidateIndex(ctx context.Context, epoch abi.ChainEpoch, backfill bool) (*types.IndexValidation, error) {
+	head := ci.cs.GetHead()
+	if epoch > head.Height() {
+		return nil, fmt.Errorf("cannot validate index at epoch %d, can only validate up to head %d", epoch, head.Height())
+	}
+	if epoch < 0 {
+		return nil, fmt.Errorf("epoch %d is negative", epoch)
+	}
+
+	ts, err := ci.cs.GetTipSetByHeight(ctx, head, epoch, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tipset at epoch %d: %w", epoch, err)
+	}
+
+	if ts.Height() != epoch {
+		var indexed bool
+		if err := ci.stmts.hasNonRevertedAtHeight.QueryRowContext(ctx, epoch).Scan(&indexed); err != nil {
+			return nil, fmt.Errorf("failed to query index at epoch %d: %w", epoch, err)
+		}
+		if indexed && backfill {
+			// nothing is canonical at a null round
+			if err := ci.withTx(ctx, func(tx *sql.Tx) error {
+				_, err := tx.Stmt(ci.stmts.revertTipsetsAt).ExecContext(ctx, epoch)
+				return err
+			}); err != nil {
+				return nil, fmt.Errorf("failed to repair null round %d: %w", epoch, err)
+			}
+			return &types.IndexValidation{Height: epoch, IsNullRound: true, Backfilled: true}, nil
+		}
+		if indexed {
+			return nil, fmt.Errorf("epoch %d is a null round but has non-reverted entries in the index", epoch)
+		}
+		return &types.IndexValidation{Height: epoch, IsNullRound: true}, nil
+	}
+
+	tsKeyCid, err := ts.Key().Cid()
+	if err != nil {
+		return nil, err
+	}
+	msgs, err := ci.ms.MessagesForTipset(ts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load messages of tipset: %w", err)
+	}
+
+	var backfilled bool
+	msgCount, err := ci.checkTipset(ctx, tsKeyCid, epoch, uint64(len(msgs)))
+	if err != nil {
+		if !backfill {
+			return nil, err
+		}
+		log.Infof("re-indexing tipset %s at epoch %d: %v", ts.Key(), epoch, err)
+		if err := ci.withTx(ctx, func(tx *sql.Tx) error {
+			// the stale entries of the tipset are replaced and the other tipsets at epoch are off the chain
+			if _, err := tx.Stmt(ci.stmts.removeTipset).ExecContext(ctx, tsKeyCid.Bytes()); err != nil {
+				return err
+			}
+			if _, err := tx.Stmt(ci.stmts.revertOtherTipsetsAt).ExecContext(ctx, epoch, tsKeyCid.Bytes()); err != nil {
+				return err
+			}
+			return ci.indexTipset(ctx, tx, ts)
+		}); err != nil {
+			return nil, fmt.Errorf("failed to backfill epoch %d: %w", epoch, err)
+		}
+		if msgCount, err = ci.checkTipset(ctx, tsKeyCid, epoch, uint64(len(msgs))); err != nil {
+			return nil, fmt.Errorf("index still mismatched after backfill: %w", err)
+		}
+		backfilled = true
+	}
+
+	return &types.IndexValidation{
+		TipSetKey:            ts.Key(),
+		Height:               epoch,
+		IndexedMessagesCount: msgCount,
+		Backfilled:           backfilled,
+	}, nil
+}
+
+// checkTipset checks that the tipset tsKeyCid is the only non-reverted tipset indexed at epoch and that
+// it has the expected number of messages, it returns the number of indexed messages.
+func (ci *ChainIndexer) checkTipset(ctx context.Context, tsKeyCid cid.Cid, epoch abi.ChainEpoch, expectMsgs uint64) (uint64, error) {
+	indexedTipsets, err := ci.nonRevertedTipsetsAt(ctx, epoch)
+	if err != nil {
+		return 0, err
+	}
+	switch len(indexedTipsets) {
+	case 0:
+		return 0, fmt.Errorf("tipset %s at epoch %d is missing in the index", tsKeyCid, epoch)
+	case 1:
+		if !indexedTipsets[0].Equals(tsKeyCid) {
+			return 0, fmt.Errorf("indexed tipset %s at epoch %d is not the canonical tipset %s", indexedTipsets[0], epoch, tsKeyCid)
+		}
+	default:
+		return 0, fmt.Errorf("expected one indexed tipset at epoch %d, found %d", epoch, len(indexedTipsets))
+	}
+
+	var msgCount uint64
+	if err := ci.stmts.countMessagesAtTipset.QueryRowContext(ctx, tsKeyCid.Bytes()).Scan(&msgCount); err != nil {
+		return 0, fmt.Errorf("failed to count indexed messages: %w", err)
+	}
+	if msgCount != expectMsgs {
+		return 0, fmt.Errorf("message count mismatch at epoch %d: indexed %d, chain %d", epoch, msgCount, expectMsgs)
+	}
+	return msgCount, nil
+}
+
+func (ci *ChainIndexer) nonRevertedTipsetsAt(ctx context.Context, epoch abi.ChainEpoch) ([]cid.Cid, error) {
+	rows, err := ci.stmts.getNonRevertedTipsetsAt.QueryContext(ctx, epoch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query indexed tipsets at epoch %d: %w", epoch, err)
+	}
+	defer rows.Close() // nolint:errcheck
+
+	var out []cid.Cid
+	for rows.Next() {
+		var b []byte
+		if err := rows.Scan(&b); err != nil {
+			return nil, err
+		}
+		c, err := cid.Cast(b)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
@@ -3,6 +3,8 @@ package chainsync
 import (
 	"context"
 
+	"github.com/ipfs/go-cid"
+
 	chain2 "github.com/filecoin-project/venus/app/submodule/chain"
 	"github.com/filecoin-project/venus/pkg/chainsync/types"
 	"github.com/filecoin-project/venus/pkg/consensus"
@@ -27,6 +29,10 @@ type BlockProposer interface {
 	SendGossipBlock(ci *types2.ChainInfo) error
 	IncomingBlocks(ctx context.Context) (<-chan *types2.BlockHeader, error)
 	SyncCheckpoint(ctx context.Context, tsk types2.TipSetKey) error
+	SyncMarkBad(ctx context.Context, c cid.Cid) error
+	SyncUnmarkBad(ctx context.Context, c cid.Cid) error
+	SyncUnmarkAllBad(ctx context.Context) error
+	SyncCheckBad(c cid.Cid) string
 }
 
 var _ = (BlockProposer)((*dispatcher.Dispatcher)(nil))
@@ -45,13 +51,15 @@ func NewManager(
 	exchangeClient exchange.Client,
 	c clock.Clock,
 	fork fork.IFork,
+	badBlocks *syncer.BadBlockCache,
 ) (Manager, error) {
 	chainSyncer, err := syncer.NewSyncer(stmgr, hv, submodule.ChainReader,
 		submodule.MessageStore, bsstore,
-		exchangeClient, c, fork)
+		exchangeClient, c, fork, badBlocks)
 	if err != nil {
 		return Manager{}, err
 	}
+	exchangeClient.SetBadBlockChecker(badBlocks)
 
 	return Manager{
 		dispatcher: dispatcher.NewDispatcher(struct {
@@ -13,6 +13,7 @@ import (
 	"github.com/filecoin-project/venus/pkg/chain"
 	"github.com/filecoin-project/venus/pkg/chainsync/types"
 	types2 "github.com/filecoin-project/venus/venus-shared/types"
+	"github.com/ipfs/go-cid"
 	"github.com/streadway/handy/atomic"
 
 	logging "github.com/ipfs/go-log/v2"
@@ -34,6 +35,10 @@ type dispatchSyncer interface {
 	HandleNewTipSet(context.Context, *types.Target) error
 	ValidateMsgMeta(ctx context.Context, fblk *types2.FullBlock) error
 	SyncCheckpoint(ctx context.Context, tsk types2.TipSetKey) error
+	MarkBad(ctx context.Context, c cid.Cid) error
+	UnmarkBad(ctx context.Context, c cid.Cid) error
+	UnmarkAllBad(ctx context.Context) error
+	CheckBad(c cid.Cid) string
 }
 
 // NewDispatcher creates a new syncing dispatcher with default queue sizes.
@@ -110,6 +115,18 @@ func (d *Dispatcher) sendHead(ci *types2.ChainInfo) error {
 		return fmt.Errorf("got nil tipset")
 	}
 
+	// refuse bad blocks and blocks building on them early, before they are tracked
+	for _, b := range fts.Blocks {
+		if reason := d.syncer.CheckBad(b.Cid()); reason != "" {
+			return fmt.Errorf("block %s is bad: %s", b.Cid(), reason)
+		}
+		for _, p := range b.Header.Parents {
+			if reason := d.syncer.CheckBad(p); reason != "" {
+				return fmt.Errorf("block %s builds on bad block %s: %s", b.Cid(), p, reason)
+			}
+		}
+	}
+
 	for _, b := range fts.Blocks {
 		if err := d.syncer.ValidateMsgMeta(ctx, b); err != nil {
 			log.Warnf("invalid block %s received: %s", b.Cid(), err)
@@ -340,3 +357,19 @@ func (d *Dispatcher) processCtrl(ctrlMsg interface{}) {
 func (d *Dispatcher) SyncCheckpoint(ctx context.Context, tsk types2.TipSetKey) error {
 	return d.syncer.SyncCheckpoint(ctx, tsk)
 }
+
+func (d *Dispatcher) SyncMarkBad(ctx context.Context, c cid.Cid) error {
+	return d.syncer.MarkBad(ctx, c)
+}
+
+func (d *Dispatcher) SyncUnmarkBad(ctx context.Context, c cid.Cid) error {
+	return d.syncer.UnmarkBad(ctx, c)
+}
+
+func (d *Dispatcher) SyncUnmarkAllBad(ctx context.Context) error {
+	return d.syncer.UnmarkAllBad(ctx)
+}
+
+func (d *Dispatcher) SyncCheckBad(c cid.Cid) string {
+	return d.syncer.CheckBad(c)
+}
@@ -46,6 +46,22 @@ func (fs *mockSyncer) SyncCheckpoint(ctx context.Context, tsk types.TipSetKey) e
 	return nil
 }
 
+func (fs *mockSyncer) MarkBad(ctx context.Context, c cid.Cid) error {
+	return nil
+}
+
+func (fs *mockSyncer) UnmarkBad(ctx context.Context, c cid.Cid) error {
+	return nil
+}
+
+func (fs *mockSyncer) UnmarkAllBad(ctx context.Context) error {
+	return nil
+}
+
+func (fs *mockSyncer) CheckBad(c cid.Cid) string {
+	return ""
+}
+
 func TestDispatchStartHappy(t *testing.T) {
 	tf.UnitTest(t)
 	s := &mockSyncer{
@@ -0,0 +1,190 @@
+package syncer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"github.com/ipfs/go-datastore/query"
+
+	"github.com/filecoin-project/venus/pkg/repo"
+)
+
+// DefaultBadBlockCacheSize is the number of invalid blocks remembered by the syncer.
+const DefaultBadBlockCacheSize = 1 << 15
+
+var markedBadBlocksKey = datastore.NewKey("/syncer/badblocks/marked")
+
+// BadBlockReason records why a block is bad. A block descending from a bad block is linked to the
+// reason of the offending ancestor, kept in OriginalReason.
+type BadBlockReason struct {
+	Reason         string
+	TipSet         []cid.Cid
+	OriginalReason *BadBlockReason `json:",omitempty"`
+}
+
+// NewBadBlockReason returns the reason of a block of the tipset cids found bad.
+func NewBadBlockReason(cids []cid.Cid, format string, args ...interface{}) BadBlockReason {
+	return BadBlockReason{
+		TipSet: cids,
+		Reason: fmt.Sprintf(format, args...),
+	}
+}
+
+// Linked returns the reason of a block descending from the bad block of bbr.
+func (bbr BadBlockReason) Linked(format string, args ...interface{}) BadBlockReason {
+	or := &bbr
+	if bbr.OriginalReason != nil {
+		or = bbr.OriginalReason
+	}
+	return BadBlockReason{Reason: fmt.Sprintf(format, args...), OriginalReason: or}
+}
+
+func (bbr BadBlockReason) String() string {
+	res := bbr.Reason
+	if bbr.OriginalReason != nil {
+		res += fmt.Sprintf(" caused by: %s %s", bbr.OriginalReason.TipSet, bbr.OriginalReason.String())
+	}
+	return res
+}
+
+// BadBlockCache keeps track of the blocks the syncer must refuse, along with their descendants.
+// Blocks found invalid while syncing are kept in a bounded in-memory LRU, a sync failure isn't
+// necessarily a consensus failure, e.g. a missing block or an IO error, so they are forgotten on
+// restart. Blocks marked bad by the operator are never evicted and are persisted in the metadata
+// datastore.
+type BadBlockCache struct {
+	lk sync.Mutex
+
+	markedDs datastore.Batching
+
+	invalid *lru.Cache[cid.Cid, BadBlockReason]
+	marked  map[cid.Cid]BadBlockReason
+}
+
+// NewBadBlockCache creates a BadBlockCache and loads the blocks marked bad persisted in ds.
+func NewBadBlockCache(ctx context.Context, ds repo.Datastore, size int) (*BadBlockCache, error) {
+	bbc := &BadBlockCache{
+		markedDs: namespace.Wrap(ds, markedBadBlocksKey),
+		marked:   make(map[cid.Cid]BadBlockReason),
+	}
+
+	var err error
+	bbc.invalid, err = lru.New[cid.Cid, BadBlockReason](size)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := loadBadBlocks(ctx, bbc.markedDs, func(c cid.Cid, r BadBlockReason) {
+		bbc.marked[c] = r
+	}); err != nil {
+		return nil, fmt.Errorf("failed to load marked bad blocks: %w", err)
+	}
+
+	return bbc, nil
+}
+
+func loadBadBlocks(ctx context.Context, ds datastore.Batching, cb func(cid.Cid, BadBlockReason)) error {
+	res, err := ds.Query(ctx, query.Query{})
+	if err != nil {
+		return err
+	}
+	defer res.Close() // nolint:errcheck
+
+	for r := range res.Next() {
+		if r.Error != nil {
+			return r.Error
+		}
+		c, err := cid.Decode(datastore.RawKey(r.Key).BaseNamespace())
+		if err != nil {
+			return fmt.Errorf("invalid bad block key %s: %w", r.Key, err)
+		}
+		var reason BadBlockReason
+		if err := json.Unmarshal(r.Value, &reason); err != nil {
+			return fmt.Errorf("invalid reason of bad block %s: %w", c, err)
+		}
+		cb(c, reason)
+	}
+	return nil
+}
+
+// Add records a block found invalid while syncing, it is kept in memory only.
+func (bbc *BadBlockCache) Add(c cid.Cid, reason BadBlockReason) {
+	bbc.lk.Lock()
+	defer bbc.lk.Unlock()
+
+	bbc.invalid.Add(c, reason)
+}
+
+// MarkBad records a block marked bad by the operator, it is never evicted.
+func (bbc *BadBlockCache) MarkBad(ctx context.Context, c cid.Cid, reason BadBlockReason) error {
+	bbc.lk.Lock()
+	defer bbc.lk.Unlock()
+
+	if err := putBadBlock(ctx, bbc.markedDs, c, reason); err != nil {
+		return fmt.Errorf("failed to persist bad block %s: %w", c, err)
+	}
+	bbc.marked[c] = reason
+	return nil
+}
+
+// Remove forgets a bad block.
+func (bbc *BadBlockCache) Remove(ctx context.Context, c cid.Cid) error {
+	bbc.lk.Lock()
+	defer bbc.lk.Unlock()
+
+	if err := bbc.markedDs.Delete(ctx, datastore.NewKey(c.String())); err != nil {
+		return err
+	}
+	delete(bbc.marked, c)
+	bbc.invalid.Remove(c)
+	return nil
+}
+
+// Purge forgets all bad blocks.
+func (bbc *BadBlockCache) Purge(ctx context.Context) error {
+	bbc.lk.Lock()
+	defer bbc.lk.Unlock()
+
+	for c := range bbc.marked {
+		if err := bbc.markedDs.Delete(ctx, datastore.NewKey(c.String())); err != nil {
+			return err
+		}
+		delete(bbc.marked, c)
+	}
+	bbc.invalid.Purge()
+	return nil
+}
+
+// Has returns the reason a block is bad, if it is.
+func (bbc *BadBlockCache) Has(c cid.Cid) (BadBlockReason, bool) {
+	bbc.lk.Lock()
+	defer bbc.lk.Unlock()
+
+	if reason, ok := bbc.marked[c]; ok {
+		return reason, true
+	}
+	return bbc.invalid.Get(c)
+}
+
+// CheckBad implements exchange.BadBlockChecker.
+func (bbc *BadBlockCache) CheckBad(c cid.Cid) (string, bool) {
+	reason, ok := bbc.Has(c)
+	if !ok {
+		return "", false
+	}
+	return reason.String(), true
+}
+
+func putBadBlock(ctx context.Context, ds datastore.Batching, c cid.Cid, reason BadBlockReason) error {
+	b, err := json.Marshal(reason)
+	if err != nil {
+		return err
+	}
+	return ds.Put(ctx, datastore.NewKey(c.String()), b)
+}
@@ -0,0 +1,80 @@
+package syncer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/chainsync/syncer"
+	"github.com/filecoin-project/venus/pkg/testhelpers"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func newBadBlockCache(t *testing.T) *syncer.BadBlockCache {
+	bbc, err := syncer.NewBadBlockCache(context.Background(), dssync.MutexWrap(datastore.NewMapDatastore()), syncer.DefaultBadBlockCacheSize)
+	require.NoError(t, err)
+	return bbc
+}
+
+func TestBadBlockCachePersistence(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	ds := dssync.MutexWrap(datastore.NewMapDatastore())
+
+	bbc, err := syncer.NewBadBlockCache(ctx, ds, 2)
+	require.NoError(t, err)
+
+	marked := testhelpers.CidFromString(t, "marked")
+	parent := testhelpers.CidFromString(t, "parent")
+	child := testhelpers.CidFromString(t, "child")
+
+	require.NoError(t, bbc.MarkBad(ctx, marked, syncer.NewBadBlockReason([]cid.Cid{marked}, "manually marked bad")))
+	reason := syncer.NewBadBlockReason([]cid.Cid{parent}, "invalid state root")
+	bbc.Add(parent, reason)
+	bbc.Add(child, reason.Linked("linked to %s", parent))
+
+	reloaded, err := syncer.NewBadBlockCache(ctx, ds, 2)
+	require.NoError(t, err)
+
+	got, ok := reloaded.Has(marked)
+	require.True(t, ok)
+	assert.Equal(t, "manually marked bad", got.Reason)
+
+	// the blocks found invalid while syncing are forgotten on restart
+	for _, c := range []cid.Cid{parent, child} {
+		_, ok = reloaded.Has(c)
+		assert.False(t, ok)
+	}
+
+	// invalid blocks are evicted from the lru, marked blocks never are
+	got, ok = bbc.Has(child)
+	require.True(t, ok)
+	require.NotNil(t, got.OriginalReason)
+	assert.Equal(t, []cid.Cid{parent}, got.OriginalReason.TipSet)
+	assert.Contains(t, got.String(), "invalid state root")
+	bbc.Add(testhelpers.CidFromString(t, "other"), reason)
+	_, ok = bbc.Has(parent)
+	assert.False(t, ok)
+	_, ok = bbc.Has(marked)
+	assert.True(t, ok)
+
+	require.NoError(t, reloaded.Remove(ctx, marked))
+	_, ok = reloaded.Has(marked)
+	assert.False(t, ok)
+
+	require.NoError(t, bbc.Purge(ctx))
+	_, ok = bbc.Has(child)
+	assert.False(t, ok)
+
+	reloaded, err = syncer.NewBadBlockCache(ctx, ds, 2)
+	require.NoError(t, err)
+	for _, c := range []cid.Cid{marked, parent, child} {
+		_, ok = reloaded.Has(c)
+		assert.False(t, ok)
+	}
+}
@@ -38,7 +38,7 @@ import (
 )
 
 // Syncer updates its chain.Store according to the methods of its
-// consensus.Protocol.  It uses a bad tipset cache and a limit on new
+// consensus.Protocol.  It uses a bad block cache and a limit on new
 // blocks to traverse during chain collection.  The Syncer can query the
 // network for blocks.  The Syncer maintains the following invariant on
 // its bsstore: all tipsets that pass the syncer's validity checks are added to the
@@ -124,8 +124,8 @@ type ChainSelector interface {
 // and check whether it can become the latest tipset
 type Syncer struct {
 	exchangeClient exchange.Client
-	// BadTipSetCache is used to filter out collections of invalid blocks.
-	badTipSets *syncTypes.BadTipSetCache
+	// badBlocks is used to refuse invalid blocks and their descendants.
+	badBlocks *BadBlockCache
 
 	// Evaluates tipset messages and stores the resulting states.
 	stmgr *statemanger.Stmgr
@@ -155,6 +155,7 @@ func NewSyncer(stmgr *statemanger.Stmgr,
 	exchangeClient exchange.Client,
 	c clock.Clock,
 	fork fork.IFork,
+	badBlocks *BadBlockCache,
 ) (*Syncer, error) {
 	if constants.InsecurePoStValidation {
 		logSyncer.Warn("*********************************************************************************************")
@@ -164,7 +165,7 @@ func NewSyncer(stmgr *statemanger.Stmgr,
 
 	syncer := &Syncer{
 		exchangeClient:  exchangeClient,
-		badTipSets:      syncTypes.NewBadTipSetCache(),
+		badBlocks:       badBlocks,
 		blockValidator:  hv,
 		bsstore:         bsstore,
 		chainStore:      s,
@@ -403,12 +404,19 @@ func (syncer *Syncer) fetchChainBlocks(ctx context.Context, knownTip *types.TipS
 		return blockstoreutil.CopyBlockstore(ctx, bs, syncer.bsstore)
 	}
 
+	if err := syncer.checkBadTipSet(targetTip, nil); err != nil {
+		return nil, err
+	}
+
 	untilHeight := knownTip.Height()
 	count := 0
 loop:
 	for chainTipsets[len(chainTipsets)-1].Height() > untilHeight {
 		tipSet, err := syncer.chainStore.GetTipSet(ctx, targetTip.Parents())
 		if err == nil {
+			if err := syncer.checkBadTipSet(tipSet, chainTipsets); err != nil {
+				return nil, err
+			}
 			chainTipsets = append(chainTipsets, tipSet)
 			targetTip = tipSet
 			count++
@@ -440,6 +448,9 @@ loop:
 			if b.Height() < untilHeight {
 				break loop
 			}
+			if err := syncer.checkBadTipSet(b, chainTipsets); err != nil {
+				return nil, err
+			}
 			chainTipsets = append(chainTipsets, b)
 			targetTip = b
 		}
@@ -479,19 +490,22 @@ loop:
 	fork, err := syncer.syncFork(ctx, base, knownTip, ignoreCheckpoint)
 	if err != nil {
 		if errors.Is(err, ErrForkTooLong) {
-			// TODO: we're marking this block bad in the same way that we mark invalid blocks bad. Maybe distinguish?
-			logSyncer.Warn("adding forked chain to our bad tipset cache")
-			/*		for _, b := range incoming.Blocks() {
-					syncer.bad.Add(b.Cid(), NewBadBlockReason(incoming.Cids(), "fork past finality"))
-				}*/
+			// the fork is only too long relative to our head, its blocks aren't invalid for that and aren't
+			// marked bad: a node which was on a minority fork must still be able to sync the canonical chain.
+			logSyncer.Warnf("fork of %s is longer than the threshold, not syncing it", base.Key())
 		}
 		return nil, fmt.Errorf("failed to sync fork: %w", err)
 	}
+	for _, ts := range fork {
+		if err := syncer.checkBadTipSet(ts, chainTipsets); err != nil {
+			return nil, err
+		}
+		chainTipsets = append(chainTipsets, ts)
+	}
 	err = flushDB(fork)
 	if err != nil {
 		return nil, err
 	}
-	chainTipsets = append(chainTipsets, fork...)
 	chain.Reverse(chainTipsets)
 	return chainTipsets, nil
 }
@@ -684,11 +698,13 @@ func (syncer *Syncer) processTipSetSegment(ctx context.Context, target *syncType
 		err := syncer.syncOne(ctx, parent, ts)
 		if err != nil {
 			// While `syncOne` can indeed fail for reasons other than consensus,
-			// adding to the badTipSets at this point is the simplest, since we
+			// adding to the badBlocks at this point is the simplest, since we
 			// have access to the chain. If syncOne fails for non-consensus reasons,
 			// there is no assumption that the running node's data is valid at all,
 			// so we don't really lose anything with this simplification.
-			syncer.badTipSets.AddChain(segTipset[i:])
+			if ctx.Err() == nil {
+				syncer.markBadTipSets(NewBadBlockReason(ts.Cids(), err.Error()), ts, segTipset[i+1:])
+			}
 			return nil, errors.Wrapf(err, "failed to sync tipset %s, number %d of %d in chain", ts.Key().String(), i, len(segTipset))
 		}
 		parent = ts
@@ -697,6 +713,60 @@ func (syncer *Syncer) processTipSetSegment(ctx context.Context, target *syncType
 	return parent, nil
 }
 
+// checkBadTipSet returns ErrChainHasBadTipSet if a block of ts is bad, the blocks of the descendants of
+// ts are then marked bad too.
+func (syncer *Syncer) checkBadTipSet(ts *types.TipSet, descendants []*types.TipSet) error {
+	for _, c := range ts.Cids() {
+		reason, ok := syncer.badBlocks.Has(c)
+		if !ok {
+			continue
+		}
+		for _, d := range descendants {
+			for _, dc := range d.Cids() {
+				syncer.badBlocks.Add(dc, reason.Linked("linked to %s", c))
+			}
+		}
+		return fmt.Errorf("%w: block %s: %s", ErrChainHasBadTipSet, c, reason)
+	}
+	return nil
+}
+
+// markBadTipSets marks the blocks of ts bad with reason and the blocks of its descendants as linked to them.
+func (syncer *Syncer) markBadTipSets(reason BadBlockReason, ts *types.TipSet, descendants []*types.TipSet) {
+	for _, c := range ts.Cids() {
+		syncer.badBlocks.Add(c, reason)
+	}
+	for _, d := range descendants {
+		for _, c := range d.Cids() {
+			syncer.badBlocks.Add(c, reason.Linked("linked to %s", ts.Key()))
+		}
+	}
+}
+
+// MarkBad marks a block bad, the syncer refuses chains including it until it is unmarked.
+func (syncer *Syncer) MarkBad(ctx context.Context, c cid.Cid) error {
+	return syncer.badBlocks.MarkBad(ctx, c, NewBadBlockReason([]cid.Cid{c}, "manually marked bad"))
+}
+
+// UnmarkBad removes a block from the bad block cache.
+func (syncer *Syncer) UnmarkBad(ctx context.Context, c cid.Cid) error {
+	return syncer.badBlocks.Remove(ctx, c)
+}
+
+// UnmarkAllBad purges the bad block cache.
+func (syncer *Syncer) UnmarkAllBad(ctx context.Context) error {
+	return syncer.badBlocks.Purge(ctx)
+}
+
+// CheckBad returns the reason a block is bad, or an empty string if it is not.
+func (syncer *Syncer) CheckBad(c cid.Cid) string {
+	reason, ok := syncer.badBlocks.Has(c)
+	if !ok {
+		return ""
+	}
+	return reason.String()
+}
+
 // Head get latest head from chain store
 func (syncer *Syncer) Head() *types.TipSet {
 	return syncer.chainStore.GetHead()
@@ -299,6 +299,31 @@ func TestRejectFinalityFork(t *testing.T) {
 	assert.Error(t, s.HandleNewTipSet(ctx, forkHeadTarget))
 }
 
+func TestRejectMarkedBadChain(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	builder, s := setup(ctx, t)
+	genesis := builder.Store().GetHead()
+
+	bad := builder.AppendOn(ctx, genesis, 1)
+	head := builder.AppendManyOn(ctx, 2, bad)
+	require.NoError(t, s.MarkBad(ctx, bad.At(0).Cid()))
+
+	target := &syncTypes.Target{Head: head}
+	err := s.HandleNewTipSet(ctx, target)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, syncer.ErrChainHasBadTipSet))
+
+	// the descendants of the bad block are refused too, pointing at the offending ancestor
+	reason := s.CheckBad(head.At(0).Cid())
+	assert.Contains(t, reason, "linked to "+bad.At(0).Cid().String())
+	assert.Contains(t, reason, "manually marked bad")
+
+	require.NoError(t, s.UnmarkAllBad(ctx))
+	assert.Empty(t, s.CheckBad(bad.At(0).Cid()))
+	assert.NoError(t, s.HandleNewTipSet(ctx, &syncTypes.Target{Head: head}))
+}
+
 func TestNoUncessesaryFetch(t *testing.T) {
 	tf.UnitTest(t)
 	ctx := context.Background()
@@ -329,7 +354,8 @@ func TestNoUncessesaryFetch(t *testing.T) {
 		builder.BlockStore(),
 		builder,
 		clock.NewFake(time.Unix(1234567890, 0)),
-		fork.NewMockFork())
+		fork.NewMockFork(),
+		newBadBlockCache(t))
 	require.NoError(t, err)
 
 	target2 := &syncTypes.Target{
@@ -600,7 +626,8 @@ func setupWithValidator(ctx context.Context, t *testing.T, builder *chain.Builde
 		builder.BlockStore(),
 		builder,
 		clock.NewFake(time.Unix(1234567890, 0)),
-		fork.NewMockFork())
+		fork.NewMockFork(),
+		newBadBlockCache(t))
 	require.NoError(t, err)
 
 	return builder, syncer
@@ -12,6 +12,7 @@ import (
 
 	"github.com/filecoin-project/go-state-types/network"
 	"github.com/ipfs/go-cid"
+	ma "github.com/multiformats/go-multiaddr"
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
@@ -44,6 +45,7 @@ type Config struct {
 	EventsConfig  *EventsConfig        `json:"events"`
 	PubsubConfig  *PubsubConfig        `json:"pubsub"`
 	FaultReporter *FaultReporterConfig `json:"faultReporter"`
+	ChainIndexer  *ChainIndexerConfig  `json:"chainIndexer"`
 }
 
 // APIConfig holds all configuration options related to the api.
@@ -82,6 +84,53 @@ func newDefaultAPIConfig() *APIConfig {
 type DatastoreConfig struct {
 	Type string `json:"type"`
 	Path string `json:"path"`
+
+	Splitstore       SplitstoreConfig       `json:"splitstore"`
+	ArchivalFallback ArchivalFallbackConfig `json:"archivalFallback"`
+}
+
+const (
+	// SplitstoreHotOnly prunes unreachable objects in place, the repo blockstore is the only store.
+	SplitstoreHotOnly = "hot-only"
+	// SplitstoreKeepCold keeps recent objects in a separate hot store and moves the rest to the repo blockstore.
+	SplitstoreKeepCold = "keep-cold"
+	// SplitstoreDiscard keeps recent objects in a separate hot store and deletes the rest,
+	// objects already in the repo blockstore stay readable but nothing new is written there.
+	SplitstoreDiscard = "discard"
+)
+
+// SplitstoreConfig holds the configuration of the hot/cold splitstore.
+type SplitstoreConfig struct {
+	// Enable turns on the splitstore and the automatic chain pruning.
+	Enable bool `json:"enable"`
+	// Mode is one of hot-only, keep-cold or discard.
+	Mode string `json:"mode"`
+	// HotStorePath is the path of the hot store, relative to the repo. It is not used in hot-only mode.
+	HotStorePath string `json:"hotStorePath"`
+	// RetainFinalities is the number of finality windows of state and messages kept in the hot store.
+	RetainFinalities int64 `json:"retainFinalities"`
+	// CompactionFinalities is the number of finality windows the head has to advance before an automatic
+	// compaction is started. Set to 0 to only compact on demand.
+	CompactionFinalities int64 `json:"compactionFinalities"`
+}
+
+const (
+	// ArchivalFallbackVenus fetches the missing blocks from the blockstore endpoint of another venus node.
+	ArchivalFallbackVenus = "venus"
+	// ArchivalFallbackBitswap fetches the missing blocks from a bitswap peer.
+	ArchivalFallbackBitswap = "bitswap"
+)
+
+// ArchivalFallbackConfig holds where the state missing from the blockstore, e.g. pruned by the splitstore, is
+// fetched from to serve the queries of historical state.
+type ArchivalFallbackConfig struct {
+	// Type is venus or bitswap, the fallback is disabled when it's empty.
+	Type string `json:"type"`
+	// Upstream is the API info of the venus node, token:multiaddr, or the p2p multiaddr of the bitswap peer.
+	Upstream string `json:"upstream"`
+	// CacheSize is the number of fetched blocks kept in memory, the fetched blocks are never written to the
+	// repo blockstore.
+	CacheSize int `json:"cacheSize"`
 }
 
 // Validators hold the list of validation functions for each configuration
@@ -90,13 +139,41 @@ type DatastoreConfig struct {
 // the given key and value are valid. Validators will only be run if a property
 // being set matches the name given in this map.
 var Validators = map[string]func(string, string) error{
-	"heartbeat.nickname": validateLettersOnly,
+	"heartbeat.nickname":                   validateLettersOnly,
+	"datastore.splitstore.mode":            validateSplitstoreMode,
+	"datastore.archivalFallback.type":      validateArchivalFallbackType,
+	"bootstrap.period":                     validateDuration,
+	"swarm.address":                        validateMultiaddr,
+	"swarm.resourceMgrMaxMemory":           validateNonNegative,
+	"swarm.resourceMgrMaxFD":               validateNonNegative,
+	"swarm.connMgrGrace":                   validateDuration,
+	"mpool.maxFee":                         validateFIL,
+	"mpool.snapshotInterval":               validateDuration,
+	"mpool.feeBump.stuckEpochs":            validateNonNegative,
+	"mpool.feeBump.maxBumps":               validateNonNegative,
+	"fevm.ethTxHashMappingLifetimeDays":    validateNonNegative,
+	"fevm.EthBlkCacheSize":                 validateNonNegative,
+	"fevm.event.filterTTL":                 validateDuration,
+	"fevm.event.maxFilters":                validateNonNegative,
+	"fevm.event.maxFilterResults":          validateNonNegative,
+	"chainIndexer.gcRetentionEpochs":       validateNonNegative,
+	"datastore.archivalFallback.cacheSize": validatePositive,
 }
 
 func newDefaultDatastoreConfig() *DatastoreConfig {
 	return &DatastoreConfig{
 		Type: "badgerds",
 		Path: "badger",
+		Splitstore: SplitstoreConfig{
+			Enable:               false,
+			Mode:                 SplitstoreKeepCold,
+			HotStorePath:         "splitstore",
+			RetainFinalities:     2,
+			CompactionFinalities: 1,
+		},
+		ArchivalFallback: ArchivalFallbackConfig{
+			CacheSize: 100000,
+		},
 	}
 }
 
@@ -118,6 +195,16 @@ type SwarmConfig struct {
 	// ConnMgrGrace is a time duration that new connections are immune from being
 	// closed by the connection manager.
 	ConnMgrGrace Duration `json:"connMgrGrace"`
+
+	// ResourceMgr enables the libp2p resource manager, it limits the memory, file descriptors, streams
+	// and connections used by libp2p in the system, per service, per protocol and per peer.
+	ResourceMgr bool `json:"resourceMgr"`
+	// ResourceMgrMaxMemory is the memory in bytes the default limits are scaled to, 0 uses 1/8 of the
+	// system memory.
+	ResourceMgrMaxMemory int64 `json:"resourceMgrMaxMemory"`
+	// ResourceMgrMaxFD is the number of file descriptors the default limits are scaled to, 0 uses half
+	// of the file descriptor limit of the process.
+	ResourceMgrMaxFD int `json:"resourceMgrMaxFD"`
 }
 
 func newDefaultSwarmConfig() *SwarmConfig {
@@ -126,6 +213,7 @@ func newDefaultSwarmConfig() *SwarmConfig {
 		ConnMgrLow:   150,
 		ConnMgrHigh:  180,
 		ConnMgrGrace: Duration(20 * time.Second),
+		ResourceMgr:  true,
 	}
 }
 
@@ -274,17 +362,54 @@ type MessagePoolConfig struct {
 	MaxNonceGap uint64 `json:"maxNonceGap"`
 	// MaxFee
 	MaxFee types.FIL `json:"maxFee"`
+	// AddressFees overrides the fee limits of the messages sent from some addresses
+	AddressFees []AddressFeeConfig `json:"addressFees,omitempty"`
+	// FeeBump replaces the local messages stuck in the message pool with higher fees
+	FeeBump FeeBumpConfig `json:"feeBump"`
+	// SnapshotInterval is how often the changes of the pending remote messages are saved, they are also saved
+	// on shutdown and verified again when loaded back on startup, 0 disables the snapshots
+	SnapshotInterval Duration `json:"snapshotInterval"`
+}
+
+// FeeBumpConfig holds the options of the replacement of stuck local messages, the fees of the
+// replacements are capped by the max fee of the sender.
+type FeeBumpConfig struct {
+	Enable bool `json:"enable"`
+	// StuckEpochs is the number of epochs a message stays pending before its fee is bumped, the fee is
+	// also bumped when the base fee rises above the fee cap of the message.
+	StuckEpochs abi.ChainEpoch `json:"stuckEpochs"`
+	// MaxBumps is the maximum number of times the fee of a message is bumped
+	MaxBumps int `json:"maxBumps"`
+}
+
+// AddressFeeConfig holds the fee limits of the messages sent from an address, they apply when the
+// address or the key address of an ID sender matches.
+type AddressFeeConfig struct {
+	Address address.Address `json:"address"`
+	// MaxFee replaces the default max fee of MessagePoolConfig, the max fee of a MessageSendSpec still wins
+	MaxFee types.FIL `json:"maxFee"`
+	// MaxPremium caps the gas premium in attoFIL per unit of gas, 0 means no cap
+	MaxPremium types.BigInt `json:"maxPremium"`
 }
 
 var DefaultMessagePoolParam = &MessagePoolConfig{
-	MaxNonceGap: 100,
-	MaxFee:      DefaultDefaultMaxFee,
+	MaxNonceGap:      100,
+	MaxFee:           DefaultDefaultMaxFee,
+	FeeBump:          defaultFeeBumpConfig,
+	SnapshotInterval: Duration(time.Minute),
+}
+
+var defaultFeeBumpConfig = FeeBumpConfig{
+	StuckEpochs: 20,
+	MaxBumps:    5,
 }
 
 func newDefaultMessagePoolConfig() *MessagePoolConfig {
 	return &MessagePoolConfig{
-		MaxNonceGap: 100,
-		MaxFee:      DefaultDefaultMaxFee,
+		MaxNonceGap:      100,
+		MaxFee:           DefaultDefaultMaxFee,
+		FeeBump:          defaultFeeBumpConfig,
+		SnapshotInterval: Duration(time.Minute),
 	}
 }
 
@@ -474,12 +599,27 @@ type EventConfig struct {
 	// relative to the CWD (current working directory).
 	DatabasePath string `json:"databasePath"`
 
+	// FilterStore is where the filters installed by eth_newFilter, eth_newBlockFilter and
+	// eth_newPendingTransactionFilter are kept.
+	FilterStore FilterStoreConfig `json:"filterStore"`
+
 	// Others, not implemented yet:
 	// Set a limit on the number of active websocket subscriptions (may be zero)
 	// Set a timeout for subscription clients
 	// Set upper bound on index size
 }
 
+type FilterStoreConfig struct {
+	// Type is one of:
+	//  - memory: the filters are lost on restart.
+	//  - sqlite: the filters are persisted in a sqlite database and survive restarts.
+	//  - mysql: the filters are persisted in a MySQL database, shared by the nodes behind a load balancer.
+	Type string `json:"type"`
+	// DatabasePath is the path of the sqlite database, filters.db in the sqlite directory of the repo by default.
+	DatabasePath string      `json:"databasePath"`
+	MySQL        MySQLConfig `json:"mysql"`
+}
+
 type FevmConfig struct {
 	//EnableEthRPC enables eth_rpc, and enables storing a mapping of eth transaction hashes to filecoin message Cids.
 	EnableEthRPC bool `json:"enableEthRPC"`
@@ -525,6 +665,9 @@ func newFevmConfig() *FevmConfig {
 			MaxFilters:               100,
 			MaxFilterResults:         10000,
 			MaxFilterHeightRange:     2880, // conservative limit of one day
+			FilterStore: FilterStoreConfig{
+				Type: "memory",
+			},
 		},
 	}
 }
@@ -569,6 +712,32 @@ func newFaultReporterConfig() *FaultReporterConfig {
 	return &FaultReporterConfig{}
 }
 
+// ChainIndexerConfig holds the configuration of the persistent chain index, which maps
+// messages to the tipsets including them and eth transaction hashes to message cids.
+// Actor events are not part of it, they stay indexed by the event index of the fevm config.
+type ChainIndexerConfig struct {
+	// EnableIndexer maintains the index from chain head changes and lets message
+	// searches and eth transaction lookups consult it first. The eth transaction hash
+	// database then only records the pending messages.
+	EnableIndexer bool `json:"enableIndexer"`
+
+	// GCRetentionEpochs is the number of epochs kept in the index, older entries are
+	// removed periodically. 0 keeps every entry.
+	GCRetentionEpochs int64 `json:"gcRetentionEpochs"`
+
+	// MaxReconcileTipsets is the maximum number of tipsets walked back from the head at
+	// startup to index the tipsets applied while the node was offline.
+	MaxReconcileTipsets uint64 `json:"maxReconcileTipsets"`
+}
+
+func newChainIndexerConfig() *ChainIndexerConfig {
+	return &ChainIndexerConfig{
+		EnableIndexer:       false,
+		GCRetentionEpochs:   0,
+		MaxReconcileTipsets: 3 * 2880,
+	}
+}
+
 // NewDefaultConfig returns a config object with all the fields filled out to
 // their default values
 func NewDefaultConfig() *Config {
@@ -587,6 +756,7 @@ func NewDefaultConfig() *Config {
 		EventsConfig:  newEventsConfig(),
 		PubsubConfig:  newPubsubConfig(),
 		FaultReporter: newFaultReporterConfig(),
+		ChainIndexer:  newChainIndexerConfig(),
 	}
 }
 
@@ -656,6 +826,15 @@ func (cfg *Config) Set(dottedKey string, jsonString string) error {
 
 // Get gets the config sub-struct referenced by `key`, e.g. 'api.address'
 func (cfg *Config) Get(key string) (interface{}, error) {
+	v, err := cfg.field(key)
+	if err != nil {
+		return nil, err
+	}
+	return v.Interface(), nil
+}
+
+// field returns the settable value of the field referenced by `key`
+func (cfg *Config) field(key string) (reflect.Value, error) {
 	v := reflect.Indirect(reflect.ValueOf(cfg))
 	keyTags := strings.Split(key, ".")
 OUTER:
@@ -668,7 +847,7 @@ OUTER:
 				if jsonTag == keyTag {
 					v = v.Field(i)
 					if j == len(keyTags)-1 {
-						return v.Interface(), nil
+						return v, nil
 					}
 					v = reflect.Indirect(v) // only attempt one dereference
 					continue OUTER
@@ -676,10 +855,10 @@ OUTER:
 			}
 		}
 
-		return nil, fmt.Errorf("key: %s invalid for config", key)
+		return reflect.Value{}, fmt.Errorf("key: %s invalid for config", key)
 	}
 	// Cannot get here as len(strings.Split(s, sep)) >= 1 with non-empty sep
-	return nil, fmt.Errorf("empty key is invalid")
+	return reflect.Value{}, fmt.Errorf("empty key is invalid")
 }
 
 // validate runs validations on a given key and json string. validate uses the
@@ -720,6 +899,115 @@ func validateLettersOnly(key string, value string) error {
 	return nil
 }
 
+// validateSplitstoreMode validates that a given value is a known splitstore mode.
+func validateSplitstoreMode(key string, value string) error {
+	var mode string
+	if err := json.Unmarshal([]byte(value), &mode); err != nil {
+		return err
+	}
+	switch mode {
+	case SplitstoreHotOnly, SplitstoreKeepCold, SplitstoreDiscard:
+		return nil
+	default:
+		return errors.Errorf(`"%s" must be one of %s, %s or %s`, key, SplitstoreHotOnly, SplitstoreKeepCold, SplitstoreDiscard)
+	}
+}
+
+func validateArchivalFallbackType(key string, value string) error {
+	var typ string
+	if err := json.Unmarshal([]byte(value), &typ); err != nil {
+		return err
+	}
+	switch typ {
+	case "", ArchivalFallbackVenus, ArchivalFallbackBitswap:
+		return nil
+	default:
+		return errors.Errorf(`"%s" must be empty, %s or %s`, key, ArchivalFallbackVenus, ArchivalFallbackBitswap)
+	}
+}
+
+// validateDuration validates that a given value is a non negative duration, e.g. "30s".
+func validateDuration(key string, value string) error {
+	var s string
+	if err := json.Unmarshal([]byte(value), &s); err != nil {
+		return err
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return errors.Errorf(`"%s" must be a duration: %v`, key, err)
+	}
+	if d < 0 {
+		return errors.Errorf(`"%s" must not be negative`, key)
+	}
+	return nil
+}
+
+// validateNonNegative validates that a given value is a number not less than zero.
+func validateNonNegative(key string, value string) error {
+	var n json.Number
+	if err := json.Unmarshal([]byte(value), &n); err != nil {
+		return errors.Errorf(`"%s" must be a number`, key)
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return errors.Errorf(`"%s" must be a number: %v`, key, err)
+	}
+	if f < 0 {
+		return errors.Errorf(`"%s" must not be negative`, key)
+	}
+	return nil
+}
+
+// validatePositive validates that a given value is a number greater than zero.
+func validatePositive(key string, value string) error {
+	var n json.Number
+	if err := json.Unmarshal([]byte(value), &n); err != nil {
+		return errors.Errorf(`"%s" must be a number`, key)
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return errors.Errorf(`"%s" must be a number: %v`, key, err)
+	}
+	if f <= 0 {
+		return errors.Errorf(`"%s" must be positive`, key)
+	}
+	return nil
+}
+
+// validateFIL validates that a given value is a non negative amount of FIL, e.g. "0.5 FIL".
+func validateFIL(key string, value string) error {
+	var fil types.FIL
+	if err := json.Unmarshal([]byte(value), &fil); err != nil {
+		return errors.Errorf(`"%s" must be an amount of FIL: %v`, key, err)
+	}
+	if fil.Int != nil && fil.Int.Sign() < 0 {
+		return errors.Errorf(`"%s" must not be negative`, key)
+	}
+	return nil
+}
+
+// validateMultiaddr validates that a given value is a multiaddr.
+func validateMultiaddr(key string, value string) error {
+	var s string
+	if err := json.Unmarshal([]byte(value), &s); err != nil {
+		return err
+	}
+	if _, err := ma.NewMultiaddr(s); err != nil {
+		return errors.Errorf(`"%s" must be a multiaddr: %v`, key, err)
+	}
+	return nil
+}
+
+// Validate checks the constraints between the fields of the config, the single fields are checked by the
+// Validators when they are set.
+func (cfg *Config) Validate() error {
+	if cfg.Swarm != nil && cfg.Swarm.ConnMgrLow > cfg.Swarm.ConnMgrHigh {
+		return errors.Errorf(`"swarm.connMgrLow" %d must not be greater than "swarm.connMgrHigh" %d`,
+			cfg.Swarm.ConnMgrLow, cfg.Swarm.ConnMgrHigh)
+	}
+	return nil
+}
+
 var (
 	_ json.Marshaler   = (*Duration)(nil)
 	_ json.Unmarshaler = (*Duration)(nil)
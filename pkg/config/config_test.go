@@ -252,6 +252,62 @@ path = "mushroom-mushroom"}`
 	})
 }
 
+func TestConfigValidators(t *testing.T) {
+	tf.UnitTest(t)
+
+	cfg := NewDefaultConfig()
+	for key, value := range map[string]string{
+		"mpool.maxFee":                `"0.5 FIL"`,
+		"mpool.snapshotInterval":      `"10m"`,
+		"swarm.address":               `"/ip4/0.0.0.0/tcp/1347"`,
+		"fevm.event.maxFilterResults": `100`,
+		"fevm.EthBlkCacheSize":        `0`,
+	} {
+		assert.NoError(t, cfg.Set(key, value), key)
+	}
+
+	for key, value := range map[string]string{
+		"mpool.maxFee":                `"-1 FIL"`,
+		"mpool.snapshotInterval":      `"-10m"`,
+		"bootstrap.period":            `"one minute"`,
+		"swarm.address":               `"0.0.0.0:1347"`,
+		"fevm.event.maxFilterResults": `-1`,
+		"fevm.EthBlkCacheSize":        `-500`,
+	} {
+		assert.Error(t, cfg.Set(key, value), key)
+	}
+
+	// the sub-keys of a table are validated too
+	assert.Error(t, cfg.Set("fevm.event", `{"maxFilters": -1}`))
+
+	cfg.Swarm.ConnMgrLow = cfg.Swarm.ConnMgrHigh + 1
+	assert.Error(t, cfg.Validate())
+}
+
+func TestConfigFields(t *testing.T) {
+	tf.UnitTest(t)
+
+	cfg := NewDefaultConfig()
+	before, err := cfg.Fields()
+	require.NoError(t, err)
+	assert.Equal(t, `"1m"`, string(before["bootstrap.period"]))
+	assert.Equal(t, `false`, string(before["fevm.event.disableRealTimeFilterAPI"]))
+
+	next, err := cfg.Clone()
+	require.NoError(t, err)
+	assert.Equal(t, cfg, next)
+
+	require.NoError(t, next.Set("mpool", `{"maxFee": "1 FIL", "feeBump": {"enable": true}}`))
+	require.NoError(t, next.Set("bootstrap.addresses", `["/ip4/127.0.0.1/tcp/1347"]`))
+	after, err := next.Fields()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bootstrap.addresses", "mpool.feeBump.enable", "mpool.maxFee"}, DiffFields(before, after))
+	assert.Empty(t, DiffFields(after, after))
+
+	// the original is untouched
+	assert.Equal(t, DefaultDefaultMaxFee, cfg.Mpool.MaxFee)
+}
+
 func createConfigFile(t *testing.T, content string) (string, error) {
 	cfgpath := filepath.Join(t.TempDir(), "config.json")
 
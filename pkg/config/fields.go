@@ -0,0 +1,126 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// Clone returns a deep copy of the fields of the config file, the fields which aren't saved to the file,
+// e.g. the network parameters set at startup, are shared with the config.
+func (cfg *Config) Clone() (*Config, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	out := &Config{}
+	if err := json.Unmarshal(data, out); err != nil {
+		return nil, err
+	}
+
+	src, dst := reflect.ValueOf(cfg).Elem(), reflect.ValueOf(out).Elem()
+	for i := 0; i < src.NumField(); i++ {
+		from, to := src.Field(i), dst.Field(i)
+		if from.Kind() != reflect.Ptr || from.IsNil() || to.IsNil() || from.Elem().Kind() != reflect.Struct {
+			continue
+		}
+		from, to = from.Elem(), to.Elem()
+		for j := 0; j < from.NumField(); j++ {
+			if from.Type().Field(j).Tag.Get("json") == "-" {
+				to.Field(j).Set(from.Field(j))
+			}
+		}
+	}
+	return out, nil
+}
+
+// CopyField sets the field referenced by key, e.g. 'mpool.maxFee', to a deep copy of its value in src. Unlike
+// Set, the maps and slices are replaced rather than merged.
+func (cfg *Config) CopyField(src *Config, key string) error {
+	from, err := src.field(key)
+	if err != nil {
+		return err
+	}
+	to, err := cfg.field(key)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(from.Interface())
+	if err != nil {
+		return err
+	}
+	val := reflect.New(to.Type())
+	if err := json.Unmarshal(data, val.Interface()); err != nil {
+		return errors.Wrapf(err, "decode %s", key)
+	}
+	to.Set(val.Elem())
+	return nil
+}
+
+// Fields returns the json values of the fields of the config by their dotted keys, the ones Set and Get
+// take, e.g. 'mpool.maxFee'. The fields of the nested objects are listed one by one while an array is a
+// single field.
+func (cfg *Config) Fields() (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err := flattenFields("", obj, fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func flattenFields(prefix string, obj map[string]json.RawMessage, fields map[string]json.RawMessage) error {
+	for key, value := range obj {
+		if len(prefix) > 0 {
+			key = prefix + "." + key
+		}
+
+		value = bytes.TrimSpace(value)
+		if len(value) == 0 || value[0] != '{' {
+			fields[key] = value
+			continue
+		}
+		var sub map[string]json.RawMessage
+		if err := json.Unmarshal(value, &sub); err != nil {
+			return errors.Wrapf(err, "decode %s", key)
+		}
+		if len(sub) == 0 {
+			fields[key] = value
+			continue
+		}
+		if err := flattenFields(key, sub, fields); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DiffFields returns the sorted dotted keys of the fields which differ between a and b, including the ones
+// only one of them holds.
+func DiffFields(a, b map[string]json.RawMessage) []string {
+	var keys []string
+	for key, va := range a {
+		if vb, ok := b[key]; !ok || !bytes.Equal(va, vb) {
+			keys = append(keys, key)
+		}
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
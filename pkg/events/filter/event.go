@@ -97,6 +97,7 @@ func (f *eventFilter) CollectEvents(ctx context.Context, te *TipSetEvents, rever
 	}
 
 	eventCount := 0
+	var cevs []*CollectedEvent
 
 	for msgIdx, em := range ems {
 		for _, ev := range em.Events() {
@@ -112,6 +113,11 @@ func (f *eventFilter) CollectEvents(ctx context.Context, te *TipSetEvents, rever
 				addressLookups[ev.Emitter] = addr
 			}
 
+			// count the events the same way the event index does, so that an event has the same index
+			// whether it was collected live or loaded from the index
+			eventIdx := eventCount
+			eventCount++
+
 			if !f.matchAddress(addr) {
 				continue
 			}
@@ -120,33 +126,42 @@ func (f *eventFilter) CollectEvents(ctx context.Context, te *TipSetEvents, rever
 			}
 
 			// event matches filter, so record it
-			cev := &CollectedEvent{
+			cevs = append(cevs, &CollectedEvent{
 				Entries:     ev.Entries,
 				EmitterAddr: addr,
-				EventIdx:    eventCount,
+				EventIdx:    eventIdx,
 				Reverted:    revert,
 				Height:      te.msgTS.Height(),
 				TipSetKey:   te.msgTS.Key(),
 				MsgCid:      em.Message().Cid(),
 				MsgIdx:      msgIdx,
-			}
+			})
+		}
+	}
 
-			f.mu.Lock()
-			// if we have a subscription channel then push event to it
-			if f.ch != nil {
-				f.ch <- cev
-				f.mu.Unlock()
-				continue
-			}
+	// the events of a reverted tipset are reverted from the last one, undoing them in the reverse order
+	// they were applied in
+	if revert {
+		for i, j := 0, len(cevs)-1; i < j; i, j = i+1, j-1 {
+			cevs[i], cevs[j] = cevs[j], cevs[i]
+		}
+	}
 
-			if f.maxResults > 0 && len(f.collected) == f.maxResults {
-				copy(f.collected, f.collected[1:])
-				f.collected = f.collected[:len(f.collected)-1]
-			}
-			f.collected = append(f.collected, cev)
+	for _, cev := range cevs {
+		f.mu.Lock()
+		// if we have a subscription channel then push event to it
+		if f.ch != nil {
+			f.ch <- cev
 			f.mu.Unlock()
-			eventCount++
+			continue
 		}
+
+		if f.maxResults > 0 && len(f.collected) == f.maxResults {
+			copy(f.collected, f.collected[1:])
+			f.collected = f.collected[:len(f.collected)-1]
+		}
+		f.collected = append(f.collected, cev)
+		f.mu.Unlock()
 	}
 
 	return nil
@@ -379,6 +394,22 @@ func (m *EventFilterManager) Revert(ctx context.Context, from, to *types.TipSet)
 }
 
 func (m *EventFilterManager) Install(ctx context.Context, minHeight, maxHeight abi.ChainEpoch, tipsetCid cid.Cid, addresses []address.Address,
+	keysWithCodec map[string][]types.ActorEventBlock, excludeReverted bool) (EventFilter, error) {
+	id, err := newFilterID()
+	if err != nil {
+		return nil, fmt.Errorf("new filter id: %w", err)
+	}
+
+	return m.install(ctx, id, minHeight, maxHeight, tipsetCid, addresses, keysWithCodec, excludeReverted)
+}
+
+// Restore installs again the filter id, which was installed before on this node or another one, see Install.
+func (m *EventFilterManager) Restore(ctx context.Context, id types.FilterID, minHeight, maxHeight abi.ChainEpoch, tipsetCid cid.Cid, addresses []address.Address,
+	keysWithCodec map[string][]types.ActorEventBlock, excludeReverted bool) (EventFilter, error) {
+	return m.install(ctx, id, minHeight, maxHeight, tipsetCid, addresses, keysWithCodec, excludeReverted)
+}
+
+func (m *EventFilterManager) install(ctx context.Context, id types.FilterID, minHeight, maxHeight abi.ChainEpoch, tipsetCid cid.Cid, addresses []address.Address,
 	keysWithCodec map[string][]types.ActorEventBlock, excludeReverted bool) (EventFilter, error) {
 	m.mu.Lock()
 	if m.currentHeight == 0 {
@@ -392,11 +423,6 @@ func (m *EventFilterManager) Install(ctx context.Context, minHeight, maxHeight a
 		return nil, fmt.Errorf("historic event index disabled")
 	}
 
-	id, err := newFilterID()
-	if err != nil {
-		return nil, fmt.Errorf("new filter id: %w", err)
-	}
-
 	f := &eventFilter{
 		id:            id,
 		minHeight:     minHeight,
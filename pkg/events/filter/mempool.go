@@ -114,6 +114,16 @@ func (m *MemPoolFilterManager) Install(ctx context.Context) (*MemPoolFilter, err
 		return nil, fmt.Errorf("new filter id: %w", err)
 	}
 
+	return m.install(id), nil
+}
+
+// Restore installs again the filter id, which was installed before on this node or another one. The messages
+// added to the message pool in the meantime are lost.
+func (m *MemPoolFilterManager) Restore(ctx context.Context, id types.FilterID) (*MemPoolFilter, error) {
+	return m.install(id), nil
+}
+
+func (m *MemPoolFilterManager) install(id types.FilterID) *MemPoolFilter {
 	f := &MemPoolFilter{
 		id:         id,
 		maxResults: m.MaxFilterResults,
@@ -126,7 +136,7 @@ func (m *MemPoolFilterManager) Install(ctx context.Context) (*MemPoolFilter, err
 	m.filters[id] = f
 	m.mu.Unlock()
 
-	return f, nil
+	return f
 }
 
 func (m *MemPoolFilterManager) Remove(ctx context.Context, id types.FilterID) error {
@@ -0,0 +1,330 @@
+package filter
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// the statements only use the SQL shared by sqlite and MySQL
+const (
+	filterTableDdl = `CREATE TABLE IF NOT EXISTS eth_filter (
+	id VARCHAR(64) NOT NULL PRIMARY KEY,
+	kind VARCHAR(16) NOT NULL,
+	spec TEXT NOT NULL,
+	cursor_height BIGINT NOT NULL,
+	last_taken BIGINT NOT NULL
+)`
+	countFilters     = `SELECT COUNT(*) FROM eth_filter`
+	insertFilter     = `INSERT INTO eth_filter (id, kind, spec, cursor_height, last_taken) VALUES (?, ?, ?, ?, ?)`
+	selectFilter     = `SELECT kind, spec, cursor_height FROM eth_filter WHERE id = ?`
+	updateCursor     = `UPDATE eth_filter SET cursor_height = ?, last_taken = ? WHERE id = ?`
+	deleteFilter     = `DELETE FROM eth_filter WHERE id = ?`
+	selectNotTaken   = `SELECT id FROM eth_filter WHERE last_taken < ?`
+	filterKindEvent  = "event"
+	filterKindTipSet = "tipset"
+	filterKindMpool  = "mempool"
+)
+
+// ChainReader reads the tipsets missed by the tipset filters while they weren't installed
+type ChainReader interface {
+	GetHead() *types.TipSet
+	GetTipSet(context.Context, types.TipSetKey) (*types.TipSet, error)
+}
+
+// eventFilterSpec is the persisted spec of an event filter
+type eventFilterSpec struct {
+	MinHeight       abi.ChainEpoch                     `json:"minHeight"`
+	MaxHeight       abi.ChainEpoch                     `json:"maxHeight"`
+	TipSetCid       *cid.Cid                           `json:"tipsetCid,omitempty"`
+	Addresses       []address.Address                  `json:"addresses"`
+	KeysWithCodec   map[string][]types.ActorEventBlock `json:"keysWithCodec"`
+	ExcludeReverted bool                               `json:"excludeReverted"`
+}
+
+type liveFilter struct {
+	filter Filter
+	cursor abi.ChainEpoch
+}
+
+// sqlFilterStore persists the filters in a SQL database, so that they survive restarts and can be polled
+// through any node sharing the database.
+//
+// A filter keeps collecting results on the node it is live on. The cursor of a filter is the height of the
+// head when it was last polled: the tipsets up to the cursor and the events of the messages below the cursor
+// were taken. A filter which isn't live on the node, or which was polled through another node since, is
+// installed again from its record and catches up from its cursor, the events from the event index and the
+// tipsets from the chain. The messages added to the message pool in the meantime are lost.
+type sqlFilterStore struct {
+	db      *sql.DB
+	max     int
+	chain   ChainReader
+	events  *EventFilterManager
+	tipsets *TipSetFilterManager
+	mpool   *MemPoolFilterManager
+
+	mu   sync.Mutex
+	live map[types.FilterID]*liveFilter
+}
+
+var _ FilterStore = (*sqlFilterStore)(nil)
+
+// NewSQLFilterStore returns a FilterStore persisting the filters in db, a sqlite or MySQL database, the filters
+// are installed again with the managers.
+func NewSQLFilterStore(ctx context.Context, db *sql.DB, maxFilters int, chain ChainReader, events *EventFilterManager,
+	tipsets *TipSetFilterManager, mpool *MemPoolFilterManager) (FilterStore, error) {
+	if _, err := db.ExecContext(ctx, filterTableDdl); err != nil {
+		return nil, fmt.Errorf("create filter table: %w", err)
+	}
+	if events.EventIndex == nil {
+		log.Warn("the historic event index is disabled, the event filters can't be restored")
+	}
+
+	return &sqlFilterStore{
+		db:      db,
+		max:     maxFilters,
+		chain:   chain,
+		events:  events,
+		tipsets: tipsets,
+		mpool:   mpool,
+		live:    make(map[types.FilterID]*liveFilter),
+	}, nil
+}
+
+func filterKey(id types.FilterID) string {
+	return hex.EncodeToString(id[:])
+}
+
+func (s *sqlFilterStore) Add(ctx context.Context, f Filter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.live[f.ID()]; exists {
+		return ErrFilterAlreadyRegistered
+	}
+	var count int
+	if err := s.db.QueryRowContext(ctx, countFilters).Scan(&count); err != nil {
+		return fmt.Errorf("count filters: %w", err)
+	}
+	if count >= s.max {
+		return ErrMaximumNumberOfFilters
+	}
+
+	var (
+		kind   string
+		spec   = []byte("{}")
+		cursor abi.ChainEpoch
+	)
+	switch f := f.(type) {
+	case *eventFilter:
+		kind = filterKindEvent
+		es := eventFilterSpec{
+			MinHeight:       f.minHeight,
+			MaxHeight:       f.maxHeight,
+			Addresses:       f.addresses,
+			KeysWithCodec:   f.keysWithCodec,
+			ExcludeReverted: true,
+		}
+		cursor = f.minHeight
+		if f.tipsetCid.Defined() {
+			es.TipSetCid = &f.tipsetCid
+			// the filter wasn't polled yet
+			cursor = -1
+		}
+		var err error
+		if spec, err = json.Marshal(es); err != nil {
+			return err
+		}
+	case *TipSetFilter:
+		kind = filterKindTipSet
+		cursor = s.chain.GetHead().Height()
+	case *MemPoolFilter:
+		kind = filterKindMpool
+	default:
+		return fmt.Errorf("unknown filter type %T", f)
+	}
+
+	if _, err := s.db.ExecContext(ctx, insertFilter, filterKey(f.ID()), kind, string(spec), int64(cursor), time.Now().Unix()); err != nil {
+		return fmt.Errorf("insert filter: %w", err)
+	}
+	s.live[f.ID()] = &liveFilter{filter: f, cursor: cursor}
+	return nil
+}
+
+func (s *sqlFilterStore) Get(ctx context.Context, id types.FilterID) (Filter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		kind   string
+		spec   string
+		cursor int64
+	)
+	err := s.db.QueryRowContext(ctx, selectFilter, filterKey(id)).Scan(&kind, &spec, &cursor)
+	if errors.Is(err, sql.ErrNoRows) {
+		// the filter was removed through another node
+		if lf, ok := s.live[id]; ok {
+			s.uninstall(ctx, lf.filter)
+			delete(s.live, id)
+		}
+		return nil, ErrFilterNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("load filter: %w", err)
+	}
+
+	lf, ok := s.live[id]
+	if ok && lf.cursor == abi.ChainEpoch(cursor) {
+		return lf.filter, nil
+	}
+	if ok {
+		// the filter was polled through another node, its results are out of date
+		s.uninstall(ctx, lf.filter)
+		delete(s.live, id)
+	}
+
+	f, err := s.restore(ctx, id, kind, []byte(spec), abi.ChainEpoch(cursor))
+	if err != nil {
+		return nil, fmt.Errorf("restore filter: %w", err)
+	}
+	s.live[id] = &liveFilter{filter: f, cursor: abi.ChainEpoch(cursor)}
+	return f, nil
+}
+
+func (s *sqlFilterStore) restore(ctx context.Context, id types.FilterID, kind string, spec []byte, cursor abi.ChainEpoch) (Filter, error) {
+	switch kind {
+	case filterKindEvent:
+		var es eventFilterSpec
+		if err := json.Unmarshal(spec, &es); err != nil {
+			return nil, fmt.Errorf("decode spec: %w", err)
+		}
+		tipsetCid := cid.Undef
+		minHeight := es.MinHeight
+		if es.TipSetCid != nil {
+			tipsetCid = *es.TipSetCid
+			if cursor >= 0 {
+				// the events of the tipset were taken already
+				minHeight = -1
+			}
+		} else if cursor > minHeight {
+			minHeight = cursor
+		}
+		return s.events.Restore(ctx, id, minHeight, es.MaxHeight, tipsetCid, es.Addresses, es.KeysWithCodec, es.ExcludeReverted)
+	case filterKindTipSet:
+		var collected []types.TipSetKey
+		ts := s.chain.GetHead()
+		for ts.Height() > cursor {
+			collected = append(collected, ts.Key())
+			if s.tipsets.MaxFilterResults > 0 && len(collected) == s.tipsets.MaxFilterResults {
+				break
+			}
+			var err error
+			if ts, err = s.chain.GetTipSet(ctx, ts.Parents()); err != nil {
+				return nil, fmt.Errorf("load tipset: %w", err)
+			}
+		}
+		for i, j := 0, len(collected)-1; i < j; i, j = i+1, j-1 {
+			collected[i], collected[j] = collected[j], collected[i]
+		}
+		return s.tipsets.Restore(ctx, id, collected)
+	case filterKindMpool:
+		return s.mpool.Restore(ctx, id)
+	}
+	return nil, fmt.Errorf("unknown filter kind %s", kind)
+}
+
+// uninstall removes a filter restored from the store from its manager
+func (s *sqlFilterStore) uninstall(ctx context.Context, f Filter) {
+	var err error
+	switch f.(type) {
+	case *eventFilter:
+		err = s.events.Remove(ctx, f.ID())
+	case *TipSetFilter:
+		err = s.tipsets.Remove(ctx, f.ID())
+	case *MemPoolFilter:
+		err = s.mpool.Remove(ctx, f.ID())
+	}
+	if err != nil && !errors.Is(err, ErrFilterNotFound) {
+		log.Warnf("failed to uninstall filter %s: %s", filterKey(f.ID()), err)
+	}
+}
+
+func (s *sqlFilterStore) MarkTaken(ctx context.Context, id types.FilterID, cursor abi.ChainEpoch) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.db.ExecContext(ctx, updateCursor, int64(cursor), time.Now().Unix(), filterKey(id)); err != nil {
+		return fmt.Errorf("update filter cursor: %w", err)
+	}
+	if lf, ok := s.live[id]; ok {
+		lf.cursor = cursor
+	}
+	return nil
+}
+
+func (s *sqlFilterStore) Remove(ctx context.Context, id types.FilterID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res, err := s.db.ExecContext(ctx, deleteFilter, filterKey(id))
+	if err != nil {
+		return fmt.Errorf("delete filter: %w", err)
+	}
+	_, live := s.live[id]
+	delete(s.live, id)
+	if n, err := res.RowsAffected(); err == nil && n == 0 && !live {
+		return ErrFilterNotFound
+	}
+	return nil
+}
+
+// NotTakenSince returns the live filters not polled through any node since when, the records of the other
+// ones are deleted right away.
+func (s *sqlFilterStore) NotTakenSince(when time.Time) []Filter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx := context.Background()
+	rows, err := s.db.QueryContext(ctx, selectNotTaken, when.Unix())
+	if err != nil {
+		log.Warnf("failed to list the filters not taken since %s: %s", when, err)
+		return nil
+	}
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			log.Warnf("failed to read filter: %s", err)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	_ = rows.Close()
+
+	var res []Filter
+	for _, key := range keys {
+		var id types.FilterID
+		if b, err := hex.DecodeString(key); err == nil {
+			copy(id[:], b)
+		}
+		if lf, ok := s.live[id]; ok {
+			res = append(res, lf.filter)
+			continue
+		}
+		if _, err := s.db.ExecContext(ctx, deleteFilter, key); err != nil {
+			log.Warnf("failed to delete filter %s: %s", key, err)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,150 @@
+package filter
+
+import (
+	"context"
+	pseudo "math/rand"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/events/filter/sqlite"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type fakeChain struct {
+	head    *types.TipSet
+	tipsets map[types.TipSetKey]*types.TipSet
+}
+
+func (c *fakeChain) GetHead() *types.TipSet {
+	return c.head
+}
+
+func (c *fakeChain) GetTipSet(_ context.Context, key types.TipSetKey) (*types.TipSet, error) {
+	return c.tipsets[key], nil
+}
+
+func (c *fakeChain) add(ts *types.TipSet) {
+	c.tipsets[ts.Key()] = ts
+	c.head = ts
+}
+
+type testNode struct {
+	store   FilterStore
+	events  *EventFilterManager
+	tipsets *TipSetFilterManager
+	mpool   *MemPoolFilterManager
+}
+
+// newTestNodes returns two nodes sharing the database of their filter stores
+func newTestNodes(t *testing.T, chain ChainReader, maxFilters int) (*testNode, *testNode) {
+	ctx := context.Background()
+	db, _, err := sqlite.Open(filepath.Join(t.TempDir(), "filters.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	newNode := func() *testNode {
+		n := &testNode{
+			// the event index isn't needed while the filters don't look into the past
+			events:  &EventFilterManager{currentHeight: chain.GetHead().Height()},
+			tipsets: &TipSetFilterManager{},
+			mpool:   &MemPoolFilterManager{},
+		}
+		n.store, err = NewSQLFilterStore(ctx, db, maxFilters, chain, n.events, n.tipsets, n.mpool)
+		require.NoError(t, err)
+		return n
+	}
+	return newNode(), newNode()
+}
+
+func TestSQLFilterStoreEventFilter(t *testing.T) {
+	ctx := context.Background()
+	rng := pseudo.New(pseudo.NewSource(299792458))
+	chain := &fakeChain{tipsets: map[types.TipSetKey]*types.TipSet{}}
+	chain.add(fakeTipSet(t, rng, 10, []cid.Cid{randomCid(t, rng)}))
+	a, b := newTestNodes(t, chain, 10)
+
+	addresses := []address.Address{randomF4Addr(t, rng)}
+	keys := keysToKeysWithCodec(map[string][][]byte{"t1": {randomBytes(32, rng)}})
+	f, err := a.events.Install(ctx, 10, -1, cid.Undef, addresses, keys, true)
+	require.NoError(t, err)
+	require.NoError(t, a.store.Add(ctx, f))
+	require.ErrorIs(t, a.store.Add(ctx, f), ErrFilterAlreadyRegistered)
+
+	got, err := a.store.Get(ctx, f.ID())
+	require.NoError(t, err)
+	require.Same(t, f, got)
+
+	// the filter is installed on the other node from its record
+	restored, err := b.store.Get(ctx, f.ID())
+	require.NoError(t, err)
+	ef := restored.(*eventFilter)
+	require.Equal(t, f.ID(), ef.ID())
+	require.Equal(t, abi.ChainEpoch(10), ef.minHeight)
+	require.Equal(t, abi.ChainEpoch(-1), ef.maxHeight)
+	require.Equal(t, addresses, ef.addresses)
+	require.Equal(t, keys, ef.keysWithCodec)
+	require.Contains(t, b.events.filters, f.ID())
+
+	// polled through the other node, the filter is installed again from the cursor
+	require.NoError(t, b.store.MarkTaken(ctx, f.ID(), 12))
+	got, err = a.store.Get(ctx, f.ID())
+	require.NoError(t, err)
+	require.NotSame(t, f, got)
+	require.Equal(t, abi.ChainEpoch(12), got.(*eventFilter).minHeight)
+
+	// removed through the other node, the filter is uninstalled
+	require.NoError(t, b.store.Remove(ctx, f.ID()))
+	_, err = a.store.Get(ctx, f.ID())
+	require.ErrorIs(t, err, ErrFilterNotFound)
+	require.NotContains(t, a.events.filters, f.ID())
+	require.ErrorIs(t, a.store.Remove(ctx, f.ID()), ErrFilterNotFound)
+}
+
+func TestSQLFilterStoreTipSetFilter(t *testing.T) {
+	ctx := context.Background()
+	rng := pseudo.New(pseudo.NewSource(299792458))
+	chain := &fakeChain{tipsets: map[types.TipSetKey]*types.TipSet{}}
+	chain.add(fakeTipSet(t, rng, 1, []cid.Cid{randomCid(t, rng)}))
+	a, b := newTestNodes(t, chain, 2)
+
+	f, err := a.tipsets.Install(ctx)
+	require.NoError(t, err)
+	require.NoError(t, a.store.Add(ctx, f))
+	mf, err := a.mpool.Install(ctx)
+	require.NoError(t, err)
+	require.NoError(t, a.store.Add(ctx, mf))
+	tf, err := b.tipsets.Install(ctx)
+	require.NoError(t, err)
+	require.ErrorIs(t, b.store.Add(ctx, tf), ErrMaximumNumberOfFilters)
+
+	var keys []types.TipSetKey
+	for h := abi.ChainEpoch(2); h <= 3; h++ {
+		ts := fakeTipSet(t, rng, h, chain.head.Cids())
+		chain.add(ts)
+		keys = append(keys, ts.Key())
+	}
+
+	// the tipsets since the cursor are collected again
+	restored, err := b.store.Get(ctx, f.ID())
+	require.NoError(t, err)
+	require.Equal(t, keys, restored.(*TipSetFilter).TakeCollectedTipSets(ctx))
+	require.NoError(t, b.store.MarkTaken(ctx, f.ID(), 3))
+
+	restored, err = b.store.Get(ctx, mf.ID())
+	require.NoError(t, err)
+	require.IsType(t, &MemPoolFilter{}, restored)
+
+	// the filters not polled since are returned when they are live on the node
+	require.Empty(t, a.store.NotTakenSince(time.Now().Add(-time.Hour)))
+	notTaken := b.store.NotTakenSince(time.Now().Add(time.Hour))
+	require.Len(t, notTaken, 2)
+	_, err = a.store.Get(ctx, f.ID())
+	require.NoError(t, err)
+	require.Len(t, a.store.NotTakenSince(time.Now().Add(time.Hour)), 2)
+}
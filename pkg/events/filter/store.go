@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/venus/venus-shared/types"
 	"github.com/google/uuid"
 )
@@ -23,6 +24,8 @@ type FilterStore interface {
 	Get(context.Context, types.FilterID) (Filter, error)
 	Remove(context.Context, types.FilterID) error
 	NotTakenSince(when time.Time) []Filter // returns a list of filters that have not had their collected results taken
+	// MarkTaken records that the results collected by the filter were taken, up to the head at height cursor
+	MarkTaken(ctx context.Context, id types.FilterID, cursor abi.ChainEpoch) error
 }
 
 var (
@@ -105,3 +108,8 @@ func (m *memFilterStore) NotTakenSince(when time.Time) []Filter {
 
 	return res
 }
+
+// MarkTaken does nothing, the filters of the store don't outlive the node
+func (m *memFilterStore) MarkTaken(context.Context, types.FilterID, abi.ChainEpoch) error {
+	return nil
+}
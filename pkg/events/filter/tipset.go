@@ -103,9 +103,23 @@ func (m *TipSetFilterManager) Install(ctx context.Context) (*TipSetFilter, error
 		return nil, fmt.Errorf("new filter id: %w", err)
 	}
 
+	return m.install(id, nil), nil
+}
+
+// Restore installs again the filter id, which was installed before on this node or another one, with the
+// tipsets it missed in the meantime.
+func (m *TipSetFilterManager) Restore(ctx context.Context, id types.FilterID, collected []types.TipSetKey) (*TipSetFilter, error) {
+	if m.MaxFilterResults > 0 && len(collected) > m.MaxFilterResults {
+		collected = collected[len(collected)-m.MaxFilterResults:]
+	}
+	return m.install(id, collected), nil
+}
+
+func (m *TipSetFilterManager) install(id types.FilterID, collected []types.TipSetKey) *TipSetFilter {
 	f := &TipSetFilter{
 		id:         id,
 		maxResults: m.MaxFilterResults,
+		collected:  collected,
 	}
 
 	m.mu.Lock()
@@ -115,7 +129,7 @@ func (m *TipSetFilterManager) Install(ctx context.Context) (*TipSetFilter, error
 	m.filters[id] = f
 	m.mu.Unlock()
 
-	return f, nil
+	return f
 }
 
 func (m *TipSetFilterManager) Remove(ctx context.Context, id types.FilterID) error {
@@ -34,6 +34,7 @@ type MpoolConfig struct {
 	ReplaceByFeeRatio      types.Percent
 	PruneCooldown          time.Duration
 	GasLimitOverestimation float64
+	PriorityLanes          []types.MpoolPriorityLane
 }
 
 func (mc *MpoolConfig) Clone() *MpoolConfig {
@@ -83,6 +84,25 @@ func validateConfg(cfg *MpoolConfig) error {
 	if cfg.GasLimitOverestimation < 1 {
 		return fmt.Errorf("'GasLimitOverestimation' cannot be less than 1")
 	}
+	var share float64
+	for _, lane := range cfg.PriorityLanes {
+		if len(lane.Methods) == 0 && len(lane.Senders) == 0 {
+			return fmt.Errorf("priority lane '%s' matches neither methods nor senders", lane.Name)
+		}
+		if len(lane.Methods) > 0 && lane.Actor == "" {
+			return fmt.Errorf("priority lane '%s' matches methods of no actor", lane.Name)
+		}
+		if lane.MaxShare <= 0 || lane.MaxShare > 1 {
+			return fmt.Errorf("'MaxShare' of priority lane '%s' must be in (0, 1]", lane.Name)
+		}
+		if !lane.MinPremium.Nil() && lane.MinPremium.Sign() < 0 {
+			return fmt.Errorf("'MinPremium' of priority lane '%s' cannot be negative", lane.Name)
+		}
+		share += lane.MaxShare
+	}
+	if share > 1 {
+		return fmt.Errorf("the priority lanes take %f of the block gas limit, more than the whole block", share)
+	}
 	return nil
 }
 
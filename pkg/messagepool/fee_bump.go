@@ -0,0 +1,224 @@
+package messagepool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// FeeBumpSigner signs the replacements of the stuck messages, it is implemented by MessageSigner
+type FeeBumpSigner interface {
+	SignReplacement(ctx context.Context, msg *types.Message) (*types.SignedMessage, error)
+}
+
+// feeBumper tracks the local pending messages and replaces the stuck ones with higher fees
+type feeBumper struct {
+	lk     sync.Mutex
+	cfg    config.FeeBumpConfig
+	signer FeeBumpSigner
+	// tracked are the local pending messages by sender and nonce
+	tracked map[address.Address]map[uint64]*types.MpoolFeeBump
+}
+
+func newFeeBumper(cfg config.FeeBumpConfig) *feeBumper {
+	return &feeBumper{
+		cfg:     cfg,
+		tracked: make(map[address.Address]map[uint64]*types.MpoolFeeBump),
+	}
+}
+
+func (fb *feeBumper) enabled() bool {
+	fb.lk.Lock()
+	defer fb.lk.Unlock()
+	return fb.cfg.Enable && fb.signer != nil
+}
+
+// SetFeeBumpSigner sets the signer of the replacements of the stuck messages, the fees of the stuck
+// messages are only bumped once it is set and the fee bump is enabled in the config.
+func (mp *MessagePool) SetFeeBumpSigner(signer FeeBumpSigner) {
+	mp.feeBump.lk.Lock()
+	defer mp.feeBump.lk.Unlock()
+	mp.feeBump.signer = signer
+}
+
+// FeeBumpStatus returns the fee bump status of the local pending messages
+func (mp *MessagePool) FeeBumpStatus() *types.MpoolFeeBumpStatus {
+	fb := mp.feeBump
+	fb.lk.Lock()
+	defer fb.lk.Unlock()
+
+	status := &types.MpoolFeeBumpStatus{Enabled: fb.cfg.Enable && fb.signer != nil}
+	for _, msgs := range fb.tracked {
+		for _, m := range msgs {
+			status.Messages = append(status.Messages, *m)
+		}
+	}
+	sort.Slice(status.Messages, func(i, j int) bool {
+		if status.Messages[i].From != status.Messages[j].From {
+			return status.Messages[i].From.String() < status.Messages[j].From.String()
+		}
+		return status.Messages[i].Nonce < status.Messages[j].Nonce
+	})
+
+	return status
+}
+
+// bumpStuckMessages replaces the local messages pending for more than StuckEpochs epochs, or whose fee cap
+// is below the base fee, with messages paying the replace by fee premium.
+func (mp *MessagePool) bumpStuckMessages(ctx context.Context) {
+	if !mp.feeBump.enabled() {
+		return
+	}
+
+	mp.curTSLk.RLock()
+	ts := mp.curTS
+	mp.curTSLk.RUnlock()
+
+	baseFee, err := mp.api.ChainComputeBaseFee(ctx, ts)
+	if err != nil {
+		log.Errorf("fee bump: computing basefee: %s", err)
+		return
+	}
+
+	pending := make(map[address.Address]map[uint64]*types.SignedMessage)
+	mp.lk.RLock()
+	for actor := range mp.localAddrs {
+		mset, ok := mp.pending[actor]
+		if !ok || len(mset.msgs) == 0 {
+			continue
+		}
+		pend := make(map[uint64]*types.SignedMessage, len(mset.msgs))
+		for nonce, m := range mset.msgs {
+			pend[nonce] = m
+		}
+		pending[actor] = pend
+	}
+	mp.lk.RUnlock()
+
+	stuck := mp.feeBump.track(pending, ts.Height(), baseFee)
+	for _, m := range stuck {
+		replacement, err := mp.bumpFee(ctx, m)
+
+		mp.feeBump.lk.Lock()
+		status, ok := mp.feeBump.tracked[m.Message.From][m.Message.Nonce]
+		if ok {
+			status.Error = ""
+			if err != nil {
+				status.Error = err.Error()
+			} else {
+				status.Cid = replacement.Cid()
+				status.Bumps++
+				status.LastBump = ts.Height()
+			}
+		}
+		mp.feeBump.lk.Unlock()
+
+		if err != nil {
+			log.Warnf("fee bump: failed to replace message %s from %s with nonce %d: %s", m.Cid(), m.Message.From, m.Message.Nonce, err)
+			continue
+		}
+		log.Infof("fee bump: replaced message %s from %s with nonce %d with %s, premium %s -> %s, fee cap %s -> %s",
+			m.Cid(), m.Message.From, m.Message.Nonce, replacement.Cid(), m.Message.GasPremium, replacement.Message.GasPremium,
+			m.Message.GasFeeCap, replacement.Message.GasFeeCap)
+	}
+}
+
+// track updates the tracked messages with the pending ones and returns the stuck messages.
+func (fb *feeBumper) track(pending map[address.Address]map[uint64]*types.SignedMessage, height abi.ChainEpoch, baseFee big.Int) []*types.SignedMessage {
+	fb.lk.Lock()
+	defer fb.lk.Unlock()
+
+	for from, msgs := range fb.tracked {
+		for nonce := range msgs {
+			if _, ok := pending[from][nonce]; !ok {
+				delete(msgs, nonce)
+			}
+		}
+		if len(msgs) == 0 {
+			delete(fb.tracked, from)
+		}
+	}
+
+	var stuck []*types.SignedMessage
+	for from, msgs := range pending {
+		tracked, ok := fb.tracked[from]
+		if !ok {
+			tracked = make(map[uint64]*types.MpoolFeeBump)
+			fb.tracked[from] = tracked
+		}
+
+		for nonce, m := range msgs {
+			status, ok := tracked[nonce]
+			if !ok || status.Cid != m.Cid() {
+				// a message replaced by someone else is tracked again from scratch, its bumps only
+				// count the replacements made by the pool
+				status = &types.MpoolFeeBump{From: from, Nonce: nonce, Cid: m.Cid(), PendingSince: height}
+				tracked[nonce] = status
+			}
+
+			if status.Bumps >= fb.cfg.MaxBumps {
+				continue
+			}
+			since := status.PendingSince
+			if status.LastBump > since {
+				since = status.LastBump
+			}
+			if height-since >= fb.cfg.StuckEpochs || (height > since && m.Message.GasFeeCap.LessThan(baseFee)) {
+				stuck = append(stuck, m)
+			}
+		}
+	}
+
+	return stuck
+}
+
+// bumpFee replaces m with a message paying the replace by fee premium and a fee cap covering the base fee,
+// the fees are capped with the max fee of the sender.
+func (mp *MessagePool) bumpFee(ctx context.Context, m *types.SignedMessage) (*types.SignedMessage, error) {
+	msg := m.Message
+	minPremium := ComputeRBF(msg.GasPremium, mp.GetConfig().ReplaceByFeeRatio)
+	msg.GasPremium = minPremium
+
+	feeCap, err := mp.GasEstimateFeeCap(ctx, &msg, feePolicies[FeePolicyDefault].FeeCapEpochs, types.EmptyTSK)
+	if err != nil {
+		return nil, fmt.Errorf("estimating fee cap: %w", err)
+	}
+	msg.GasFeeCap = big.Max(big.Max(m.Message.GasFeeCap, feeCap), msg.GasPremium)
+
+	mp.capGasFee(ctx, &msg, nil)
+	if msg.GasPremium.LessThan(minPremium) {
+		return nil, fmt.Errorf("the max fee of %s doesn't allow a premium of %s", msg.From, minPremium)
+	}
+
+	mp.feeBump.lk.Lock()
+	signer := mp.feeBump.signer
+	mp.feeBump.lk.Unlock()
+
+	replacement, err := signer.SignReplacement(ctx, &msg)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := mp.Push(ctx, replacement); err != nil {
+		return nil, fmt.Errorf("pushing replacement: %w", err)
+	}
+
+	mp.journal.RecordEvent(mp.evtTypes[evtTypeMpoolBump], func() interface{} {
+		return MessagePoolEvt{
+			Action: "bump",
+			Messages: []MessagePoolEvtMessage{
+				{Message: m.Message, CID: m.Cid()},
+				{Message: replacement.Message, CID: replacement.Cid()},
+			},
+		}
+	})
+
+	return replacement, nil
+}
@@ -0,0 +1,127 @@
+// stm: #unit
+package messagepool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	tbig "github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/config"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/pkg/wallet"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// testBumpSigner signs the replacements like makeTestMessage
+type testBumpSigner struct {
+	w *wallet.Wallet
+}
+
+func (s *testBumpSigner) SignReplacement(ctx context.Context, msg *types.Message) (*types.SignedMessage, error) {
+	sig, err := s.w.WalletSign(ctx, msg.From, msg.Cid().Bytes(), types.MsgMeta{})
+	if err != nil {
+		return nil, err
+	}
+	return &types.SignedMessage{Message: *msg, Signature: *sig}, nil
+}
+
+func TestFeeBumperTrack(t *testing.T) {
+	tf.UnitTest(t)
+
+	fb := newFeeBumper(config.FeeBumpConfig{Enable: true, StuckEpochs: 3, MaxBumps: 1})
+	from := mkAddress(1000)
+	newMsg := func(nonce uint64, feeCap int64) *types.SignedMessage {
+		return &types.SignedMessage{Message: types.Message{From: from, To: from, Nonce: nonce, Value: tbig.Zero(), GasFeeCap: tbig.NewInt(feeCap), GasPremium: tbig.Zero()}}
+	}
+	m0, m1 := newMsg(0, 1000), newMsg(1, 200)
+	pending := map[address.Address]map[uint64]*types.SignedMessage{from: {0: m0, 1: m1}}
+
+	// the messages are tracked from the first epoch they are seen pending at
+	assert.Empty(t, fb.track(pending, 10, tbig.NewInt(500)))
+	require.Len(t, fb.tracked[from], 2)
+	assert.Equal(t, abi.ChainEpoch(10), fb.tracked[from][1].PendingSince)
+
+	// the base fee is above the fee cap of m1
+	assert.Equal(t, []*types.SignedMessage{m1}, fb.track(pending, 11, tbig.NewInt(500)))
+
+	// m0 is pending for StuckEpochs epochs
+	fb.tracked[from][1].Bumps = 1
+	assert.Equal(t, []*types.SignedMessage{m0}, fb.track(pending, 13, tbig.NewInt(500)))
+
+	// a message replaced by someone else is tracked from the epoch it is seen at, without the bumps of the
+	// replaced one
+	fb.tracked[from][0].LastBump = 13
+	fb.tracked[from][1].LastBump = 13
+	replaced := newMsg(1, 300)
+	pending[from][1] = replaced
+	assert.Empty(t, fb.track(pending, 13, tbig.NewInt(200)))
+	assert.Equal(t, types.MpoolFeeBump{From: from, Nonce: 1, Cid: replaced.Cid(), PendingSince: 13}, *fb.tracked[from][1])
+
+	// the included messages are not tracked anymore
+	delete(pending[from], 0)
+	assert.Empty(t, fb.track(pending, 14, tbig.NewInt(200)))
+	assert.Len(t, fb.tracked[from], 1)
+	delete(pending, from)
+	fb.track(pending, 15, tbig.NewInt(500))
+	assert.Empty(t, fb.tracked)
+}
+
+func TestFeeBump(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	mp, tma := makeTestMpool()
+	mp.api = &headMpoolAPI{tma}
+	mp.feeBump.cfg = config.FeeBumpConfig{Enable: true, StuckEpochs: 2, MaxBumps: 2}
+
+	w := newWallet(t)
+	a1, err := w.NewAddress(ctx, address.SECP256K1)
+	require.NoError(t, err)
+	a2, err := w.NewAddress(ctx, address.SECP256K1)
+	require.NoError(t, err)
+	mp.SetFeeBumpSigner(&testBumpSigner{w: w})
+	tma.setBalance(a1, 1)
+
+	m := makeTestMessage(w, a1, a2, 0, 1_000_000, 1000)
+	_, err = mp.Push(ctx, m)
+	require.NoError(t, err)
+
+	bumpStatus := func() types.MpoolFeeBump {
+		status := mp.FeeBumpStatus()
+		assert.True(t, status.Enabled)
+		if len(status.Messages) == 0 {
+			return types.MpoolFeeBump{}
+		}
+		return status.Messages[0]
+	}
+
+	tma.applyBlock(t, tma.nextBlock())
+	require.Eventually(t, func() bool { return bumpStatus().PendingSince == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	// the message is replaced with the replace by fee premium after StuckEpochs epochs
+	tma.applyBlock(t, tma.nextBlock())
+	tma.applyBlock(t, tma.nextBlock())
+	require.Eventually(t, func() bool { return bumpStatus().Bumps == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	status := bumpStatus()
+	assert.Equal(t, abi.ChainEpoch(3), status.LastBump)
+	assert.Empty(t, status.Error)
+	pending, _ := mp.Pending(ctx)
+	require.Len(t, pending, 1)
+	assert.Equal(t, status.Cid, pending[0].Cid())
+	assert.Equal(t, ComputeRBF(m.Message.GasPremium, mp.GetConfig().ReplaceByFeeRatio), pending[0].Message.GasPremium)
+	assert.True(t, pending[0].Message.GasFeeCap.GreaterThan(m.Message.GasFeeCap))
+
+	// the max premium of the sender doesn't allow another bump
+	mp.addressFees = newAddressFees([]config.AddressFeeConfig{{Address: a1, MaxPremium: pending[0].Message.GasPremium}})
+	tma.baseFee = tbig.Add(pending[0].Message.GasFeeCap, tbig.NewInt(1))
+	tma.applyBlock(t, tma.nextBlock())
+	require.Eventually(t, func() bool { return bumpStatus().Error != "" }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1, bumpStatus().Bumps)
+}
@@ -0,0 +1,165 @@
+package messagepool
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+const (
+	FeePolicyDefault    = "default"
+	FeePolicyUrgent     = "urgent"
+	FeePolicyEconomy    = "economy"
+	FeePolicyPercentile = "percentile"
+)
+
+// MaxFeeHistoryEpochs is the maximum number of tipsets of a fee history
+const MaxFeeHistoryEpochs = 1024
+
+// defaultPercentileEpochs is the number of tipsets a percentile policy looks at when it doesn't say
+const defaultPercentileEpochs = 20
+
+// FeePolicy is how the gas premium and the fee cap of a message are estimated
+type FeePolicy struct {
+	Name string
+	// Percentile is the percentile of the gas premiums of the messages included in the last Epochs
+	// tipsets, weighted by gas limit, the premium is estimated at. When it is 0 the premium is estimated
+	// by GasEstimateGasPremium for an inclusion within Epochs epochs.
+	Percentile float64
+	Epochs     uint64
+	// FeeCapEpochs is the number of epochs of maximal base fee increase the fee cap covers
+	FeeCapEpochs int64
+}
+
+var feePolicies = map[string]FeePolicy{
+	FeePolicyDefault: {Name: FeePolicyDefault, Epochs: 10, FeeCapEpochs: 20},
+	// urgent messages outbid most of the recent messages and survive a long base fee increase
+	FeePolicyUrgent: {Name: FeePolicyUrgent, Percentile: 90, Epochs: 5, FeeCapEpochs: 40},
+	// economy messages wait for the base fee to drop rather than pay for an increase
+	FeePolicyEconomy: {Name: FeePolicyEconomy, Percentile: 25, Epochs: 20, FeeCapEpochs: 5},
+}
+
+// ParseFeePolicy parses the fee policy of a MessageSendSpec, it is one of default, urgent, economy or
+// percentile:<N>[:<epochs>]. The default policy is returned for an empty string.
+func ParseFeePolicy(s string) (FeePolicy, error) {
+	if s == "" {
+		return feePolicies[FeePolicyDefault], nil
+	}
+	if policy, ok := feePolicies[s]; ok {
+		return policy, nil
+	}
+
+	parts := strings.Split(s, ":")
+	if parts[0] != FeePolicyPercentile || len(parts) > 3 {
+		return FeePolicy{}, fmt.Errorf("unknown fee policy %s", s)
+	}
+	if len(parts) < 2 {
+		return FeePolicy{}, fmt.Errorf("fee policy %s misses the percentile", s)
+	}
+
+	percentile, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil || percentile <= 0 || percentile > 100 {
+		return FeePolicy{}, fmt.Errorf("invalid percentile %s, expected a number in (0, 100]", parts[1])
+	}
+
+	epochs := uint64(defaultPercentileEpochs)
+	if len(parts) == 3 {
+		epochs, err = strconv.ParseUint(parts[2], 10, 64)
+		if err != nil || epochs == 0 || epochs > MaxFeeHistoryEpochs {
+			return FeePolicy{}, fmt.Errorf("invalid number of epochs %s, expected a number in [1, %d]", parts[2], MaxFeeHistoryEpochs)
+		}
+	}
+
+	return FeePolicy{
+		Name:         s,
+		Percentile:   percentile,
+		Epochs:       epochs,
+		FeeCapEpochs: feePolicies[FeePolicyDefault].FeeCapEpochs,
+	}, nil
+}
+
+func sendSpecFeePolicy(spec *types.MessageSendSpec) (FeePolicy, error) {
+	if spec == nil {
+		return feePolicies[FeePolicyDefault], nil
+	}
+	return ParseFeePolicy(spec.FeePolicy)
+}
+
+func newAddressFees(cfgs []config.AddressFeeConfig) map[address.Address]config.AddressFeeConfig {
+	fees := make(map[address.Address]config.AddressFeeConfig, len(cfgs))
+	for _, cfg := range cfgs {
+		fees[cfg.Address] = cfg
+	}
+	return fees
+}
+
+// addressFeeConfig returns the fee limits configured for the sender, they are looked up by the sender
+// address and then by its key address.
+func (mp *MessagePool) addressFeeConfig(ctx context.Context, from address.Address) (config.AddressFeeConfig, bool) {
+	mp.feeCfgLk.RLock()
+	fees := mp.addressFees
+	mp.feeCfgLk.RUnlock()
+
+	if len(fees) == 0 {
+		return config.AddressFeeConfig{}, false
+	}
+	if cfg, ok := fees[from]; ok {
+		return cfg, true
+	}
+
+	key, err := mp.resolveToKey(ctx, from)
+	if err != nil {
+		log.Debugf("failed to resolve %s to a key address: %s", from, err)
+		return config.AddressFeeConfig{}, false
+	}
+	cfg, ok := fees[key]
+	return cfg, ok
+}
+
+// defaultMaxFee returns the max fee of the messages whose senders have no fee limits configured
+func (mp *MessagePool) defaultMaxFee() (abi.TokenAmount, error) {
+	mp.feeCfgLk.RLock()
+	defer mp.feeCfgLk.RUnlock()
+	return abi.TokenAmount{Int: mp.maxFee.Int}, nil
+}
+
+// UpdateFeeConfig applies the fee options of cfg without a restart: the default max fee, the fee limits of
+// the senders and the replacement of the stuck messages.
+func (mp *MessagePool) UpdateFeeConfig(cfg *config.MessagePoolConfig) {
+	mp.feeCfgLk.Lock()
+	mp.maxFee = cfg.MaxFee
+	mp.addressFees = newAddressFees(cfg.AddressFees)
+	mp.feeCfgLk.Unlock()
+
+	mp.feeBump.lk.Lock()
+	mp.feeBump.cfg = cfg.FeeBump
+	mp.feeBump.lk.Unlock()
+}
+
+// capGasFee caps the fee of msg like CapGasFee, with the max fee configured for the sender as default
+// max fee when there is one, and caps its gas premium with the max premium configured for the sender.
+func (mp *MessagePool) capGasFee(ctx context.Context, msg *types.Message, spec *types.MessageSendSpec) {
+	cfg, ok := mp.addressFeeConfig(ctx, msg.From)
+	if !ok {
+		CapGasFee(mp.GetMaxFee, msg, spec)
+		return
+	}
+
+	mff := mp.GetMaxFee
+	if cfg.MaxFee.Int != nil && cfg.MaxFee.Int.Sign() > 0 {
+		mff = newDefaultMaxFeeFunc(cfg.MaxFee)
+	}
+	CapGasFee(mff, msg, spec)
+
+	if cfg.MaxPremium.Int != nil && cfg.MaxPremium.Sign() > 0 {
+		msg.GasPremium = big.Min(msg.GasPremium, cfg.MaxPremium)
+	}
+}
@@ -0,0 +1,164 @@
+// stm: #unit
+package messagepool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	tbig "github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/config"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// headMpoolAPI returns the last tipset of the test chain as head
+type headMpoolAPI struct {
+	*testMpoolAPI
+}
+
+func (tma *headMpoolAPI) ChainHead(ctx context.Context) (*types.TipSet, error) {
+	return tma.tipsets[len(tma.tipsets)-1], nil
+}
+
+func TestParseFeePolicy(t *testing.T) {
+	tf.UnitTest(t)
+
+	policy, err := ParseFeePolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, feePolicies[FeePolicyDefault], policy)
+
+	policy, err = ParseFeePolicy(FeePolicyUrgent)
+	require.NoError(t, err)
+	assert.Equal(t, feePolicies[FeePolicyUrgent], policy)
+
+	policy, err = ParseFeePolicy("percentile:75")
+	require.NoError(t, err)
+	assert.Equal(t, FeePolicy{Name: "percentile:75", Percentile: 75, Epochs: defaultPercentileEpochs, FeeCapEpochs: 20}, policy)
+
+	policy, err = ParseFeePolicy("percentile:12.5:40")
+	require.NoError(t, err)
+	assert.Equal(t, 12.5, policy.Percentile)
+	assert.Equal(t, uint64(40), policy.Epochs)
+
+	for _, s := range []string{"cheap", "percentile", "percentile:0", "percentile:101", "percentile:50:0", "percentile:50:2000", "percentile:50:10:1"} {
+		_, err = ParseFeePolicy(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestPremiumPercentiles(t *testing.T) {
+	tf.UnitTest(t)
+
+	prices := []GasMeta{
+		{Price: tbig.NewInt(300), Limit: 10},
+		{Price: tbig.NewInt(100), Limit: 60},
+		{Price: tbig.NewInt(200), Limit: 30},
+	}
+	out := premiumPercentiles(prices, []float64{0, 50, 60, 61, 90, 100})
+	assert.Equal(t, []tbig.Int{tbig.NewInt(100), tbig.NewInt(100), tbig.NewInt(100), tbig.NewInt(200), tbig.NewInt(200), tbig.NewInt(300)}, out)
+	// the prices are not reordered
+	assert.Equal(t, tbig.NewInt(300), prices[0].Price)
+
+	assert.Equal(t, []tbig.Int{tbig.Zero()}, premiumPercentiles(nil, []float64{50}))
+}
+
+func TestGasEstimateFeeHistory(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	mp, tma := makeTestMpool()
+	mp.api = &headMpoolAPI{tma}
+
+	w := newWallet(t)
+	a1, err := w.NewAddress(ctx, address.SECP256K1)
+	require.NoError(t, err)
+	a2, err := w.NewAddress(ctx, address.SECP256K1)
+	require.NoError(t, err)
+
+	// the tipset at epoch i includes messages with premiums i*1000 and i*2000
+	for i := 1; i <= 3; i++ {
+		blk := tma.nextBlock()
+		tma.setBlockMessages(blk,
+			makeTestMessage(w, a1, a2, uint64(2*i), 1000, uint64(i*1000)),
+			makeTestMessage(w, a1, a2, uint64(2*i+1), 3000, uint64(i*2000)),
+		)
+	}
+
+	history, err := mp.GasEstimateFeeHistory(ctx, 2, []float64{20, 80}, types.EmptyTSK)
+	require.NoError(t, err)
+	require.Len(t, history.Tipsets, 2)
+	assert.Equal(t, abi.ChainEpoch(2), history.Tipsets[0].Epoch)
+	assert.Equal(t, abi.ChainEpoch(3), history.Tipsets[1].Epoch)
+	assert.Equal(t, int64(4000), history.Tipsets[1].GasLimit)
+	assert.Equal(t, []tbig.Int{tbig.NewInt(3000), tbig.NewInt(6000)}, history.Tipsets[1].Premiums)
+	assert.Equal(t, []tbig.Int{tbig.NewInt(3000), tbig.NewInt(6000)}, history.Premiums)
+
+	// the walk stops at genesis
+	history, err = mp.GasEstimateFeeHistory(ctx, 10, []float64{50}, types.EmptyTSK)
+	require.NoError(t, err)
+	require.Len(t, history.Tipsets, 4)
+	assert.Equal(t, abi.ChainEpoch(0), history.Tipsets[0].Epoch)
+	assert.Equal(t, tbig.Zero(), history.Tipsets[0].Premiums[0])
+
+	_, err = mp.GasEstimateFeeHistory(ctx, 0, nil, types.EmptyTSK)
+	assert.Error(t, err)
+	_, err = mp.GasEstimateFeeHistory(ctx, 1, []float64{120}, types.EmptyTSK)
+	assert.Error(t, err)
+
+	// the percentile policies estimate the premium from the history, at least MinGasPremium
+	premium, err := mp.estimateGasPremium(ctx, FeePolicy{Percentile: 80, Epochs: 1}, &types.Message{})
+	require.NoError(t, err)
+	assert.Equal(t, tbig.NewInt(MinGasPremium), premium)
+
+	tma.setBlockMessages(tma.nextBlock(), makeTestMessage(w, a1, a2, 8, 1000, 2*MinGasPremium))
+	premium, err = mp.estimateGasPremium(ctx, FeePolicy{Percentile: 80, Epochs: 1}, &types.Message{})
+	require.NoError(t, err)
+	assert.Equal(t, tbig.NewInt(2*MinGasPremium), premium)
+}
+
+func TestAddressFeeConfig(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	mp, _ := makeTestMpool()
+	capped := mkAddress(1000)
+	mp.addressFees = newAddressFees([]config.AddressFeeConfig{{
+		Address:    capped,
+		MaxFee:     types.FIL(abi.NewTokenAmount(10_000_000)),
+		MaxPremium: abi.NewTokenAmount(500),
+	}})
+	mp.GetMaxFee = func() (abi.TokenAmount, error) {
+		return abi.NewTokenAmount(100_000_000), nil
+	}
+
+	newMsg := func(from address.Address) *types.Message {
+		return &types.Message{
+			From:       from,
+			GasLimit:   10_000,
+			GasFeeCap:  abi.NewTokenAmount(100_000),
+			GasPremium: abi.NewTokenAmount(2000),
+		}
+	}
+
+	// the default max fee applies to the other senders
+	msg := newMsg(mkAddress(1001))
+	mp.capGasFee(ctx, msg, nil)
+	assert.Equal(t, abi.NewTokenAmount(10_000), msg.GasFeeCap)
+	assert.Equal(t, abi.NewTokenAmount(2000), msg.GasPremium)
+
+	msg = newMsg(capped)
+	mp.capGasFee(ctx, msg, nil)
+	assert.Equal(t, abi.NewTokenAmount(1000), msg.GasFeeCap)
+	assert.Equal(t, abi.NewTokenAmount(500), msg.GasPremium)
+
+	// the max fee of the send spec wins over the one of the sender
+	msg = newMsg(capped)
+	mp.capGasFee(ctx, msg, &types.MessageSendSpec{MaxFee: abi.NewTokenAmount(100_000_000)})
+	assert.Equal(t, abi.NewTokenAmount(10_000), msg.GasFeeCap)
+	assert.Equal(t, abi.NewTokenAmount(500), msg.GasPremium)
+}
@@ -184,6 +184,120 @@ func (mp *MessagePool) GasEstimateGasPremium(
 	return premium, nil
 }
 
+// estimateGasPremium estimates the gas premium of msg with the fee policy.
+func (mp *MessagePool) estimateGasPremium(ctx context.Context, policy FeePolicy, msg *types.Message) (big.Int, error) {
+	if policy.Percentile == 0 {
+		return mp.GasEstimateGasPremium(ctx, policy.Epochs, msg.From, msg.GasLimit, types.EmptyTSK, mp.PriceCache)
+	}
+
+	history, err := mp.GasEstimateFeeHistory(ctx, policy.Epochs, []float64{policy.Percentile}, types.EmptyTSK)
+	if err != nil {
+		return big.Int{}, err
+	}
+
+	premium := history.Premiums[0]
+	if big.Cmp(premium, big.NewInt(MinGasPremium)) < 0 {
+		premium = big.NewInt(MinGasPremium)
+	}
+	return premium, nil
+}
+
+// GasEstimateFeeHistory returns the parent base fee and the percentiles of the gas premiums of the messages
+// included in the last epochs tipsets up to tsk, the head when tsk is empty.
+func (mp *MessagePool) GasEstimateFeeHistory(ctx context.Context, epochs uint64, percentiles []float64, tsk types.TipSetKey) (*types.FeeHistory, error) {
+	if epochs == 0 || epochs > MaxFeeHistoryEpochs {
+		return nil, fmt.Errorf("invalid number of epochs %d, expected a number in [1, %d]", epochs, MaxFeeHistoryEpochs)
+	}
+	for _, p := range percentiles {
+		if p < 0 || p > 100 {
+			return nil, fmt.Errorf("invalid percentile %f, expected a number in [0, 100]", p)
+		}
+	}
+
+	var ts *types.TipSet
+	var err error
+	if tsk.IsEmpty() {
+		ts, err = mp.api.ChainHead(ctx)
+	} else {
+		ts, err = mp.api.LoadTipSet(ctx, tsk)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading tipset %s: %w", tsk, err)
+	}
+
+	history := &types.FeeHistory{Percentiles: percentiles}
+	var prices []GasMeta
+	for i := uint64(0); i < epochs; i++ {
+		meta, err := mp.PriceCache.GetTSGasStats(ctx, mp.api, ts)
+		if err != nil {
+			return nil, err
+		}
+		prices = append(prices, meta...)
+
+		entry := types.FeeHistoryTipSet{
+			Epoch:         ts.Height(),
+			ParentBaseFee: ts.Blocks()[0].ParentBaseFee,
+			Premiums:      premiumPercentiles(meta, percentiles),
+		}
+		for _, m := range meta {
+			entry.GasLimit += m.Limit
+		}
+		history.Tipsets = append(history.Tipsets, entry)
+
+		if ts.Height() == 0 {
+			break // genesis
+		}
+		ts, err = mp.api.LoadTipSet(ctx, ts.Parents())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// oldest first
+	for i, j := 0, len(history.Tipsets)-1; i < j; i, j = i+1, j-1 {
+		history.Tipsets[i], history.Tipsets[j] = history.Tipsets[j], history.Tipsets[i]
+	}
+	history.Premiums = premiumPercentiles(prices, percentiles)
+
+	return history, nil
+}
+
+// premiumPercentiles returns the percentiles of the gas premiums of prices weighted by their gas limit,
+// the premiums are zero when there are no prices.
+func premiumPercentiles(prices []GasMeta, percentiles []float64) []big.Int {
+	out := make([]big.Int, len(percentiles))
+	var total int64
+	for _, p := range prices {
+		total += p.Limit
+	}
+	if total == 0 {
+		for i := range out {
+			out[i] = big.Zero()
+		}
+		return out
+	}
+
+	// the prices are shared with the cache, sort a copy
+	sorted := append([]GasMeta(nil), prices...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Price.LessThan(sorted[j].Price)
+	})
+
+	for i, percentile := range percentiles {
+		at := float64(total) * percentile / 100
+		out[i] = sorted[len(sorted)-1].Price
+		var sum int64
+		for _, p := range sorted {
+			sum += p.Limit
+			if float64(sum) >= at {
+				out[i] = p.Price
+				break
+			}
+		}
+	}
+	return out
+}
+
 func (mp *MessagePool) GasEstimateGasLimit(ctx context.Context, msgIn *types.Message, tsk types.TipSetKey) (int64, error) {
 	if tsk.IsEmpty() {
 		ts, err := mp.api.ChainHead(ctx)
@@ -360,6 +474,10 @@ func (mp *MessagePool) GasEstimateMessageGas(ctx context.Context, estimateMessag
 		return nil, fmt.Errorf("estimate message is nil")
 	}
 	log.Debugf("call GasEstimateMessageGas %v, send spec: %v", estimateMessage.Msg, estimateMessage.Spec)
+	policy, err := sendSpecFeePolicy(estimateMessage.Spec)
+	if err != nil {
+		return nil, err
+	}
 	if estimateMessage.Msg.GasLimit == 0 {
 		gasLimit, err := mp.GasEstimateGasLimit(ctx, estimateMessage.Msg, types.TipSetKey{})
 		if err != nil {
@@ -377,7 +495,7 @@ func (mp *MessagePool) GasEstimateMessageGas(ctx context.Context, estimateMessag
 	}
 
 	if estimateMessage.Msg.GasPremium == types.EmptyInt || types.BigCmp(estimateMessage.Msg.GasPremium, types.NewInt(0)) == 0 {
-		gasPremium, err := mp.GasEstimateGasPremium(ctx, 10, estimateMessage.Msg.From, estimateMessage.Msg.GasLimit, types.TipSetKey{}, mp.PriceCache)
+		gasPremium, err := mp.estimateGasPremium(ctx, policy, estimateMessage.Msg)
 		if err != nil {
 			return nil, fmt.Errorf("estimating gas price: %w", err)
 		}
@@ -391,14 +509,14 @@ func (mp *MessagePool) GasEstimateMessageGas(ctx context.Context, estimateMessag
 	}
 
 	if estimateMessage.Msg.GasFeeCap == types.EmptyInt || types.BigCmp(estimateMessage.Msg.GasFeeCap, types.NewInt(0)) == 0 {
-		feeCap, err := mp.GasEstimateFeeCap(ctx, estimateMessage.Msg, 20, types.EmptyTSK)
+		feeCap, err := mp.GasEstimateFeeCap(ctx, estimateMessage.Msg, policy.FeeCapEpochs, types.EmptyTSK)
 		if err != nil {
 			return nil, fmt.Errorf("estimating fee cap: %w", err)
 		}
 		estimateMessage.Msg.GasFeeCap = feeCap
 	}
 
-	CapGasFee(mp.GetMaxFee, estimateMessage.Msg, estimateMessage.Spec)
+	mp.capGasFee(ctx, estimateMessage.Msg, estimateMessage.Spec)
 
 	return estimateMessage.Msg, nil
 }
@@ -432,6 +550,16 @@ func (mp *MessagePool) GasBatchEstimateMessageGas(ctx context.Context, estimateM
 
 		log.Debugf("call GasBatchEstimateMessageGas msg %v, spec %v", estimateMsg, estimateMessage.Spec)
 
+		policy, err := sendSpecFeePolicy(estimateMessage.Spec)
+		if err != nil {
+			estimateMsg.Nonce = 0
+			estimateResults = append(estimateResults, &types.EstimateResult{
+				Msg: estimateMsg,
+				Err: err.Error(),
+			})
+			continue
+		}
+
 		if estimateMsg.GasLimit == 0 {
 			gasUsed, err := mp.evalMessageGasLimit(ctx, estimateMsg, priorMsgs, ts)
 			if err != nil {
@@ -450,7 +578,7 @@ func (mp *MessagePool) GasBatchEstimateMessageGas(ctx context.Context, estimateM
 		}
 
 		if estimateMsg.GasPremium == types.EmptyInt || types.BigCmp(estimateMsg.GasPremium, types.NewInt(0)) == 0 {
-			gasPremium, err := mp.GasEstimateGasPremium(ctx, 10, estimateMsg.From, estimateMsg.GasLimit, types.TipSetKey{}, mp.PriceCache)
+			gasPremium, err := mp.estimateGasPremium(ctx, policy, estimateMsg)
 			if err != nil {
 				estimateMsg.Nonce = 0
 				estimateResults = append(estimateResults, &types.EstimateResult{
@@ -469,7 +597,7 @@ func (mp *MessagePool) GasBatchEstimateMessageGas(ctx context.Context, estimateM
 		}
 
 		if estimateMsg.GasFeeCap == types.EmptyInt || types.BigCmp(estimateMsg.GasFeeCap, types.NewInt(0)) == 0 {
-			feeCap, err := mp.GasEstimateFeeCap(ctx, estimateMsg, 20, types.EmptyTSK)
+			feeCap, err := mp.GasEstimateFeeCap(ctx, estimateMsg, policy.FeeCapEpochs, types.EmptyTSK)
 			if err != nil {
 				estimateMsg.Nonce = 0
 				estimateResults = append(estimateResults, &types.EstimateResult{
@@ -481,7 +609,7 @@ func (mp *MessagePool) GasBatchEstimateMessageGas(ctx context.Context, estimateM
 			estimateMsg.GasFeeCap = feeCap
 		}
 
-		CapGasFee(mp.GetMaxFee, estimateMsg, estimateMessage.Spec)
+		mp.capGasFee(ctx, estimateMsg, estimateMessage.Spec)
 
 		estimateResults = append(estimateResults, &types.EstimateResult{
 			Msg: estimateMsg,
@@ -0,0 +1,147 @@
+package messagepool
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+
+	"github.com/filecoin-project/venus/pkg/constants"
+	"github.com/filecoin-project/venus/pkg/messagepool/gasguess"
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// selectLaneMessages adds the messages of the priority lanes to result, in the order of the lanes, each lane
+// taking at most its share of the block gas limit. Like the priority addresses, the senders of the selected
+// messages are removed from pending: their other messages can't be chained after the ones of the lane.
+func (mp *MessagePool) selectLaneMessages(ctx context.Context, result *selectedMessages, pending map[address.Address]map[uint64]*types.SignedMessage, baseFee types.BigInt, ts *types.TipSet) {
+	lanes := mp.cfg.PriorityLanes
+	if len(lanes) == 0 {
+		return
+	}
+
+	start := time.Now()
+	defer func() {
+		if dt := time.Since(start); dt > time.Millisecond {
+			log.Infow("select priority lane messages done", "took", dt)
+		}
+	}()
+
+	// the same method number means different things to different actors, the lanes match the receiver actor
+	receivers := make(map[address.Address]string)
+	receiverActor := func(to address.Address) string {
+		if name, ok := receivers[to]; ok {
+			return name
+		}
+		var name string
+		act, err := mp.api.GetActorAfter(ctx, to, ts)
+		if err != nil {
+			log.Debugf("failed to load receiver %s: %s", to, err)
+		} else {
+			name = actors.CanonicalName(builtin.ActorNameByCode(act.Code))
+		}
+		receivers[to] = name
+		return name
+	}
+
+	minGas := int64(gasguess.MinGas)
+	for _, lane := range lanes {
+		msgLimit := constants.BlockMessageLimit - len(result.msgs)
+		if result.gasLimit < minGas || msgLimit <= 0 {
+			return
+		}
+
+		senders := make(map[address.Address]struct{}, len(lane.Senders))
+		for _, sender := range lane.Senders {
+			pk, err := mp.resolveToKey(ctx, sender)
+			if err != nil {
+				log.Debugf("failed to resolve sender %s of priority lane %s: %s", sender, lane.Name, err)
+				continue
+			}
+			senders[pk] = struct{}{}
+		}
+		if len(lane.Senders) > 0 && len(senders) == 0 {
+			continue
+		}
+
+		var chains []*msgChain
+		for actor, mset := range pending {
+			if _, ok := senders[actor]; len(senders) > 0 && !ok {
+				continue
+			}
+			if lmset := laneMessages(lane, mset, receiverActor); len(lmset) > 0 {
+				chains = append(chains, mp.createMessageChains(ctx, actor, lmset, baseFee, ts)...)
+			}
+		}
+		if len(chains) == 0 {
+			continue
+		}
+
+		gasLimit := int64(lane.MaxShare * float64(constants.BlockGasLimit))
+		if gasLimit > result.gasLimit {
+			gasLimit = result.gasLimit
+		}
+		blsLimit, secpLimit := min(result.blsLimit, msgLimit), min(result.secpLimit, msgLimit)
+		laneResult := &selectedMessages{
+			gasLimit:  gasLimit,
+			blsLimit:  blsLimit,
+			secpLimit: secpLimit,
+		}
+		mp.selectChains(laneResult, chains, baseFee)
+
+		for _, m := range laneResult.msgs {
+			pk, err := mp.resolveToKey(ctx, m.Message.From)
+			if err != nil {
+				log.Debugf("failed to resolve sender %s: %s", m.Message.From, err)
+				continue
+			}
+			delete(pending, pk)
+		}
+		result.msgs = append(result.msgs, laneResult.msgs...)
+		result.gasLimit -= gasLimit - laneResult.gasLimit
+		result.blsLimit -= blsLimit - laneResult.blsLimit
+		result.secpLimit -= secpLimit - laneResult.secpLimit
+		log.Debugw("selected priority lane messages", "lane", lane.Name, "count", len(laneResult.msgs), "gas", gasLimit-laneResult.gasLimit)
+	}
+}
+
+// laneMessages returns the messages of mset which can be selected in the lane: the messages from the lowest
+// nonce on, up to the first one which doesn't match the lane.
+func laneMessages(lane types.MpoolPriorityLane, mset map[uint64]*types.SignedMessage, receiverActor func(address.Address) string) map[uint64]*types.SignedMessage {
+	nonces := make([]uint64, 0, len(mset))
+	for nonce := range mset {
+		nonces = append(nonces, nonce)
+	}
+	sort.Slice(nonces, func(i, j int) bool {
+		return nonces[i] < nonces[j]
+	})
+
+	res := make(map[uint64]*types.SignedMessage)
+	for _, nonce := range nonces {
+		m := mset[nonce]
+		if !laneMatches(lane, &m.Message, receiverActor) {
+			break
+		}
+		res[nonce] = m
+	}
+	return res
+}
+
+func laneMatches(lane types.MpoolPriorityLane, msg *types.Message, receiverActor func(address.Address) string) bool {
+	if !lane.MinPremium.Nil() && big.Cmp(msg.GasPremium, lane.MinPremium) < 0 {
+		return false
+	}
+	if len(lane.Methods) == 0 {
+		return true
+	}
+	for _, method := range lane.Methods {
+		if msg.Method == method {
+			return receiverActor(msg.To) == lane.Actor
+		}
+	}
+	return false
+}
@@ -98,6 +98,8 @@ var (
 
 const (
 	localMsgsDs = "/mpool/local"
+	// snapshotMsgsDs holds the pending remote messages saved by the snapshots
+	snapshotMsgsDs = "/mpool/snapshot"
 
 	localUpdates = "update"
 )
@@ -107,6 +109,7 @@ const (
 	evtTypeMpoolAdd = iota
 	evtTypeMpoolRemove
 	evtTypeMpoolRepub
+	evtTypeMpoolBump
 )
 
 // MessagePoolEvt is the journal entry for message pool events.
@@ -175,7 +178,7 @@ type MessagePool struct {
 
 	stateNonceCache *lru.Cache[stateNonceCacheKey, uint64]
 
-	evtTypes [3]journal.EventType
+	evtTypes [4]journal.EventType
 	journal  journal.Journal
 
 	forkParams       *config.ForkUpgradeConfig
@@ -183,6 +186,22 @@ type MessagePool struct {
 
 	GetMaxFee  DefaultMaxFeeFunc
 	PriceCache *GasPriceCache
+
+	// feeCfgLk guards maxFee and addressFees, they are updated live by UpdateFeeConfig
+	feeCfgLk sync.RWMutex
+	maxFee   types.FIL
+	// addressFees are the fee limits configured per sender
+	addressFees map[address.Address]config.AddressFeeConfig
+
+	feeBump     *feeBumper
+	bumpTrigger chan struct{}
+
+	// snapshotInterval is how often the pending messages are saved, 0 disables the snapshots
+	snapshotInterval time.Duration
+	snapshotMsgs     datastore.Datastore
+	// snapshotLk guards snapshotted, the cids of the messages saved by the last snapshot
+	snapshotLk  sync.Mutex
+	snapshotted map[cid.Cid]struct{}
 }
 
 type stateNonceCacheKey struct {
@@ -437,14 +456,23 @@ func New(ctx context.Context,
 			evtTypeMpoolAdd:    j.RegisterEventType("mpool", "add"),
 			evtTypeMpoolRemove: j.RegisterEventType("mpool", "remove"),
 			evtTypeMpoolRepub:  j.RegisterEventType("mpool", "repub"),
+			evtTypeMpoolBump:   j.RegisterEventType("mpool", "bump"),
 		},
 		journal:          j,
 		forkParams:       networkParams.ForkUpgradeParam,
 		gasPriceSchedule: gas.NewPricesSchedule(networkParams.ForkUpgradeParam),
-		GetMaxFee:        newDefaultMaxFeeFunc(mpoolCfg.MaxFee),
+		maxFee:           mpoolCfg.MaxFee,
 		PriceCache:       NewGasPriceCache(),
+		addressFees:      newAddressFees(mpoolCfg.AddressFees),
+		feeBump:          newFeeBumper(mpoolCfg.FeeBump),
+		bumpTrigger:      make(chan struct{}, 1),
+		snapshotInterval: time.Duration(mpoolCfg.SnapshotInterval),
+		snapshotMsgs:     namespace.Wrap(ds, datastore.NewKey(snapshotMsgsDs)),
+		snapshotted:      make(map[cid.Cid]struct{}),
 	}
 
+	mp.GetMaxFee = mp.defaultMaxFee
+
 	// enable initial prunes
 	mp.pruneCooldown <- struct{}{}
 
@@ -465,16 +493,21 @@ func New(ctx context.Context,
 	go func() {
 		defer cancel()
 		err := mp.loadLocal(ctx)
-
-		mp.lk.Unlock()
-		mp.curTSLk.Unlock()
-
 		if err != nil {
 			log.Errorf("loading local messages: %+v", err)
 		}
+		if mp.snapshotInterval > 0 {
+			if err := mp.loadSnapshot(ctx); err != nil {
+				log.Errorf("loading mpool snapshot: %+v", err)
+			}
+		}
+
+		mp.lk.Unlock()
+		mp.curTSLk.Unlock()
 
 		log.Info("mpool ready")
 
+		go mp.runFeeBumpLoop(ctx)
 		mp.runLoop(ctx)
 	}()
 
@@ -648,6 +681,11 @@ func (mp *MessagePool) PublishMsg(ctx context.Context, smsg *types.SignedMessage
 }
 
 func (mp *MessagePool) Close() error {
+	if mp.snapshotInterval > 0 {
+		if err := mp.saveSnapshot(context.TODO()); err != nil {
+			log.Errorf("error while saving mpool snapshot: %s", err)
+		}
+	}
 	close(mp.closer)
 	return mp.journal.Close()
 }
@@ -665,6 +703,13 @@ func (mp *MessagePool) Prune() {
 }
 
 func (mp *MessagePool) runLoop(ctx context.Context) {
+	var snapshotC <-chan time.Time
+	if mp.snapshotInterval > 0 {
+		snapshotTk := constants.Clock.Ticker(mp.snapshotInterval)
+		defer snapshotTk.Stop()
+		snapshotC = snapshotTk.C
+	}
+
 	for {
 		select {
 		case <-mp.repubTk.C:
@@ -676,6 +721,11 @@ func (mp *MessagePool) runLoop(ctx context.Context) {
 				log.Errorf("error while republishing messages: %s", err)
 			}
 
+		case <-snapshotC:
+			if err := mp.saveSnapshot(ctx); err != nil {
+				log.Errorf("error while saving mpool snapshot: %s", err)
+			}
+
 		case <-mp.pruneTrigger:
 			if err := mp.pruneExcessMessages(); err != nil {
 				log.Errorf("failed to prune excess messages from mempool: %s", err)
@@ -688,6 +738,19 @@ func (mp *MessagePool) runLoop(ctx context.Context) {
 	}
 }
 
+// runFeeBumpLoop bumps the fees of the stuck messages at the new heads, apart from runLoop as signing the
+// replacements can wait on a remote wallet. The heads applied while bumping trigger a single run.
+func (mp *MessagePool) runFeeBumpLoop(ctx context.Context) {
+	for {
+		select {
+		case <-mp.bumpTrigger:
+			mp.bumpStuckMessages(ctx)
+		case <-mp.closer:
+			return
+		}
+	}
+}
+
 func (mp *MessagePool) addLocal(ctx context.Context, m *types.SignedMessage) error {
 	if err := mp.setLocal(ctx, m.Message.From); err != nil {
 		return err
@@ -1308,6 +1371,25 @@ func (mp *MessagePool) allPending(ctx context.Context) ([]*types.SignedMessage,
 	return out, mp.curTS
 }
 
+// PendingWithGaps returns the pending messages split in the messages whose nonce follows the nonce of their
+// sender, and the messages queued behind a nonce gap which can't be included until the gap is filled.
+func (mp *MessagePool) PendingWithGaps(ctx context.Context) (pending, queued []*types.SignedMessage) {
+	mp.lk.RLock()
+	defer mp.lk.RUnlock()
+
+	mp.forEachPending(func(a address.Address, mset *msgSet) {
+		for _, m := range mset.toSlice() {
+			if m.Message.Nonce < mset.nextNonce {
+				pending = append(pending, m)
+			} else {
+				queued = append(queued, m)
+			}
+		}
+	})
+
+	return pending, queued
+}
+
 func (mp *MessagePool) PendingFor(ctx context.Context, a address.Address) ([]*types.SignedMessage, *types.TipSet) {
 	mp.curTSLk.RLock()
 	defer mp.curTSLk.RUnlock()
@@ -1427,6 +1509,14 @@ func (mp *MessagePool) HeadChange(ctx context.Context, revert []*types.TipSet, a
 		}
 	}
 
+	// look for stuck messages at every new head
+	if len(apply) > 0 {
+		select {
+		case mp.bumpTrigger <- struct{}{}:
+		default:
+		}
+	}
+
 	for _, s := range rmsgs {
 		for _, msg := range s {
 			if err := mp.addSkipChecks(ctx, msg); err != nil {
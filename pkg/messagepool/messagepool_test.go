@@ -2,6 +2,7 @@
 package messagepool
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"sort"
@@ -19,6 +20,8 @@ import (
 	"github.com/filecoin-project/go-state-types/network"
 	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"github.com/ipfs/go-datastore/query"
 	logging "github.com/ipfs/go-log/v2"
 	"github.com/stretchr/testify/assert"
 
@@ -44,6 +47,7 @@ type testMpoolAPI struct {
 	bmsgs      map[cid.Cid][]*types.SignedMessage
 	statenonce map[address.Address]uint64
 	balance    map[address.Address]tbig.Int
+	codes      map[address.Address]cid.Cid
 
 	tipsets []*types.TipSet
 
@@ -141,6 +145,7 @@ func newTestMpoolAPI() *testMpoolAPI {
 		bmsgs:      make(map[cid.Cid][]*types.SignedMessage),
 		statenonce: make(map[address.Address]uint64),
 		balance:    make(map[address.Address]tbig.Int),
+		codes:      make(map[address.Address]cid.Cid),
 		baseFee:    tbig.NewInt(100),
 	}
 	genesis := mkBlock(nil, 1, 1)
@@ -183,6 +188,10 @@ func (tma *testMpoolAPI) setBalance(addr address.Address, v uint64) {
 	tma.balance[addr] = types.FromFil(v)
 }
 
+func (tma *testMpoolAPI) setActorCode(addr address.Address, code cid.Cid) {
+	tma.codes[addr] = code
+}
+
 func (tma *testMpoolAPI) setBalanceRaw(addr address.Address, v tbig.Int) {
 	tma.balance[addr] = v
 }
@@ -226,8 +235,13 @@ func (tma *testMpoolAPI) GetActorBefore(addr address.Address, ts *types.TipSet)
 
 	nonce := tma.statenonce[addr]
 
+	code, ok := tma.codes[addr]
+	if !ok {
+		code = builtin2.AccountActorCodeID
+	}
+
 	return &types.Actor{
-		Code:    builtin2.AccountActorCodeID,
+		Code:    code,
 		Nonce:   nonce,
 		Balance: balance,
 	}, nil
@@ -267,8 +281,13 @@ func (tma *testMpoolAPI) GetActorAfter(ctx context.Context, addr address.Address
 		nonce++
 	}
 
+	code, ok := tma.codes[addr]
+	if !ok {
+		code = builtin2.AccountActorCodeID
+	}
+
 	return &types.Actor{
-		Code:    builtin2.AccountActorCodeID,
+		Code:    code,
 		Nonce:   nonce,
 		Balance: balance,
 	}, nil
@@ -551,6 +570,40 @@ func TestCheckMessageBig(t *testing.T) {
 	}
 }
 
+func TestPendingWithGaps(t *testing.T) {
+	tf.UnitTest(t)
+
+	tma := newTestMpoolAPI()
+	w, mp := newWalletAndMpool(t, tma)
+	defer mp.Close() // nolint
+
+	sender, err := w.NewAddress(context.Background(), address.SECP256K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := mkAddress(1001)
+	tma.setStateNonce(sender, 0)
+	tma.setBalance(sender, 1000e9)
+
+	var msgs []*types.SignedMessage
+	for i := 0; i < 4; i++ {
+		msgs = append(msgs, mkMessage(sender, target, uint64(i), w))
+	}
+	mustAdd(t, mp, msgs[0])
+	mustAdd(t, mp, msgs[1])
+	mustAdd(t, mp, msgs[3])
+
+	pending, queued := mp.PendingWithGaps(context.Background())
+	assert.Equal(t, msgs[:2], pending)
+	assert.Equal(t, msgs[3:], queued)
+
+	// filling the gap moves the queued message to the pending ones
+	mustAdd(t, mp, msgs[2])
+	pending, queued = mp.PendingWithGaps(context.Background())
+	assert.Equal(t, msgs, pending)
+	assert.Empty(t, queued)
+}
+
 func TestMessagePoolMessagesInEachBlock(t *testing.T) {
 	tf.UnitTest(t)
 
@@ -757,6 +810,121 @@ func TestLoadLocal(t *testing.T) {
 	}
 }
 
+func TestSnapshot(t *testing.T) {
+	tf.UnitTest(t)
+
+	tma := newTestMpoolAPI()
+	ds := datastore.NewMapDatastore()
+
+	mp, err := New(context.Background(), tma, nil, ds, config.NewDefaultConfig().NetworkParams, config.DefaultMessagePoolParam, "mptest", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the actors
+	w1 := newWallet(t)
+	a1, err := w1.NewAddress(context.Background(), address.SECP256K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w2 := newWallet(t)
+	a2, err := w2.NewAddress(context.Background(), address.SECP256K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tma.setBalance(a1, 1) // in FIL
+	tma.setBalance(a2, 1) // in FIL
+	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
+
+	// local messages from a1 and remote ones from a2
+	msgs := make(map[cid.Cid]struct{})
+	for i := 0; i < 5; i++ {
+		c, err := mp.Push(context.TODO(), makeTestMessage(w1, a1, a2, uint64(i), gasLimit, uint64(i+1)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		msgs[c] = struct{}{}
+	}
+	for i := 0; i < 5; i++ {
+		m := makeTestMessage(w2, a2, a1, uint64(i), gasLimit, uint64(i+1))
+		if err := mp.Add(context.TODO(), m); err != nil {
+			t.Fatal(err)
+		}
+		// the first remote messages are included on chain while the pool is down
+		if i >= 2 {
+			msgs[m.Cid()] = struct{}{}
+		}
+	}
+	err = mp.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// only the remote messages are saved, one key per message
+	snapshot := namespace.Wrap(ds, datastore.NewKey(snapshotMsgsDs))
+	keys, err := snapshot.Query(context.TODO(), query.Query{KeysOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := keys.Rest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 5 {
+		t.Fatalf("expected 5 messages in the snapshot, but got %d", len(entries))
+	}
+
+	// the restored messages are checked as remote ones, a nonce gap isn't accepted
+	gapped := makeTestMessage(w2, a2, a1, 7, gasLimit, 1)
+	buf := new(bytes.Buffer)
+	if err := gapped.MarshalCBOR(buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := snapshot.Put(context.TODO(), datastore.NewKey(string(gapped.Cid().Bytes())), buf.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+
+	tma.setStateNonce(a2, 2)
+	mp, err = New(context.Background(), tma, nil, ds, config.NewDefaultConfig().NetworkParams, config.DefaultMessagePoolParam, "mptest", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pmsgs, _ := mp.Pending(context.TODO())
+	if len(msgs) != len(pmsgs) {
+		t.Fatalf("expected %d messages, but got %d", len(msgs), len(pmsgs))
+	}
+	for _, m := range pmsgs {
+		if _, ok := msgs[m.Cid()]; !ok {
+			t.Fatal("unknown message")
+		}
+	}
+
+	// the remote messages aren't local
+	local, err := mp.isLocal(context.TODO(), a2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if local {
+		t.Fatal("expected the sender of the remote messages not to be local")
+	}
+
+	// the dropped messages are removed from the snapshot
+	keys, err = snapshot.Query(context.TODO(), query.Query{KeysOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err = keys.Rest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 messages in the snapshot, but got %d", len(entries))
+	}
+}
+
 func TestClearAll(t *testing.T) {
 	tf.UnitTest(t)
 
@@ -55,6 +55,31 @@ func (ms *MessageSigner) SignMessage(ctx context.Context, msg *types.Message, cb
 	// Sign the message with the nonce
 	msg.Nonce = nonce
 
+	smsg, err := ms.sign(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Callback with the signed message
+	err = cb(smsg)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the callback executed successfully, write the nonce to the datastore
+	if err := ms.saveNonce(ctx, msg.From, nonce); err != nil {
+		return nil, fmt.Errorf("failed to save nonce: %w", err)
+	}
+
+	return smsg, nil
+}
+
+// SignReplacement signs msg with its nonce, msg replaces a pending message so the nonce is not incremented
+func (ms *MessageSigner) SignReplacement(ctx context.Context, msg *types.Message) (*types.SignedMessage, error) {
+	return ms.sign(ctx, msg)
+}
+
+func (ms *MessageSigner) sign(ctx context.Context, msg *types.Message) (*types.SignedMessage, error) {
 	sb, err := msg.SigningBytes(types.AddressProtocol2SignType(msg.From.Protocol()))
 	if err != nil {
 		return nil, err
@@ -73,22 +98,10 @@ func (ms *MessageSigner) SignMessage(ctx context.Context, msg *types.Message, cb
 		return nil, fmt.Errorf("failed to sign message: %w", err)
 	}
 
-	// Callback with the signed message
-	smsg := &types.SignedMessage{
+	return &types.SignedMessage{
 		Message:   *msg,
 		Signature: *sig,
-	}
-	err = cb(smsg)
-	if err != nil {
-		return nil, err
-	}
-
-	// If the callback executed successfully, write the nonce to the datastore
-	if err := ms.saveNonce(ctx, msg.From, nonce); err != nil {
-		return nil, fmt.Errorf("failed to save nonce: %w", err)
-	}
-
-	return smsg, nil
+	}, nil
 }
 
 // nextNonce gets the next nonce for the given address.
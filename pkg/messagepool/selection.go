@@ -233,6 +233,7 @@ func (mp *MessagePool) selectMessagesOptimal(ctx context.Context, curTS, ts *typ
 	// 0b. Select all priority messages that fit in the block
 	minGas := int64(gasguess.MinGas)
 	result := mp.selectPriorityMessages(ctx, pending, baseFee, ts)
+	mp.selectLaneMessages(ctx, result, pending, baseFee, ts)
 
 	// have we filled the block?
 	if result.gasLimit < minGas || len(result.msgs) >= constants.BlockMessageLimit {
@@ -474,6 +475,7 @@ func (mp *MessagePool) selectMessagesGreedy(ctx context.Context, curTS, ts *type
 	// 0b. Select all priority messages that fit in the block
 	minGas := int64(gasguess.MinGas)
 	result := mp.selectPriorityMessages(ctx, pending, baseFee, ts)
+	mp.selectLaneMessages(ctx, result, pending, baseFee, ts)
 
 	// have we filled the block?
 	if result.gasLimit < minGas || len(result.msgs) > constants.BlockMessageLimit {
@@ -595,7 +597,6 @@ func (mp *MessagePool) selectPriorityMessages(ctx context.Context, pending map[a
 		blsLimit:  cbg.MaxLength,
 		secpLimit: cbg.MaxLength,
 	}
-	minGas := int64(gasguess.MinGas)
 
 	// 1. Get priority actor chains
 	var chains []*msgChain
@@ -620,6 +621,15 @@ func (mp *MessagePool) selectPriorityMessages(ctx context.Context, pending map[a
 		return result
 	}
 
+	mp.selectChains(result, chains, baseFee)
+	return result
+}
+
+// selectChains merges the chains into result until the block limits, as long as they have non-negative gas
+// performance
+func (mp *MessagePool) selectChains(result *selectedMessages, chains []*msgChain, baseFee types.BigInt) {
+	minGas := int64(gasguess.MinGas)
+
 	// 2. Sort the chains
 	sort.Slice(chains, func(i, j int) bool {
 		return chains[i].Before(chains[j])
@@ -627,7 +637,7 @@ func (mp *MessagePool) selectPriorityMessages(ctx context.Context, pending map[a
 
 	if len(chains) != 0 && chains[0].gasPerf < 0 {
 		log.Warnw("all priority messages in mpool have negative gas performance", "bestGasPerf", chains[0].gasPerf)
-		return result
+		return
 	}
 
 	// 3. Merge chains until the block limit, as long as they have non-negative gas performance
@@ -690,8 +700,6 @@ tailLoop:
 		// end the loop
 		break
 	}
-
-	return result
 }
 
 func (mp *MessagePool) getPendingMessages(ctx context.Context, curTS, ts *types.TipSet) (map[address.Address]map[uint64]*types.SignedMessage, error) {
@@ -17,6 +17,7 @@ import (
 	cbg "github.com/whyrusleeping/cbor-gen"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
 	tbig "github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/crypto"
 	"github.com/ipfs/go-cid"
@@ -43,10 +44,14 @@ func init() {
 const UpgradeBreezeHeight = 41280
 
 func makeTestMessage(w *wallet.Wallet, from, to address.Address, nonce uint64, gasLimit int64, gasPrice uint64) *types.SignedMessage {
+	return makeTestMethodMessage(w, from, to, 2, nonce, gasLimit, gasPrice)
+}
+
+func makeTestMethodMessage(w *wallet.Wallet, from, to address.Address, method abi.MethodNum, nonce uint64, gasLimit int64, gasPrice uint64) *types.SignedMessage {
 	msg := &types.Message{
 		From:       from,
 		To:         to,
-		Method:     2,
+		Method:     method,
 		Value:      types.FromFil(0),
 		Nonce:      nonce,
 		GasLimit:   gasLimit,
@@ -963,6 +968,137 @@ func TestPriorityMessageSelection3(t *testing.T) {
 	}
 }
 
+func TestPriorityLaneSelection(t *testing.T) {
+	tf.UnitTest(t)
+
+	mp, tma := makeTestMpool()
+
+	// the actors
+	w1 := newWallet(t)
+	a1, err := w1.NewAddress(context.Background(), address.SECP256K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w2 := newWallet(t)
+	a2, err := w2.NewAddress(context.Background(), address.SECP256K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w3 := newWallet(t)
+	a3, err := w3.NewAddress(context.Background(), address.SECP256K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w4 := newWallet(t)
+	a4, err := w4.NewAddress(context.Background(), address.SECP256K1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	miner := mkAddress(1000)
+	tma.setActorCode(miner, builtin2.StorageMinerActorCodeID)
+
+	block := tma.nextBlock()
+	ts := mkTipSet(block)
+	tma.applyBlock(t, block)
+
+	gasLimit := gasguess.Costs[gasguess.CostKey{Code: builtin2.StorageMarketActorCodeID, M: 2}]
+
+	tma.setBalance(a1, 1) // in FIL
+	tma.setBalance(a2, 1) // in FIL
+	tma.setBalance(a3, 1) // in FIL
+	tma.setBalance(a4, 1) // in FIL
+
+	// a1 and a3 pay less than a2, a3 calls method 5 of the miner but its last message, a4 calls method 5 of
+	// an account
+	nMessages := 10
+	for i := 0; i < nMessages; i++ {
+		mustAdd(t, mp, makeTestMessage(w1, a1, a2, uint64(i), gasLimit, 1))
+		mustAdd(t, mp, makeTestMessage(w2, a2, a1, uint64(i), gasLimit, 10))
+	}
+	for i := 0; i < 3; i++ {
+		method := abi.MethodNum(5)
+		if i == 2 {
+			method = 2
+		}
+		mustAdd(t, mp, makeTestMethodMessage(w3, a3, miner, method, uint64(i), gasLimit, 2))
+	}
+	for i := 0; i < 2; i++ {
+		mustAdd(t, mp, makeTestMethodMessage(w4, a4, a1, 5, uint64(i), gasLimit, 2))
+	}
+
+	// a1 is limited to the gas of 3 messages
+	share := float64(3*gasLimit+gasLimit/2) / float64(constants.BlockGasLimit)
+	err = mp.SetConfig(context.Background(), &MpoolConfig{
+		SizeLimitHigh:          MemPoolSizeLimitHiDefault,
+		SizeLimitLow:           MemPoolSizeLimitLoDefault,
+		ReplaceByFeeRatio:      ReplaceByFeePercentageDefault,
+		GasLimitOverestimation: GasLimitOverestimation,
+		PriorityLanes: []types.MpoolPriorityLane{
+			{Name: "posts", Methods: []abi.MethodNum{5}, Actor: "storageminer", MaxShare: 0.5},
+			{Name: "senders", Senders: []address.Address{a1}, MinPremium: tbig.NewInt(1), MaxShare: share},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tq := range []float64{1.0, 0.1} {
+		msgs, err := mp.SelectMessages(context.Background(), ts, tq)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the lanes come first, in order, the senders of the lanes are left out of the rest of the block and
+		// the messages of a4 don't get in the lane of the miner methods
+		expected := []struct {
+			from  address.Address
+			count int
+		}{{a3, 2}, {a1, 3}, {a2, nMessages}, {a4, 2}}
+		if len(msgs) != 17 {
+			t.Fatalf("expected 17 messages but got %d", len(msgs))
+		}
+		for _, e := range expected {
+			for nonce := 0; nonce < e.count; nonce++ {
+				if msgs[0].Message.From != e.from || msgs[0].Message.Nonce != uint64(nonce) {
+					t.Fatalf("expected message %d from %s but got message %d from %s", nonce, e.from, msgs[0].Message.Nonce, msgs[0].Message.From)
+				}
+				msgs = msgs[1:]
+			}
+		}
+	}
+
+	// the messages paying less than the min premium are left out of the lane
+	cfg := mp.GetConfig()
+	cfg.PriorityLanes = []types.MpoolPriorityLane{{Name: "senders", Senders: []address.Address{a1}, MinPremium: tbig.NewInt(2), MaxShare: share}}
+	if err := mp.SetConfig(context.Background(), cfg); err != nil {
+		t.Fatal(err)
+	}
+	msgs, err := mp.SelectMessages(context.Background(), ts, 1.0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 2*nMessages+5 || msgs[0].Message.From != a2 {
+		t.Fatalf("expected the messages of a2 first")
+	}
+
+	// the methods are the methods of an actor
+	lanes := cfg.PriorityLanes
+	cfg.PriorityLanes = append(lanes, types.MpoolPriorityLane{Name: "posts", Methods: []abi.MethodNum{5}, MaxShare: 0.1})
+	if err := mp.SetConfig(context.Background(), cfg); err == nil {
+		t.Fatal("expected the lane matching the methods of no actor to be rejected")
+	}
+
+	// the lanes can't take more than the whole block
+	cfg.PriorityLanes = append(lanes, types.MpoolPriorityLane{Name: "posts", Methods: []abi.MethodNum{5}, Actor: "storageminer", MaxShare: 1})
+	if err := mp.SetConfig(context.Background(), cfg); err == nil {
+		t.Fatal("expected the lanes taking more than the block to be rejected")
+	}
+}
+
 func TestOptimalMessageSelection1(t *testing.T) {
 	tf.UnitTest(t)
 
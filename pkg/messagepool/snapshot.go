@@ -0,0 +1,174 @@
+package messagepool
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+
+	"github.com/filecoin-project/venus/pkg/consensus"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// saveSnapshot saves the pending remote messages so that they survive restarts, the local ones are persisted
+// already. Each message is kept under its own key and only the messages added or removed since the last
+// snapshot are written.
+func (mp *MessagePool) saveSnapshot(ctx context.Context) error {
+	pending := make(map[cid.Cid]*types.SignedMessage)
+	mp.lk.RLock()
+	mp.forEachPending(func(a address.Address, ms *msgSet) {
+		if local, err := mp.isLocal(ctx, a); err != nil || local {
+			return
+		}
+		for _, m := range ms.msgs {
+			pending[m.Cid()] = m
+		}
+	})
+	mp.lk.RUnlock()
+
+	// loadSnapshot takes snapshotLk while holding mp.lk
+	mp.snapshotLk.Lock()
+	defer mp.snapshotLk.Unlock()
+
+	var added, removed int
+	for c := range mp.snapshotted {
+		if _, ok := pending[c]; ok {
+			continue
+		}
+		if err := mp.snapshotMsgs.Delete(ctx, datastore.NewKey(string(c.Bytes()))); err != nil {
+			return fmt.Errorf("deleting snapshot message: %v", err)
+		}
+		delete(mp.snapshotted, c)
+		removed++
+	}
+	for c, m := range pending {
+		if _, ok := mp.snapshotted[c]; ok {
+			continue
+		}
+		buf := new(bytes.Buffer)
+		if err := m.MarshalCBOR(buf); err != nil {
+			return fmt.Errorf("error serializing message: %v", err)
+		}
+		if err := mp.snapshotMsgs.Put(ctx, datastore.NewKey(string(c.Bytes())), buf.Bytes()); err != nil {
+			return fmt.Errorf("persisting snapshot message: %v", err)
+		}
+		mp.snapshotted[c] = struct{}{}
+		added++
+	}
+	if added > 0 || removed > 0 {
+		log.Debugf("mpool snapshot: saved %d messages, removed %d", added, removed)
+	}
+	return nil
+}
+
+// loadSnapshot adds the messages of the snapshot back to the pool. They were received from the network so they
+// are verified again as remote messages against the current head, the ones which became invalid, e.g. included
+// on chain meanwhile, are dropped from the snapshot.
+func (mp *MessagePool) loadSnapshot(ctx context.Context) error {
+	mp.snapshotLk.Lock()
+	defer mp.snapshotLk.Unlock()
+
+	res, err := mp.snapshotMsgs.Query(ctx, query.Query{})
+	if err != nil {
+		return fmt.Errorf("query snapshot messages: %v", err)
+	}
+	entries, err := res.Rest()
+	if err != nil {
+		return fmt.Errorf("load mpool snapshot: %v", err)
+	}
+
+	// the untrusted messages are refused on a nonce gap, add them ordered by sender and nonce
+	msgs := make([]*types.SignedMessage, 0, len(entries))
+	for _, e := range entries {
+		var sm types.SignedMessage
+		if err := sm.UnmarshalCBOR(bytes.NewReader(e.Value)); err != nil {
+			return fmt.Errorf("unmarshaling snapshot message: %v", err)
+		}
+		msgs = append(msgs, &sm)
+	}
+	sort.Slice(msgs, func(i, j int) bool {
+		if msgs[i].Message.From != msgs[j].Message.From {
+			return msgs[i].Message.From.String() < msgs[j].Message.From.String()
+		}
+		return msgs[i].Message.Nonce < msgs[j].Message.Nonce
+	})
+
+	var loaded, dropped int
+	for _, sm := range msgs {
+		c := sm.Cid()
+		if mp.hasPending(ctx, sm) {
+			mp.snapshotted[c] = struct{}{}
+			continue
+		}
+		if err := mp.addSnapshotted(ctx, sm); err != nil {
+			log.Debugf("dropping snapshot message %s: %s", c, err)
+			if err := mp.snapshotMsgs.Delete(ctx, datastore.NewKey(string(c.Bytes()))); err != nil {
+				return fmt.Errorf("deleting snapshot message: %v", err)
+			}
+			dropped++
+			continue
+		}
+		mp.snapshotted[c] = struct{}{}
+		loaded++
+	}
+	log.Infof("loaded %d messages from the mpool snapshot, dropped %d invalid ones", loaded, dropped)
+
+	return nil
+}
+
+// addSnapshotted adds a message of the snapshot with the checks of the messages received from the network,
+// the caller holds mp.curTSLk and mp.lk.
+func (mp *MessagePool) addSnapshotted(ctx context.Context, m *types.SignedMessage) error {
+	if err := mp.checkMessage(ctx, m); err != nil {
+		return err
+	}
+
+	curTS := mp.curTS
+	if curTS == nil {
+		return fmt.Errorf("current tipset not loaded")
+	}
+
+	snonce, err := mp.getStateNonce(ctx, m.Message.From, curTS)
+	if err != nil {
+		return fmt.Errorf("failed to look up actor state nonce: %s: %w", err, ErrSoftValidationFailure)
+	}
+	if snonce > m.Message.Nonce {
+		return fmt.Errorf("minimum expected nonce is %d: %w", snonce, ErrNonceTooLow)
+	}
+
+	senderAct, err := mp.api.GetActorAfter(ctx, m.Message.From, curTS)
+	if err != nil {
+		return fmt.Errorf("failed to get sender actor: %w", err)
+	}
+	nv := mp.api.StateNetworkVersion(ctx, curTS.Height()+1)
+	if m.Signature.Type == crypto.SigTypeDelegated && !consensus.IsValidEthTxForSending(nv, m) {
+		return fmt.Errorf("network version should be atleast NV23 for sending legacy ETH transactions; but current network version is %d", nv)
+	}
+	if !consensus.IsValidForSending(nv, senderAct) {
+		return fmt.Errorf("sender actor %s is not a valid top-level sender", m.Message.From)
+	}
+
+	if _, err := mp.verifyMsgBeforeAdd(ctx, m, curTS, false); err != nil {
+		return err
+	}
+	if err := mp.checkBalance(ctx, m, curTS); err != nil {
+		return err
+	}
+
+	return mp.addLocked(ctx, m, true, true)
+}
+
+func (mp *MessagePool) hasPending(ctx context.Context, m *types.SignedMessage) bool {
+	mset, ok, err := mp.getPendingMset(ctx, m.Message.From)
+	if err != nil || !ok {
+		return false
+	}
+	exms, ok := mset.msgs[m.Message.Nonce]
+	return ok && exms.Cid() == m.Cid()
+}
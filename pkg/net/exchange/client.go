@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"sync"
 	"time"
 
 	cborutil "github.com/filecoin-project/go-cbor-util"
+	"github.com/ipfs/go-cid"
 	logging "github.com/ipfs/go-log"
 
 	"github.com/libp2p/go-libp2p/core/host"
@@ -39,6 +41,9 @@ type client struct {
 	host host.Host
 
 	peerTracker *bsPeerTracker
+
+	lk        sync.RWMutex
+	badBlocks BadBlockChecker
 }
 
 var _ Client = (*client)(nil)
@@ -91,6 +96,9 @@ func (c *client) doRequest(
 	if req.Options == 0 {
 		return nil, fmt.Errorf("request with no options set")
 	}
+	if err := c.checkBadBlocks(req.Head); err != nil {
+		return nil, fmt.Errorf("refusing to request a bad chain: %w", err)
+	}
 
 	// Generate the list of peers to be queried, either the
 	// `singlePeer` indicated or all peers available (sorted
@@ -206,6 +214,13 @@ func (c *client) processResponse(req *exchange.Request, res *exchange.Response,
 			return nil, fmt.Errorf("returned chain head does not match request")
 		}
 
+		// Refuse chains including known bad blocks.
+		for i, ts := range validRes.tipsets {
+			if err := c.checkBadBlocks(ts.Cids()); err != nil {
+				return nil, fmt.Errorf("bad tipset at height (head - %d): %w", i, err)
+			}
+		}
+
 		// Check `TipSet`s are connected (valid chain).
 		for i := 0; i < len(validRes.tipsets)-1; i++ {
 			if !validRes.tipsets[i].IsChildOf(validRes.tipsets[i+1]) {
@@ -464,6 +479,27 @@ func (c *client) sendRequestToPeer(ctx context.Context, peer peer.ID, req *excha
 	return &res, nil
 }
 
+// SetBadBlockChecker implements Client.SetBadBlockChecker(). Refer to the godocs there.
+func (c *client) SetBadBlockChecker(checker BadBlockChecker) {
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	c.badBlocks = checker
+}
+
+func (c *client) checkBadBlocks(cids []cid.Cid) error {
+	c.lk.RLock()
+	defer c.lk.RUnlock()
+	if c.badBlocks == nil {
+		return nil
+	}
+	for _, bcid := range cids {
+		if reason, bad := c.badBlocks.CheckBad(bcid); bad {
+			return fmt.Errorf("block %s is bad: %s", bcid, reason)
+		}
+	}
+	return nil
+}
+
 // AddPeer implements Client.AddPeer(). Refer to the godocs there.
 func (c *client) AddPeer(p peer.ID) {
 	c.peerTracker.addPeer(p)
@@ -3,6 +3,7 @@ package exchange
 import (
 	"context"
 
+	"github.com/ipfs/go-cid"
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/filecoin-project/venus/venus-shared/libp2p/exchange"
@@ -40,4 +41,13 @@ type Client interface {
 	// RemovePeer removes a peer from the pool of peers that the Client
 	// requests data from.
 	RemovePeer(peer peer.ID)
+
+	// SetBadBlockChecker sets the checker used to refuse responses containing
+	// known bad blocks.
+	SetBadBlockChecker(checker BadBlockChecker)
+}
+
+// BadBlockChecker reports whether a block is known to be bad, and why.
+type BadBlockChecker interface {
+	CheckBad(c cid.Cid) (string, bool)
 }
@@ -2,14 +2,17 @@ package net
 
 import (
 	"context"
+	"net"
 	"sort"
 
+	logging "github.com/ipfs/go-log/v2"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/metrics"
 	network2 "github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	basichost "github.com/libp2p/go-libp2p/p2p/host/basic"
+	"github.com/libp2p/go-libp2p/p2p/net/conngater"
 	swarm "github.com/libp2p/go-libp2p/p2p/net/swarm"
 	ma "github.com/multiformats/go-multiaddr"
 	manet "github.com/multiformats/go-multiaddr/net"
@@ -18,10 +21,13 @@ import (
 	"github.com/filecoin-project/venus/venus-shared/types"
 )
 
+var networkLog = logging.Logger("network")
+
 // Network is a unified interface for dealing with libp2p
 type Network struct {
 	host    host.Host
 	rawHost types.RawHost
+	gater   *conngater.BasicConnectionGater
 	metrics.Reporter
 	*Router
 }
@@ -30,12 +36,14 @@ type Network struct {
 func New(
 	host host.Host,
 	rawHost types.RawHost,
+	gater *conngater.BasicConnectionGater,
 	router *Router,
 	reporter metrics.Reporter,
 ) *Network {
 	return &Network{
 		host:     host,
 		rawHost:  rawHost,
+		gater:    gater,
 		Reporter: reporter,
 		Router:   router,
 	}
@@ -185,6 +193,119 @@ func (network *Network) ProtectList() ([]peer.ID, error) {
 	return result, nil
 }
 
+// BlockAdd adds the peers, IP addresses and subnets of acl to the connection gater and closes the
+// connections to them.
+func (network *Network) BlockAdd(acl types.NetBlockList) error {
+	for _, p := range acl.Peers {
+		if err := network.gater.BlockPeer(p); err != nil {
+			return errors.Wrapf(err, "error blocking peer %s", p)
+		}
+
+		if err := network.host.Network().ClosePeer(p); err != nil {
+			return errors.Wrapf(err, "error closing connections to peer %s", p)
+		}
+	}
+
+	for _, addr := range acl.IPAddrs {
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			return errors.Errorf("error parsing IP address %s", addr)
+		}
+
+		if err := network.gater.BlockAddr(ip); err != nil {
+			return errors.Wrapf(err, "error blocking IP address %s", addr)
+		}
+
+		if err := network.closeConns(ip.Equal); err != nil {
+			return err
+		}
+	}
+
+	for _, subnet := range acl.IPSubnets {
+		_, cidr, err := net.ParseCIDR(subnet)
+		if err != nil {
+			return errors.Wrapf(err, "error parsing subnet %s", subnet)
+		}
+
+		if err := network.gater.BlockSubnet(cidr); err != nil {
+			return errors.Wrapf(err, "error blocking subnet %s", subnet)
+		}
+
+		if err := network.closeConns(cidr.Contains); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// closeConns closes the connections whose remote IP address matches.
+func (network *Network) closeConns(match func(net.IP) bool) error {
+	for _, c := range network.host.Network().Conns() {
+		remote := c.RemoteMultiaddr()
+		ip, err := manet.ToIP(remote)
+		if err != nil {
+			continue
+		}
+
+		if match(ip) {
+			if err := c.Close(); err != nil {
+				// just log this, don't fail
+				networkLog.Warnf("error closing connection to %s: %s", remote, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// BlockRemove removes the peers, IP addresses and subnets of acl from the connection gater
+func (network *Network) BlockRemove(acl types.NetBlockList) error {
+	for _, p := range acl.Peers {
+		if err := network.gater.UnblockPeer(p); err != nil {
+			return errors.Wrapf(err, "error unblocking peer %s", p)
+		}
+	}
+
+	for _, addr := range acl.IPAddrs {
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			return errors.Errorf("error parsing IP address %s", addr)
+		}
+
+		if err := network.gater.UnblockAddr(ip); err != nil {
+			return errors.Wrapf(err, "error unblocking IP address %s", addr)
+		}
+	}
+
+	for _, subnet := range acl.IPSubnets {
+		_, cidr, err := net.ParseCIDR(subnet)
+		if err != nil {
+			return errors.Wrapf(err, "error parsing subnet %s", subnet)
+		}
+
+		if err := network.gater.UnblockSubnet(cidr); err != nil {
+			return errors.Wrapf(err, "error unblocking subnet %s", subnet)
+		}
+	}
+
+	return nil
+}
+
+// BlockList returns the peers, IP addresses and subnets refused by the connection gater
+func (network *Network) BlockList() (types.NetBlockList, error) {
+	var result types.NetBlockList
+	result.Peers = network.gater.ListBlockedPeers()
+	for _, ip := range network.gater.ListBlockedAddrs() {
+		result.IPAddrs = append(result.IPAddrs, ip.String())
+	}
+	for _, subnet := range network.gater.ListBlockedSubnets() {
+		result.IPSubnets = append(result.IPSubnets, subnet.String())
+	}
+
+	return result, nil
+}
+
 // Connectedness returns a state signaling connection capabilities
 func (network *Network) Connectedness(p peer.ID) (network2.Connectedness, error) {
 	return network.host.Network().Connectedness(p), nil
@@ -0,0 +1,74 @@
+package net_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/net/conngater"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/net"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func newTestHost(t *testing.T, opts ...libp2p.Option) host.Host {
+	h, err := libp2p.New(append(opts, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = h.Close() })
+	return h
+}
+
+func TestNetworkBlockList(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	ds := dssync.MutexWrap(datastore.NewMapDatastore())
+
+	gater, err := conngater.NewBasicConnectionGater(ds)
+	require.NoError(t, err)
+	h := newTestHost(t, libp2p.ConnectionGater(gater))
+	other := newTestHost(t)
+	nw := net.New(h, h, gater, nil, nil)
+
+	otherInfo := peer.AddrInfo{ID: other.ID(), Addrs: other.Addrs()}
+	require.NoError(t, h.Connect(ctx, otherInfo))
+
+	// blocking the address closes the existing connection and refuses new ones
+	require.NoError(t, nw.BlockAdd(types.NetBlockList{IPAddrs: []string{"127.0.0.1"}}))
+	assert.Equal(t, network.NotConnected, h.Network().Connectedness(other.ID()))
+	assert.Error(t, h.Connect(ctx, otherInfo))
+
+	require.NoError(t, nw.BlockAdd(types.NetBlockList{Peers: []peer.ID{other.ID()}, IPSubnets: []string{"10.0.0.0/8"}}))
+	acl, err := nw.BlockList()
+	require.NoError(t, err)
+	assert.Equal(t, types.NetBlockList{
+		Peers:     []peer.ID{other.ID()},
+		IPAddrs:   []string{"127.0.0.1"},
+		IPSubnets: []string{"10.0.0.0/8"},
+	}, acl)
+
+	// the block list is persisted
+	reloaded, err := conngater.NewBasicConnectionGater(ds)
+	require.NoError(t, err)
+	assert.Equal(t, []peer.ID{other.ID()}, reloaded.ListBlockedPeers())
+	assert.Len(t, reloaded.ListBlockedAddrs(), 1)
+	assert.Len(t, reloaded.ListBlockedSubnets(), 1)
+
+	require.NoError(t, nw.BlockRemove(acl))
+	acl, err = nw.BlockList()
+	require.NoError(t, err)
+	assert.Empty(t, acl.Peers)
+	assert.Empty(t, acl.IPAddrs)
+	assert.Empty(t, acl.IPSubnets)
+	require.NoError(t, h.Connect(ctx, otherInfo))
+
+	assert.Error(t, nw.BlockAdd(types.NetBlockList{IPAddrs: []string{"not an ip"}}))
+	assert.Error(t, nw.BlockAdd(types.NetBlockList{IPSubnets: []string{"10.0.0.0"}}))
+}
@@ -0,0 +1,197 @@
+package net
+
+import (
+	"strings"
+
+	network2 "github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	rcmgr "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
+	"github.com/pkg/errors"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// The scopes of the resource manager are named like in lotus: system, transient, svc:<service>,
+// proto:<protocol> and peer:<peer id>. The stat of all the scopes is queried with all.
+const (
+	ScopeAll       = "all"
+	ScopeSystem    = "system"
+	ScopeTransient = "transient"
+	ScopeService   = "svc:"
+	ScopeProtocol  = "proto:"
+	ScopePeer      = "peer:"
+)
+
+// ErrResourceManagerDisabled is returned when the resource manager of the host doesn't expose its scopes,
+// the resource manager is disabled in the swarm config.
+var ErrResourceManagerDisabled = errors.New("the libp2p resource manager is disabled")
+
+// Stat returns the resource usage of scope.
+func (network *Network) Stat(scope string) (types.NetStat, error) {
+	var result types.NetStat
+	rm := network.host.Network().ResourceManager()
+
+	switch {
+	case scope == ScopeAll:
+		rapi, ok := rm.(rcmgr.ResourceManagerState)
+		if !ok {
+			return result, ErrResourceManagerDisabled
+		}
+
+		stat := rapi.Stat()
+		result.System = &stat.System
+		result.Transient = &stat.Transient
+		if len(stat.Services) > 0 {
+			result.Services = stat.Services
+		}
+		if len(stat.Protocols) > 0 {
+			result.Protocols = make(map[string]network2.ScopeStat, len(stat.Protocols))
+			for proto, stat := range stat.Protocols {
+				result.Protocols[string(proto)] = stat
+			}
+		}
+		if len(stat.Peers) > 0 {
+			result.Peers = make(map[string]network2.ScopeStat, len(stat.Peers))
+			for p, stat := range stat.Peers {
+				result.Peers[p.String()] = stat
+			}
+		}
+
+		return result, nil
+
+	case scope == ScopeSystem:
+		err := rm.ViewSystem(func(s network2.ResourceScope) error {
+			stat := s.Stat()
+			result.System = &stat
+			return nil
+		})
+		return result, err
+
+	case scope == ScopeTransient:
+		err := rm.ViewTransient(func(s network2.ResourceScope) error {
+			stat := s.Stat()
+			result.Transient = &stat
+			return nil
+		})
+		return result, err
+
+	case strings.HasPrefix(scope, ScopeService):
+		svc := strings.TrimPrefix(scope, ScopeService)
+		err := rm.ViewService(svc, func(s network2.ServiceScope) error {
+			result.Services = map[string]network2.ScopeStat{svc: s.Stat()}
+			return nil
+		})
+		return result, err
+
+	case strings.HasPrefix(scope, ScopeProtocol):
+		proto := strings.TrimPrefix(scope, ScopeProtocol)
+		err := rm.ViewProtocol(protocol.ID(proto), func(s network2.ProtocolScope) error {
+			result.Protocols = map[string]network2.ScopeStat{proto: s.Stat()}
+			return nil
+		})
+		return result, err
+
+	case strings.HasPrefix(scope, ScopePeer):
+		p, err := peer.Decode(strings.TrimPrefix(scope, ScopePeer))
+		if err != nil {
+			return result, errors.Wrap(err, "invalid peer ID")
+		}
+		err = rm.ViewPeer(p, func(s network2.PeerScope) error {
+			result.Peers = map[string]network2.ScopeStat{p.String(): s.Stat()}
+			return nil
+		})
+		return result, err
+
+	default:
+		return result, errors.Errorf("invalid scope %s", scope)
+	}
+}
+
+// Limit returns the limit of scope.
+func (network *Network) Limit(scope string) (types.NetLimit, error) {
+	var result types.NetLimit
+	err := network.viewScope(scope, func(s network2.ResourceScope) error {
+		limiter, ok := s.(rcmgr.ResourceScopeLimiter)
+		if !ok {
+			return ErrResourceManagerDisabled
+		}
+
+		limit, ok := limiter.Limit().(*rcmgr.BaseLimit)
+		if !ok {
+			return errors.Errorf("unknown limit type %T", limiter.Limit())
+		}
+
+		result = types.NetLimit{
+			Memory:          limit.Memory,
+			Streams:         limit.Streams,
+			StreamsInbound:  limit.StreamsInbound,
+			StreamsOutbound: limit.StreamsOutbound,
+			Conns:           limit.Conns,
+			ConnsInbound:    limit.ConnsInbound,
+			ConnsOutbound:   limit.ConnsOutbound,
+			FD:              limit.FD,
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// SetLimit replaces the limit of scope, the new limit applies to the resources reserved from now on, the
+// resources already in use are not released. The limit is not persisted, it is reset by a restart.
+func (network *Network) SetLimit(scope string, limit types.NetLimit) error {
+	return network.viewScope(scope, func(s network2.ResourceScope) error {
+		limiter, ok := s.(rcmgr.ResourceScopeLimiter)
+		if !ok {
+			return ErrResourceManagerDisabled
+		}
+
+		limiter.SetLimit(&rcmgr.BaseLimit{
+			Memory:          limit.Memory,
+			Streams:         limit.Streams,
+			StreamsInbound:  limit.StreamsInbound,
+			StreamsOutbound: limit.StreamsOutbound,
+			Conns:           limit.Conns,
+			ConnsInbound:    limit.ConnsInbound,
+			ConnsOutbound:   limit.ConnsOutbound,
+			FD:              limit.FD,
+		})
+		return nil
+	})
+}
+
+// viewScope calls f with the scope of the resource manager named by scope.
+func (network *Network) viewScope(scope string, f func(network2.ResourceScope) error) error {
+	rm := network.host.Network().ResourceManager()
+
+	switch {
+	case scope == ScopeSystem:
+		return rm.ViewSystem(f)
+
+	case scope == ScopeTransient:
+		return rm.ViewTransient(f)
+
+	case strings.HasPrefix(scope, ScopeService):
+		return rm.ViewService(strings.TrimPrefix(scope, ScopeService), func(s network2.ServiceScope) error {
+			return f(s)
+		})
+
+	case strings.HasPrefix(scope, ScopeProtocol):
+		return rm.ViewProtocol(protocol.ID(strings.TrimPrefix(scope, ScopeProtocol)), func(s network2.ProtocolScope) error {
+			return f(s)
+		})
+
+	case strings.HasPrefix(scope, ScopePeer):
+		p, err := peer.Decode(strings.TrimPrefix(scope, ScopePeer))
+		if err != nil {
+			return errors.Wrap(err, "invalid peer ID")
+		}
+		return rm.ViewPeer(p, func(s network2.PeerScope) error {
+			return f(s)
+		})
+
+	default:
+		return errors.Errorf("invalid scope %s", scope)
+	}
+}
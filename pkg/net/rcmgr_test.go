@@ -0,0 +1,66 @@
+package net_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	rcmgr "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/net"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestNetworkResourceManager(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	limits := rcmgr.DefaultLimits
+	libp2p.SetDefaultServiceLimits(&limits)
+	rm, err := rcmgr.NewResourceManager(rcmgr.NewFixedLimiter(limits.Scale(1<<30, 1024)))
+	require.NoError(t, err)
+
+	h := newTestHost(t, libp2p.ResourceManager(rm))
+	other := newTestHost(t)
+	nw := net.New(h, h, nil, nil, nil)
+	require.NoError(t, h.Connect(ctx, peer.AddrInfo{ID: other.ID(), Addrs: other.Addrs()}))
+
+	stat, err := nw.Stat(net.ScopeAll)
+	require.NoError(t, err)
+	require.NotNil(t, stat.System)
+	assert.Equal(t, 1, stat.System.NumConnsOutbound)
+	assert.Contains(t, stat.Peers, other.ID().String())
+
+	stat, err = nw.Stat(net.ScopePeer + other.ID().String())
+	require.NoError(t, err)
+	assert.Nil(t, stat.System)
+	assert.Equal(t, 1, stat.Peers[other.ID().String()].NumConnsOutbound)
+
+	limit, err := nw.Limit(net.ScopeSystem)
+	require.NoError(t, err)
+	assert.Greater(t, limit.Conns, 0)
+	assert.Greater(t, limit.Memory, int64(0))
+
+	// the new limit applies right away, the node refuses connections above it
+	limit.ConnsOutbound = 1
+	require.NoError(t, nw.SetLimit(net.ScopeSystem, limit))
+	got, err := nw.Limit(net.ScopeSystem)
+	require.NoError(t, err)
+	assert.Equal(t, limit, got)
+
+	third := newTestHost(t)
+	assert.Error(t, h.Connect(ctx, peer.AddrInfo{ID: third.ID(), Addrs: third.Addrs()}))
+
+	_, err = nw.Limit("unknown")
+	assert.Error(t, err)
+	_, err = nw.Stat(net.ScopePeer + "not a peer")
+	assert.Error(t, err)
+
+	disabled := newTestHost(t, libp2p.ResourceManager(&network.NullResourceManager{}))
+	_, err = net.New(disabled, disabled, nil, nil, nil).Limit(net.ScopeSystem)
+	assert.ErrorIs(t, err, net.ErrResourceManagerDisabled)
+}
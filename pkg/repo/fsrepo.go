@@ -39,6 +39,7 @@ const (
 	metaDatastorePrefix    = "metadata"
 	paychDatastorePrefix   = "paych"
 	snapshotFilenamePrefix = "snapshot"
+	markSetPrefix          = "markset"
 	dataTransfer           = "data-transfer"
 	fsSqlite               = "sqlite"
 )
@@ -53,6 +54,7 @@ type FSRepo struct {
 	lk sync.RWMutex
 
 	ds       *blockstoreutil.BadgerBlockstore
+	splitDs  *blockstoreutil.SplitStore
 	keystore fskeystore.Keystore
 	walletDs Datastore
 	chainDs  Datastore
@@ -251,20 +253,35 @@ func (r *FSRepo) ReplaceConfig(cfg *config.Config) error {
 	defer r.lk.Unlock()
 
 	Config = cfg
+	return r.writeConfig(cfg)
+}
+
+// WriteConfig writes cfg to the config file without replacing the current config.
+func (r *FSRepo) WriteConfig(cfg *config.Config) error {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+
+	return r.writeConfig(cfg)
+}
+
+func (r *FSRepo) writeConfig(cfg *config.Config) error {
 	tmp := filepath.Join(r.path, tempConfigFilename)
 	err := os.RemoveAll(tmp)
 	if err != nil {
 		return err
 	}
-	err = Config.WriteFile(tmp)
+	err = cfg.WriteFile(tmp)
 	if err != nil {
 		return err
 	}
 	return os.Rename(tmp, filepath.Join(r.path, configFilename))
 }
 
-// Datastore returns the datastore.
+// Datastore returns the datastore, it is the splitstore when the splitstore is enabled.
 func (r *FSRepo) Datastore() blockstoreutil.Blockstore {
+	if r.splitDs != nil {
+		return r.splitDs
+	}
 	return r.ds
 }
 
@@ -298,6 +315,12 @@ func (r *FSRepo) Keystore() fskeystore.Keystore {
 
 // Close closes the repo.
 func (r *FSRepo) Close() error {
+	if r.splitDs != nil {
+		if err := r.splitDs.Close(); err != nil {
+			return errors.Wrap(err, "failed to close splitstore")
+		}
+	}
+
 	if err := r.ds.Close(); err != nil {
 		return errors.Wrap(err, "failed to close datastore")
 	}
@@ -394,6 +417,50 @@ func (r *FSRepo) openDatastore() error {
 		return fmt.Errorf("unknown datastore type in config: %s", Config.Datastore.Type)
 	}
 
+	if Config.Datastore.Splitstore.Enable {
+		return r.openSplitstore()
+	}
+
+	return nil
+}
+
+// openSplitstore wraps the repo blockstore into a splitstore according to the configured mode.
+func (r *FSRepo) openSplitstore() error {
+	cfg := Config.Datastore.Splitstore
+	if cfg.Mode == config.SplitstoreHotOnly {
+		splitDs, err := blockstoreutil.NewSplitStore(r.ds, nil, false, filepath.Join(r.path, markSetPrefix))
+		if err != nil {
+			return err
+		}
+		r.splitDs = splitDs
+		return nil
+	}
+
+	opts, err := blockstoreutil.BadgerBlockstoreOptions(filepath.Join(r.path, cfg.HotStorePath), false)
+	if err != nil {
+		return err
+	}
+	opts.Prefix = bstore.BlockPrefix.String()
+	hot, err := blockstoreutil.Open(opts)
+	if err != nil {
+		return errors.Wrap(err, "failed to open hot store")
+	}
+
+	var splitDs *blockstoreutil.SplitStore
+	switch cfg.Mode {
+	case config.SplitstoreKeepCold:
+		splitDs, err = blockstoreutil.NewSplitStore(hot, r.ds, true, filepath.Join(r.path, markSetPrefix))
+	case config.SplitstoreDiscard:
+		splitDs, err = blockstoreutil.NewSplitStore(hot, r.ds, false, filepath.Join(r.path, markSetPrefix))
+	default:
+		err = fmt.Errorf("unknown splitstore mode in config: %s", cfg.Mode)
+	}
+	if err != nil {
+		_ = hot.Close()
+		return err
+	}
+	r.splitDs = splitDs
+
 	return nil
 }
 
@@ -10,12 +10,14 @@ import (
 	"strconv"
 	"testing"
 
+	blocks "github.com/ipfs/go-block-format"
 	ds "github.com/ipfs/go-datastore"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/filecoin-project/venus/pkg/config"
 	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
 )
 
 func TestInitRepoDirect(t *testing.T) {
@@ -114,6 +116,40 @@ func TestFSRepoRoundtrip(t *testing.T) {
 	assert.NoError(t, r2.Close())
 }
 
+func TestFSRepoSplitstore(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	blk := blocks.NewBlock([]byte("splitstore"))
+
+	for _, mode := range []string{config.SplitstoreHotOnly, config.SplitstoreKeepCold, config.SplitstoreDiscard} {
+		t.Run(mode, func(t *testing.T) {
+			cfg := config.NewDefaultConfig()
+			cfg.Datastore.Splitstore.Enable = true
+			cfg.Datastore.Splitstore.Mode = mode
+
+			repoPath := path.Join(t.TempDir(), "repo")
+			require.NoError(t, InitFSRepo(repoPath, 42, cfg))
+
+			r, err := OpenFSRepo(repoPath, 42)
+			require.NoError(t, err)
+
+			ss, ok := r.Datastore().(*blockstoreutil.SplitStore)
+			require.True(t, ok)
+			assert.Equal(t, mode == config.SplitstoreHotOnly, ss.Cold() == nil)
+			require.NoError(t, r.Datastore().Put(ctx, blk))
+			require.NoError(t, r.Close())
+
+			r2, err := OpenFSRepo(repoPath, 42)
+			require.NoError(t, err)
+			has, err := r2.Datastore().Has(ctx, blk.Cid())
+			require.NoError(t, err)
+			assert.True(t, has)
+			require.NoError(t, r2.Close())
+		})
+	}
+}
+
 func TestFSRepoReplaceAndSnapshotConfig(t *testing.T) {
 	tf.UnitTest(t)
 
@@ -132,12 +168,18 @@ func TestFSRepoReplaceAndSnapshotConfig(t *testing.T) {
 	// stm: @REPO_FSREPO_REPLACE_CONFIG_001, @REPO_FSREPO_SNAPSHOT_CONFIG_001
 	assert.NoError(t, r1.ReplaceConfig(newCfg))
 	assert.Equal(t, "bar", r1.Config().API.APIAddress)
+
+	// the written config is only loaded by the next open
+	writtenCfg := config.NewDefaultConfig()
+	writtenCfg.API.APIAddress = "baz"
+	assert.NoError(t, r1.WriteConfig(writtenCfg))
+	assert.Equal(t, "bar", r1.Config().API.APIAddress)
 	// stm: REPO_FSREPO_CLOSE_001
 	assert.NoError(t, r1.Close())
 
 	r2, err := OpenFSRepo(repoPath, 42)
 	assert.NoError(t, err)
-	assert.Equal(t, "bar", r2.Config().API.APIAddress)
+	assert.Equal(t, "baz", r2.Config().API.APIAddress)
 	assert.NoError(t, r2.Close())
 }
 
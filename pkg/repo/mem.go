@@ -71,6 +71,11 @@ func (mr *MemRepo) ReplaceConfig(cfg *config.Config) error {
 	return nil
 }
 
+// WriteConfig is a no-op, the in-memory repo has no config file.
+func (mr *MemRepo) WriteConfig(cfg *config.Config) error {
+	return nil
+}
+
 // Datastore returns the datastore.
 func (mr *MemRepo) Datastore() blockstoreutil.Blockstore {
 	return mr.D
@@ -18,6 +18,9 @@ type Repo interface {
 	Config() *config.Config
 	// ReplaceConfig replaces the current config, with the newly passed in one.
 	ReplaceConfig(cfg *config.Config) error
+	// WriteConfig saves cfg as the config of the repo without replacing the current config, the
+	// node runs with it after a restart.
+	WriteConfig(cfg *config.Config) error
 
 	// Datastore is a general storage solution for things like blocks.
 	Datastore() blockstoreutil.Blockstore
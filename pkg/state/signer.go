@@ -22,14 +22,17 @@ type tipSignerView interface {
 // Signer looks up non-signing addresses before signing
 type Signer struct {
 	wallet     *wallet.Wallet
+	sign       wallet.WalletSignFunc
 	signerView AccountView
 }
 
-// NewSigner creates a new signer
-func NewSigner(signerView AccountView, wallet *wallet.Wallet) *Signer {
+// NewSigner creates a new signer, the bytes are signed with sign, which signs with the keys of wallet
+// through the checks of the caller, e.g. the wallet policies.
+func NewSigner(signerView AccountView, wallet *wallet.Wallet, sign wallet.WalletSignFunc) *Signer {
 	return &Signer{
 		signerView: signerView,
 		wallet:     wallet,
+		sign:       sign,
 	}
 }
 
@@ -39,7 +42,7 @@ func (s *Signer) SignBytes(ctx context.Context, data []byte, addr address.Addres
 	if err != nil {
 		return nil, err
 	}
-	return s.wallet.SignBytes(ctx, data, signingAddr)
+	return s.sign(ctx, signingAddr, data, types.MsgMeta{Type: types.MTUnknown})
 }
 
 // HasAddress returns whether this signer can sign with the given address
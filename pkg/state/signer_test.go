@@ -30,7 +30,7 @@ func TestSigner(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	signer := NewSigner(&mockAccountView{}, wallet)
+	signer := NewSigner(&mockAccountView{}, wallet, wallet.WalletSign)
 	// stm: @STATE_VIEW_SIGN_BYTES_001
 	_, err = signer.SignBytes(ctx, []byte("to sign data"), walletAddr)
 	assert.NoError(t, err)
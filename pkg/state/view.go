@@ -26,6 +26,7 @@ import (
 	notinit "github.com/filecoin-project/venus/venus-shared/actors/builtin/init"
 	"github.com/filecoin-project/venus/venus-shared/actors/builtin/market"
 	lminer "github.com/filecoin-project/venus/venus-shared/actors/builtin/miner"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin/multisig"
 	paychActor "github.com/filecoin-project/venus/venus-shared/actors/builtin/paych"
 	"github.com/filecoin-project/venus/venus-shared/actors/builtin/power"
 	"github.com/filecoin-project/venus/venus-shared/actors/builtin/reward"
@@ -728,6 +729,11 @@ func (v *View) LoadPaychState(ctx context.Context, actor *types.Actor) (paychAct
 	return paychActor.Load(adt.WrapStore(context.TODO(), v.ipldStore), actor)
 }
 
+// LoadMultisigState get multisig state for actor
+func (v *View) LoadMultisigState(ctx context.Context, actor *types.Actor) (multisig.State, error) {
+	return multisig.Load(adt.WrapStore(ctx, v.ipldStore), actor)
+}
+
 // LoadMinerState return miner state
 func (v *View) LoadMinerState(ctx context.Context, maddr addr.Address) (lminer.State, error) {
 	resolvedAddr, err := v.InitResolveAddress(ctx, maddr)
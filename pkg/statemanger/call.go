@@ -2,6 +2,7 @@ package statemanger
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/filecoin-project/venus/pkg/chain"
@@ -55,12 +56,22 @@ func (s *Stmgr) Call(ctx context.Context, msg *types.Message, ts *types.TipSet)
 		msg.Value = types.NewInt(0)
 	}
 
-	return s.callInternal(ctx, msg, nil, ts, cid.Undef, s.GetNetworkVersion, false, execSameSenderMessages)
+	return s.callInternal(ctx, msg, nil, ts, cid.Undef, s.GetNetworkVersion, false, execSameSenderMessages, nil)
 }
 
 // ApplyOnStateWithGas applies the given message on top of the given state root with gas tracing enabled
 func (s *Stmgr) ApplyOnStateWithGas(ctx context.Context, stateCid cid.Cid, msg *types.Message, ts *types.TipSet) (*types.InvocResult, error) {
-	return s.callInternal(ctx, msg, nil, ts, stateCid, s.GetNetworkVersion, true, execNoMessages)
+	return s.callInternal(ctx, msg, nil, ts, stateCid, s.GetNetworkVersion, true, execNoMessages, nil)
+}
+
+// ApplyOnStateWithStates is ApplyOnStateWithGas returning the state roots before and after the message.
+func (s *Stmgr) ApplyOnStateWithStates(ctx context.Context, stateCid cid.Cid, msg *types.Message, ts *types.TipSet) (*types.InvocResult, *CallStates, error) {
+	states := &CallStates{}
+	res, err := s.callInternal(ctx, msg, nil, ts, stateCid, s.GetNetworkVersion, true, execNoMessages, states)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, states, nil
 }
 
 // CallWithGas calculates the state for a given tipset, and then applies the given message on top of that state.
@@ -71,7 +82,7 @@ func (s *Stmgr) CallWithGas(ctx context.Context, msg *types.Message, priorMsgs [
 	} else {
 		strategy = execSameSenderMessages
 	}
-	return s.callInternal(ctx, msg, priorMsgs, ts, cid.Undef, s.GetNetworkVersion, true, strategy)
+	return s.callInternal(ctx, msg, priorMsgs, ts, cid.Undef, s.GetNetworkVersion, true, strategy, nil)
 }
 
 // CallAtStateAndVersion allows you to specify a message to execute on the given stateCid and network version.
@@ -83,7 +94,7 @@ func (s *Stmgr) CallAtStateAndVersion(ctx context.Context, msg *types.Message, s
 		return v
 	}
 
-	return s.callInternal(ctx, msg, nil, nil, stateCid, nvGetter, true, execSameSenderMessages)
+	return s.callInternal(ctx, msg, nil, nil, stateCid, nvGetter, true, execSameSenderMessages, nil)
 }
 
 //   - If no tipset is specified, the first tipset without an expensive migration or one in its parent is used.
@@ -97,6 +108,7 @@ func (s *Stmgr) callInternal(ctx context.Context,
 	nvGetter chain.NetworkVersionGetter,
 	checkGas bool,
 	strategy execMessageStrategy,
+	states *CallStates,
 ) (*types.InvocResult, error) {
 	ctx, span := trace.StartSpan(ctx, "statemanager.callInternal")
 	defer span.End()
@@ -158,31 +170,8 @@ func (s *Stmgr) callInternal(ctx context.Context,
 		)
 	}
 
-	random := chain.NewChainRandomnessSource(s.cs, ts.Key(), s.beacon, s.GetNetworkVersion)
 	buffStore := blockstoreutil.NewTieredBstore(s.cs.Blockstore(), blockstoreutil.NewTemporarySync())
-	vmopt := vm.VmOption{
-		CircSupplyCalculator: func(ctx context.Context, epoch abi.ChainEpoch, tree tree.Tree) (abi.TokenAmount, error) {
-			cs, err := s.circulatingSupplyCalculator.GetCirculatingSupplyDetailed(ctx, epoch, tree)
-			if err != nil {
-				return abi.TokenAmount{}, err
-			}
-			return cs.FilCirculating, nil
-		},
-		PRoot:               stateCid,
-		Epoch:               ts.Height(),
-		Timestamp:           ts.MinTimestamp(),
-		Rnd:                 random,
-		Bsstore:             buffStore,
-		SysCallsImpl:        s.syscallsImpl,
-		GasPriceSchedule:    s.gasSchedule,
-		NetworkVersion:      nvGetter(ctx, ts.Height()),
-		BaseFee:             ts.Blocks()[0].ParentBaseFee,
-		Fork:                s.fork,
-		LookbackStateGetter: vmcontext.LookbackStateGetterForTipset(ctx, s.cs, s.fork, ts),
-		TipSetGetter:        vmcontext.TipSetGetterForTipset(s.cs.GetTipSetByHeight, ts),
-		Tracing:             true,
-		ActorDebugging:      s.actorDebugging,
-	}
+	vmopt := s.callVMOption(ctx, ts, stateCid, nvGetter, buffStore)
 	vmi, err := fvm.NewVM(ctx, vmopt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to set up vm: %w", err)
@@ -255,30 +244,7 @@ func (s *Stmgr) callInternal(ctx context.Context,
 			return nil, fmt.Errorf("could not resolve key: %w", err)
 		}
 
-		var msgApply types.ChainMsg
-
-		switch fromKey.Protocol() {
-		case address.BLS:
-			msgApply = msg
-		case address.SECP256K1:
-			msgApply = &types.SignedMessage{
-				Message: *msg,
-				Signature: crypto.Signature{
-					Type: crypto.SigTypeSecp256k1,
-					Data: make([]byte, 65),
-				},
-			}
-		case address.Delegated:
-			msgApply = &types.SignedMessage{
-				Message: *msg,
-				Signature: crypto.Signature{
-					Type: crypto.SigTypeDelegated,
-					Data: make([]byte, 65),
-				},
-			}
-		}
-
-		ret, err = vmi.ApplyMessage(ctx, msgApply)
+		ret, err = vmi.ApplyMessage(ctx, fakeSignedMessage(msg, fromKey))
 		if err != nil {
 			return nil, fmt.Errorf("gas estimation failed: %w", err)
 		}
@@ -295,6 +261,16 @@ func (s *Stmgr) callInternal(ctx context.Context,
 		errs = ret.ActorErr.Error()
 	}
 
+	if states != nil {
+		post, err := vmi.Flush(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("flushing vm: %w", err)
+		}
+		states.Pre = stateCid
+		states.Post = post
+		states.Store = cbor.NewCborStore(buffStore)
+	}
+
 	return &types.InvocResult{
 		MsgCid:         msg.Cid(),
 		Msg:            msg,
@@ -305,3 +281,261 @@ func (s *Stmgr) callInternal(ctx context.Context,
 		Duration:       ret.Duration,
 	}, err
 }
+
+// CallStates are the state roots before and after a message, they are read from Store as the state after
+// the message is not persisted.
+type CallStates struct {
+	Pre   cid.Cid
+	Post  cid.Cid
+	Store cbor.IpldStore
+}
+
+// ReplayCallback is called by ReplayWithStates with the result of each message and the states around it,
+// the replay stops once it returns ErrStopReplay.
+type ReplayCallback func(msg types.ChainMsg, res *types.InvocResult, states *CallStates) error
+
+// ErrStopReplay stops ReplayWithStates without error.
+var ErrStopReplay = fmt.Errorf("stop replay")
+
+// ReplayWithStates applies the messages of ts one by one on the parent state of ts, at the epoch of ts, and
+// calls cb with the states before and after each message. Unlike RunStateTransition it neither runs cron
+// nor pays the block rewards, so the states only reflect the messages.
+func (s *Stmgr) ReplayWithStates(ctx context.Context, ts *types.TipSet, cb ReplayCallback) error {
+	ctx, span := trace.StartSpan(ctx, "statemanager.ReplayWithStates")
+	defer span.End()
+
+	if ts.Height() > 0 {
+		pts, err := s.cs.GetTipSet(ctx, ts.Parents())
+		if err != nil {
+			return fmt.Errorf("failed to find a non-forking epoch: %w", err)
+		}
+		if s.fork.HasExpensiveForkBetween(pts.Height(), ts.Height()+1) {
+			return fork.ErrExpensiveFork
+		}
+	}
+
+	stateCid, err := s.fork.HandleStateForks(ctx, ts.ParentState(), ts.Height(), ts)
+	if err != nil {
+		return fmt.Errorf("failed to handle fork: %w", err)
+	}
+
+	buffStore := blockstoreutil.NewTieredBstore(s.cs.Blockstore(), blockstoreutil.NewTemporarySync())
+	vmi, err := fvm.NewVM(ctx, s.callVMOption(ctx, ts, stateCid, s.GetNetworkVersion, buffStore))
+	if err != nil {
+		return fmt.Errorf("failed to set up vm: %w", err)
+	}
+
+	msgs, err := s.ms.MessagesForTipset(ts)
+	if err != nil {
+		return fmt.Errorf("failed to lookup messages for tipset: %w", err)
+	}
+
+	store := cbor.NewCborStore(buffStore)
+	for _, m := range msgs {
+		ret, err := vmi.ApplyMessage(ctx, m)
+		if err != nil {
+			return fmt.Errorf("applying message %s: %w", m.Cid(), err)
+		}
+		post, err := vmi.Flush(ctx)
+		if err != nil {
+			return fmt.Errorf("flushing vm: %w", err)
+		}
+
+		res := &types.InvocResult{
+			MsgCid:         m.Cid(),
+			Msg:            m.VMMessage(),
+			MsgRct:         &ret.Receipt,
+			GasCost:        MakeMsgGasCost(m.VMMessage(), ret),
+			ExecutionTrace: ret.GasTracker.ExecutionTrace,
+			Duration:       ret.Duration,
+		}
+		if ret.ActorErr != nil {
+			res.Error = ret.ActorErr.Error()
+		}
+
+		err = cb(m, res, &CallStates{Pre: stateCid, Post: post, Store: store})
+		if errors.Is(err, ErrStopReplay) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		stateCid = post
+	}
+
+	return nil
+}
+
+// fakeSignedMessage returns msg with a signature of the type of fromKey, so that applying it is charged the
+// gas of the signature. The signature itself isn't checked by the vm.
+func fakeSignedMessage(msg *types.Message, fromKey address.Address) types.ChainMsg {
+	switch fromKey.Protocol() {
+	case address.SECP256K1:
+		return &types.SignedMessage{
+			Message: *msg,
+			Signature: crypto.Signature{
+				Type: crypto.SigTypeSecp256k1,
+				Data: make([]byte, 65),
+			},
+		}
+	case address.Delegated:
+		return &types.SignedMessage{
+			Message: *msg,
+			Signature: crypto.Signature{
+				Type: crypto.SigTypeDelegated,
+				Data: make([]byte, 65),
+			},
+		}
+	default:
+		return msg
+	}
+}
+
+// SimulationBlock is a batch of messages of a simulation, applied after Override changed the state, bs
+// being the blockstore of the state.
+type SimulationBlock struct {
+	Override func(ctx context.Context, bs blockstoreutil.Blockstore, st *tree.State) error
+	Msgs     []*types.Message
+}
+
+// SimulatedCall is the result of a message of a simulation, with the events it emitted and the states
+// around it.
+type SimulatedCall struct {
+	Result *types.InvocResult
+	Events []types.Event
+	States *CallStates
+}
+
+// Simulate applies the blocks one after another on stateCid, the state computed by ts, the first one at
+// the epoch following ts and each following one at the next epoch, blockDelay seconds after the previous
+// one. It returns the results of their messages. The messages are applied with a zero base fee, the
+// nonces of their senders are taken from the state. The overrides and the messages only change a copy of
+// the state kept in memory.
+func (s *Stmgr) Simulate(ctx context.Context, ts *types.TipSet, stateCid cid.Cid, blockDelay uint64, blocks []SimulationBlock) ([][]*SimulatedCall, error) {
+	ctx, span := trace.StartSpan(ctx, "statemanager.Simulate")
+	defer span.End()
+
+	if ts.Height() > 0 {
+		pts, err := s.cs.GetTipSet(ctx, ts.Parents())
+		if err != nil {
+			return nil, fmt.Errorf("failed to find a non-forking epoch: %w", err)
+		}
+		if s.fork.HasExpensiveForkBetween(pts.Height(), ts.Height()+abi.ChainEpoch(len(blocks))) {
+			return nil, fork.ErrExpensiveFork
+		}
+	}
+
+	buffStore := blockstoreutil.NewTieredBstore(s.cs.Blockstore(), blockstoreutil.NewTemporarySync())
+	store := cbor.NewCborStore(buffStore)
+	results := make([][]*SimulatedCall, 0, len(blocks))
+	for i, blk := range blocks {
+		epoch := ts.Height() + 1 + abi.ChainEpoch(i)
+
+		var err error
+		if stateCid, err = s.fork.HandleStateForks(ctx, stateCid, epoch-1, ts); err != nil {
+			return nil, fmt.Errorf("failed to handle fork: %w", err)
+		}
+		st, err := tree.LoadState(ctx, store, stateCid)
+		if err != nil {
+			return nil, fmt.Errorf("loading state: %w", err)
+		}
+		if blk.Override != nil {
+			if err := blk.Override(ctx, buffStore, st); err != nil {
+				return nil, fmt.Errorf("overriding state of block %d: %w", i, err)
+			}
+			if stateCid, err = st.Flush(ctx); err != nil {
+				return nil, fmt.Errorf("flushing state: %w", err)
+			}
+		}
+
+		vmopt := s.callVMOption(ctx, ts, stateCid, s.GetNetworkVersion, buffStore)
+		vmopt.Epoch = epoch
+		vmopt.Timestamp = ts.MinTimestamp() + uint64(1+i)*blockDelay
+		vmopt.NetworkVersion = s.GetNetworkVersion(ctx, epoch)
+		vmopt.BaseFee = big.Zero()
+		vmopt.ReturnEvents = true
+		vmi, err := fvm.NewVM(ctx, vmopt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set up vm: %w", err)
+		}
+
+		calls := make([]*SimulatedCall, 0, len(blk.Msgs))
+		for _, msg := range blk.Msgs {
+			msgCopy := *msg
+			msg = &msgCopy
+
+			fromActor, found, err := st.GetActor(ctx, msg.From)
+			if err != nil {
+				return nil, fmt.Errorf("get sender actor: %w", err)
+			}
+			fromKey := msg.From
+			if found {
+				msg.Nonce = fromActor.Nonce
+				if fromActor.DelegatedAddress != nil {
+					fromKey = *fromActor.DelegatedAddress
+				}
+			}
+
+			ret, err := vmi.ApplyMessage(ctx, fakeSignedMessage(msg, fromKey))
+			if err != nil {
+				return nil, fmt.Errorf("applying message %d of block %d: %w", len(calls), i, err)
+			}
+			post, err := vmi.Flush(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("flushing vm: %w", err)
+			}
+
+			res := &types.InvocResult{
+				MsgCid:         msg.Cid(),
+				Msg:            msg,
+				MsgRct:         &ret.Receipt,
+				GasCost:        MakeMsgGasCost(msg, ret),
+				ExecutionTrace: ret.GasTracker.ExecutionTrace,
+				Duration:       ret.Duration,
+			}
+			if ret.ActorErr != nil {
+				res.Error = ret.ActorErr.Error()
+			}
+			calls = append(calls, &SimulatedCall{
+				Result: res,
+				Events: ret.Events,
+				States: &CallStates{Pre: stateCid, Post: post, Store: store},
+			})
+
+			stateCid = post
+			if st, err = tree.LoadState(ctx, store, stateCid); err != nil {
+				return nil, fmt.Errorf("loading state: %w", err)
+			}
+		}
+		results = append(results, calls)
+	}
+
+	return results, nil
+}
+
+// callVMOption returns the options of a vm applying messages on stateCid at the epoch of ts.
+func (s *Stmgr) callVMOption(ctx context.Context, ts *types.TipSet, stateCid cid.Cid, nvGetter chain.NetworkVersionGetter, buffStore blockstoreutil.Blockstore) vm.VmOption {
+	return vm.VmOption{
+		CircSupplyCalculator: func(ctx context.Context, epoch abi.ChainEpoch, tree tree.Tree) (abi.TokenAmount, error) {
+			cs, err := s.circulatingSupplyCalculator.GetCirculatingSupplyDetailed(ctx, epoch, tree)
+			if err != nil {
+				return abi.TokenAmount{}, err
+			}
+			return cs.FilCirculating, nil
+		},
+		PRoot:               stateCid,
+		Epoch:               ts.Height(),
+		Timestamp:           ts.MinTimestamp(),
+		Rnd:                 chain.NewChainRandomnessSource(s.cs, ts.Key(), s.beacon, s.GetNetworkVersion),
+		Bsstore:             buffStore,
+		SysCallsImpl:        s.syscallsImpl,
+		GasPriceSchedule:    s.gasSchedule,
+		NetworkVersion:      nvGetter(ctx, ts.Height()),
+		BaseFee:             ts.Blocks()[0].ParentBaseFee,
+		Fork:                s.fork,
+		LookbackStateGetter: vmcontext.LookbackStateGetterForTipset(ctx, s.cs, s.fork, ts),
+		TipSetGetter:        vmcontext.TipSetGetterForTipset(s.cs.GetTipSetByHeight, ts),
+		Tracing:             true,
+		ActorDebugging:      s.actorDebugging,
+	}
+}
@@ -121,3 +121,11 @@ func ManageFdLimit() (changed bool, newLimit uint64, err error) {
 
 	return newLimit > 0, newLimit, err
 }
+
+// GetLimit returns the soft and hard limits of file descriptors counts of the process
+func GetLimit() (uint64, uint64, error) {
+	if !supportsFDManagement {
+		return 0, 0, fmt.Errorf("cannot get file descriptor limit on this platform")
+	}
+	return getLimit()
+}
@@ -0,0 +1,130 @@
+package wallet
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/filecoin-project/go-address"
+	"github.com/pkg/errors"
+)
+
+const (
+	// hardenedOffset is the first index of the hardened child keys
+	hardenedOffset = 0x80000000
+
+	// FilecoinCoinType is the BIP-44 coin type of filecoin, the secp256k1 keys are derived under it
+	FilecoinCoinType = 461
+	// EthereumCoinType is the BIP-44 coin type of ethereum, the delegated keys are derived under it so that
+	// the f410 addresses match the ones of the ethereum wallets using the same mnemonic
+	EthereumCoinType = 60
+)
+
+var errInvalidChildKey = errors.New("invalid child key")
+
+// HDPath returns the BIP-44 path of the index-th key of protocol, m/44'/coin'/0'/0/index
+func HDPath(protocol address.Protocol, index uint32) (string, error) {
+	var coin int
+	switch protocol {
+	case address.SECP256K1:
+		coin = FilecoinCoinType
+	case address.Delegated:
+		coin = EthereumCoinType
+	default:
+		return "", errors.Errorf("HD wallet can't derive keys of address protocol %d", protocol)
+	}
+	if index >= hardenedOffset {
+		return "", errors.Errorf("index %d is out of range", index)
+	}
+	return fmt.Sprintf("m/44'/%d'/0'/0/%d", coin, index), nil
+}
+
+// parseHDPath parses a path like m/44'/461'/0'/0/0 into the indexes of the child keys
+func parseHDPath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) == 0 || parts[0] != "m" {
+		return nil, errors.Errorf("invalid HD path %s", path)
+	}
+
+	out := make([]uint32, 0, len(parts)-1)
+	for _, p := range parts[1:] {
+		var offset uint32
+		if strings.HasSuffix(p, "'") {
+			offset = hardenedOffset
+			p = p[:len(p)-1]
+		}
+		i, err := strconv.ParseUint(p, 10, 31)
+		if err != nil {
+			return nil, errors.Errorf("invalid HD path %s: %v", path, err)
+		}
+		out = append(out, uint32(i)+offset)
+	}
+	return out, nil
+}
+
+// extendedKey is a BIP-32 private key with its chain code
+type extendedKey struct {
+	key       []byte
+	chainCode []byte
+}
+
+func newMasterKey(seed []byte) (*extendedKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	_, _ = mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	var k secp256k1.ModNScalar
+	if overflow := k.SetByteSlice(sum[:32]); overflow || k.IsZero() {
+		return nil, errors.New("invalid master key, use another seed")
+	}
+	return &extendedKey{key: sum[:32], chainCode: sum[32:]}, nil
+}
+
+func (k *extendedKey) child(i uint32) (*extendedKey, error) {
+	data := make([]byte, 0, 37)
+	if i >= hardenedOffset {
+		data = append(data, 0)
+		data = append(data, k.key...)
+	} else {
+		data = append(data, secp256k1.PrivKeyFromBytes(k.key).PubKey().SerializeCompressed()...)
+	}
+	data = binary.BigEndian.AppendUint32(data, i)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	_, _ = mac.Write(data)
+	sum := mac.Sum(nil)
+
+	var il, parent secp256k1.ModNScalar
+	if overflow := il.SetByteSlice(sum[:32]); overflow {
+		return nil, errInvalidChildKey
+	}
+	parent.SetByteSlice(k.key)
+	il.Add(&parent)
+	if il.IsZero() {
+		return nil, errInvalidChildKey
+	}
+	key := il.Bytes()
+	return &extendedKey{key: key[:], chainCode: sum[32:]}, nil
+}
+
+// deriveKey returns the secp256k1 private key at path from the BIP-32 seed
+func deriveKey(seed []byte, path string) ([]byte, error) {
+	indexes, err := parseHDPath(path)
+	if err != nil {
+		return nil, err
+	}
+	k, err := newMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range indexes {
+		if k, err = k.child(i); err != nil {
+			return nil, errors.Wrapf(err, "derive %s", path)
+		}
+	}
+	return k.key, nil
+}
@@ -0,0 +1,133 @@
+// stm: #unit
+package wallet
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/crypto"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// the mnemonic of the accounts of the ethereum development tools
+const testMnemonic = "test test test test test test test test test test test junk"
+
+func TestDeriveKey(t *testing.T) {
+	tf.UnitTest(t)
+
+	// test vector 1 of BIP-32
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+	for path, expect := range map[string]string{
+		"m":                      "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35",
+		"m/0'":                   "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea",
+		"m/0'/1/2'/2/1000000000": "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8",
+	} {
+		k, err := deriveKey(seed, path)
+		require.NoError(t, err)
+		assert.Equal(t, expect, hex.EncodeToString(k), path)
+	}
+
+	for _, path := range []string{"", "0/1", "m/a", "m/2147483648"} {
+		_, err := deriveKey(seed, path)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestHDPath(t *testing.T) {
+	tf.UnitTest(t)
+
+	path, err := HDPath(address.SECP256K1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "m/44'/461'/0'/0/3", path)
+
+	path, err = HDPath(address.Delegated, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "m/44'/60'/0'/0/0", path)
+
+	_, err = HDPath(address.BLS, 0)
+	assert.Error(t, err)
+}
+
+func TestHDBackend(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	password := []byte("hd-password")
+	store := datastore.NewMapDatastore()
+	hd, err := NewHDBackend(ctx, store, config.TestPassphraseConfig(), password)
+	require.NoError(t, err)
+
+	_, err = hd.NewAddress(ctx, address.SECP256K1)
+	require.ErrorIs(t, err, ErrNoSeed)
+
+	require.Error(t, hd.SetMnemonic(ctx, "test test test"))
+	require.NoError(t, hd.SetMnemonic(ctx, testMnemonic))
+	require.ErrorIs(t, hd.SetMnemonic(ctx, testMnemonic), ErrSeedExists)
+
+	t.Log("the delegated addresses match the ethereum ones")
+	f410, err := hd.NewAddress(ctx, address.Delegated)
+	require.NoError(t, err)
+	ethAddr, err := types.EthAddressFromFilecoinAddress(f410)
+	require.NoError(t, err)
+	assert.Equal(t, "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266", ethAddr.String())
+	ki, err := hd.GetKeyInfo(ctx, f410)
+	require.NoError(t, err)
+	assert.Equal(t, "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80", hex.EncodeToString(ki.Key()))
+
+	t.Log("the addresses follow each other")
+	first, err := hd.NewAddress(ctx, address.SECP256K1)
+	require.NoError(t, err)
+	third, err := hd.Derive(ctx, address.SECP256K1, 2)
+	require.NoError(t, err)
+	next, err := hd.NewAddress(ctx, address.SECP256K1)
+	require.NoError(t, err)
+	path, err := hd.Path(next)
+	require.NoError(t, err)
+	assert.Equal(t, "m/44'/461'/0'/0/3", path)
+	again, err := hd.Derive(ctx, address.SECP256K1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, first, again)
+	assert.Len(t, hd.Addresses(ctx), 4)
+
+	data := []byte("data to sign")
+	sig, err := hd.SignBytes(ctx, data, third)
+	require.NoError(t, err)
+	require.NoError(t, crypto.Verify(sig, third, data))
+
+	t.Log("only the seed and the indexes are stored")
+	reloaded, err := NewHDBackend(ctx, store, config.TestPassphraseConfig(), nil)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, hd.Addresses(ctx), reloaded.Addresses(ctx))
+	_, err = reloaded.SignBytes(ctx, data, third)
+	require.Error(t, err)
+	_, err = reloaded.GetKeyInfoPassphrase(ctx, third, []byte("wrong password"))
+	require.ErrorIs(t, err, ErrDecrypt)
+	exported, err := reloaded.GetKeyInfoPassphrase(ctx, third, password)
+	require.NoError(t, err)
+	exportedAddr, err := exported.Address()
+	require.NoError(t, err)
+	assert.Equal(t, third, exportedAddr)
+
+	require.NoError(t, reloaded.UnLockWallet(ctx, password))
+	sig, err = reloaded.SignBytes(ctx, data, third)
+	require.NoError(t, err)
+	require.NoError(t, crypto.Verify(sig, third, data))
+
+	require.NoError(t, reloaded.LockWallet(ctx))
+	_, err = reloaded.SignBytes(ctx, data, third)
+	require.Error(t, err)
+	_, err = reloaded.NewAddress(ctx, address.SECP256K1)
+	require.Error(t, err)
+
+	require.NoError(t, reloaded.DeleteAddress(ctx, third))
+	assert.False(t, reloaded.HasAddress(ctx, third))
+}
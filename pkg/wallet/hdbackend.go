@@ -0,0 +1,446 @@
+package wallet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/awnumar/memguard"
+	"github.com/filecoin-project/go-address"
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	"github.com/pkg/errors"
+	"github.com/tyler-smith/go-bip39"
+
+	"github.com/filecoin-project/venus/pkg/config"
+	"github.com/filecoin-project/venus/pkg/crypto"
+	"github.com/filecoin-project/venus/pkg/repo"
+	"github.com/filecoin-project/venus/pkg/wallet/key"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// HDBackendType is the reflect type of the HDBackend.
+var HDBackendType = reflect.TypeOf(&HDBackend{})
+
+var (
+	ErrNoSeed     = errors.New("the HD wallet has no seed, create one or import a mnemonic first")
+	ErrSeedExists = errors.New("the HD wallet has a seed already")
+)
+
+var (
+	hdSeedKey       = ds.NewKey("/wallet/hd/seed")
+	hdAccountPrefix = ds.NewKey("/wallet/hd/account")
+)
+
+// hdAccount is an address derived from the seed, the key is derived again when the wallet is unlocked
+type hdAccount struct {
+	Address  address.Address
+	Protocol address.Protocol
+	Index    uint32
+}
+
+// HDBackend is a wallet backend deriving the keys of its addresses from a BIP-39 seed, along the BIP-44
+// path of filecoin for the secp256k1 addresses and the one of ethereum for the delegated addresses. Only the
+// seed, encrypted with the wallet password, and the indexes of the derived addresses are stored.
+type HDBackend struct {
+	lk sync.RWMutex
+
+	ds repo.Datastore
+
+	passphraseConf config.PassphraseConfig
+
+	accounts map[address.Address]hdAccount
+
+	password *memguard.Enclave
+	seed     *memguard.Enclave
+	unLocked map[address.Address]*key.KeyInfo
+
+	state atomic.Int64
+}
+
+var _ Backend = (*HDBackend)(nil)
+
+// NewHDBackend constructs a new HD backend using the passed in datastore.
+func NewHDBackend(ctx context.Context, store repo.Datastore, passphraseCfg config.PassphraseConfig, password []byte) (*HDBackend, error) {
+	result, err := store.Query(ctx, dsq.Query{Prefix: hdAccountPrefix.String()})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query datastore")
+	}
+	list, err := result.Rest()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read query results")
+	}
+
+	accounts := make(map[address.Address]hdAccount, len(list))
+	for _, el := range list {
+		var acc hdAccount
+		if err := json.Unmarshal(el.Value, &acc); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode HD account %s", el.Key)
+		}
+		accounts[acc.Address] = acc
+	}
+
+	backend := &HDBackend{
+		ds:             store,
+		passphraseConf: passphraseCfg,
+		accounts:       accounts,
+		unLocked:       make(map[address.Address]*key.KeyInfo, len(accounts)),
+	}
+
+	if len(password) != 0 {
+		if err := backend.SetPassword(ctx, password); err != nil {
+			return nil, err
+		}
+	}
+
+	return backend, nil
+}
+
+// NewMnemonic returns a new random 24 words mnemonic
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// HasSeed returns whether the backend has a seed to derive the keys from
+func (backend *HDBackend) HasSeed(ctx context.Context) (bool, error) {
+	return backend.ds.Has(ctx, hdSeedKey)
+}
+
+// SetMnemonic stores the seed of mnemonic, a backend has a single seed which can't be replaced.
+func (backend *HDBackend) SetMnemonic(ctx context.Context, mnemonic string) error {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+	if err != nil {
+		return errors.Wrap(err, "invalid mnemonic")
+	}
+
+	backend.lk.Lock()
+	defer backend.lk.Unlock()
+
+	if backend.state.Load() == Lock {
+		return errors.New("the HD wallet is locked")
+	}
+	if has, err := backend.HasSeed(ctx); err != nil {
+		return err
+	} else if has {
+		return ErrSeedExists
+	}
+
+	var seedJSON []byte
+	if err := backend.UsePassword(func(password []byte) error {
+		cryptoStruct, err := encryptData(seed, password, backend.passphraseConf.ScryptN, backend.passphraseConf.ScryptP)
+		if err != nil {
+			return err
+		}
+		seedJSON, err = json.Marshal(cryptoStruct)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if err := backend.ds.Put(ctx, hdSeedKey, seedJSON); err != nil {
+		return errors.Wrap(err, "failed to store HD seed")
+	}
+	backend.seed = memguard.NewEnclave(seed)
+	return nil
+}
+
+// NewAddress derives the address following the last one derived for protocol and stores it.
+// Safe for concurrent access.
+func (backend *HDBackend) NewAddress(ctx context.Context, protocol address.Protocol) (address.Address, error) {
+	backend.lk.Lock()
+	defer backend.lk.Unlock()
+
+	var index uint32
+	for _, acc := range backend.accounts {
+		if acc.Protocol == protocol && acc.Index >= index {
+			index = acc.Index + 1
+		}
+	}
+	return backend.derive(ctx, protocol, index)
+}
+
+// Derive derives the index-th address of protocol and stores it.
+// Safe for concurrent access.
+func (backend *HDBackend) Derive(ctx context.Context, protocol address.Protocol, index uint32) (address.Address, error) {
+	backend.lk.Lock()
+	defer backend.lk.Unlock()
+
+	return backend.derive(ctx, protocol, index)
+}
+
+func (backend *HDBackend) derive(ctx context.Context, protocol address.Protocol, index uint32) (address.Address, error) {
+	if backend.seed == nil {
+		if has, err := backend.HasSeed(ctx); err != nil {
+			return address.Undef, err
+		} else if !has {
+			return address.Undef, ErrNoSeed
+		}
+		return address.Undef, errors.New("the HD wallet is locked")
+	}
+
+	buf, err := backend.seed.Open()
+	if err != nil {
+		return address.Undef, err
+	}
+	ki, err := deriveKeyInfo(buf.Bytes(), protocol, index)
+	buf.Destroy()
+	if err != nil {
+		return address.Undef, err
+	}
+	addr, err := ki.Address()
+	if err != nil {
+		return address.Undef, err
+	}
+	if _, ok := backend.accounts[addr]; ok {
+		return addr, nil
+	}
+
+	acc := hdAccount{Address: addr, Protocol: protocol, Index: index}
+	data, err := json.Marshal(acc)
+	if err != nil {
+		return address.Undef, err
+	}
+	if err := backend.ds.Put(ctx, hdAccountPrefix.ChildString(addr.String()), data); err != nil {
+		return address.Undef, errors.Wrapf(err, "failed to store new address: %s", addr)
+	}
+	backend.accounts[addr] = acc
+	backend.unLocked[addr] = ki
+	return addr, nil
+}
+
+// Path returns the derivation path of addr
+func (backend *HDBackend) Path(addr address.Address) (string, error) {
+	backend.lk.RLock()
+	defer backend.lk.RUnlock()
+
+	acc, ok := backend.accounts[addr]
+	if !ok {
+		return "", errors.New("backend does not contain address")
+	}
+	return HDPath(acc.Protocol, acc.Index)
+}
+
+func deriveKeyInfo(seed []byte, protocol address.Protocol, index uint32) (*key.KeyInfo, error) {
+	path, err := HDPath(protocol, index)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := deriveKey(seed, path)
+	if err != nil {
+		return nil, err
+	}
+
+	ki := &key.KeyInfo{SigType: types.SigTypeSecp256k1}
+	if protocol == address.Delegated {
+		ki.SigType = types.SigTypeDelegated
+	}
+	ki.SetPrivateKey(privateKey)
+	return ki, nil
+}
+
+// Addresses returns a list of all addresses that are stored in this backend.
+func (backend *HDBackend) Addresses(ctx context.Context) []address.Address {
+	backend.lk.RLock()
+	defer backend.lk.RUnlock()
+
+	var addrs []address.Address
+	for addr := range backend.accounts {
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+// HasAddress checks if the passed in address is stored in this backend.
+// Safe for concurrent access.
+func (backend *HDBackend) HasAddress(ctx context.Context, addr address.Address) bool {
+	backend.lk.RLock()
+	defer backend.lk.RUnlock()
+
+	_, ok := backend.accounts[addr]
+	return ok
+}
+
+// DeleteAddress forgets addr, it can be derived again from the seed.
+func (backend *HDBackend) DeleteAddress(ctx context.Context, addr address.Address) error {
+	backend.lk.Lock()
+	defer backend.lk.Unlock()
+
+	if _, ok := backend.accounts[addr]; !ok {
+		return errors.New("backend does not contain address")
+	}
+	if err := backend.ds.Delete(ctx, hdAccountPrefix.ChildString(addr.String())); err != nil {
+		return err
+	}
+	delete(backend.accounts, addr)
+	delete(backend.unLocked, addr)
+	return nil
+}
+
+// SignBytes cryptographically signs `data` using the private key of `addr`.
+func (backend *HDBackend) SignBytes(ctx context.Context, data []byte, addr address.Address) (*crypto.Signature, error) {
+	backend.lk.RLock()
+	ki, found := backend.unLocked[addr]
+	backend.lk.RUnlock()
+	if !found {
+		return nil, errors.Errorf("%s is locked", addr.String())
+	}
+
+	var signature *crypto.Signature
+	err := ki.UsePrivateKey(func(privateKey []byte) error {
+		var err error
+		signature, err = crypto.Sign(data, privateKey, ki.SigType)
+		return err
+	})
+	return signature, err
+}
+
+// GetKeyInfo will return the private & public keys associated with address `addr`
+// iff backend contains the addr.
+func (backend *HDBackend) GetKeyInfo(ctx context.Context, addr address.Address) (*key.KeyInfo, error) {
+	if !backend.HasAddress(ctx, addr) {
+		return nil, errors.New("backend does not contain address")
+	}
+
+	backend.lk.RLock()
+	defer backend.lk.RUnlock()
+	ki, ok := backend.unLocked[addr]
+	if !ok {
+		return nil, errors.Errorf("%s is locked", addr.String())
+	}
+	return ki, nil
+}
+
+// GetKeyInfoPassphrase decrypts the seed with password and derives the key of addr from it
+func (backend *HDBackend) GetKeyInfoPassphrase(ctx context.Context, addr address.Address, password []byte) (*key.KeyInfo, error) {
+	backend.lk.RLock()
+	acc, ok := backend.accounts[addr]
+	backend.lk.RUnlock()
+	if !ok {
+		return nil, errors.New("backend does not contain address")
+	}
+
+	seed, err := backend.loadSeed(ctx, password)
+	if err != nil {
+		return nil, err
+	}
+	defer seed.Destroy()
+	return deriveKeyInfo(seed.Bytes(), acc.Protocol, acc.Index)
+}
+
+// loadSeed decrypts the stored seed with password
+func (backend *HDBackend) loadSeed(ctx context.Context, password []byte) (*memguard.LockedBuffer, error) {
+	b, err := backend.ds.Get(ctx, hdSeedKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch HD seed from backend")
+	}
+	var cryptoJSON CryptoJSON
+	if err := json.Unmarshal(b, &cryptoJSON); err != nil {
+		return nil, err
+	}
+	seed, err := decryptData(cryptoJSON, password)
+	if err != nil {
+		return nil, err
+	}
+	return memguard.NewBufferFromBytes(seed), nil
+}
+
+// unlock decrypts the seed with password and derives the keys of all the addresses
+func (backend *HDBackend) unlock(ctx context.Context, password []byte) error {
+	has, err := backend.HasSeed(ctx)
+	if err != nil || !has {
+		return err
+	}
+
+	seed, err := backend.loadSeed(ctx, password)
+	if err != nil {
+		return err
+	}
+	defer seed.Destroy()
+
+	backend.lk.Lock()
+	defer backend.lk.Unlock()
+	for addr, acc := range backend.accounts {
+		ki, err := deriveKeyInfo(seed.Bytes(), acc.Protocol, acc.Index)
+		if err != nil {
+			return err
+		}
+		backend.unLocked[addr] = ki
+	}
+	backend.seed = seed.Seal()
+	return nil
+}
+
+// LockWallet wipes the seed and the derived keys from memory
+func (backend *HDBackend) LockWallet(ctx context.Context) error {
+	backend.lk.Lock()
+	defer backend.lk.Unlock()
+
+	for addr := range backend.unLocked {
+		delete(backend.unLocked, addr)
+	}
+	backend.seed = nil
+	backend.password = nil
+	backend.state.Store(Lock)
+	return nil
+}
+
+// UnLockWallet decrypts the seed with password and derives the keys of the addresses again
+func (backend *HDBackend) UnLockWallet(ctx context.Context, password []byte) error {
+	if backend.state.Load() == Unlock {
+		return fmt.Errorf("already unlocked")
+	}
+	if err := backend.unlock(ctx, password); err != nil {
+		return err
+	}
+	backend.setPassword(password)
+	backend.state.Store(Unlock)
+	return nil
+}
+
+// SetPassword set password for wallet, and wallet used this password to encrypt the seed
+func (backend *HDBackend) SetPassword(ctx context.Context, password []byte) error {
+	if backend.password != nil {
+		return ErrRepeatPassword
+	}
+	if err := backend.unlock(ctx, password); err != nil {
+		return err
+	}
+	backend.state.CompareAndSwap(undetermined, Unlock)
+	backend.setPassword(password)
+	return nil
+}
+
+// WalletState return wallet state(lock/unlock)
+func (backend *HDBackend) WalletState(ctx context.Context) int {
+	return int(backend.state.Load())
+}
+
+func (backend *HDBackend) setPassword(password []byte) {
+	backend.lk.Lock()
+	defer backend.lk.Unlock()
+
+	// the password is shared with the other backends, keep it intact
+	cpy := make([]byte, len(password))
+	copy(cpy, password)
+	backend.password = memguard.NewEnclave(cpy)
+}
+
+func (backend *HDBackend) UsePassword(f func(password []byte) error) error {
+	if backend.password == nil {
+		return f([]byte{})
+	}
+	buf, err := backend.password.Open()
+	if err != nil {
+		return err
+	}
+	defer buf.Destroy()
+
+	return f(buf.Bytes())
+}
@@ -0,0 +1,153 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pborman/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/filecoin-project/venus/pkg/wallet/key"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+// KeyFormat is the format of a key file, which the keys are imported from and exported to
+type KeyFormat string
+
+const (
+	// KeyFormatHexLotus is the hex encoded json KeyInfo of lotus and venus
+	KeyFormatHexLotus KeyFormat = "hex-lotus"
+	// KeyFormatJSONLotus is the json KeyInfo of lotus and venus
+	KeyFormatJSONLotus KeyFormat = "json-lotus"
+	// KeyFormatEthKeystore is the V3 keystore of the ethereum wallets, for the secp256k1 and delegated keys
+	KeyFormatEthKeystore KeyFormat = "eth-keystore"
+	// KeyFormatRawHex is the hex encoded private key
+	KeyFormatRawHex KeyFormat = "raw-hex"
+)
+
+// KeyFormats are the supported key file formats
+var KeyFormats = []KeyFormat{KeyFormatHexLotus, KeyFormatJSONLotus, KeyFormatEthKeystore, KeyFormatRawHex}
+
+// the scrypt parameters of the ethereum wallets
+var (
+	ethKeystoreScryptN = 1 << 18
+	ethKeystoreScryptP = 1
+)
+
+// ethKeystore is the V3 keystore of the ethereum wallets
+type ethKeystore struct {
+	Address string     `json:"address"`
+	Crypto  CryptoJSON `json:"crypto"`
+	ID      string     `json:"id"`
+	Version int        `json:"version"`
+}
+
+// EncodeKeyFile encodes ki in format, the eth-keystore format encrypts the key with password.
+func EncodeKeyFile(ki *types.KeyInfo, format KeyFormat, password []byte) ([]byte, error) {
+	switch format {
+	case KeyFormatHexLotus:
+		b, err := json.Marshal(ki)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(hex.EncodeToString(b)), nil
+	case KeyFormatJSONLotus:
+		return json.Marshal(ki)
+	case KeyFormatEthKeystore:
+		ethAddr, err := ethAddressOf(ki)
+		if err != nil {
+			return nil, err
+		}
+		cryptoStruct, err := encryptData(ki.PrivateKey, password, ethKeystoreScryptN, ethKeystoreScryptP)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(ethKeystore{
+			Address: strings.TrimPrefix(ethAddr.String(), "0x"),
+			Crypto:  cryptoStruct,
+			ID:      uuid.NewRandom().String(),
+			Version: version,
+		})
+	case KeyFormatRawHex:
+		return []byte(hex.EncodeToString(ki.PrivateKey)), nil
+	default:
+		return nil, errors.Errorf("unknown key format %s", format)
+	}
+}
+
+// DecodeKeyFile decodes the key of data in format. The eth-keystore format is decrypted with password, and
+// the raw-hex and eth-keystore formats don't hold the type of the key, it is keyType.
+func DecodeKeyFile(data []byte, format KeyFormat, keyType types.KeyType, password []byte) (*types.KeyInfo, error) {
+	data = bytes.TrimSpace(data)
+
+	switch format {
+	case KeyFormatHexLotus:
+		var ki types.KeyInfo
+		if err := json.NewDecoder(hex.NewDecoder(bytes.NewReader(data))).Decode(&ki); err != nil {
+			return nil, errors.Wrap(err, "decode hex-lotus key")
+		}
+		return &ki, nil
+	case KeyFormatJSONLotus:
+		var ki types.KeyInfo
+		if err := json.Unmarshal(data, &ki); err != nil {
+			return nil, errors.Wrap(err, "decode json-lotus key")
+		}
+		return &ki, nil
+	case KeyFormatEthKeystore:
+		if keyType != types.KTSecp256k1 && keyType != types.KTDelegated {
+			return nil, errors.Errorf("an ethereum keystore can't hold a %s key", keyType)
+		}
+		var ks ethKeystore
+		if err := json.Unmarshal(data, &ks); err != nil {
+			return nil, errors.Wrap(err, "decode eth-keystore key")
+		}
+		if ks.Version != version {
+			return nil, fmt.Errorf("version not supported: %v", ks.Version)
+		}
+		privateKey, err := decryptData(ks.Crypto, password)
+		if err != nil {
+			return nil, err
+		}
+		ki := &types.KeyInfo{Type: keyType, PrivateKey: privateKey}
+		if len(ks.Address) != 0 {
+			ethAddr, err := ethAddressOf(ki)
+			if err != nil {
+				return nil, err
+			}
+			if !strings.EqualFold(strings.TrimPrefix(ks.Address, "0x"), strings.TrimPrefix(ethAddr.String(), "0x")) {
+				return nil, errors.Errorf("the key doesn't match the address %s of the keystore", ks.Address)
+			}
+		}
+		return ki, nil
+	case KeyFormatRawHex:
+		privateKey, err := hex.DecodeString(strings.TrimPrefix(string(data), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "decode raw-hex key")
+		}
+		if keyType != types.KTSecp256k1 && keyType != types.KTDelegated && keyType != types.KTBLS {
+			return nil, errors.Errorf("unknown key type %s", keyType)
+		}
+		return &types.KeyInfo{Type: keyType, PrivateKey: privateKey}, nil
+	default:
+		return nil, errors.Errorf("unknown key format %s", format)
+	}
+}
+
+// ethAddressOf returns the ethereum address of a secp256k1 or delegated key
+func ethAddressOf(ki *types.KeyInfo) (types.EthAddress, error) {
+	if ki.Type != types.KTSecp256k1 && ki.Type != types.KTDelegated {
+		return types.EthAddress{}, errors.Errorf("an ethereum keystore can't hold a %s key", ki.Type)
+	}
+
+	// the address of a delegated key is the one of ethereum
+	dki := &key.KeyInfo{SigType: types.SigTypeDelegated}
+	dki.SetPrivateKey(append([]byte(nil), ki.PrivateKey...))
+	addr, err := dki.Address()
+	if err != nil {
+		return types.EthAddress{}, err
+	}
+	return types.EthAddressFromFilecoinAddress(addr)
+}
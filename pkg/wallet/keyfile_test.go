@@ -0,0 +1,86 @@
+// stm: #unit
+package wallet
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/pkg/wallet/key"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+func TestKeyFileRoundTrip(t *testing.T) {
+	tf.UnitTest(t)
+
+	// keep the test fast
+	defer func(n int) { ethKeystoreScryptN = n }(ethKeystoreScryptN)
+	ethKeystoreScryptN = 1 << 10
+
+	secp, err := key.NewSecpKeyFromSeed(rand.Reader)
+	require.NoError(t, err)
+	delegated, err := key.NewDelegatedKeyFromSeed(rand.Reader)
+	require.NoError(t, err)
+	keys := []*types.KeyInfo{
+		{Type: types.KTSecp256k1, PrivateKey: secp.Key()},
+		{Type: types.KTDelegated, PrivateKey: delegated.Key()},
+		{Type: types.KTBLS, PrivateKey: []byte("0123456789abcdef0123456789abcdef")},
+	}
+	password := []byte("keystore-password")
+
+	for _, format := range KeyFormats {
+		for _, ki := range keys {
+			data, err := EncodeKeyFile(ki, format, password)
+			if format == KeyFormatEthKeystore && ki.Type == types.KTBLS {
+				require.Error(t, err)
+				continue
+			}
+			require.NoError(t, err, format)
+
+			decoded, err := DecodeKeyFile(data, format, ki.Type, password)
+			require.NoError(t, err, format)
+			assert.Equal(t, ki, decoded, format)
+		}
+	}
+
+	t.Run("lotus", func(t *testing.T) {
+		data, err := EncodeKeyFile(keys[0], KeyFormatJSONLotus, nil)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"Type":"secp256k1"`)
+
+		// the sig type numbers of the old venus key files
+		data = []byte(`{"Type":1,"PrivateKey":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="}`)
+		decoded, err := DecodeKeyFile(data, KeyFormatJSONLotus, "", nil)
+		require.NoError(t, err)
+		assert.Equal(t, types.KTSecp256k1, decoded.Type)
+	})
+
+	t.Run("eth keystore", func(t *testing.T) {
+		data, err := EncodeKeyFile(keys[1], KeyFormatEthKeystore, password)
+		require.NoError(t, err)
+		_, err = DecodeKeyFile(data, KeyFormatEthKeystore, types.KTDelegated, []byte("wrong password"))
+		require.ErrorIs(t, err, ErrDecrypt)
+
+		// the key of the web3 secret storage test vectors, with scrypt and pbkdf2
+		for _, ks := range []string{
+			`{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"83dbcc02d8ccb40e466191a123791e0e"},"ciphertext":"d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c","kdf":"scrypt","kdfparams":{"dklen":32,"n":262144,"r":1,"p":8,"salt":"ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"},"mac":"2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`,
+			`{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"6087dab2f9fdbbfaddc31a909735c1e6"},"ciphertext":"5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46","kdf":"pbkdf2","kdfparams":{"c":262144,"dklen":32,"prf":"hmac-sha256","salt":"ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"},"mac":"517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`,
+		} {
+			ki, err := DecodeKeyFile([]byte(ks), KeyFormatEthKeystore, types.KTDelegated, []byte("testpassword"))
+			require.NoError(t, err)
+			assert.Equal(t, "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d", hex.EncodeToString(ki.PrivateKey))
+		}
+	})
+
+	t.Run("raw hex", func(t *testing.T) {
+		decoded, err := DecodeKeyFile([]byte("0x"+hex.EncodeToString(keys[0].PrivateKey)+"\n"), KeyFormatRawHex, types.KTSecp256k1, nil)
+		require.NoError(t, err)
+		assert.Equal(t, keys[0], decoded)
+		_, err = DecodeKeyFile([]byte("zz"), KeyFormatRawHex, types.KTSecp256k1, nil)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,385 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	"github.com/pkg/errors"
+
+	"github.com/filecoin-project/venus/pkg/crypto"
+	"github.com/filecoin-project/venus/pkg/journal"
+	"github.com/filecoin-project/venus/pkg/repo"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	"github.com/filecoin-project/venus/venus-shared/types"
+	msgparser "github.com/filecoin-project/venus/venus-shared/utils/msg_parser"
+)
+
+var (
+	ErrPolicyDenied   = errors.New("denied by wallet policy")
+	ErrPolicyNotFound = errors.New("wallet policy not found")
+)
+
+var (
+	policyPrefix = ds.NewKey("/wallet/policy")
+	spendPrefix  = ds.NewKey("/wallet/policy-spend")
+)
+
+// spendWindow is the window of MaxDailyValue
+const spendWindow = 24 * time.Hour
+
+// spend is the value of a message signed under a policy
+type spend struct {
+	Time  time.Time
+	Cid   cid.Cid
+	Value big.Int
+}
+
+// PolicyChain loads the receivers of the messages checked by the policies
+type PolicyChain interface {
+	msgparser.ActorGetter
+	StateLookupID(context.Context, address.Address, types.TipSetKey) (address.Address, error)
+}
+
+var _ WalletIntersection = (*PolicyWallet)(nil)
+
+// PolicyWallet checks what the addresses sign against their policies, the addresses without a policy sign
+// anything. The denials are written to the journal.
+type PolicyWallet struct {
+	WalletIntersection
+
+	// lk guards the maps, signLks serialize the checks and the spends of each address
+	lk       sync.Mutex
+	signLks  map[address.Address]*sync.Mutex
+	ds       repo.Datastore
+	parser   *msgparser.MessagePaser
+	getter   PolicyChain
+	journal  journal.Writer
+	policies map[address.Address]*types.WalletPolicy
+	spends   map[address.Address][]spend
+	now      func() time.Time
+}
+
+// NewPolicyWallet wraps w with the policies stored in store, the receivers of the messages are loaded with getter.
+func NewPolicyWallet(ctx context.Context, w WalletIntersection, store repo.Datastore, getter PolicyChain, jrnl journal.Journal) (*PolicyWallet, error) {
+	parser, err := msgparser.NewMessageParser(getter)
+	if err != nil {
+		return nil, err
+	}
+	pw := &PolicyWallet{
+		WalletIntersection: w,
+		signLks:            make(map[address.Address]*sync.Mutex),
+		ds:                 store,
+		parser:             parser,
+		getter:             getter,
+		journal:            jrnl.Topic("wallet"),
+		policies:           make(map[address.Address]*types.WalletPolicy),
+		spends:             make(map[address.Address][]spend),
+		now:                time.Now,
+	}
+
+	res, err := store.Query(ctx, dsq.Query{Prefix: policyPrefix.String()})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query wallet policies")
+	}
+	entries, err := res.Rest()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read wallet policies")
+	}
+	for _, e := range entries {
+		var p types.WalletPolicy
+		if err := json.Unmarshal(e.Value, &p); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode wallet policy %s", e.Key)
+		}
+		pw.policies[p.Address] = &p
+
+		data, err := store.Get(ctx, spendPrefix.ChildString(p.Address.String()))
+		if err == nil {
+			var spends []spend
+			if err := json.Unmarshal(data, &spends); err != nil {
+				return nil, errors.Wrapf(err, "failed to decode the spends of %s", p.Address)
+			}
+			pw.spends[p.Address] = spends
+		} else if !errors.Is(err, ds.ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	return pw, nil
+}
+
+// SetPolicy sets the policy of an address, replacing the previous one
+func (pw *PolicyWallet) SetPolicy(ctx context.Context, p *types.WalletPolicy) error {
+	if p.Address.Empty() {
+		return errors.New("the address of the policy is empty")
+	}
+	for name, v := range map[string]*big.Int{"MaxValue": p.MaxValue, "MaxDailyValue": p.MaxDailyValue, "MaxFee": p.MaxFee, "MaxGasFeeCap": p.MaxGasFeeCap} {
+		if v != nil && (v.Nil() || v.Sign() < 0) {
+			return errors.Errorf("%s of the policy must be a positive amount", name)
+		}
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	pw.lk.Lock()
+	defer pw.lk.Unlock()
+	if err := pw.ds.Put(ctx, policyPrefix.ChildString(p.Address.String()), data); err != nil {
+		return errors.Wrap(err, "failed to save wallet policy")
+	}
+	cpy := *p
+	pw.policies[p.Address] = &cpy
+	pw.journal.Write("policy_set", "address", p.Address.String(), "policy", string(data))
+	return nil
+}
+
+// Policy returns the policy of an address
+func (pw *PolicyWallet) Policy(addr address.Address) (*types.WalletPolicy, error) {
+	pw.lk.Lock()
+	defer pw.lk.Unlock()
+
+	p, ok := pw.policies[addr]
+	if !ok {
+		return nil, errors.Wrapf(ErrPolicyNotFound, "address %s", addr)
+	}
+	cpy := *p
+	return &cpy, nil
+}
+
+// Policies returns all the policies, ordered by address
+func (pw *PolicyWallet) Policies() []*types.WalletPolicy {
+	pw.lk.Lock()
+	defer pw.lk.Unlock()
+
+	out := make([]*types.WalletPolicy, 0, len(pw.policies))
+	for _, p := range pw.policies {
+		cpy := *p
+		out = append(out, &cpy)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return bytes.Compare(out[i].Address.Bytes(), out[j].Address.Bytes()) < 0
+	})
+	return out
+}
+
+// WalletSign signs msg if the policy of keyAddr allows it
+func (pw *PolicyWallet) WalletSign(ctx context.Context, keyAddr address.Address, msg []byte, meta types.MsgMeta) (*crypto.Signature, error) {
+	return pw.sign(ctx, pw.WalletIntersection.WalletSign, keyAddr, msg, meta)
+}
+
+// Enforce returns sign checked against the policies, for the signers that don't sign through the
+// wrapped wallet, e.g. the wallet gateway or the signer of the blocks.
+func (pw *PolicyWallet) Enforce(sign WalletSignFunc) WalletSignFunc {
+	return func(ctx context.Context, keyAddr address.Address, msg []byte, meta types.MsgMeta) (*crypto.Signature, error) {
+		return pw.sign(ctx, sign, keyAddr, msg, meta)
+	}
+}
+
+func (pw *PolicyWallet) sign(ctx context.Context, sign WalletSignFunc, keyAddr address.Address, msg []byte, meta types.MsgMeta) (*crypto.Signature, error) {
+	pw.lk.Lock()
+	p, ok := pw.policies[keyAddr]
+	if !ok {
+		pw.lk.Unlock()
+		return sign(ctx, keyAddr, msg, meta)
+	}
+	signLk, ok := pw.signLks[keyAddr]
+	if !ok {
+		signLk = new(sync.Mutex)
+		pw.signLks[keyAddr] = signLk
+	}
+	pw.lk.Unlock()
+
+	// the checks and the spends of an address are serialized, the other addresses sign meanwhile
+	signLk.Lock()
+	defer signLk.Unlock()
+
+	m, err := pw.check(ctx, p, keyAddr, msg, meta)
+	if err != nil {
+		pw.journal.Write("sign_denied", "address", keyAddr.String(), "type", string(meta.Type), "reason", err.Error())
+		return nil, fmt.Errorf("%w: %s", ErrPolicyDenied, err)
+	}
+
+	sig, err := sign(ctx, keyAddr, msg, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	if m != nil && p.MaxDailyValue != nil && m.Value.Sign() > 0 {
+		pw.addSpend(ctx, keyAddr, m)
+	}
+
+	return sig, nil
+}
+
+// addSpend records the value of m signed by addr
+func (pw *PolicyWallet) addSpend(ctx context.Context, addr address.Address, m *types.Message) {
+	pw.lk.Lock()
+	defer pw.lk.Unlock()
+
+	// a message signed again, e.g. to retry sending it, is only counted once
+	spends := pw.recentSpends(addr)
+	for _, s := range spends {
+		if s.Cid == m.Cid() {
+			return
+		}
+	}
+	spends = append(spends, spend{Time: pw.now(), Cid: m.Cid(), Value: m.Value})
+	pw.spends[addr] = spends
+	if data, err := json.Marshal(spends); err != nil {
+		walletLog.Errorf("failed to encode the spends of %s: %v", addr, err)
+	} else if err := pw.ds.Put(ctx, spendPrefix.ChildString(addr.String()), data); err != nil {
+		walletLog.Errorf("failed to save the spends of %s: %v", addr, err)
+	}
+}
+
+// check returns why the policy denies signing msg, and the message signed if it is a chain message
+func (pw *PolicyWallet) check(ctx context.Context, p *types.WalletPolicy, keyAddr address.Address, msg []byte, meta types.MsgMeta) (*types.Message, error) {
+	switch {
+	case meta.Type == types.MTChainMsg:
+	case meta.Type == types.MTUnknown || meta.Type == types.MTUndefined:
+		if p.AllowRawBytes {
+			return nil, nil
+		}
+		return nil, errors.New("signing raw bytes isn't allowed")
+	case meta.Type == types.MTMessageBytes:
+		// the prefix keeps the bytes from being anything else, e.g. a chain message
+		if !bytes.Equal(types.SignedMessageBytes(keyAddr.Protocol(), meta.Extra), msg) {
+			return nil, errors.New("the signed bytes don't match the prefixed message")
+		}
+		return nil, nil
+	case slices.Contains(p.AllowedTypes, meta.Type):
+		return nil, nil
+	default:
+		return nil, errors.Errorf("signing %s isn't allowed", meta.Type)
+	}
+
+	m, err := types.DecodeMessage(meta.Extra)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode message")
+	}
+	sb, err := m.SigningBytes(types.AddressProtocol2SignType(keyAddr.Protocol()))
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(sb, msg) {
+		return nil, errors.New("the signed bytes don't match the message")
+	}
+
+	if len(p.AllowedTo) > 0 {
+		allowed, err := pw.allowedTo(ctx, p.AllowedTo, m.To)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, errors.Errorf("receiver %s isn't allowed", m.To)
+		}
+	}
+	if len(p.AllowedMethods) > 0 && !slices.Contains(p.AllowedMethods, m.Method) {
+		return nil, errors.Errorf("method %d isn't allowed", m.Method)
+	}
+	if len(p.AllowedActors) > 0 {
+		name, err := pw.actorName(ctx, m.To)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(p.AllowedActors, name) {
+			return nil, errors.Errorf("receiver actor %s isn't allowed", name)
+		}
+	}
+	if len(p.AllowedMethods) > 0 || len(p.AllowedActors) > 0 {
+		// the parameters must be the ones of the method of the receiver
+		if _, _, err := pw.parser.ParseMessage(ctx, m, nil); err != nil {
+			return nil, errors.Wrap(err, "failed to parse message")
+		}
+	}
+
+	if p.MaxValue != nil && m.Value.GreaterThan(*p.MaxValue) {
+		return nil, errors.Errorf("value %s is above the maximum %s", types.FIL(m.Value), types.FIL(*p.MaxValue))
+	}
+	if p.MaxGasFeeCap != nil && m.GasFeeCap.GreaterThan(*p.MaxGasFeeCap) {
+		return nil, errors.Errorf("gas fee cap %s is above the maximum %s", m.GasFeeCap, *p.MaxGasFeeCap)
+	}
+	if fee := big.Mul(m.GasFeeCap, big.NewInt(m.GasLimit)); p.MaxFee != nil && fee.GreaterThan(*p.MaxFee) {
+		return nil, errors.Errorf("fee %s is above the maximum %s", types.FIL(fee), types.FIL(*p.MaxFee))
+	}
+	if p.MaxDailyValue != nil {
+		pw.lk.Lock()
+		spends := pw.recentSpends(keyAddr)
+		pw.lk.Unlock()
+		total := m.Value
+		for _, s := range spends {
+			if s.Cid != m.Cid() {
+				total = big.Add(total, s.Value)
+			}
+		}
+		if total.GreaterThan(*p.MaxDailyValue) {
+			return nil, errors.Errorf("value signed over 24h %s is above the maximum %s", types.FIL(total), types.FIL(*p.MaxDailyValue))
+		}
+	}
+
+	return m, nil
+}
+
+// allowedTo returns whether to is one of allowed, the addresses are compared by the ID of their actor when
+// they differ
+func (pw *PolicyWallet) allowedTo(ctx context.Context, allowed []address.Address, to address.Address) (bool, error) {
+	if slices.Contains(allowed, to) {
+		return true, nil
+	}
+	toID, err := pw.getter.StateLookupID(ctx, to, types.EmptyTSK)
+	if err != nil {
+		if errors.Is(err, types.ErrActorNotFound) {
+			// an actor not created yet has no ID, only its robust address could be allowed
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to look up the ID of receiver %s", to)
+	}
+	for _, a := range allowed {
+		id, err := pw.getter.StateLookupID(ctx, a, types.EmptyTSK)
+		if err != nil {
+			if errors.Is(err, types.ErrActorNotFound) {
+				continue
+			}
+			return false, errors.Wrapf(err, "failed to look up the ID of %s", a)
+		}
+		if id == toID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// recentSpends returns the spends of addr within the spend window, the caller holds pw.lk
+func (pw *PolicyWallet) recentSpends(addr address.Address) []spend {
+	since := pw.now().Add(-spendWindow)
+	spends := pw.spends[addr]
+	for len(spends) > 0 && !spends[0].Time.After(since) {
+		spends = spends[1:]
+	}
+	return spends
+}
+
+// actorName returns the type of the receiver, the accounts not created yet are accounts
+func (pw *PolicyWallet) actorName(ctx context.Context, addr address.Address) (string, error) {
+	act, err := pw.getter.StateGetActor(ctx, addr, types.EmptyTSK)
+	if err != nil {
+		if errors.Is(err, types.ErrActorNotFound) && (addr.Protocol() == address.SECP256K1 || addr.Protocol() == address.BLS) {
+			return "account", nil
+		}
+		return "", errors.Wrapf(err, "failed to load receiver %s", addr)
+	}
+	return path.Base(builtin.ActorNameByCode(act.Code)), nil
+}
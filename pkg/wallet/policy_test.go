@@ -0,0 +1,257 @@
+// stm: #unit
+package wallet
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/specs-actors/v7/actors/builtin"
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/venus/pkg/clock"
+	"github.com/filecoin-project/venus/pkg/crypto"
+	"github.com/filecoin-project/venus/pkg/journal"
+	"github.com/filecoin-project/venus/pkg/testhelpers"
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type fakePolicyChain struct {
+	actors map[address.Address]*types.Actor
+	ids    map[address.Address]address.Address
+}
+
+func (c *fakePolicyChain) StateGetActor(_ context.Context, addr address.Address, _ types.TipSetKey) (*types.Actor, error) {
+	act, ok := c.actors[addr]
+	if !ok {
+		return nil, types.ErrActorNotFound
+	}
+	return act, nil
+}
+
+func (c *fakePolicyChain) StateLookupID(_ context.Context, addr address.Address, _ types.TipSetKey) (address.Address, error) {
+	if addr.Protocol() == address.ID {
+		return addr, nil
+	}
+	id, ok := c.ids[addr]
+	if !ok {
+		return address.Undef, types.ErrActorNotFound
+	}
+	return id, nil
+}
+
+// blockingWallet waits for release before signing with blocked
+type blockingWallet struct {
+	WalletIntersection
+	blocked address.Address
+	signing chan struct{}
+	release chan struct{}
+}
+
+func (w *blockingWallet) WalletSign(ctx context.Context, keyAddr address.Address, msg []byte, meta types.MsgMeta) (*crypto.Signature, error) {
+	if keyAddr == w.blocked {
+		close(w.signing)
+		<-w.release
+	}
+	return w.WalletIntersection.WalletSign(ctx, keyAddr, msg, meta)
+}
+
+func TestPolicyWalletSignConcurrently(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	w, fs := newWalletAndDSBackend(t)
+	slow, err := fs.NewAddress(ctx, address.SECP256K1)
+	require.NoError(t, err)
+	fast, err := fs.NewAddress(ctx, address.SECP256K1)
+	require.NoError(t, err)
+
+	bw := &blockingWallet{WalletIntersection: w, blocked: slow, signing: make(chan struct{}), release: make(chan struct{})}
+	pw, err := NewPolicyWallet(ctx, bw, datastore.NewMapDatastore(), &fakePolicyChain{}, journal.NewInMemoryJournal(t, clock.NewFake(time.Unix(1234567890, 0))))
+	require.NoError(t, err)
+	for _, addr := range []address.Address{slow, fast} {
+		require.NoError(t, pw.SetPolicy(ctx, &types.WalletPolicy{Address: addr, AllowRawBytes: true}))
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := pw.WalletSign(ctx, slow, []byte("slow"), types.MsgMeta{Type: types.MTUnknown})
+		done <- err
+	}()
+	<-bw.signing
+
+	// the backend signing for slow doesn't hold the other addresses
+	_, err = pw.WalletSign(ctx, fast, []byte("fast"), types.MsgMeta{Type: types.MTUnknown})
+	require.NoError(t, err)
+	_, err = pw.Policy(slow)
+	require.NoError(t, err)
+
+	close(bw.release)
+	require.NoError(t, <-done)
+}
+
+func TestPolicyWallet(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	w, fs := newWalletAndDSBackend(t)
+	from, err := fs.NewAddress(ctx, address.SECP256K1)
+	require.NoError(t, err)
+	other, err := fs.NewAddress(ctx, address.SECP256K1)
+	require.NoError(t, err)
+	third, err := fs.NewAddress(ctx, address.SECP256K1)
+	require.NoError(t, err)
+	otherID, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+
+	addrGetter := testhelpers.NewForTestGetter()
+	msig, miner := addrGetter(), addrGetter()
+	getter := &fakePolicyChain{
+		actors: map[address.Address]*types.Actor{
+			msig:  {Code: builtin.MultisigActorCodeID},
+			miner: {Code: builtin.StorageMinerActorCodeID},
+		},
+		ids: map[address.Address]address.Address{other: otherID},
+	}
+
+	store := datastore.NewMapDatastore()
+	pw, err := NewPolicyWallet(ctx, w, store, getter, journal.NewInMemoryJournal(t, clock.NewFake(time.Unix(1234567890, 0))))
+	require.NoError(t, err)
+	now := time.Unix(1234567890, 0)
+	pw.now = func() time.Time { return now }
+
+	maxValue, maxDaily, maxFeeCap := types.NewInt(10), types.NewInt(25), types.NewInt(100)
+	require.NoError(t, pw.SetPolicy(ctx, &types.WalletPolicy{
+		Address:        from,
+		AllowedActors:  []string{"account", "multisig"},
+		AllowedMethods: []abi.MethodNum{builtin.MethodSend},
+		MaxValue:       &maxValue,
+		MaxDailyValue:  &maxDaily,
+		MaxGasFeeCap:   &maxFeeCap,
+	}))
+	negative := big.NewInt(-1)
+	require.Error(t, pw.SetPolicy(ctx, &types.WalletPolicy{Address: from, MaxValue: &negative}))
+
+	nonce := uint64(0)
+	sign := func(signer address.Address, to address.Address, method abi.MethodNum, value int64) error {
+		m := &types.Message{
+			From:       signer,
+			To:         to,
+			Nonce:      nonce,
+			Value:      types.NewInt(uint64(value)),
+			Method:     method,
+			GasLimit:   1000,
+			GasFeeCap:  types.NewInt(100),
+			GasPremium: types.NewInt(1),
+		}
+		nonce++
+		data, err := m.SigningBytes(types.AddressProtocol2SignType(signer.Protocol()))
+		require.NoError(t, err)
+		extra, err := m.Serialize()
+		require.NoError(t, err)
+		_, err = pw.WalletSign(ctx, signer, data, types.MsgMeta{Type: types.MTChainMsg, Extra: extra})
+		return err
+	}
+
+	t.Run("allowed", func(t *testing.T) {
+		require.NoError(t, sign(from, other, builtin.MethodSend, 5))
+		require.NoError(t, sign(from, msig, builtin.MethodSend, 5))
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		require.ErrorIs(t, sign(from, miner, builtin.MethodSend, 1), ErrPolicyDenied)
+		require.ErrorIs(t, sign(from, msig, builtin.MethodsMultisig.Approve, 0), ErrPolicyDenied)
+		require.ErrorIs(t, sign(from, other, builtin.MethodSend, 11), ErrPolicyDenied)
+
+		_, err := pw.WalletSign(ctx, from, []byte("raw bytes"), types.MsgMeta{Type: types.MTUnknown})
+		require.ErrorIs(t, err, ErrPolicyDenied)
+
+		// the signed bytes must be the ones of the message
+		m := &types.Message{From: from, To: other, Value: types.NewInt(1), GasFeeCap: types.NewInt(1), GasPremium: types.NewInt(1)}
+		extra, err := m.Serialize()
+		require.NoError(t, err)
+		_, err = pw.WalletSign(ctx, from, []byte("other bytes"), types.MsgMeta{Type: types.MTChainMsg, Extra: extra})
+		require.ErrorIs(t, err, ErrPolicyDenied)
+	})
+
+	t.Run("enforced on other signers", func(t *testing.T) {
+		signed := 0
+		sign := pw.Enforce(func(ctx context.Context, addr address.Address, msg []byte, meta types.MsgMeta) (*crypto.Signature, error) {
+			signed++
+			return w.WalletSign(ctx, addr, msg, meta)
+		})
+
+		_, err := sign(ctx, from, []byte("raw bytes"), types.MsgMeta{Type: types.MTUnknown})
+		require.ErrorIs(t, err, ErrPolicyDenied)
+		require.Equal(t, 0, signed)
+
+		// the addresses without a policy sign anything
+		_, err = sign(ctx, third, []byte("raw bytes"), types.MsgMeta{Type: types.MTUnknown})
+		require.NoError(t, err)
+		require.Equal(t, 1, signed)
+	})
+
+	t.Run("daily value", func(t *testing.T) {
+		require.NoError(t, sign(from, other, builtin.MethodSend, 10))
+		// 20 was signed already
+		require.ErrorIs(t, sign(from, other, builtin.MethodSend, 10), ErrPolicyDenied)
+
+		// the spends are kept across restarts
+		reloaded, err := NewPolicyWallet(ctx, w, store, getter, journal.NewInMemoryJournal(t, clock.NewFake(now)))
+		require.NoError(t, err)
+		reloaded.now = pw.now
+		p, err := reloaded.Policy(from)
+		require.NoError(t, err)
+		require.Equal(t, maxDaily, *p.MaxDailyValue)
+		require.Len(t, reloaded.Policies(), 1)
+		pw = reloaded
+		require.ErrorIs(t, sign(from, other, builtin.MethodSend, 10), ErrPolicyDenied)
+
+		now = now.Add(spendWindow)
+		require.NoError(t, sign(from, other, builtin.MethodSend, 10))
+	})
+
+	t.Run("no policy", func(t *testing.T) {
+		_, err := pw.Policy(other)
+		require.True(t, errors.Is(err, ErrPolicyNotFound))
+		require.NoError(t, sign(other, miner, builtin.MethodsMiner.WithdrawBalance, 100))
+	})
+
+	t.Run("types", func(t *testing.T) {
+		require.NoError(t, pw.SetPolicy(ctx, &types.WalletPolicy{Address: third}))
+		// the types not listed are denied
+		_, err := pw.WalletSign(ctx, third, []byte("block"), types.MsgMeta{Type: types.MTBlock})
+		require.ErrorIs(t, err, ErrPolicyDenied)
+
+		require.NoError(t, pw.SetPolicy(ctx, &types.WalletPolicy{Address: third, AllowedTypes: []types.MsgType{types.MTBlock}}))
+		_, err = pw.WalletSign(ctx, third, []byte("block"), types.MsgMeta{Type: types.MTBlock})
+		require.NoError(t, err)
+		_, err = pw.WalletSign(ctx, third, []byte("voucher"), types.MsgMeta{Type: types.MTSignedVoucher})
+		require.ErrorIs(t, err, ErrPolicyDenied)
+		require.NoError(t, sign(third, miner, builtin.MethodsMiner.WithdrawBalance, 100))
+
+		// the prefixed messages proving the ownership of the address are allowed
+		_, err = pw.WalletSign(ctx, third, types.SignedMessageBytes(third.Protocol(), []byte("hello")), types.MsgMeta{Type: types.MTMessageBytes, Extra: []byte("hello")})
+		require.NoError(t, err)
+		_, err = pw.WalletSign(ctx, third, []byte("hello"), types.MsgMeta{Type: types.MTMessageBytes, Extra: []byte("hello")})
+		require.ErrorIs(t, err, ErrPolicyDenied)
+	})
+
+	t.Run("receiver id", func(t *testing.T) {
+		// the robust and the ID addresses of an actor are the same receiver
+		require.NoError(t, pw.SetPolicy(ctx, &types.WalletPolicy{Address: third, AllowedTo: []address.Address{other}}))
+		require.NoError(t, sign(third, other, builtin.MethodSend, 1))
+		require.NoError(t, sign(third, otherID, builtin.MethodSend, 1))
+		require.ErrorIs(t, sign(third, msig, builtin.MethodSend, 1), ErrPolicyDenied)
+
+		require.NoError(t, pw.SetPolicy(ctx, &types.WalletPolicy{Address: third, AllowedTo: []address.Address{otherID}}))
+		require.NoError(t, sign(third, other, builtin.MethodSend, 1))
+		require.ErrorIs(t, sign(third, miner, builtin.MethodSend, 1), ErrPolicyDenied)
+	})
+}
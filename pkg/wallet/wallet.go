@@ -254,6 +254,16 @@ func (w *Wallet) DSBacked() (*DSBackend, error) {
 	return (backends[0]).(*DSBackend), nil
 }
 
+// HDBacked return the HD wallet backend
+func (w *Wallet) HDBacked() (*HDBackend, error) {
+	backends := w.Backends(HDBackendType)
+	if len(backends) == 0 {
+		return nil, errors.Errorf("missing HD backend")
+	}
+
+	return (backends[0]).(*HDBackend), nil
+}
+
 // LockWallet locks wallet
 func (w *Wallet) LockWallet(ctx context.Context) error {
 	backend, err := w.DSBacked()
@@ -261,7 +271,15 @@ func (w *Wallet) LockWallet(ctx context.Context) error {
 		return err
 	}
 
-	return backend.LockWallet(ctx)
+	if err := backend.LockWallet(ctx); err != nil {
+		return err
+	}
+	for _, hd := range w.Backends(HDBackendType) {
+		if err := hd.LockWallet(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // UnLockWallet unlock local wallet with password
@@ -271,7 +289,19 @@ func (w *Wallet) UnLockWallet(ctx context.Context, password []byte) error {
 	if err != nil {
 		return err
 	}
-	return backend.UnLockWallet(ctx, password)
+
+	// the ds backend wipes the password
+	cpy := append([]byte(nil), password...)
+	defer copy(cpy, make([]byte, len(cpy)))
+	if err := backend.UnLockWallet(ctx, password); err != nil {
+		return err
+	}
+	for _, hd := range w.Backends(HDBackendType) {
+		if err := hd.UnLockWallet(ctx, cpy); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // SetPassword
@@ -280,7 +310,19 @@ func (w *Wallet) SetPassword(ctx context.Context, password []byte) error {
 	if err != nil {
 		return err
 	}
-	return backend.SetPassword(ctx, password)
+
+	// the ds backend wipes the password
+	cpy := append([]byte(nil), password...)
+	defer copy(cpy, make([]byte, len(cpy)))
+	if err := backend.SetPassword(ctx, password); err != nil {
+		return err
+	}
+	for _, hd := range w.Backends(HDBackendType) {
+		if err := hd.(*HDBackend).SetPassword(ctx, cpy); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // HasPassword return whether the password has been set in the wallet
@@ -321,6 +321,7 @@ func init() {
 		FromHeight: epochPtr(1010),
 		ToHeight:   epochPtr(1020),
 	})
+	addExample(types.ActorEventApplied)
 
 	percent := types.Percent(123)
 	addExample(percent)
@@ -351,6 +352,18 @@ func init() {
 	addExample(&ethTraceFilterCriteria)
 	addExample(ethTraceFilterCriteria)
 
+	ethCallValue := types.EthBigInt(abi.NewTokenAmount(100))
+	addExample(&types.EthCallFrame{
+		Type:    "CALL",
+		From:    ethaddr,
+		To:      &ethaddr,
+		Value:   &ethCallValue,
+		Gas:     ethint,
+		GasUsed: ethint,
+		Input:   types.EthBytes("input"),
+		Output:  types.EthBytes("output"),
+	})
+
 	f3Lease := types.F3ParticipationLease{
 		Network:      "filecoin",
 		Issuer:       pid.String(),
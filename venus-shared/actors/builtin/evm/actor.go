@@ -108,4 +108,10 @@ type State interface {
 	GetBytecode() ([]byte, error)
 	GetBytecodeCID() (cid.Cid, error)
 	GetBytecodeHash() ([32]byte, error)
+	// GetContractStateCID returns the root of the KAMT holding the storage of the contract
+	GetContractStateCID() (cid.Cid, error)
+
+	SetNonce(nonce uint64) error
+	SetBytecode(bytecode cid.Cid, hash [32]byte) error
+	SetContractStateCID(root cid.Cid) error
 }
@@ -59,6 +59,26 @@ func (s *state14) GetBytecodeHash() ([32]byte, error) {
 	return s.State.BytecodeHash, nil
 }
 
+func (s *state14) GetContractStateCID() (cid.Cid, error) {
+	return s.State.ContractState, nil
+}
+
+func (s *state14) SetNonce(nonce uint64) error {
+	s.State.Nonce = nonce
+	return nil
+}
+
+func (s *state14) SetBytecode(bytecode cid.Cid, hash [32]byte) error {
+	s.State.Bytecode = bytecode
+	s.State.BytecodeHash = hash
+	return nil
+}
+
+func (s *state14) SetContractStateCID(root cid.Cid) error {
+	s.State.ContractState = root
+	return nil
+}
+
 func (s *state14) GetBytecode() ([]byte, error) {
 	bc, err := s.GetBytecodeCID()
 	if err != nil {
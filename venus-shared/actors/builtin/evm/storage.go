@@ -0,0 +1,467 @@
+package evm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"math/bits"
+	"sort"
+
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/venus/venus-shared/actors/types"
+)
+
+// The storage of a contract is a KAMT mapping the slots to their values, see GetContractStateCID. The keys
+// are used as their own hashes, each node has up to 2^storageBitWidth pointers and each pointer holds up
+// to storageBucketSize slots before being split into a child node.
+const (
+	storageBitWidth   = 5
+	storageBucketSize = 1
+)
+
+// GetStorage returns the value of the slot key in the storage of a contract, and whether the slot is set.
+// The nodes are read from the root to the slot, so the blocks read by it prove the value of the slot.
+func GetStorage(ctx context.Context, store ipldcbor.IpldStore, root cid.Cid, key types.EthHash) (types.EthHash, bool, error) {
+	consumed := 0
+	for c := root; ; {
+		var node storageNode
+		if err := store.Get(ctx, c, &node); err != nil {
+			return types.EthHash{}, false, fmt.Errorf("failed to load storage node %s: %w", c, err)
+		}
+		if consumed+storageBitWidth > len(key)*8 {
+			return types.EthHash{}, false, fmt.Errorf("storage node %s is deeper than the keys", c)
+		}
+
+		idx := storageIndex(key, consumed)
+		consumed += storageBitWidth
+		if node.bitfield.Bit(idx) == 0 {
+			return types.EthHash{}, false, nil
+		}
+		ptr, err := node.pointer(idx)
+		if err != nil {
+			return types.EthHash{}, false, fmt.Errorf("storage node %s: %w", c, err)
+		}
+
+		if !ptr.link.Defined() {
+			for _, kv := range ptr.values {
+				if kv[0] == key {
+					return kv[1], true, nil
+				}
+			}
+			return types.EthHash{}, false, nil
+		}
+		// the bits skipped by the extension of the link are the same for all the keys under it, if they
+		// don't match the key, the key isn't under the link either
+		consumed += int(ptr.extension)
+		c = ptr.link
+	}
+}
+
+// DiffStorage returns the slots which differ between the contract storages pre and post, with their values
+// in each of them. A storage is undefined when the contract doesn't exist, the subtrees shared by the
+// storages are skipped.
+func DiffStorage(ctx context.Context, store ipldcbor.IpldStore, pre, post cid.Cid) (map[types.EthHash]types.EthHash, map[types.EthHash]types.EthHash, error) {
+	preSlots := make(map[types.EthHash]types.EthHash)
+	postSlots := make(map[types.EthHash]types.EthHash)
+	if pre == post {
+		return preSlots, postSlots, nil
+	}
+
+	var preLinks, postLinks []cid.Cid
+	if pre.Defined() {
+		preLinks = append(preLinks, pre)
+	}
+	if post.Defined() {
+		postLinks = append(postLinks, post)
+	}
+	for len(preLinks) > 0 || len(postLinks) > 0 {
+		preLinks, postLinks = dropSharedLinks(preLinks, postLinks)
+
+		var err error
+		if preLinks, err = loadStorageNodes(ctx, store, preLinks, preSlots); err != nil {
+			return nil, nil, err
+		}
+		if postLinks, err = loadStorageNodes(ctx, store, postLinks, postSlots); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for k, v := range preSlots {
+		if postSlots[k] == v {
+			delete(preSlots, k)
+			delete(postSlots, k)
+		}
+	}
+
+	return preSlots, postSlots, nil
+}
+
+// ReadStorage returns all the slots of the storage of a contract.
+func ReadStorage(ctx context.Context, store ipldcbor.IpldStore, root cid.Cid) (map[types.EthHash]types.EthHash, error) {
+	slots := make(map[types.EthHash]types.EthHash)
+	for links := []cid.Cid{root}; len(links) > 0; {
+		var err error
+		if links, err = loadStorageNodes(ctx, store, links, slots); err != nil {
+			return nil, err
+		}
+	}
+	return slots, nil
+}
+
+// PutStorage stores a contract storage holding slots and returns its root. The slots set to zero are left
+// out, as the EVM deletes them.
+func PutStorage(ctx context.Context, store ipldcbor.IpldStore, slots map[types.EthHash]types.EthHash) (cid.Cid, error) {
+	kvs := make([][2]types.EthHash, 0, len(slots))
+	for k, v := range slots {
+		if v != (types.EthHash{}) {
+			kvs = append(kvs, [2]types.EthHash{k, v})
+		}
+	}
+	sort.Slice(kvs, func(i, j int) bool {
+		return bytes.Compare(kvs[i][0][:], kvs[j][0][:]) < 0
+	})
+	return putStorageNode(ctx, store, kvs, 0)
+}
+
+// putStorageNode stores the node holding the sorted kvs, which share their first consumed bits.
+func putStorageNode(ctx context.Context, store ipldcbor.IpldStore, kvs [][2]types.EthHash, consumed int) (cid.Cid, error) {
+	maxConsumed := types.EthHashLength*8 - storageBitWidth
+	if consumed > maxConsumed {
+		return cid.Undef, fmt.Errorf("the storage keys are too close to be split")
+	}
+
+	var node storageNode
+	for start := 0; start < len(kvs); {
+		idx := storageIndex(kvs[start][0], consumed)
+		end := start + 1
+		for end < len(kvs) && storageIndex(kvs[end][0], consumed) == idx {
+			end++
+		}
+		group := kvs[start:end]
+		start = end
+
+		node.bitfield.SetBit(&node.bitfield, idx, 1)
+		if len(group) <= storageBucketSize {
+			node.pointers = append(node.pointers, storagePointer{values: group})
+			continue
+		}
+
+		// the child skips the bits shared by all its keys, leaving enough bits for its own index
+		next := consumed + storageBitWidth
+		ext := commonPrefix(group[0][0], group[len(group)-1][0], next)
+		if next+ext > maxConsumed {
+			ext = maxConsumed - next
+		}
+		if ext < 0 {
+			ext = 0
+		}
+		child, err := putStorageNode(ctx, store, group, next+ext)
+		if err != nil {
+			return cid.Undef, err
+		}
+		node.pointers = append(node.pointers, storagePointer{
+			link:      child,
+			extension: uint64(ext),
+			path:      extensionPath(group[0][0], next, ext),
+		})
+	}
+
+	return store.Put(ctx, &node)
+}
+
+// commonPrefix returns the number of bits shared by a and b after the consumed ones
+func commonPrefix(a, b types.EthHash, consumed int) int {
+	n := 0
+	for i := consumed; i < len(a)*8 && a[i/8]>>(7-i%8)&1 == b[i/8]>>(7-i%8)&1; i++ {
+		n++
+	}
+	return n
+}
+
+// extensionPath returns the n bits of key after the consumed ones, by chunks of up to 8 bits.
+func extensionPath(key types.EthHash, consumed, n int) []byte {
+	var path []byte
+	for ; n > 0; n -= 8 {
+		chunk := n
+		if chunk > 8 {
+			chunk = 8
+		}
+		var b byte
+		for i := consumed; i < consumed+chunk; i++ {
+			b = b<<1 | key[i/8]>>(7-i%8)&1
+		}
+		path = append(path, b)
+		consumed += chunk
+	}
+	return path
+}
+
+// storageIndex returns the storageBitWidth bits of key after the consumed ones
+func storageIndex(key types.EthHash, consumed int) int {
+	idx := 0
+	for i := consumed; i < consumed+storageBitWidth; i++ {
+		idx = idx<<1 | int(key[i/8]>>(7-i%8)&1)
+	}
+	return idx
+}
+
+func dropSharedLinks(a, b []cid.Cid) ([]cid.Cid, []cid.Cid) {
+	inA := make(map[cid.Cid]struct{}, len(a))
+	for _, c := range a {
+		inA[c] = struct{}{}
+	}
+	shared := make(map[cid.Cid]struct{})
+	outB := b[:0]
+	for _, c := range b {
+		if _, ok := inA[c]; ok {
+			shared[c] = struct{}{}
+			continue
+		}
+		outB = append(outB, c)
+	}
+	outA := a[:0]
+	for _, c := range a {
+		if _, ok := shared[c]; !ok {
+			outA = append(outA, c)
+		}
+	}
+	return outA, outB
+}
+
+// loadStorageNodes adds the slots of the nodes to slots and returns the links to their children
+func loadStorageNodes(ctx context.Context, store ipldcbor.IpldStore, nodes []cid.Cid, slots map[types.EthHash]types.EthHash) ([]cid.Cid, error) {
+	var links []cid.Cid
+	for _, c := range nodes {
+		var node storageNode
+		if err := store.Get(ctx, c, &node); err != nil {
+			return nil, fmt.Errorf("failed to load storage node %s: %w", c, err)
+		}
+		for _, ptr := range node.pointers {
+			if ptr.link.Defined() {
+				links = append(links, ptr.link)
+				continue
+			}
+			for _, kv := range ptr.values {
+				slots[kv[0]] = kv[1]
+			}
+		}
+	}
+	return links, nil
+}
+
+// storageNode is a node of the KAMT, encoded as [bitfield, [pointer...]] where a pointer is either a link,
+// a link with an extension [link, [consumed, path]] or the key value pairs [[key, value]...]. The keys
+// and values are big endian without their leading zeros.
+type storageNode struct {
+	bitfield big.Int
+	pointers []storagePointer
+}
+
+type storagePointer struct {
+	link cid.Cid
+	// extension is the number of bits skipped by the link, path their value
+	extension uint64
+	path      []byte
+	values    [][2]types.EthHash
+}
+
+func (n *storageNode) pointer(idx int) (*storagePointer, error) {
+	var below big.Int
+	below.SetBit(&below, idx, 1)
+	below.Sub(&below, big.NewInt(1))
+	below.And(&below, &n.bitfield)
+
+	pos := 0
+	for _, w := range below.Bits() {
+		pos += bits.OnesCount(uint(w))
+	}
+	if pos >= len(n.pointers) {
+		return nil, fmt.Errorf("bitfield doesn't match the %d pointers", len(n.pointers))
+	}
+	return &n.pointers[pos], nil
+}
+
+func (n *storageNode) UnmarshalCBOR(r io.Reader) error {
+	cr := cbg.NewCborReader(r)
+	if err := readArrayHeader(cr, 2); err != nil {
+		return err
+	}
+	bitfield, err := cbg.ReadByteArray(cr, cbg.ByteArrayMaxLen)
+	if err != nil {
+		return fmt.Errorf("reading bitfield: %w", err)
+	}
+	n.bitfield.SetBytes(bitfield)
+
+	maj, count, err := cr.ReadHeader()
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected an array of pointers")
+	}
+	n.pointers = make([]storagePointer, count)
+	for i := range n.pointers {
+		var raw cbg.Deferred
+		if err := raw.UnmarshalCBOR(cr); err != nil {
+			return err
+		}
+		if err := n.pointers[i].unmarshal(raw.Raw); err != nil {
+			return fmt.Errorf("reading pointer %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (n *storageNode) MarshalCBOR(w io.Writer) error {
+	cw := cbg.NewCborWriter(w)
+	if err := cw.WriteMajorTypeHeader(cbg.MajArray, 2); err != nil {
+		return err
+	}
+	if err := cbg.WriteByteArray(cw, n.bitfield.Bytes()); err != nil {
+		return err
+	}
+	if err := cw.WriteMajorTypeHeader(cbg.MajArray, uint64(len(n.pointers))); err != nil {
+		return err
+	}
+	for _, p := range n.pointers {
+		if err := p.marshal(cw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *storagePointer) marshal(cw *cbg.CborWriter) error {
+	if !p.link.Defined() {
+		if err := cw.WriteMajorTypeHeader(cbg.MajArray, uint64(len(p.values))); err != nil {
+			return err
+		}
+		for _, kv := range p.values {
+			if err := cw.WriteMajorTypeHeader(cbg.MajArray, 2); err != nil {
+				return err
+			}
+			for _, b := range kv {
+				if err := cbg.WriteByteArray(cw, bytes.TrimLeft(b[:], "\x00")); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	if p.extension == 0 {
+		return cbg.WriteCid(cw, p.link)
+	}
+	if err := cw.WriteMajorTypeHeader(cbg.MajArray, 2); err != nil {
+		return err
+	}
+	if err := cbg.WriteCid(cw, p.link); err != nil {
+		return err
+	}
+	if err := cw.WriteMajorTypeHeader(cbg.MajArray, 2); err != nil {
+		return err
+	}
+	if err := cw.WriteMajorTypeHeader(cbg.MajUnsignedInt, p.extension); err != nil {
+		return err
+	}
+	return cbg.WriteByteArray(cw, p.path)
+}
+
+func (p *storagePointer) unmarshal(raw []byte) error {
+	if isCid(raw) {
+		c, err := cbg.ReadCid(bytes.NewReader(raw))
+		if err != nil {
+			return err
+		}
+		p.link = c
+		return nil
+	}
+
+	cr := cbg.NewCborReader(bytes.NewReader(raw))
+	maj, count, err := cr.ReadHeader()
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray || count == 0 {
+		return fmt.Errorf("unexpected pointer")
+	}
+	elems := make([]cbg.Deferred, count)
+	for i := range elems {
+		if err := elems[i].UnmarshalCBOR(cr); err != nil {
+			return err
+		}
+	}
+
+	if count == 2 && isCid(elems[0].Raw) {
+		c, err := cbg.ReadCid(bytes.NewReader(elems[0].Raw))
+		if err != nil {
+			return err
+		}
+		p.link = c
+		return p.unmarshalExtension(elems[1].Raw)
+	}
+
+	for _, elem := range elems {
+		kv, err := unmarshalStoragePair(elem.Raw)
+		if err != nil {
+			return err
+		}
+		p.values = append(p.values, kv)
+	}
+	return nil
+}
+
+func (p *storagePointer) unmarshalExtension(raw []byte) error {
+	cr := cbg.NewCborReader(bytes.NewReader(raw))
+	if err := readArrayHeader(cr, 2); err != nil {
+		return fmt.Errorf("reading extension: %w", err)
+	}
+	maj, consumed, err := cr.ReadHeader()
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajUnsignedInt {
+		return fmt.Errorf("expected the number of bits consumed by the extension")
+	}
+	p.extension = consumed
+	if p.path, err = cbg.ReadByteArray(cr, cbg.ByteArrayMaxLen); err != nil {
+		return fmt.Errorf("reading extension path: %w", err)
+	}
+	return nil
+}
+
+func unmarshalStoragePair(raw []byte) ([2]types.EthHash, error) {
+	var kv [2]types.EthHash
+	cr := cbg.NewCborReader(bytes.NewReader(raw))
+	if err := readArrayHeader(cr, 2); err != nil {
+		return kv, err
+	}
+	for i := range kv {
+		b, err := cbg.ReadByteArray(cr, types.EthHashLength)
+		if err != nil {
+			return kv, err
+		}
+		copy(kv[i][types.EthHashLength-len(b):], b)
+	}
+	return kv, nil
+}
+
+func isCid(raw []byte) bool {
+	return len(raw) > 0 && raw[0]>>5 == cbg.MajTag
+}
+
+func readArrayHeader(cr *cbg.CborReader, length uint64) error {
+	maj, extra, err := cr.ReadHeader()
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray || extra != length {
+		return fmt.Errorf("expected an array of %d elements", length)
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+package evm
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/venus/venus-shared/actors/types"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+)
+
+// rawNode writes its cbor encoding as is
+type rawNode []byte
+
+func (n rawNode) MarshalCBOR(w io.Writer) error {
+	_, err := w.Write(n)
+	return err
+}
+
+type storageBuilder struct {
+	t     *testing.T
+	store ipldcbor.IpldStore
+}
+
+// node stores a node with the pointers at the given indexes
+func (b *storageBuilder) node(pointers map[int][]byte) cid.Cid {
+	var bitfield uint64
+	for idx := range pointers {
+		bitfield |= 1 << idx
+	}
+	var bitfieldBytes []byte
+	for ; bitfield > 0; bitfield >>= 8 {
+		bitfieldBytes = append([]byte{byte(bitfield)}, bitfieldBytes...)
+	}
+
+	out := cbg.CborEncodeMajorType(cbg.MajArray, 2)
+	out = append(out, byteString(bitfieldBytes)...)
+	out = append(out, cbg.CborEncodeMajorType(cbg.MajArray, uint64(len(pointers)))...)
+	for idx := 0; idx < 1<<storageBitWidth; idx++ {
+		out = append(out, pointers[idx]...)
+	}
+	c, err := b.store.Put(context.Background(), rawNode(out))
+	require.NoError(b.t, err)
+	return c
+}
+
+func byteString(b []byte) []byte {
+	return append(cbg.CborEncodeMajorType(cbg.MajByteString, uint64(len(b))), b...)
+}
+
+func link(c cid.Cid) []byte {
+	var w bytesWriter
+	_ = cbg.WriteCid(&w, c)
+	return w
+}
+
+// linkWithExt is a link skipping consumed bits
+func linkWithExt(c cid.Cid, consumed uint64) []byte {
+	out := cbg.CborEncodeMajorType(cbg.MajArray, 2)
+	out = append(out, link(c)...)
+	out = append(out, cbg.CborEncodeMajorType(cbg.MajArray, 2)...)
+	out = append(out, cbg.CborEncodeMajorType(cbg.MajUnsignedInt, consumed)...)
+	return append(out, byteString([]byte{0})...)
+}
+
+func values(kvs ...[2][]byte) []byte {
+	out := cbg.CborEncodeMajorType(cbg.MajArray, uint64(len(kvs)))
+	for _, kv := range kvs {
+		out = append(out, cbg.CborEncodeMajorType(cbg.MajArray, 2)...)
+		out = append(out, byteString(kv[0])...)
+		out = append(out, byteString(kv[1])...)
+	}
+	return out
+}
+
+type bytesWriter []byte
+
+func (w *bytesWriter) Write(b []byte) (int, error) {
+	*w = append(*w, b...)
+	return len(b), nil
+}
+
+func TestGetStorage(t *testing.T) {
+	ctx := context.Background()
+	b := &storageBuilder{t: t, store: ipldcbor.NewCborStore(blockstoreutil.NewTemporarySync())}
+
+	// the first 5 bits of the keys are their index in the root
+	k1 := types.EthHash{0: 1 << 3}
+	k2 := types.EthHash{0: 1 << 3, 1: 0x80}
+	k3 := types.EthHash{0: 3 << 3, 31: 1}
+	k4 := types.EthHash{0: 4 << 3, 1: 0x04}
+
+	leaf := b.node(map[int][]byte{
+		0: values([2][]byte{k1[:], {1}}),
+		2: values([2][]byte{k2[:], {2}}),
+	})
+	// the extension skips the bits 5 to 9 of k4
+	extended := b.node(map[int][]byte{
+		2: values([2][]byte{k4[:], {4, 0}}),
+	})
+	root := b.node(map[int][]byte{
+		1: link(leaf),
+		3: values([2][]byte{k3[:], {3}}),
+		4: linkWithExt(extended, 5),
+	})
+
+	for key, value := range map[types.EthHash]types.EthHash{
+		k1: {31: 1},
+		k2: {31: 2},
+		k3: {31: 3},
+		k4: {30: 4},
+	} {
+		v, found, err := GetStorage(ctx, b.store, root, key)
+		require.NoError(t, err)
+		require.True(t, found, key)
+		require.Equal(t, value, v)
+	}
+
+	for _, key := range []types.EthHash{{0: 5 << 3}, {0: 1 << 3, 1: 0x40}, {0: 3 << 3}} {
+		v, found, err := GetStorage(ctx, b.store, root, key)
+		require.NoError(t, err)
+		require.False(t, found, key)
+		require.Equal(t, types.EthHash{}, v)
+	}
+}
+
+func TestDiffStorage(t *testing.T) {
+	ctx := context.Background()
+	b := &storageBuilder{t: t, store: ipldcbor.NewCborStore(blockstoreutil.NewTemporarySync())}
+	slot := func(v byte) types.EthHash { return types.EthHash{31: v} }
+
+	shared := b.node(map[int][]byte{0: values([2][]byte{{9}, {9}})})
+	pre := b.node(map[int][]byte{
+		0: link(shared),
+		1: linkWithExt(b.node(map[int][]byte{0: values([2][]byte{{1}, {1}}), 1: values([2][]byte{{2}, {2}})}), 5),
+	})
+	post := b.node(map[int][]byte{
+		0: link(shared),
+		1: link(b.node(map[int][]byte{0: values([2][]byte{{1}, {1}}), 1: values([2][]byte{{2}, {1, 0}})})),
+		2: values([2][]byte{{3}, {3}}),
+	})
+
+	preSlots, postSlots, err := DiffStorage(ctx, b.store, pre, post)
+	require.NoError(t, err)
+	require.Equal(t, map[types.EthHash]types.EthHash{slot(2): slot(2)}, preSlots)
+	require.Equal(t, map[types.EthHash]types.EthHash{
+		slot(2): {30: 1},
+		slot(3): slot(3),
+	}, postSlots)
+
+	// a contract created by the message
+	preSlots, postSlots, err = DiffStorage(ctx, b.store, cid.Undef, shared)
+	require.NoError(t, err)
+	require.Empty(t, preSlots)
+	require.Equal(t, map[types.EthHash]types.EthHash{slot(9): slot(9)}, postSlots)
+}
+
+func TestPutStorage(t *testing.T) {
+	ctx := context.Background()
+	store := ipldcbor.NewCborStore(blockstoreutil.NewTemporarySync())
+
+	slots := map[types.EthHash]types.EthHash{
+		{}:                      {31: 1},
+		{31: 1}:                 {31: 2},
+		{31: 2}:                 {0: 1},
+		{0: 0x80}:               {31: 3},
+		{0: 0x80, 31: 1}:        {31: 4},
+		{0: 0x04, 15: 1, 31: 1}: {31: 5},
+	}
+	withZero := map[types.EthHash]types.EthHash{{31: 9}: {}}
+	for k, v := range slots {
+		withZero[k] = v
+	}
+
+	root, err := PutStorage(ctx, store, withZero)
+	require.NoError(t, err)
+
+	read, err := ReadStorage(ctx, store, root)
+	require.NoError(t, err)
+	require.Equal(t, slots, read)
+	for key, value := range slots {
+		v, found, err := GetStorage(ctx, store, root, key)
+		require.NoError(t, err)
+		require.True(t, found, key)
+		require.Equal(t, value, v)
+	}
+	_, found, err := GetStorage(ctx, store, root, types.EthHash{31: 9})
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// the storage doesn't depend on the order of the slots
+	again, err := PutStorage(ctx, store, slots)
+	require.NoError(t, err)
+	require.Equal(t, root, again)
+
+	empty, err := PutStorage(ctx, store, nil)
+	require.NoError(t, err)
+	read, err = ReadStorage(ctx, store, empty)
+	require.NoError(t, err)
+	require.Empty(t, read)
+}
+
+// TestPutStorageLayout checks that PutStorage lays the slots out like the KAMT of the EVM actor, see
+// fvm_ipld_kamt with the config of the actor: bit width 5, one slot per bucket, no minimal data depth.
+// The expected nodes are encoded by hand: a bucket holding two slots is split into a child node, the bits
+// shared by the slots after the index of the bucket are skipped by an extension of the link.
+func TestPutStorageLayout(t *testing.T) {
+	ctx := context.Background()
+	b := &storageBuilder{t: t, store: ipldcbor.NewCborStore(blockstoreutil.NewTemporarySync())}
+
+	// k1 and k2 share the index 0 and the next bit, the child is indexed by the bits 6 to 10
+	k1 := types.EthHash{0: 0x01}
+	k2 := types.EthHash{0: 0x02}
+	// k3 is alone in its bucket
+	k3 := types.EthHash{0: 0x08}
+	// k4 and k5 share the index 2 but not the next bit, the child is linked without extension
+	k4 := types.EthHash{0: 0x10}
+	k5 := types.EthHash{0: 0x14, 31: 1}
+
+	expect := b.node(map[int][]byte{
+		0: linkWithExt(b.node(map[int][]byte{
+			8:  values([2][]byte{k1[:], {1}}),
+			16: values([2][]byte{k2[:], {2}}),
+		}), 1),
+		1: values([2][]byte{k3[:], {3}}),
+		2: link(b.node(map[int][]byte{
+			0:  values([2][]byte{k4[:], {4}}),
+			16: values([2][]byte{k5[:], {1, 0}}),
+		})),
+	})
+
+	root, err := PutStorage(ctx, b.store, map[types.EthHash]types.EthHash{
+		k1: {31: 1},
+		k2: {31: 2},
+		k3: {31: 3},
+		k4: {31: 4},
+		k5: {30: 1},
+	})
+	require.NoError(t, err)
+	require.Equal(t, expect, root)
+}
@@ -208,6 +208,7 @@ var (
 	EmptyEthHash   = EthHash{}
 	EmptyUncleHash = One(ParseEthHash("0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347")) // Keccak-256 of an RLP of an empty array
 	EmptyRootHash  = One(ParseEthHash("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")) // Keccak-256 hash of the RLP of null
+	EmptyCodeHash  = One(ParseEthHash("0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470")) // Keccak-256 hash of empty bytes
 	EmptyEthInt    = EthUint64(0)
 	EmptyEthNonce  = [8]byte{0, 0, 0, 0, 0, 0, 0, 0}
 )
@@ -495,6 +496,20 @@ func (ea *EthAddress) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalText allows EthAddress to be used as a JSON map key
+func (ea EthAddress) MarshalText() ([]byte, error) {
+	return []byte(ea.String()), nil
+}
+
+func (ea *EthAddress) UnmarshalText(b []byte) error {
+	addr, err := ParseEthAddress(string(b))
+	if err != nil {
+		return err
+	}
+	copy(ea[:], addr[:])
+	return nil
+}
+
 func (ea EthAddress) IsMaskedID() bool {
 	return bytes.HasPrefix(ea[:], maskedIDPrefix[:])
 }
@@ -566,6 +581,20 @@ func (h *EthHash) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalText allows EthHash to be used as a JSON map key
+func (h EthHash) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+func (h *EthHash) UnmarshalText(b []byte) error {
+	hash, err := ParseEthHash(string(b))
+	if err != nil {
+		return err
+	}
+	copy(h[:], hash[:])
+	return nil
+}
+
 func (h EthHash) String() string {
 	return "0x" + hex.EncodeToString(h[:])
 }
@@ -1226,3 +1255,397 @@ type EthTraceFilterCriteria struct {
 	// Optional, default: all traces.
 	Count *EthUint64 `json:"count,omitempty"`
 }
+
+const (
+	// EthCallTracer builds the tree of the calls of a transaction
+	EthCallTracer = "callTracer"
+	// EthPrestateTracer returns the state of the accounts touched by a transaction
+	EthPrestateTracer = "prestateTracer"
+)
+
+// EthTraceConfig is the config of the debug_trace* methods, only the callTracer and prestateTracer
+// tracers are supported.
+type EthTraceConfig struct {
+	Tracer       string          `json:"tracer"`
+	TracerConfig EthTracerConfig `json:"tracerConfig,omitempty"`
+}
+
+type EthTracerConfig struct {
+	// OnlyTopCall makes the callTracer skip the subcalls
+	OnlyTopCall bool `json:"onlyTopCall,omitempty"`
+	// DiffMode makes the prestateTracer return the state before and after the transaction
+	DiffMode bool `json:"diffMode,omitempty"`
+}
+
+// EthCallFrame is a call of the callTracer
+type EthCallFrame struct {
+	Type    string          `json:"type"`
+	From    EthAddress      `json:"from"`
+	To      *EthAddress     `json:"to,omitempty"`
+	Value   *EthBigInt      `json:"value,omitempty"`
+	Gas     EthUint64       `json:"gas"`
+	GasUsed EthUint64       `json:"gasUsed"`
+	Input   EthBytes        `json:"input"`
+	Output  EthBytes        `json:"output,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	Calls   []*EthCallFrame `json:"calls,omitempty"`
+}
+
+// EthPrestateAccount is the state of an account returned by the prestateTracer
+type EthPrestateAccount struct {
+	Balance *EthBigInt          `json:"balance,omitempty"`
+	Nonce   EthUint64           `json:"nonce,omitempty"`
+	Code    EthBytes            `json:"code,omitempty"`
+	Storage map[EthHash]EthHash `json:"storage,omitempty"`
+}
+
+type EthPrestate map[EthAddress]*EthPrestateAccount
+
+// EthPrestateDiff is the result of the prestateTracer in diff mode
+type EthPrestateDiff struct {
+	Pre  EthPrestate `json:"pre"`
+	Post EthPrestate `json:"post"`
+}
+
+// EthDebugTrace is the result of a debug_trace* method, only the field of the requested tracer is set.
+type EthDebugTrace struct {
+	CallFrame *EthCallFrame
+	Prestate  EthPrestate
+	Diff      *EthPrestateDiff
+}
+
+func (e EthDebugTrace) MarshalJSON() ([]byte, error) {
+	switch {
+	case e.CallFrame != nil:
+		return json.Marshal(e.CallFrame)
+	case e.Diff != nil:
+		return json.Marshal(e.Diff)
+	case e.Prestate != nil:
+		return json.Marshal(e.Prestate)
+	}
+	return []byte("null"), nil
+}
+
+func (e *EthDebugTrace) UnmarshalJSON(b []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil || fields == nil {
+		return err
+	}
+	if _, ok := fields["type"]; ok {
+		e.CallFrame = new(EthCallFrame)
+		return json.Unmarshal(b, e.CallFrame)
+	}
+	_, pre := fields["pre"]
+	_, post := fields["post"]
+	if pre && post {
+		e.Diff = new(EthPrestateDiff)
+		return json.Unmarshal(b, e.Diff)
+	}
+	return json.Unmarshal(b, &e.Prestate)
+}
+
+type EthDebugTraceBlockResult struct {
+	TxHash EthHash       `json:"txHash"`
+	Result EthDebugTrace `json:"result"`
+}
+
+// EthDebugTraceTransactionParams handles raw jsonrpc params for debug_traceTransaction
+type EthDebugTraceTransactionParams struct {
+	TxHash EthHash
+	Config *EthTraceConfig
+}
+
+func (e *EthDebugTraceTransactionParams) UnmarshalJSON(b []byte) error {
+	params, err := unmarshalTraceParams(b, 1, &e.Config)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(params[0], &e.TxHash)
+}
+
+func (e EthDebugTraceTransactionParams) MarshalJSON() ([]byte, error) {
+	if e.Config != nil {
+		return json.Marshal([]interface{}{e.TxHash, e.Config})
+	}
+	return json.Marshal([]interface{}{e.TxHash})
+}
+
+// EthDebugTraceBlockParams handles raw jsonrpc params for debug_traceBlockByNumber
+type EthDebugTraceBlockParams struct {
+	BlkNum string
+	Config *EthTraceConfig
+}
+
+func (e *EthDebugTraceBlockParams) UnmarshalJSON(b []byte) error {
+	params, err := unmarshalTraceParams(b, 1, &e.Config)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(params[0], &e.BlkNum)
+}
+
+func (e EthDebugTraceBlockParams) MarshalJSON() ([]byte, error) {
+	if e.Config != nil {
+		return json.Marshal([]interface{}{e.BlkNum, e.Config})
+	}
+	return json.Marshal([]interface{}{e.BlkNum})
+}
+
+// EthDebugTraceCallParams handles raw jsonrpc params for debug_traceCall
+type EthDebugTraceCallParams struct {
+	Tx       EthCall
+	BlkParam EthBlockNumberOrHash
+	Config   *EthTraceConfig
+}
+
+func (e *EthDebugTraceCallParams) UnmarshalJSON(b []byte) error {
+	params, err := unmarshalTraceParams(b, 2, &e.Config)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(params[0], &e.Tx); err != nil {
+		return err
+	}
+	return json.Unmarshal(params[1], &e.BlkParam)
+}
+
+func (e EthDebugTraceCallParams) MarshalJSON() ([]byte, error) {
+	if e.Config != nil {
+		return json.Marshal([]interface{}{e.Tx, e.BlkParam, e.Config})
+	}
+	return json.Marshal([]interface{}{e.Tx, e.BlkParam})
+}
+
+// unmarshalTraceParams splits the params of a debug_trace* method made of n required params and an optional
+// trace config.
+func unmarshalTraceParams(b []byte, n int, config **EthTraceConfig) ([]json.RawMessage, error) {
+	var params []json.RawMessage
+	if err := json.Unmarshal(b, &params); err != nil {
+		return nil, err
+	}
+	switch len(params) {
+	case n + 1:
+		if err := json.Unmarshal(params[n], config); err != nil {
+			return nil, err
+		}
+	case n:
+	default:
+		return nil, fmt.Errorf("expected %d or %d params, got %d", n, n+1, len(params))
+	}
+	return params, nil
+}
+
+// EthProof is the result of eth_getProof. Unlike Ethereum the proofs aren't Merkle-Patricia proofs, they are
+// the IPLD blocks read from the state root to the actor and from the storage root of the contract to the
+// slots, see ethproof.Verify.
+type EthProof struct {
+	Address  EthAddress `json:"address"`
+	Balance  EthBigInt  `json:"balance"`
+	Nonce    EthUint64  `json:"nonce"`
+	CodeHash EthHash    `json:"codeHash"`
+	// StorageHash is the hash of the CID of the storage root of the contract, EmptyRootHash when the
+	// account isn't a contract
+	StorageHash  EthHash           `json:"storageHash"`
+	AccountProof []EthBytes        `json:"accountProof"`
+	StorageProof []EthStorageProof `json:"storageProof"`
+}
+
+type EthStorageProof struct {
+	Key   EthHash    `json:"key"`
+	Value EthBigInt  `json:"value"`
+	Proof []EthBytes `json:"proof"`
+}
+
+// EthAccountOverride replaces parts of an account before a simulation, an account which doesn't exist is
+// created. State replaces the whole storage of the contract while StateDiff only sets the given slots.
+type EthAccountOverride struct {
+	Balance   *EthBigInt          `json:"balance,omitempty"`
+	Nonce     *EthUint64          `json:"nonce,omitempty"`
+	Code      *EthBytes           `json:"code,omitempty"`
+	State     map[EthHash]EthHash `json:"state,omitempty"`
+	StateDiff map[EthHash]EthHash `json:"stateDiff,omitempty"`
+}
+
+// EthStateOverride maps the accounts to their overrides.
+type EthStateOverride map[EthAddress]EthAccountOverride
+
+// EthTraceResults is the result of trace_call.
+type EthTraceResults struct {
+	Output    EthBytes    `json:"output"`
+	StateDiff *string     `json:"stateDiff"`
+	Trace     []*EthTrace `json:"trace"`
+	VMTrace   *string     `json:"vmTrace"`
+}
+
+// EthTraceCallParams handles raw jsonrpc params for trace_call, the block defaults to "latest".
+type EthTraceCallParams struct {
+	Tx             EthCall
+	TraceTypes     []string
+	BlkParam       *EthBlockNumberOrHash
+	StateOverrides EthStateOverride
+}
+
+func (e *EthTraceCallParams) UnmarshalJSON(b []byte) error {
+	var params []json.RawMessage
+	err := json.Unmarshal(b, &params)
+	if err != nil {
+		return err
+	}
+
+	switch len(params) {
+	case 4:
+		err = json.Unmarshal(params[3], &e.StateOverrides)
+		if err != nil {
+			return err
+		}
+		fallthrough
+	case 3:
+		err = json.Unmarshal(params[2], &e.BlkParam)
+		if err != nil {
+			return err
+		}
+		fallthrough
+	case 2:
+		err = json.Unmarshal(params[1], &e.TraceTypes)
+		if err != nil {
+			return err
+		}
+		err = json.Unmarshal(params[0], &e.Tx)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("expected 2 to 4 params, got %d", len(params))
+	}
+
+	return nil
+}
+
+func (e EthTraceCallParams) MarshalJSON() ([]byte, error) {
+	params := []interface{}{e.Tx, e.TraceTypes}
+	if e.BlkParam != nil || e.StateOverrides != nil {
+		params = append(params, e.BlkParam)
+	}
+	if e.StateOverrides != nil {
+		params = append(params, e.StateOverrides)
+	}
+	return json.Marshal(params)
+}
+
+// EthSimulatePayload is the first param of eth_simulateV1. All the blocks are simulated at the epoch of the
+// requested block, one after another.
+type EthSimulatePayload struct {
+	BlockStateCalls []EthBlockStateCalls `json:"blockStateCalls"`
+}
+
+// EthBlockStateCalls are the calls of a simulated block, applied after the state overrides. Overriding
+// the fields of the block isn't supported.
+type EthBlockStateCalls struct {
+	StateOverrides EthStateOverride `json:"stateOverrides,omitempty"`
+	Calls          []EthCall        `json:"calls"`
+}
+
+func (e *EthBlockStateCalls) UnmarshalJSON(b []byte) error {
+	type TempEthBlockStateCalls EthBlockStateCalls
+	var calls struct {
+		TempEthBlockStateCalls
+		BlockOverrides json.RawMessage `json:"blockOverrides"`
+	}
+	if err := json.Unmarshal(b, &calls); err != nil {
+		return err
+	}
+	if len(calls.BlockOverrides) > 0 && string(calls.BlockOverrides) != "null" {
+		return fmt.Errorf("block overrides are not supported")
+	}
+	*e = EthBlockStateCalls(calls.TempEthBlockStateCalls)
+	return nil
+}
+
+// EthSimulateParams handles raw jsonrpc params for eth_simulateV1, the block defaults to "latest".
+type EthSimulateParams struct {
+	Payload  EthSimulatePayload
+	BlkParam *EthBlockNumberOrHash
+}
+
+func (e *EthSimulateParams) UnmarshalJSON(b []byte) error {
+	var params []json.RawMessage
+	err := json.Unmarshal(b, &params)
+	if err != nil {
+		return err
+	}
+
+	switch len(params) {
+	case 2:
+		err = json.Unmarshal(params[1], &e.BlkParam)
+		if err != nil {
+			return err
+		}
+		fallthrough
+	case 1:
+		err = json.Unmarshal(params[0], &e.Payload)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("expected 1 or 2 params, got %d", len(params))
+	}
+
+	return nil
+}
+
+func (e EthSimulateParams) MarshalJSON() ([]byte, error) {
+	if e.BlkParam != nil {
+		return json.Marshal([]interface{}{e.Payload, e.BlkParam})
+	}
+	return json.Marshal([]interface{}{e.Payload})
+}
+
+// EthSimulatedBlock is the result of a block of eth_simulateV1, Number is the epoch the block was
+// simulated at.
+type EthSimulatedBlock struct {
+	Number EthUint64          `json:"number"`
+	Calls  []EthSimulatedCall `json:"calls"`
+}
+
+// EthSimulatedCall is the result of a call of eth_simulateV1, with its logs and traces.
+type EthSimulatedCall struct {
+	ReturnData EthBytes          `json:"returnData"`
+	Logs       []EthLog          `json:"logs"`
+	GasUsed    EthUint64         `json:"gasUsed"`
+	Status     EthUint64         `json:"status"`
+	Error      *EthSimulateError `json:"error,omitempty"`
+	Trace      []*EthTrace       `json:"trace"`
+}
+
+// EthSimulateError is the error of a failed call of eth_simulateV1, the code is 3 for reverted calls as
+// in Ethereum, and the data is the revert data.
+type EthSimulateError struct {
+	Code    int      `json:"code"`
+	Message string   `json:"message"`
+	Data    EthBytes `json:"data,omitempty"`
+}
+
+// EthTxPoolContent is the result of txpool_content, the transactions of each sender keyed by their nonce.
+// The pending transactions can be included in the next block, the queued ones are behind a nonce gap.
+type EthTxPoolContent struct {
+	Pending map[EthAddress]map[string]EthTx `json:"pending"`
+	Queued  map[EthAddress]map[string]EthTx `json:"queued"`
+}
+
+// EthTxPoolContentFrom is the result of txpool_contentFrom, the transactions of a sender keyed by their nonce.
+type EthTxPoolContentFrom struct {
+	Pending map[string]EthTx `json:"pending"`
+	Queued  map[string]EthTx `json:"queued"`
+}
+
+// EthTxPoolInspect is the result of txpool_inspect, a textual summary of the transactions of txpool_content.
+type EthTxPoolInspect struct {
+	Pending map[EthAddress]map[string]string `json:"pending"`
+	Queued  map[EthAddress]map[string]string `json:"queued"`
+}
+
+// EthTxPoolStatus is the result of txpool_status, the number of pending and queued transactions.
+type EthTxPoolStatus struct {
+	Pending EthUint64 `json:"pending"`
+	Queued  EthUint64 `json:"queued"`
+}
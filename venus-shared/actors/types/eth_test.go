@@ -569,3 +569,95 @@ func TestEthBlockNumberOrHashUnmarshalJSON(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestEthDebugTraceJSON(t *testing.T) {
+	addr, err := ParseEthAddress("0xd4c5fb16488Aa48081296299d54b0c648C9333dA")
+	require.NoError(t, err)
+	value := EthBigInt(big.NewInt(10))
+
+	traces := []EthDebugTrace{
+		{CallFrame: &EthCallFrame{
+			Type:  "CALL",
+			From:  addr,
+			To:    &addr,
+			Value: &value,
+			Calls: []*EthCallFrame{{Type: "DELEGATECALL", From: addr, Input: EthBytes{}}},
+			Input: EthBytes{1},
+		}},
+		{Prestate: EthPrestate{addr: {
+			Balance: &value,
+			Nonce:   1,
+			Storage: map[EthHash]EthHash{{1}: {2}},
+		}}},
+		{Diff: &EthPrestateDiff{
+			Pre:  EthPrestate{addr: {Balance: &value}},
+			Post: EthPrestate{addr: {Nonce: 2}},
+		}},
+	}
+	for _, trace := range traces {
+		b, err := json.Marshal(trace)
+		require.NoError(t, err)
+		var out EthDebugTrace
+		require.NoError(t, json.Unmarshal(b, &out))
+		require.Equal(t, trace, out)
+	}
+
+	b, err := json.Marshal(traces[1])
+	require.NoError(t, err)
+	require.Contains(t, string(b), `"0xd4c5fb16488aa48081296299d54b0c648c9333da":`)
+}
+
+func TestEthDebugTraceParamsUnmarshalJSON(t *testing.T) {
+	var txParams EthDebugTraceTransactionParams
+	require.NoError(t, json.Unmarshal([]byte(`["0x1234567890123456789012345678901234567890123456789012345678901234"]`), &txParams))
+	require.Nil(t, txParams.Config)
+
+	var blkParams EthDebugTraceBlockParams
+	require.NoError(t, json.Unmarshal([]byte(`["latest", {"tracer": "prestateTracer", "tracerConfig": {"diffMode": true}}]`), &blkParams))
+	require.Equal(t, "latest", blkParams.BlkNum)
+	require.Equal(t, &EthTraceConfig{Tracer: EthPrestateTracer, TracerConfig: EthTracerConfig{DiffMode: true}}, blkParams.Config)
+
+	var callParams EthDebugTraceCallParams
+	require.NoError(t, json.Unmarshal([]byte(`[{"to": "0xd4c5fb16488Aa48081296299d54b0c648C9333dA"}, "latest", {"tracer": "callTracer"}]`), &callParams))
+	require.Equal(t, EthCallTracer, callParams.Config.Tracer)
+	require.Equal(t, "latest", *callParams.BlkParam.PredefinedBlock)
+
+	require.Error(t, json.Unmarshal([]byte(`[]`), &txParams))
+	require.Error(t, json.Unmarshal([]byte(`[{}]`), &callParams))
+}
+
+func TestEthSimulateParamsUnmarshalJSON(t *testing.T) {
+	addr := "0xd4c5fb16488aa48081296299d54b0c648c9333da"
+
+	var traceParams EthTraceCallParams
+	require.NoError(t, json.Unmarshal([]byte(`[{"to": "`+addr+`"}, ["trace"]]`), &traceParams))
+	require.Equal(t, []string{"trace"}, traceParams.TraceTypes)
+	require.Nil(t, traceParams.BlkParam)
+
+	traceParams = EthTraceCallParams{}
+	require.NoError(t, json.Unmarshal([]byte(`[{"to": "`+addr+`"}, ["trace"], "latest", {"`+addr+`": {"balance": "0x10", "stateDiff": {"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000002"}}}]`), &traceParams))
+	require.Equal(t, "latest", *traceParams.BlkParam.PredefinedBlock)
+	ethAddr, err := ParseEthAddress(addr)
+	require.NoError(t, err)
+	override := traceParams.StateOverrides[ethAddr]
+	require.Equal(t, EthBigInt(big.NewInt(16)), *override.Balance)
+	require.Equal(t, map[EthHash]EthHash{{31: 1}: {31: 2}}, override.StateDiff)
+	require.Nil(t, override.State)
+
+	b, err := json.Marshal(traceParams)
+	require.NoError(t, err)
+	var out EthTraceCallParams
+	require.NoError(t, json.Unmarshal(b, &out))
+	require.Equal(t, traceParams.StateOverrides, out.StateOverrides)
+	require.Error(t, json.Unmarshal([]byte(`[{"to": "`+addr+`"}]`), &traceParams))
+
+	var simParams EthSimulateParams
+	require.NoError(t, json.Unmarshal([]byte(`[{"blockStateCalls": [{"calls": [{"to": "`+addr+`"}]}, {"stateOverrides": {"`+addr+`": {"nonce": "0x1"}}, "blockOverrides": null, "calls": []}]}]`), &simParams))
+	require.Nil(t, simParams.BlkParam)
+	require.Len(t, simParams.Payload.BlockStateCalls, 2)
+	require.Len(t, simParams.Payload.BlockStateCalls[0].Calls, 1)
+	require.Equal(t, EthUint64(1), *simParams.Payload.BlockStateCalls[1].StateOverrides[ethAddr].Nonce)
+
+	require.ErrorContains(t, json.Unmarshal([]byte(`[{"blockStateCalls": [{"blockOverrides": {"number": "0x1"}, "calls": []}]}, "latest"]`), &simParams), "block overrides are not supported")
+	require.Error(t, json.Unmarshal([]byte(`[]`), &simParams))
+}
@@ -120,6 +120,9 @@ type IChainInfo interface {
 	StateGetRandomnessFromTickets(ctx context.Context, personalization crypto.DomainSeparationTag, randEpoch abi.ChainEpoch, entropy []byte, tsk types.TipSetKey) (abi.Randomness, error) //perm:read
 	// StateGetRandomnessFromBeacon is used to sample the beacon for randomness.
 	StateGetRandomnessFromBeacon(ctx context.Context, personalization crypto.DomainSeparationTag, randEpoch abi.ChainEpoch, entropy []byte, tsk types.TipSetKey) (abi.Randomness, error) //perm:read
+	// ChainGetFinalizedTipSet returns the latest tipset finalized by F3 when F3 is running, or the tipset
+	// finalized by EC, `Finality` epochs behind the head, otherwise.
+	ChainGetFinalizedTipSet(ctx context.Context) (*types.TipSet, error) //perm:read
 }
 
 type IMinerState interface {
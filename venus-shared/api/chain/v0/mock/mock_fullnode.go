@@ -143,6 +143,21 @@ func (mr *MockFullNodeMockRecorder) ChainGetBlockMessages(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetBlockMessages", reflect.TypeOf((*MockFullNode)(nil).ChainGetBlockMessages), arg0, arg1)
 }
 
+// ChainGetFinalizedTipSet mocks base method.
+func (m *MockFullNode) ChainGetFinalizedTipSet(arg0 context.Context) (*types0.TipSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainGetFinalizedTipSet", arg0)
+	ret0, _ := ret[0].(*types0.TipSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainGetFinalizedTipSet indicates an expected call of ChainGetFinalizedTipSet.
+func (mr *MockFullNodeMockRecorder) ChainGetFinalizedTipSet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetFinalizedTipSet", reflect.TypeOf((*MockFullNode)(nil).ChainGetFinalizedTipSet), arg0)
+}
+
 // ChainGetGenesis mocks base method.
 func (m *MockFullNode) ChainGetGenesis(arg0 context.Context) (*types0.TipSet, error) {
 	m.ctrl.T.Helper()
@@ -499,6 +514,21 @@ func (mr *MockFullNodeMockRecorder) GasEstimateFeeCap(arg0, arg1, arg2, arg3 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GasEstimateFeeCap", reflect.TypeOf((*MockFullNode)(nil).GasEstimateFeeCap), arg0, arg1, arg2, arg3)
 }
 
+// GasEstimateFeeHistory mocks base method.
+func (m *MockFullNode) GasEstimateFeeHistory(arg0 context.Context, arg1 uint64, arg2 []float64, arg3 types0.TipSetKey) (*types0.FeeHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GasEstimateFeeHistory", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types0.FeeHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GasEstimateFeeHistory indicates an expected call of GasEstimateFeeHistory.
+func (mr *MockFullNodeMockRecorder) GasEstimateFeeHistory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GasEstimateFeeHistory", reflect.TypeOf((*MockFullNode)(nil).GasEstimateFeeHistory), arg0, arg1, arg2, arg3)
+}
+
 // GasEstimateGasLimit mocks base method.
 func (m *MockFullNode) GasEstimateGasLimit(arg0 context.Context, arg1 *types.Message, arg2 types0.TipSetKey) (int64, error) {
 	m.ctrl.T.Helper()
@@ -765,6 +795,21 @@ func (mr *MockFullNodeMockRecorder) MpoolDeleteByAdress(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolDeleteByAdress", reflect.TypeOf((*MockFullNode)(nil).MpoolDeleteByAdress), arg0, arg1)
 }
 
+// MpoolFeeBumpStatus mocks base method.
+func (m *MockFullNode) MpoolFeeBumpStatus(arg0 context.Context) (*types0.MpoolFeeBumpStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolFeeBumpStatus", arg0)
+	ret0, _ := ret[0].(*types0.MpoolFeeBumpStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MpoolFeeBumpStatus indicates an expected call of MpoolFeeBumpStatus.
+func (mr *MockFullNodeMockRecorder) MpoolFeeBumpStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolFeeBumpStatus", reflect.TypeOf((*MockFullNode)(nil).MpoolFeeBumpStatus), arg0)
+}
+
 // MpoolGetConfig mocks base method.
 func (m *MockFullNode) MpoolGetConfig(arg0 context.Context) (*types0.MpoolConfig, error) {
 	m.ctrl.T.Helper()
@@ -1032,6 +1077,49 @@ func (mr *MockFullNodeMockRecorder) NetBandwidthStatsByProtocol(arg0 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetBandwidthStatsByProtocol", reflect.TypeOf((*MockFullNode)(nil).NetBandwidthStatsByProtocol), arg0)
 }
 
+// NetBlockAdd mocks base method.
+func (m *MockFullNode) NetBlockAdd(arg0 context.Context, arg1 types0.NetBlockList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetBlockAdd", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NetBlockAdd indicates an expected call of NetBlockAdd.
+func (mr *MockFullNodeMockRecorder) NetBlockAdd(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetBlockAdd", reflect.TypeOf((*MockFullNode)(nil).NetBlockAdd), arg0, arg1)
+}
+
+// NetBlockList mocks base method.
+func (m *MockFullNode) NetBlockList(arg0 context.Context) (types0.NetBlockList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetBlockList", arg0)
+	ret0, _ := ret[0].(types0.NetBlockList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NetBlockList indicates an expected call of NetBlockList.
+func (mr *MockFullNodeMockRecorder) NetBlockList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetBlockList", reflect.TypeOf((*MockFullNode)(nil).NetBlockList), arg0)
+}
+
+// NetBlockRemove mocks base method.
+func (m *MockFullNode) NetBlockRemove(arg0 context.Context, arg1 types0.NetBlockList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetBlockRemove", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NetBlockRemove indicates an expected call of NetBlockRemove.
+func (mr *MockFullNodeMockRecorder) NetBlockRemove(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetBlockRemove", reflect.TypeOf((*MockFullNode)(nil).NetBlockRemove), arg0, arg1)
+}
+
 // NetConnect mocks base method.
 func (m *MockFullNode) NetConnect(arg0 context.Context, arg1 peer.AddrInfo) error {
 	m.ctrl.T.Helper()
@@ -1119,6 +1207,21 @@ func (mr *MockFullNodeMockRecorder) NetGetClosestPeers(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetGetClosestPeers", reflect.TypeOf((*MockFullNode)(nil).NetGetClosestPeers), arg0, arg1)
 }
 
+// NetLimit mocks base method.
+func (m *MockFullNode) NetLimit(arg0 context.Context, arg1 string) (types0.NetLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetLimit", arg0, arg1)
+	ret0, _ := ret[0].(types0.NetLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NetLimit indicates an expected call of NetLimit.
+func (mr *MockFullNodeMockRecorder) NetLimit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetLimit", reflect.TypeOf((*MockFullNode)(nil).NetLimit), arg0, arg1)
+}
+
 // NetPeerInfo mocks base method.
 func (m *MockFullNode) NetPeerInfo(arg0 context.Context, arg1 peer.ID) (*types0.ExtendedPeerInfo, error) {
 	m.ctrl.T.Helper()
@@ -1222,6 +1325,35 @@ func (mr *MockFullNodeMockRecorder) NetPubsubScores(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetPubsubScores", reflect.TypeOf((*MockFullNode)(nil).NetPubsubScores), arg0)
 }
 
+// NetSetLimit mocks base method.
+func (m *MockFullNode) NetSetLimit(arg0 context.Context, arg1 string, arg2 types0.NetLimit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetSetLimit", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NetSetLimit indicates an expected call of NetSetLimit.
+func (mr *MockFullNodeMockRecorder) NetSetLimit(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetSetLimit", reflect.TypeOf((*MockFullNode)(nil).NetSetLimit), arg0, arg1, arg2)
+}
+
+// NetStat mocks base method.
+func (m *MockFullNode) NetStat(arg0 context.Context, arg1 string) (types0.NetStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetStat", arg0, arg1)
+	ret0, _ := ret[0].(types0.NetStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NetStat indicates an expected call of NetStat.
+func (mr *MockFullNodeMockRecorder) NetStat(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetStat", reflect.TypeOf((*MockFullNode)(nil).NetStat), arg0, arg1)
+}
+
 // PaychAllocateLane mocks base method.
 func (m *MockFullNode) PaychAllocateLane(arg0 context.Context, arg1 address.Address) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -33,4 +33,10 @@ type IMessagePool interface {
 	GasEstimateFeeCap(ctx context.Context, msg *types.Message, maxqueueblks int64, tsk types.TipSetKey) (big.Int, error)                                               //perm:read
 	GasEstimateGasPremium(ctx context.Context, nblocksincl uint64, sender address.Address, gaslimit int64, tsk types.TipSetKey) (big.Int, error)                       //perm:read
 	GasEstimateGasLimit(ctx context.Context, msgIn *types.Message, tsk types.TipSetKey) (int64, error)                                                                 //perm:read
+	// GasEstimateFeeHistory returns the parent base fee and the percentiles, weighted by gas limit, of the gas
+	// premiums of the messages included in the last epochs tipsets up to tsk.
+	GasEstimateFeeHistory(ctx context.Context, epochs uint64, percentiles []float64, tsk types.TipSetKey) (*types.FeeHistory, error) //perm:read
+	// MpoolFeeBumpStatus returns the local pending messages tracked by the replacement of stuck messages,
+	// with the number of times their fee was bumped.
+	MpoolFeeBumpStatus(ctx context.Context) (*types.MpoolFeeBumpStatus, error) //perm:read
 }
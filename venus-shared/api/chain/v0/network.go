@@ -44,4 +44,20 @@ type INetwork interface {
 	NetProtectAdd(ctx context.Context, acl []peer.ID) error    //perm:admin
 	NetProtectRemove(ctx context.Context, acl []peer.ID) error //perm:admin
 	NetProtectList(ctx context.Context) ([]peer.ID, error)     //perm:read
+
+	// NetBlockAdd blocks the peers, IP addresses and CIDR subnets of acl, the existing connections to them
+	// are closed. The block list is persisted and applied again when the node restarts.
+	NetBlockAdd(ctx context.Context, acl types.NetBlockList) error //perm:admin
+	// NetBlockRemove removes the peers, IP addresses and CIDR subnets of acl from the block list.
+	NetBlockRemove(ctx context.Context, acl types.NetBlockList) error //perm:admin
+	// NetBlockList returns the peers, IP addresses and CIDR subnets that are blocked.
+	NetBlockList(ctx context.Context) (types.NetBlockList, error) //perm:read
+
+	// NetStat returns the usage of a scope of the libp2p resource manager, the scope is one of all, system,
+	// transient, svc:<service>, proto:<protocol> or peer:<peer id>.
+	NetStat(ctx context.Context, scope string) (types.NetStat, error) //perm:read
+	// NetLimit returns the limit of a scope of the libp2p resource manager.
+	NetLimit(ctx context.Context, scope string) (types.NetLimit, error) //perm:read
+	// NetSetLimit replaces the limit of a scope of the libp2p resource manager until the node restarts.
+	NetSetLimit(ctx context.Context, scope string, limit types.NetLimit) error //perm:admin
 }
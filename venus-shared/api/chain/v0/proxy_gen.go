@@ -259,6 +259,7 @@ type IChainInfoStruct struct {
 		ChainExport                   func(context.Context, abi.ChainEpoch, bool, types.TipSetKey) (<-chan []byte, error)                                                                          `perm:"read"`
 		ChainGetBlock                 func(ctx context.Context, id cid.Cid) (*types.BlockHeader, error)                                                                                            `perm:"read"`
 		ChainGetBlockMessages         func(ctx context.Context, bid cid.Cid) (*types.BlockMessages, error)                                                                                         `perm:"read"`
+		ChainGetFinalizedTipSet       func(ctx context.Context) (*types.TipSet, error)                                                                                                             `perm:"read"`
 		ChainGetGenesis               func(context.Context) (*types.TipSet, error)                                                                                                                 `perm:"read"`
 		ChainGetMessage               func(ctx context.Context, msgID cid.Cid) (*types.Message, error)                                                                                             `perm:"read"`
 		ChainGetMessagesInTipset      func(ctx context.Context, key types.TipSetKey) ([]types.MessageCID, error)                                                                                   `perm:"read"`
@@ -313,6 +314,9 @@ func (s *IChainInfoStruct) ChainGetBlock(p0 context.Context, p1 cid.Cid) (*types
 func (s *IChainInfoStruct) ChainGetBlockMessages(p0 context.Context, p1 cid.Cid) (*types.BlockMessages, error) {
 	return s.Internal.ChainGetBlockMessages(p0, p1)
 }
+func (s *IChainInfoStruct) ChainGetFinalizedTipSet(p0 context.Context) (*types.TipSet, error) {
+	return s.Internal.ChainGetFinalizedTipSet(p0)
+}
 func (s *IChainInfoStruct) ChainGetGenesis(p0 context.Context) (*types.TipSet, error) {
 	return s.Internal.ChainGetGenesis(p0)
 }
@@ -467,6 +471,7 @@ type IMessagePoolStruct struct {
 	Internal struct {
 		GasBatchEstimateMessageGas func(ctx context.Context, estimateMessages []*types.EstimateMessage, fromNonce uint64, tsk types.TipSetKey) ([]*types.EstimateResult, error) `perm:"read"`
 		GasEstimateFeeCap          func(ctx context.Context, msg *types.Message, maxqueueblks int64, tsk types.TipSetKey) (big.Int, error)                                      `perm:"read"`
+		GasEstimateFeeHistory      func(ctx context.Context, epochs uint64, percentiles []float64, tsk types.TipSetKey) (*types.FeeHistory, error)                              `perm:"read"`
 		GasEstimateGasLimit        func(ctx context.Context, msgIn *types.Message, tsk types.TipSetKey) (int64, error)                                                          `perm:"read"`
 		GasEstimateGasPremium      func(ctx context.Context, nblocksincl uint64, sender address.Address, gaslimit int64, tsk types.TipSetKey) (big.Int, error)                  `perm:"read"`
 		GasEstimateMessageGas      func(ctx context.Context, msg *types.Message, spec *types.MessageSendSpec, tsk types.TipSetKey) (*types.Message, error)                      `perm:"read"`
@@ -475,6 +480,7 @@ type IMessagePoolStruct struct {
 		MpoolBatchPushUntrusted    func(ctx context.Context, smsgs []*types.SignedMessage) ([]cid.Cid, error)                                                                   `perm:"write"`
 		MpoolClear                 func(ctx context.Context, local bool) error                                                                                                  `perm:"write"`
 		MpoolDeleteByAdress        func(ctx context.Context, addr address.Address) error                                                                                        `perm:"admin"`
+		MpoolFeeBumpStatus         func(ctx context.Context) (*types.MpoolFeeBumpStatus, error)                                                                                 `perm:"read"`
 		MpoolGetConfig             func(context.Context) (*types.MpoolConfig, error)                                                                                            `perm:"read"`
 		MpoolGetNonce              func(ctx context.Context, addr address.Address) (uint64, error)                                                                              `perm:"read"`
 		MpoolPending               func(ctx context.Context, tsk types.TipSetKey) ([]*types.SignedMessage, error)                                                               `perm:"read"`
@@ -496,6 +502,9 @@ func (s *IMessagePoolStruct) GasBatchEstimateMessageGas(p0 context.Context, p1 [
 func (s *IMessagePoolStruct) GasEstimateFeeCap(p0 context.Context, p1 *types.Message, p2 int64, p3 types.TipSetKey) (big.Int, error) {
 	return s.Internal.GasEstimateFeeCap(p0, p1, p2, p3)
 }
+func (s *IMessagePoolStruct) GasEstimateFeeHistory(p0 context.Context, p1 uint64, p2 []float64, p3 types.TipSetKey) (*types.FeeHistory, error) {
+	return s.Internal.GasEstimateFeeHistory(p0, p1, p2, p3)
+}
 func (s *IMessagePoolStruct) GasEstimateGasLimit(p0 context.Context, p1 *types.Message, p2 types.TipSetKey) (int64, error) {
 	return s.Internal.GasEstimateGasLimit(p0, p1, p2)
 }
@@ -520,6 +529,9 @@ func (s *IMessagePoolStruct) MpoolClear(p0 context.Context, p1 bool) error {
 func (s *IMessagePoolStruct) MpoolDeleteByAdress(p0 context.Context, p1 address.Address) error {
 	return s.Internal.MpoolDeleteByAdress(p0, p1)
 }
+func (s *IMessagePoolStruct) MpoolFeeBumpStatus(p0 context.Context) (*types.MpoolFeeBumpStatus, error) {
+	return s.Internal.MpoolFeeBumpStatus(p0)
+}
 func (s *IMessagePoolStruct) MpoolGetConfig(p0 context.Context) (*types.MpoolConfig, error) {
 	return s.Internal.MpoolGetConfig(p0)
 }
@@ -566,12 +578,16 @@ type INetworkStruct struct {
 		NetBandwidthStats           func(ctx context.Context) (metrics.Stats, error)                       `perm:"read"`
 		NetBandwidthStatsByPeer     func(ctx context.Context) (map[string]metrics.Stats, error)            `perm:"read"`
 		NetBandwidthStatsByProtocol func(ctx context.Context) (map[protocol.ID]metrics.Stats, error)       `perm:"read"`
+		NetBlockAdd                 func(ctx context.Context, acl types.NetBlockList) error                `perm:"admin"`
+		NetBlockList                func(ctx context.Context) (types.NetBlockList, error)                  `perm:"read"`
+		NetBlockRemove              func(ctx context.Context, acl types.NetBlockList) error                `perm:"admin"`
 		NetConnect                  func(ctx context.Context, pi peer.AddrInfo) error                      `perm:"admin"`
 		NetConnectedness            func(context.Context, peer.ID) (network2.Connectedness, error)         `perm:"read"`
 		NetDisconnect               func(ctx context.Context, p peer.ID) error                             `perm:"admin"`
 		NetFindPeer                 func(ctx context.Context, p peer.ID) (peer.AddrInfo, error)            `perm:"read"`
 		NetFindProvidersAsync       func(ctx context.Context, key cid.Cid, count int) <-chan peer.AddrInfo `perm:"read"`
 		NetGetClosestPeers          func(ctx context.Context, key string) ([]peer.ID, error)               `perm:"read"`
+		NetLimit                    func(ctx context.Context, scope string) (types.NetLimit, error)        `perm:"read"`
 		NetPeerInfo                 func(ctx context.Context, p peer.ID) (*types.ExtendedPeerInfo, error)  `perm:"read"`
 		NetPeers                    func(ctx context.Context) ([]peer.AddrInfo, error)                     `perm:"read"`
 		NetPing                     func(ctx context.Context, p peer.ID) (time.Duration, error)            `perm:"read"`
@@ -579,6 +595,8 @@ type INetworkStruct struct {
 		NetProtectList              func(ctx context.Context) ([]peer.ID, error)                           `perm:"read"`
 		NetProtectRemove            func(ctx context.Context, acl []peer.ID) error                         `perm:"admin"`
 		NetPubsubScores             func(context.Context) ([]types.PubsubScore, error)                     `perm:"read"`
+		NetSetLimit                 func(ctx context.Context, scope string, limit types.NetLimit) error    `perm:"admin"`
+		NetStat                     func(ctx context.Context, scope string) (types.NetStat, error)         `perm:"read"`
 	}
 }
 
@@ -601,6 +619,15 @@ func (s *INetworkStruct) NetBandwidthStatsByPeer(p0 context.Context) (map[string
 func (s *INetworkStruct) NetBandwidthStatsByProtocol(p0 context.Context) (map[protocol.ID]metrics.Stats, error) {
 	return s.Internal.NetBandwidthStatsByProtocol(p0)
 }
+func (s *INetworkStruct) NetBlockAdd(p0 context.Context, p1 types.NetBlockList) error {
+	return s.Internal.NetBlockAdd(p0, p1)
+}
+func (s *INetworkStruct) NetBlockList(p0 context.Context) (types.NetBlockList, error) {
+	return s.Internal.NetBlockList(p0)
+}
+func (s *INetworkStruct) NetBlockRemove(p0 context.Context, p1 types.NetBlockList) error {
+	return s.Internal.NetBlockRemove(p0, p1)
+}
 func (s *INetworkStruct) NetConnect(p0 context.Context, p1 peer.AddrInfo) error {
 	return s.Internal.NetConnect(p0, p1)
 }
@@ -619,6 +646,9 @@ func (s *INetworkStruct) NetFindProvidersAsync(p0 context.Context, p1 cid.Cid, p
 func (s *INetworkStruct) NetGetClosestPeers(p0 context.Context, p1 string) ([]peer.ID, error) {
 	return s.Internal.NetGetClosestPeers(p0, p1)
 }
+func (s *INetworkStruct) NetLimit(p0 context.Context, p1 string) (types.NetLimit, error) {
+	return s.Internal.NetLimit(p0, p1)
+}
 func (s *INetworkStruct) NetPeerInfo(p0 context.Context, p1 peer.ID) (*types.ExtendedPeerInfo, error) {
 	return s.Internal.NetPeerInfo(p0, p1)
 }
@@ -640,6 +670,12 @@ func (s *INetworkStruct) NetProtectRemove(p0 context.Context, p1 []peer.ID) erro
 func (s *INetworkStruct) NetPubsubScores(p0 context.Context) ([]types.PubsubScore, error) {
 	return s.Internal.NetPubsubScores(p0)
 }
+func (s *INetworkStruct) NetSetLimit(p0 context.Context, p1 string, p2 types.NetLimit) error {
+	return s.Internal.NetSetLimit(p0, p1, p2)
+}
+func (s *INetworkStruct) NetStat(p0 context.Context, p1 string) (types.NetStat, error) {
+	return s.Internal.NetStat(p0, p1)
+}
 
 type IPaychanStruct struct {
 	Internal struct {
@@ -33,4 +33,17 @@ type IActorEvent interface {
 	// Note: this API is only available via websocket connections.
 	// This is an EXPERIMENTAL API and may be subject to change.
 	SubscribeActorEventsRaw(ctx context.Context, filter *types.ActorEventFilter) (<-chan *types.ActorEvent, error) //perm:read
+
+	// SubscribeActorEventsResumable is like SubscribeActorEventsRaw, but the stream can be resumed from the
+	// cursor of the last notification handled by the client, which makes the delivery exactly-once across
+	// reconnections.
+	// When resuming, the events the client received from tipsets reverted since are reverted first, then
+	// the events after the cursor are replayed from the event index before the real-time events.
+	// Events reverted by a network re-org are written to the stream as "reverted" notifications, the
+	// events of a tipset are reverted from the last one to the first one.
+	// A nil cursor starts from the filter's FromHeight, or from the current head if it isn't set.
+	//
+	// Note: this API is only available via websocket connections.
+	// This is an EXPERIMENTAL API and may be subject to change.
+	SubscribeActorEventsResumable(ctx context.Context, filter *types.ActorEventFilter, cursor *types.ActorEventCursor) (<-chan *types.ActorEventNotification, error) //perm:read
 }
@@ -45,6 +45,9 @@ type IChainInfo interface {
 	ChainGetTipSetAfterHeight(ctx context.Context, height abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error)                                                                     //perm:read
 	StateGetRandomnessFromTickets(ctx context.Context, personalization crypto.DomainSeparationTag, randEpoch abi.ChainEpoch, entropy []byte, tsk types.TipSetKey) (abi.Randomness, error) //perm:read
 	StateGetRandomnessFromBeacon(ctx context.Context, personalization crypto.DomainSeparationTag, randEpoch abi.ChainEpoch, entropy []byte, tsk types.TipSetKey) (abi.Randomness, error)  //perm:read
+	// ChainGetFinalizedTipSet returns the latest tipset finalized by F3 when F3 is running, or the tipset
+	// finalized by EC, `Finality` epochs behind the head, otherwise.
+	ChainGetFinalizedTipSet(ctx context.Context) (*types.TipSet, error) //perm:read
 	// StateGetRandomnessDigestFromTickets is used to sample the chain for randomness.
 	StateGetRandomnessDigestFromTickets(ctx context.Context, randEpoch abi.ChainEpoch, tsk types.TipSetKey) (abi.Randomness, error) //perm:read
 	// StateGetRandomnessDigestFromBeacon is used to sample the beacon for randomness.
@@ -111,6 +114,27 @@ type IChainInfo interface {
 	VerifyEntry(parent, child *types.BeaconEntry, height abi.ChainEpoch) bool                                                             //perm:read
 	ChainExport(context.Context, abi.ChainEpoch, bool, types.TipSetKey) (<-chan []byte, error)                                            //perm:read
 	ChainGetPath(ctx context.Context, from types.TipSetKey, to types.TipSetKey) ([]*types.HeadChange, error)                              //perm:read
+	// ChainExportJobStart starts writing a snapshot to a file on the node in the background, only one export
+	// runs at a time. The last snapshot written successfully is served over http at /chain/snapshot.
+	ChainExportJobStart(ctx context.Context, params types.ExportJobParams) error //perm:admin
+	// ChainExportJobStatus returns the progress of the running, or last, background snapshot export.
+	ChainExportJobStatus(ctx context.Context) (*types.ExportJobStatus, error) //perm:read
+	// ChainExportJobCancel cancels the running background snapshot export.
+	ChainExportJobCancel(ctx context.Context) error //perm:admin
+	// ChainPrune starts a splitstore compaction from the current head, the state and messages older
+	// than the retained finality windows are moved to the cold store or discarded. It returns once the
+	// compaction started, or once it finished when opts.Wait is set.
+	ChainPrune(ctx context.Context, opts types.PruneOpts) error //perm:admin
+	// ChainPruneStatus returns the progress of the running, or last, splitstore compaction.
+	ChainPruneStatus(ctx context.Context) (*types.PruneStatus, error) //perm:read
+	// ChainCheckBlockstore walks the chain from the tipset through headers, messages and the recent receipts
+	// and state trees, and reports the blocks missing in the blockstore or not hashing to their cid. Missing
+	// or corrupt headers and messages are refetched from peers when params.Repair is set.
+	ChainCheckBlockstore(ctx context.Context, params types.BlockstoreCheckParams) (*types.BlockstoreCheckResult, error) //perm:admin
+	// ChainValidateIndex checks that the chain index at epoch matches the canonical chain, a tipset missing
+	// in the index or not matching the chain is re-indexed when backfill is true. It fails when the chain
+	// indexer is disabled.
+	ChainValidateIndex(ctx context.Context, epoch abi.ChainEpoch, backfill bool) (*types.IndexValidation, error) //perm:write
 	// StateGetNetworkParams return current network params
 	StateGetNetworkParams(ctx context.Context) (*types.NetworkParams, error) //perm:read
 	// StateActorCodeCIDs returns the CIDs of all the builtin actors for the given network version
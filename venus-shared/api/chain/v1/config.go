@@ -0,0 +1,19 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/filecoin-project/venus/venus-shared/types"
+)
+
+type IConfig interface {
+	// ConfigSet sets the json value of the field of the config at the dotted path, e.g. mpool.maxFee, and
+	// writes it to the config file. The node keeps running with the previous values of the changes it can't
+	// apply live, they are reported as requiring a restart.
+	ConfigSet(ctx context.Context, dottedPath string, paramJSON string) (*types.ConfigSetResult, error) //perm:admin
+	// ConfigGet returns the value of the field of the config file at the dotted path.
+	ConfigGet(ctx context.Context, dottedPath string) (interface{}, error) //perm:admin
+	// ConfigDiff returns the fields of the config file which differ from the ones the node runs with, they
+	// take effect after the node restarts.
+	ConfigDiff(ctx context.Context) ([]types.ConfigChange, error) //perm:admin
+}
@@ -56,12 +56,24 @@ type IETH interface {
 	EthGasPrice(ctx context.Context) (types.EthBigInt, error)                                                                                            //perm:read
 	EthFeeHistory(ctx context.Context, p jsonrpc.RawParams) (types.EthFeeHistory, error)                                                                 //perm:read
 
+	// EthGetProof returns the account and storage slots with the IPLD blocks proving them against the parent
+	// state root of the tipset, see ethproof.Verify.
+	EthGetProof(ctx context.Context, address types.EthAddress, storageKeys []types.EthBytes, blkParam types.EthBlockNumberOrHash) (*types.EthProof, error) //perm:read
+
 	EthMaxPriorityFeePerGas(ctx context.Context) (types.EthBigInt, error)                                       //perm:read
 	EthEstimateGas(ctx context.Context, p jsonrpc.RawParams) (types.EthUint64, error)                           //perm:read
 	EthCall(ctx context.Context, tx types.EthCall, blkParam types.EthBlockNumberOrHash) (types.EthBytes, error) //perm:read
 
 	EthSendRawTransaction(ctx context.Context, rawTx types.EthBytes) (types.EthHash, error) //perm:read
 
+	// Implements geth-compatible API methods txpool_content, txpool_contentFrom, txpool_inspect and txpool_status.
+	// Only the messages signed with delegated signatures are reported, the pending ones can be included in the
+	// next block while the queued ones are behind a nonce gap of their sender.
+	EthTxPoolContent(ctx context.Context) (*types.EthTxPoolContent, error)                                   //perm:read
+	EthTxPoolContentFrom(ctx context.Context, address types.EthAddress) (*types.EthTxPoolContentFrom, error) //perm:read
+	EthTxPoolInspect(ctx context.Context) (*types.EthTxPoolInspect, error)                                   //perm:read
+	EthTxPoolStatus(ctx context.Context) (types.EthTxPoolStatus, error)                                      //perm:read
+
 	// Returns the client version
 	Web3ClientVersion(ctx context.Context) (string, error) //perm:read
 
@@ -91,6 +103,21 @@ type IETH interface {
 	EthTraceTransaction(ctx context.Context, txHash string) ([]*types.EthTraceTransaction, error) //perm:read
 	// Implements OpenEthereum-compatible API method trace_filter
 	EthTraceFilter(ctx context.Context, filter types.EthTraceFilterCriteria) ([]*types.EthTraceFilterResult, error) //perm:read
+	// Implements OpenEthereum-compatible API method trace_call, with optional geth-style state overrides
+	// as its fourth param.
+	EthTraceCall(ctx context.Context, p jsonrpc.RawParams) (*types.EthTraceResults, error) //perm:read
+	// Implements geth-compatible API method eth_simulateV1. The blocks are simulated one after another on the
+	// state computed by the requested block, the first one at the next epoch and each following one at the
+	// epoch after it, and their calls return their logs and traces. A request holds up to 256 blocks and 1000 calls.
+	EthSimulateV1(ctx context.Context, p jsonrpc.RawParams) ([]types.EthSimulatedBlock, error) //perm:read
+
+	// Implements geth-compatible API method debug_traceTransaction, with the callTracer and prestateTracer
+	// tracers. The prestateTracer only reports the storage slots written by the transaction.
+	EthDebugTraceTransaction(ctx context.Context, p jsonrpc.RawParams) (*types.EthDebugTrace, error) //perm:read
+	// Implements geth-compatible API method debug_traceBlockByNumber
+	EthDebugTraceBlockByNumber(ctx context.Context, p jsonrpc.RawParams) ([]*types.EthDebugTraceBlockResult, error) //perm:read
+	// Implements geth-compatible API method debug_traceCall
+	EthDebugTraceCall(ctx context.Context, p jsonrpc.RawParams) (*types.EthDebugTrace, error) //perm:read
 }
 
 type IETHEvent interface {
@@ -6,6 +6,7 @@ type FullNode interface {
 	IMarket
 	IMining
 	IMessagePool
+	IMultiSig
 	INetwork
 	IPaychan
 	ISyncer
@@ -14,4 +15,5 @@ type FullNode interface {
 	FullETH
 	IActorEvent
 	IF3
+	IConfig
 }
@@ -74,6 +74,21 @@ func (mr *MockFullNodeMockRecorder) BlockTime(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockTime", reflect.TypeOf((*MockFullNode)(nil).BlockTime), arg0)
 }
 
+// ChainCheckBlockstore mocks base method.
+func (m *MockFullNode) ChainCheckBlockstore(arg0 context.Context, arg1 types0.BlockstoreCheckParams) (*types0.BlockstoreCheckResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainCheckBlockstore", arg0, arg1)
+	ret0, _ := ret[0].(*types0.BlockstoreCheckResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainCheckBlockstore indicates an expected call of ChainCheckBlockstore.
+func (mr *MockFullNodeMockRecorder) ChainCheckBlockstore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainCheckBlockstore", reflect.TypeOf((*MockFullNode)(nil).ChainCheckBlockstore), arg0, arg1)
+}
+
 // ChainDeleteObj mocks base method.
 func (m *MockFullNode) ChainDeleteObj(arg0 context.Context, arg1 cid.Cid) error {
 	m.ctrl.T.Helper()
@@ -103,6 +118,49 @@ func (mr *MockFullNodeMockRecorder) ChainExport(arg0, arg1, arg2, arg3 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainExport", reflect.TypeOf((*MockFullNode)(nil).ChainExport), arg0, arg1, arg2, arg3)
 }
 
+// ChainExportJobCancel mocks base method.
+func (m *MockFullNode) ChainExportJobCancel(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainExportJobCancel", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChainExportJobCancel indicates an expected call of ChainExportJobCancel.
+func (mr *MockFullNodeMockRecorder) ChainExportJobCancel(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainExportJobCancel", reflect.TypeOf((*MockFullNode)(nil).ChainExportJobCancel), arg0)
+}
+
+// ChainExportJobStart mocks base method.
+func (m *MockFullNode) ChainExportJobStart(arg0 context.Context, arg1 types0.ExportJobParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainExportJobStart", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChainExportJobStart indicates an expected call of ChainExportJobStart.
+func (mr *MockFullNodeMockRecorder) ChainExportJobStart(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainExportJobStart", reflect.TypeOf((*MockFullNode)(nil).ChainExportJobStart), arg0, arg1)
+}
+
+// ChainExportJobStatus mocks base method.
+func (m *MockFullNode) ChainExportJobStatus(arg0 context.Context) (*types0.ExportJobStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainExportJobStatus", arg0)
+	ret0, _ := ret[0].(*types0.ExportJobStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainExportJobStatus indicates an expected call of ChainExportJobStatus.
+func (mr *MockFullNodeMockRecorder) ChainExportJobStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainExportJobStatus", reflect.TypeOf((*MockFullNode)(nil).ChainExportJobStatus), arg0)
+}
+
 // ChainGetBlock mocks base method.
 func (m *MockFullNode) ChainGetBlock(arg0 context.Context, arg1 cid.Cid) (*types0.BlockHeader, error) {
 	m.ctrl.T.Helper()
@@ -148,6 +206,21 @@ func (mr *MockFullNodeMockRecorder) ChainGetEvents(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetEvents", reflect.TypeOf((*MockFullNode)(nil).ChainGetEvents), arg0, arg1)
 }
 
+// ChainGetFinalizedTipSet mocks base method.
+func (m *MockFullNode) ChainGetFinalizedTipSet(arg0 context.Context) (*types0.TipSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainGetFinalizedTipSet", arg0)
+	ret0, _ := ret[0].(*types0.TipSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainGetFinalizedTipSet indicates an expected call of ChainGetFinalizedTipSet.
+func (mr *MockFullNodeMockRecorder) ChainGetFinalizedTipSet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainGetFinalizedTipSet", reflect.TypeOf((*MockFullNode)(nil).ChainGetFinalizedTipSet), arg0)
+}
+
 // ChainGetGenesis mocks base method.
 func (m *MockFullNode) ChainGetGenesis(arg0 context.Context) (*types0.TipSet, error) {
 	m.ctrl.T.Helper()
@@ -358,6 +431,35 @@ func (mr *MockFullNodeMockRecorder) ChainNotify(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainNotify", reflect.TypeOf((*MockFullNode)(nil).ChainNotify), arg0)
 }
 
+// ChainPrune mocks base method.
+func (m *MockFullNode) ChainPrune(arg0 context.Context, arg1 types0.PruneOpts) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainPrune", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChainPrune indicates an expected call of ChainPrune.
+func (mr *MockFullNodeMockRecorder) ChainPrune(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainPrune", reflect.TypeOf((*MockFullNode)(nil).ChainPrune), arg0, arg1)
+}
+
+// ChainPruneStatus mocks base method.
+func (m *MockFullNode) ChainPruneStatus(arg0 context.Context) (*types0.PruneStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainPruneStatus", arg0)
+	ret0, _ := ret[0].(*types0.PruneStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainPruneStatus indicates an expected call of ChainPruneStatus.
+func (mr *MockFullNodeMockRecorder) ChainPruneStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainPruneStatus", reflect.TypeOf((*MockFullNode)(nil).ChainPruneStatus), arg0)
+}
+
 // ChainPutObj mocks base method.
 func (m *MockFullNode) ChainPutObj(arg0 context.Context, arg1 blocks.Block) error {
 	m.ctrl.T.Helper()
@@ -445,6 +547,21 @@ func (mr *MockFullNodeMockRecorder) ChainTipSetWeight(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainTipSetWeight", reflect.TypeOf((*MockFullNode)(nil).ChainTipSetWeight), arg0, arg1)
 }
 
+// ChainValidateIndex mocks base method.
+func (m *MockFullNode) ChainValidateIndex(arg0 context.Context, arg1 abi.ChainEpoch, arg2 bool) (*types0.IndexValidation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainValidateIndex", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.IndexValidation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainValidateIndex indicates an expected call of ChainValidateIndex.
+func (mr *MockFullNodeMockRecorder) ChainValidateIndex(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainValidateIndex", reflect.TypeOf((*MockFullNode)(nil).ChainValidateIndex), arg0, arg1, arg2)
+}
+
 // Concurrent mocks base method.
 func (m *MockFullNode) Concurrent(arg0 context.Context) int64 {
 	m.ctrl.T.Helper()
@@ -459,6 +576,51 @@ func (mr *MockFullNodeMockRecorder) Concurrent(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Concurrent", reflect.TypeOf((*MockFullNode)(nil).Concurrent), arg0)
 }
 
+// ConfigDiff mocks base method.
+func (m *MockFullNode) ConfigDiff(arg0 context.Context) ([]types0.ConfigChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigDiff", arg0)
+	ret0, _ := ret[0].([]types0.ConfigChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfigDiff indicates an expected call of ConfigDiff.
+func (mr *MockFullNodeMockRecorder) ConfigDiff(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigDiff", reflect.TypeOf((*MockFullNode)(nil).ConfigDiff), arg0)
+}
+
+// ConfigGet mocks base method.
+func (m *MockFullNode) ConfigGet(arg0 context.Context, arg1 string) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigGet", arg0, arg1)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfigGet indicates an expected call of ConfigGet.
+func (mr *MockFullNodeMockRecorder) ConfigGet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigGet", reflect.TypeOf((*MockFullNode)(nil).ConfigGet), arg0, arg1)
+}
+
+// ConfigSet mocks base method.
+func (m *MockFullNode) ConfigSet(arg0 context.Context, arg1, arg2 string) (*types0.ConfigSetResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigSet", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.ConfigSetResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfigSet indicates an expected call of ConfigSet.
+func (mr *MockFullNodeMockRecorder) ConfigSet(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigSet", reflect.TypeOf((*MockFullNode)(nil).ConfigSet), arg0, arg1, arg2)
+}
+
 // EthAccounts mocks base method.
 func (m *MockFullNode) EthAccounts(arg0 context.Context) ([]types.EthAddress, error) {
 	m.ctrl.T.Helper()
@@ -534,6 +696,51 @@ func (mr *MockFullNodeMockRecorder) EthChainId(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthChainId", reflect.TypeOf((*MockFullNode)(nil).EthChainId), arg0)
 }
 
+// EthDebugTraceBlockByNumber mocks base method.
+func (m *MockFullNode) EthDebugTraceBlockByNumber(arg0 context.Context, arg1 jsonrpc.RawParams) ([]*types.EthDebugTraceBlockResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthDebugTraceBlockByNumber", arg0, arg1)
+	ret0, _ := ret[0].([]*types.EthDebugTraceBlockResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthDebugTraceBlockByNumber indicates an expected call of EthDebugTraceBlockByNumber.
+func (mr *MockFullNodeMockRecorder) EthDebugTraceBlockByNumber(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthDebugTraceBlockByNumber", reflect.TypeOf((*MockFullNode)(nil).EthDebugTraceBlockByNumber), arg0, arg1)
+}
+
+// EthDebugTraceCall mocks base method.
+func (m *MockFullNode) EthDebugTraceCall(arg0 context.Context, arg1 jsonrpc.RawParams) (*types.EthDebugTrace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthDebugTraceCall", arg0, arg1)
+	ret0, _ := ret[0].(*types.EthDebugTrace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthDebugTraceCall indicates an expected call of EthDebugTraceCall.
+func (mr *MockFullNodeMockRecorder) EthDebugTraceCall(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthDebugTraceCall", reflect.TypeOf((*MockFullNode)(nil).EthDebugTraceCall), arg0, arg1)
+}
+
+// EthDebugTraceTransaction mocks base method.
+func (m *MockFullNode) EthDebugTraceTransaction(arg0 context.Context, arg1 jsonrpc.RawParams) (*types.EthDebugTrace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthDebugTraceTransaction", arg0, arg1)
+	ret0, _ := ret[0].(*types.EthDebugTrace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthDebugTraceTransaction indicates an expected call of EthDebugTraceTransaction.
+func (mr *MockFullNodeMockRecorder) EthDebugTraceTransaction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthDebugTraceTransaction", reflect.TypeOf((*MockFullNode)(nil).EthDebugTraceTransaction), arg0, arg1)
+}
+
 // EthEstimateGas mocks base method.
 func (m *MockFullNode) EthEstimateGas(arg0 context.Context, arg1 jsonrpc.RawParams) (types.EthUint64, error) {
 	m.ctrl.T.Helper()
@@ -759,6 +966,21 @@ func (mr *MockFullNodeMockRecorder) EthGetMessageCidByTransactionHash(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetMessageCidByTransactionHash", reflect.TypeOf((*MockFullNode)(nil).EthGetMessageCidByTransactionHash), arg0, arg1)
 }
 
+// EthGetProof mocks base method.
+func (m *MockFullNode) EthGetProof(arg0 context.Context, arg1 types.EthAddress, arg2 []types.EthBytes, arg3 types.EthBlockNumberOrHash) (*types.EthProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthGetProof", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types.EthProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthGetProof indicates an expected call of EthGetProof.
+func (mr *MockFullNodeMockRecorder) EthGetProof(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetProof", reflect.TypeOf((*MockFullNode)(nil).EthGetProof), arg0, arg1, arg2, arg3)
+}
+
 // EthGetStorageAt mocks base method.
 func (m *MockFullNode) EthGetStorageAt(arg0 context.Context, arg1 types.EthAddress, arg2 types.EthBytes, arg3 types.EthBlockNumberOrHash) (types.EthBytes, error) {
 	m.ctrl.T.Helper()
@@ -984,6 +1206,21 @@ func (mr *MockFullNodeMockRecorder) EthSendRawTransaction(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthSendRawTransaction", reflect.TypeOf((*MockFullNode)(nil).EthSendRawTransaction), arg0, arg1)
 }
 
+// EthSimulateV1 mocks base method.
+func (m *MockFullNode) EthSimulateV1(arg0 context.Context, arg1 jsonrpc.RawParams) ([]types.EthSimulatedBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthSimulateV1", arg0, arg1)
+	ret0, _ := ret[0].([]types.EthSimulatedBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthSimulateV1 indicates an expected call of EthSimulateV1.
+func (mr *MockFullNodeMockRecorder) EthSimulateV1(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthSimulateV1", reflect.TypeOf((*MockFullNode)(nil).EthSimulateV1), arg0, arg1)
+}
+
 // EthSubscribe mocks base method.
 func (m *MockFullNode) EthSubscribe(arg0 context.Context, arg1 jsonrpc.RawParams) (types.EthSubscriptionID, error) {
 	m.ctrl.T.Helper()
@@ -1029,6 +1266,21 @@ func (mr *MockFullNodeMockRecorder) EthTraceBlock(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthTraceBlock", reflect.TypeOf((*MockFullNode)(nil).EthTraceBlock), arg0, arg1)
 }
 
+// EthTraceCall mocks base method.
+func (m *MockFullNode) EthTraceCall(arg0 context.Context, arg1 jsonrpc.RawParams) (*types.EthTraceResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthTraceCall", arg0, arg1)
+	ret0, _ := ret[0].(*types.EthTraceResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthTraceCall indicates an expected call of EthTraceCall.
+func (mr *MockFullNodeMockRecorder) EthTraceCall(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthTraceCall", reflect.TypeOf((*MockFullNode)(nil).EthTraceCall), arg0, arg1)
+}
+
 // EthTraceFilter mocks base method.
 func (m *MockFullNode) EthTraceFilter(arg0 context.Context, arg1 types.EthTraceFilterCriteria) ([]*types.EthTraceFilterResult, error) {
 	m.ctrl.T.Helper()
@@ -1074,6 +1326,66 @@ func (mr *MockFullNodeMockRecorder) EthTraceTransaction(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthTraceTransaction", reflect.TypeOf((*MockFullNode)(nil).EthTraceTransaction), arg0, arg1)
 }
 
+// EthTxPoolContent mocks base method.
+func (m *MockFullNode) EthTxPoolContent(arg0 context.Context) (*types.EthTxPoolContent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthTxPoolContent", arg0)
+	ret0, _ := ret[0].(*types.EthTxPoolContent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthTxPoolContent indicates an expected call of EthTxPoolContent.
+func (mr *MockFullNodeMockRecorder) EthTxPoolContent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthTxPoolContent", reflect.TypeOf((*MockFullNode)(nil).EthTxPoolContent), arg0)
+}
+
+// EthTxPoolContentFrom mocks base method.
+func (m *MockFullNode) EthTxPoolContentFrom(arg0 context.Context, arg1 types.EthAddress) (*types.EthTxPoolContentFrom, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthTxPoolContentFrom", arg0, arg1)
+	ret0, _ := ret[0].(*types.EthTxPoolContentFrom)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthTxPoolContentFrom indicates an expected call of EthTxPoolContentFrom.
+func (mr *MockFullNodeMockRecorder) EthTxPoolContentFrom(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthTxPoolContentFrom", reflect.TypeOf((*MockFullNode)(nil).EthTxPoolContentFrom), arg0, arg1)
+}
+
+// EthTxPoolInspect mocks base method.
+func (m *MockFullNode) EthTxPoolInspect(arg0 context.Context) (*types.EthTxPoolInspect, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthTxPoolInspect", arg0)
+	ret0, _ := ret[0].(*types.EthTxPoolInspect)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthTxPoolInspect indicates an expected call of EthTxPoolInspect.
+func (mr *MockFullNodeMockRecorder) EthTxPoolInspect(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthTxPoolInspect", reflect.TypeOf((*MockFullNode)(nil).EthTxPoolInspect), arg0)
+}
+
+// EthTxPoolStatus mocks base method.
+func (m *MockFullNode) EthTxPoolStatus(arg0 context.Context) (types.EthTxPoolStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthTxPoolStatus", arg0)
+	ret0, _ := ret[0].(types.EthTxPoolStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthTxPoolStatus indicates an expected call of EthTxPoolStatus.
+func (mr *MockFullNodeMockRecorder) EthTxPoolStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthTxPoolStatus", reflect.TypeOf((*MockFullNode)(nil).EthTxPoolStatus), arg0)
+}
+
 // EthUninstallFilter mocks base method.
 func (m *MockFullNode) EthUninstallFilter(arg0 context.Context, arg1 types.EthFilterID) (bool, error) {
 	m.ctrl.T.Helper()
@@ -1299,6 +1611,21 @@ func (mr *MockFullNodeMockRecorder) GasEstimateFeeCap(arg0, arg1, arg2, arg3 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GasEstimateFeeCap", reflect.TypeOf((*MockFullNode)(nil).GasEstimateFeeCap), arg0, arg1, arg2, arg3)
 }
 
+// GasEstimateFeeHistory mocks base method.
+func (m *MockFullNode) GasEstimateFeeHistory(arg0 context.Context, arg1 uint64, arg2 []float64, arg3 types0.TipSetKey) (*types0.FeeHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GasEstimateFeeHistory", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types0.FeeHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GasEstimateFeeHistory indicates an expected call of GasEstimateFeeHistory.
+func (mr *MockFullNodeMockRecorder) GasEstimateFeeHistory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GasEstimateFeeHistory", reflect.TypeOf((*MockFullNode)(nil).GasEstimateFeeHistory), arg0, arg1, arg2, arg3)
+}
+
 // GasEstimateGasLimit mocks base method.
 func (m *MockFullNode) GasEstimateGasLimit(arg0 context.Context, arg1 *types.Message, arg2 types0.TipSetKey) (int64, error) {
 	m.ctrl.T.Helper()
@@ -1625,6 +1952,21 @@ func (mr *MockFullNodeMockRecorder) MpoolDeleteByAdress(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolDeleteByAdress", reflect.TypeOf((*MockFullNode)(nil).MpoolDeleteByAdress), arg0, arg1)
 }
 
+// MpoolFeeBumpStatus mocks base method.
+func (m *MockFullNode) MpoolFeeBumpStatus(arg0 context.Context) (*types0.MpoolFeeBumpStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MpoolFeeBumpStatus", arg0)
+	ret0, _ := ret[0].(*types0.MpoolFeeBumpStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MpoolFeeBumpStatus indicates an expected call of MpoolFeeBumpStatus.
+func (mr *MockFullNodeMockRecorder) MpoolFeeBumpStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolFeeBumpStatus", reflect.TypeOf((*MockFullNode)(nil).MpoolFeeBumpStatus), arg0)
+}
+
 // MpoolGetConfig mocks base method.
 func (m *MockFullNode) MpoolGetConfig(arg0 context.Context) (*types0.MpoolConfig, error) {
 	m.ctrl.T.Helper()
@@ -1802,6 +2144,261 @@ func (mr *MockFullNodeMockRecorder) MpoolSub(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MpoolSub", reflect.TypeOf((*MockFullNode)(nil).MpoolSub), arg0)
 }
 
+// MsigAddApprove mocks base method.
+func (m *MockFullNode) MsigAddApprove(arg0 context.Context, arg1, arg2 address.Address, arg3 uint64, arg4, arg5 address.Address, arg6 bool) (*types0.MessagePrototype, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MsigAddApprove", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(*types0.MessagePrototype)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MsigAddApprove indicates an expected call of MsigAddApprove.
+func (mr *MockFullNodeMockRecorder) MsigAddApprove(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MsigAddApprove", reflect.TypeOf((*MockFullNode)(nil).MsigAddApprove), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
+// MsigAddCancel mocks base method.
+func (m *MockFullNode) MsigAddCancel(arg0 context.Context, arg1, arg2 address.Address, arg3 uint64, arg4 address.Address, arg5 bool) (*types0.MessagePrototype, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MsigAddCancel", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*types0.MessagePrototype)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MsigAddCancel indicates an expected call of MsigAddCancel.
+func (mr *MockFullNodeMockRecorder) MsigAddCancel(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MsigAddCancel", reflect.TypeOf((*MockFullNode)(nil).MsigAddCancel), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// MsigAddPropose mocks base method.
+func (m *MockFullNode) MsigAddPropose(arg0 context.Context, arg1, arg2, arg3 address.Address, arg4 bool) (*types0.MessagePrototype, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MsigAddPropose", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*types0.MessagePrototype)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MsigAddPropose indicates an expected call of MsigAddPropose.
+func (mr *MockFullNodeMockRecorder) MsigAddPropose(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MsigAddPropose", reflect.TypeOf((*MockFullNode)(nil).MsigAddPropose), arg0, arg1, arg2, arg3, arg4)
+}
+
+// MsigApprove mocks base method.
+func (m *MockFullNode) MsigApprove(arg0 context.Context, arg1 address.Address, arg2 uint64, arg3 address.Address) (*types0.MessagePrototype, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MsigApprove", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types0.MessagePrototype)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MsigApprove indicates an expected call of MsigApprove.
+func (mr *MockFullNodeMockRecorder) MsigApprove(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MsigApprove", reflect.TypeOf((*MockFullNode)(nil).MsigApprove), arg0, arg1, arg2, arg3)
+}
+
+// MsigApproveTxnHash mocks base method.
+func (m *MockFullNode) MsigApproveTxnHash(arg0 context.Context, arg1 address.Address, arg2 uint64, arg3, arg4 address.Address, arg5 big.Int, arg6 address.Address, arg7 uint64, arg8 []byte) (*types0.MessagePrototype, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MsigApproveTxnHash", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+	ret0, _ := ret[0].(*types0.MessagePrototype)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MsigApproveTxnHash indicates an expected call of MsigApproveTxnHash.
+func (mr *MockFullNodeMockRecorder) MsigApproveTxnHash(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MsigApproveTxnHash", reflect.TypeOf((*MockFullNode)(nil).MsigApproveTxnHash), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+}
+
+// MsigCancel mocks base method.
+func (m *MockFullNode) MsigCancel(arg0 context.Context, arg1 address.Address, arg2 uint64, arg3 address.Address) (*types0.MessagePrototype, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MsigCancel", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*types0.MessagePrototype)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MsigCancel indicates an expected call of MsigCancel.
+func (mr *MockFullNodeMockRecorder) MsigCancel(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MsigCancel", reflect.TypeOf((*MockFullNode)(nil).MsigCancel), arg0, arg1, arg2, arg3)
+}
+
+// MsigCancelTxnHash mocks base method.
+func (m *MockFullNode) MsigCancelTxnHash(arg0 context.Context, arg1 address.Address, arg2 uint64, arg3 address.Address, arg4 big.Int, arg5 address.Address, arg6 uint64, arg7 []byte) (*types0.MessagePrototype, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MsigCancelTxnHash", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	ret0, _ := ret[0].(*types0.MessagePrototype)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MsigCancelTxnHash indicates an expected call of MsigCancelTxnHash.
+func (mr *MockFullNodeMockRecorder) MsigCancelTxnHash(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MsigCancelTxnHash", reflect.TypeOf((*MockFullNode)(nil).MsigCancelTxnHash), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+}
+
+// MsigCreate mocks base method.
+func (m *MockFullNode) MsigCreate(arg0 context.Context, arg1 uint64, arg2 []address.Address, arg3 abi.ChainEpoch, arg4 big.Int, arg5 address.Address, arg6 big.Int) (*types0.MessagePrototype, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MsigCreate", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(*types0.MessagePrototype)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MsigCreate indicates an expected call of MsigCreate.
+func (mr *MockFullNodeMockRecorder) MsigCreate(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MsigCreate", reflect.TypeOf((*MockFullNode)(nil).MsigCreate), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
+// MsigGetAvailableBalance mocks base method.
+func (m *MockFullNode) MsigGetAvailableBalance(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) (big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MsigGetAvailableBalance", arg0, arg1, arg2)
+	ret0, _ := ret[0].(big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MsigGetAvailableBalance indicates an expected call of MsigGetAvailableBalance.
+func (mr *MockFullNodeMockRecorder) MsigGetAvailableBalance(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MsigGetAvailableBalance", reflect.TypeOf((*MockFullNode)(nil).MsigGetAvailableBalance), arg0, arg1, arg2)
+}
+
+// MsigGetPending mocks base method.
+func (m *MockFullNode) MsigGetPending(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) ([]*types0.MsigTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MsigGetPending", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*types0.MsigTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MsigGetPending indicates an expected call of MsigGetPending.
+func (mr *MockFullNodeMockRecorder) MsigGetPending(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MsigGetPending", reflect.TypeOf((*MockFullNode)(nil).MsigGetPending), arg0, arg1, arg2)
+}
+
+// MsigGetVested mocks base method.
+func (m *MockFullNode) MsigGetVested(arg0 context.Context, arg1 address.Address, arg2, arg3 types0.TipSetKey) (big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MsigGetVested", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MsigGetVested indicates an expected call of MsigGetVested.
+func (mr *MockFullNodeMockRecorder) MsigGetVested(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MsigGetVested", reflect.TypeOf((*MockFullNode)(nil).MsigGetVested), arg0, arg1, arg2, arg3)
+}
+
+// MsigGetVestingSchedule mocks base method.
+func (m *MockFullNode) MsigGetVestingSchedule(arg0 context.Context, arg1 address.Address, arg2 types0.TipSetKey) (types0.MsigVesting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MsigGetVestingSchedule", arg0, arg1, arg2)
+	ret0, _ := ret[0].(types0.MsigVesting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MsigGetVestingSchedule indicates an expected call of MsigGetVestingSchedule.
+func (mr *MockFullNodeMockRecorder) MsigGetVestingSchedule(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MsigGetVestingSchedule", reflect.TypeOf((*MockFullNode)(nil).MsigGetVestingSchedule), arg0, arg1, arg2)
+}
+
+// MsigPropose mocks base method.
+func (m *MockFullNode) MsigPropose(arg0 context.Context, arg1, arg2 address.Address, arg3 big.Int, arg4 address.Address, arg5 uint64, arg6 []byte) (*types0.MessagePrototype, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MsigPropose", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(*types0.MessagePrototype)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MsigPropose indicates an expected call of MsigPropose.
+func (mr *MockFullNodeMockRecorder) MsigPropose(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MsigPropose", reflect.TypeOf((*MockFullNode)(nil).MsigPropose), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
+// MsigRemoveSigner mocks base method.
+func (m *MockFullNode) MsigRemoveSigner(arg0 context.Context, arg1, arg2, arg3 address.Address, arg4 bool) (*types0.MessagePrototype, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MsigRemoveSigner", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*types0.MessagePrototype)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MsigRemoveSigner indicates an expected call of MsigRemoveSigner.
+func (mr *MockFullNodeMockRecorder) MsigRemoveSigner(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MsigRemoveSigner", reflect.TypeOf((*MockFullNode)(nil).MsigRemoveSigner), arg0, arg1, arg2, arg3, arg4)
+}
+
+// MsigSwapApprove mocks base method.
+func (m *MockFullNode) MsigSwapApprove(arg0 context.Context, arg1, arg2 address.Address, arg3 uint64, arg4, arg5, arg6 address.Address) (*types0.MessagePrototype, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MsigSwapApprove", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(*types0.MessagePrototype)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MsigSwapApprove indicates an expected call of MsigSwapApprove.
+func (mr *MockFullNodeMockRecorder) MsigSwapApprove(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MsigSwapApprove", reflect.TypeOf((*MockFullNode)(nil).MsigSwapApprove), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
+// MsigSwapCancel mocks base method.
+func (m *MockFullNode) MsigSwapCancel(arg0 context.Context, arg1, arg2 address.Address, arg3 uint64, arg4, arg5 address.Address) (*types0.MessagePrototype, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MsigSwapCancel", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*types0.MessagePrototype)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MsigSwapCancel indicates an expected call of MsigSwapCancel.
+func (mr *MockFullNodeMockRecorder) MsigSwapCancel(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MsigSwapCancel", reflect.TypeOf((*MockFullNode)(nil).MsigSwapCancel), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// MsigSwapPropose mocks base method.
+func (m *MockFullNode) MsigSwapPropose(arg0 context.Context, arg1, arg2, arg3, arg4 address.Address) (*types0.MessagePrototype, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MsigSwapPropose", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*types0.MessagePrototype)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MsigSwapPropose indicates an expected call of MsigSwapPropose.
+func (mr *MockFullNodeMockRecorder) MsigSwapPropose(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MsigSwapPropose", reflect.TypeOf((*MockFullNode)(nil).MsigSwapPropose), arg0, arg1, arg2, arg3, arg4)
+}
+
 // NetAddrsListen mocks base method.
 func (m *MockFullNode) NetAddrsListen(arg0 context.Context) (peer.AddrInfo, error) {
 	m.ctrl.T.Helper()
@@ -1892,6 +2489,49 @@ func (mr *MockFullNodeMockRecorder) NetBandwidthStatsByProtocol(arg0 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetBandwidthStatsByProtocol", reflect.TypeOf((*MockFullNode)(nil).NetBandwidthStatsByProtocol), arg0)
 }
 
+// NetBlockAdd mocks base method.
+func (m *MockFullNode) NetBlockAdd(arg0 context.Context, arg1 types0.NetBlockList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetBlockAdd", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NetBlockAdd indicates an expected call of NetBlockAdd.
+func (mr *MockFullNodeMockRecorder) NetBlockAdd(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetBlockAdd", reflect.TypeOf((*MockFullNode)(nil).NetBlockAdd), arg0, arg1)
+}
+
+// NetBlockList mocks base method.
+func (m *MockFullNode) NetBlockList(arg0 context.Context) (types0.NetBlockList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetBlockList", arg0)
+	ret0, _ := ret[0].(types0.NetBlockList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NetBlockList indicates an expected call of NetBlockList.
+func (mr *MockFullNodeMockRecorder) NetBlockList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetBlockList", reflect.TypeOf((*MockFullNode)(nil).NetBlockList), arg0)
+}
+
+// NetBlockRemove mocks base method.
+func (m *MockFullNode) NetBlockRemove(arg0 context.Context, arg1 types0.NetBlockList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetBlockRemove", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NetBlockRemove indicates an expected call of NetBlockRemove.
+func (mr *MockFullNodeMockRecorder) NetBlockRemove(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetBlockRemove", reflect.TypeOf((*MockFullNode)(nil).NetBlockRemove), arg0, arg1)
+}
+
 // NetConnect mocks base method.
 func (m *MockFullNode) NetConnect(arg0 context.Context, arg1 peer.AddrInfo) error {
 	m.ctrl.T.Helper()
@@ -1979,6 +2619,21 @@ func (mr *MockFullNodeMockRecorder) NetGetClosestPeers(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetGetClosestPeers", reflect.TypeOf((*MockFullNode)(nil).NetGetClosestPeers), arg0, arg1)
 }
 
+// NetLimit mocks base method.
+func (m *MockFullNode) NetLimit(arg0 context.Context, arg1 string) (types0.NetLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetLimit", arg0, arg1)
+	ret0, _ := ret[0].(types0.NetLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NetLimit indicates an expected call of NetLimit.
+func (mr *MockFullNodeMockRecorder) NetLimit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetLimit", reflect.TypeOf((*MockFullNode)(nil).NetLimit), arg0, arg1)
+}
+
 // NetListening mocks base method.
 func (m *MockFullNode) NetListening(arg0 context.Context) (bool, error) {
 	m.ctrl.T.Helper()
@@ -2097,6 +2752,35 @@ func (mr *MockFullNodeMockRecorder) NetPubsubScores(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetPubsubScores", reflect.TypeOf((*MockFullNode)(nil).NetPubsubScores), arg0)
 }
 
+// NetSetLimit mocks base method.
+func (m *MockFullNode) NetSetLimit(arg0 context.Context, arg1 string, arg2 types0.NetLimit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetSetLimit", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NetSetLimit indicates an expected call of NetSetLimit.
+func (mr *MockFullNodeMockRecorder) NetSetLimit(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetSetLimit", reflect.TypeOf((*MockFullNode)(nil).NetSetLimit), arg0, arg1, arg2)
+}
+
+// NetStat mocks base method.
+func (m *MockFullNode) NetStat(arg0 context.Context, arg1 string) (types0.NetStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetStat", arg0, arg1)
+	ret0, _ := ret[0].(types0.NetStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NetStat indicates an expected call of NetStat.
+func (mr *MockFullNodeMockRecorder) NetStat(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetStat", reflect.TypeOf((*MockFullNode)(nil).NetStat), arg0, arg1)
+}
+
 // NetVersion mocks base method.
 func (m *MockFullNode) NetVersion(arg0 context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -3504,6 +4188,36 @@ func (mr *MockFullNodeMockRecorder) SubscribeActorEventsRaw(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeActorEventsRaw", reflect.TypeOf((*MockFullNode)(nil).SubscribeActorEventsRaw), arg0, arg1)
 }
 
+// SubscribeActorEventsResumable mocks base method.
+func (m *MockFullNode) SubscribeActorEventsResumable(arg0 context.Context, arg1 *types0.ActorEventFilter, arg2 *types0.ActorEventCursor) (<-chan *types0.ActorEventNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeActorEventsResumable", arg0, arg1, arg2)
+	ret0, _ := ret[0].(<-chan *types0.ActorEventNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeActorEventsResumable indicates an expected call of SubscribeActorEventsResumable.
+func (mr *MockFullNodeMockRecorder) SubscribeActorEventsResumable(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeActorEventsResumable", reflect.TypeOf((*MockFullNode)(nil).SubscribeActorEventsResumable), arg0, arg1, arg2)
+}
+
+// SyncCheckBad mocks base method.
+func (m *MockFullNode) SyncCheckBad(arg0 context.Context, arg1 cid.Cid) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncCheckBad", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncCheckBad indicates an expected call of SyncCheckBad.
+func (mr *MockFullNodeMockRecorder) SyncCheckBad(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncCheckBad", reflect.TypeOf((*MockFullNode)(nil).SyncCheckBad), arg0, arg1)
+}
+
 // SyncCheckpoint mocks base method.
 func (m *MockFullNode) SyncCheckpoint(arg0 context.Context, arg1 types0.TipSetKey) error {
 	m.ctrl.T.Helper()
@@ -3533,6 +4247,20 @@ func (mr *MockFullNodeMockRecorder) SyncIncomingBlocks(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncIncomingBlocks", reflect.TypeOf((*MockFullNode)(nil).SyncIncomingBlocks), arg0)
 }
 
+// SyncMarkBad mocks base method.
+func (m *MockFullNode) SyncMarkBad(arg0 context.Context, arg1 cid.Cid) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncMarkBad", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncMarkBad indicates an expected call of SyncMarkBad.
+func (mr *MockFullNodeMockRecorder) SyncMarkBad(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncMarkBad", reflect.TypeOf((*MockFullNode)(nil).SyncMarkBad), arg0, arg1)
+}
+
 // SyncState mocks base method.
 func (m *MockFullNode) SyncState(arg0 context.Context) (*types0.SyncState, error) {
 	m.ctrl.T.Helper()
@@ -3562,6 +4290,34 @@ func (mr *MockFullNodeMockRecorder) SyncSubmitBlock(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncSubmitBlock", reflect.TypeOf((*MockFullNode)(nil).SyncSubmitBlock), arg0, arg1)
 }
 
+// SyncUnmarkAllBad mocks base method.
+func (m *MockFullNode) SyncUnmarkAllBad(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncUnmarkAllBad", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncUnmarkAllBad indicates an expected call of SyncUnmarkAllBad.
+func (mr *MockFullNodeMockRecorder) SyncUnmarkAllBad(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncUnmarkAllBad", reflect.TypeOf((*MockFullNode)(nil).SyncUnmarkAllBad), arg0)
+}
+
+// SyncUnmarkBad mocks base method.
+func (m *MockFullNode) SyncUnmarkBad(arg0 context.Context, arg1 cid.Cid) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncUnmarkBad", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncUnmarkBad indicates an expected call of SyncUnmarkBad.
+func (mr *MockFullNodeMockRecorder) SyncUnmarkBad(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncUnmarkBad", reflect.TypeOf((*MockFullNode)(nil).SyncUnmarkBad), arg0, arg1)
+}
+
 // SyncerTracker mocks base method.
 func (m *MockFullNode) SyncerTracker(arg0 context.Context) *types0.TargetTracker {
 	m.ctrl.T.Helper()
@@ -3677,6 +4433,21 @@ func (mr *MockFullNodeMockRecorder) WalletDelete(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletDelete", reflect.TypeOf((*MockFullNode)(nil).WalletDelete), arg0, arg1)
 }
 
+// WalletDeriveHDAddress mocks base method.
+func (m *MockFullNode) WalletDeriveHDAddress(arg0 context.Context, arg1 byte, arg2 uint32) (*types0.WalletHDAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletDeriveHDAddress", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.WalletHDAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletDeriveHDAddress indicates an expected call of WalletDeriveHDAddress.
+func (mr *MockFullNodeMockRecorder) WalletDeriveHDAddress(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletDeriveHDAddress", reflect.TypeOf((*MockFullNode)(nil).WalletDeriveHDAddress), arg0, arg1, arg2)
+}
+
 // WalletExport mocks base method.
 func (m *MockFullNode) WalletExport(arg0 context.Context, arg1 address.Address, arg2 string) (*types0.KeyInfo, error) {
 	m.ctrl.T.Helper()
@@ -3722,6 +4493,21 @@ func (mr *MockFullNodeMockRecorder) WalletImport(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletImport", reflect.TypeOf((*MockFullNode)(nil).WalletImport), arg0, arg1)
 }
 
+// WalletImportMnemonic mocks base method.
+func (m *MockFullNode) WalletImportMnemonic(arg0 context.Context, arg1 string, arg2 byte) (*types0.WalletHDAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletImportMnemonic", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types0.WalletHDAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletImportMnemonic indicates an expected call of WalletImportMnemonic.
+func (mr *MockFullNodeMockRecorder) WalletImportMnemonic(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletImportMnemonic", reflect.TypeOf((*MockFullNode)(nil).WalletImportMnemonic), arg0, arg1, arg2)
+}
+
 // WalletNewAddress mocks base method.
 func (m *MockFullNode) WalletNewAddress(arg0 context.Context, arg1 byte) (address.Address, error) {
 	m.ctrl.T.Helper()
@@ -3737,6 +4523,65 @@ func (mr *MockFullNodeMockRecorder) WalletNewAddress(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletNewAddress", reflect.TypeOf((*MockFullNode)(nil).WalletNewAddress), arg0, arg1)
 }
 
+// WalletNewHDAddress mocks base method.
+func (m *MockFullNode) WalletNewHDAddress(arg0 context.Context, arg1 byte) (*types0.WalletHDAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletNewHDAddress", arg0, arg1)
+	ret0, _ := ret[0].(*types0.WalletHDAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletNewHDAddress indicates an expected call of WalletNewHDAddress.
+func (mr *MockFullNodeMockRecorder) WalletNewHDAddress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletNewHDAddress", reflect.TypeOf((*MockFullNode)(nil).WalletNewHDAddress), arg0, arg1)
+}
+
+// WalletPolicyGet mocks base method.
+func (m *MockFullNode) WalletPolicyGet(arg0 context.Context, arg1 address.Address) (*types0.WalletPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletPolicyGet", arg0, arg1)
+	ret0, _ := ret[0].(*types0.WalletPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletPolicyGet indicates an expected call of WalletPolicyGet.
+func (mr *MockFullNodeMockRecorder) WalletPolicyGet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletPolicyGet", reflect.TypeOf((*MockFullNode)(nil).WalletPolicyGet), arg0, arg1)
+}
+
+// WalletPolicyList mocks base method.
+func (m *MockFullNode) WalletPolicyList(arg0 context.Context) ([]*types0.WalletPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletPolicyList", arg0)
+	ret0, _ := ret[0].([]*types0.WalletPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletPolicyList indicates an expected call of WalletPolicyList.
+func (mr *MockFullNodeMockRecorder) WalletPolicyList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletPolicyList", reflect.TypeOf((*MockFullNode)(nil).WalletPolicyList), arg0)
+}
+
+// WalletPolicySet mocks base method.
+func (m *MockFullNode) WalletPolicySet(arg0 context.Context, arg1 *types0.WalletPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletPolicySet", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WalletPolicySet indicates an expected call of WalletPolicySet.
+func (mr *MockFullNodeMockRecorder) WalletPolicySet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletPolicySet", reflect.TypeOf((*MockFullNode)(nil).WalletPolicySet), arg0, arg1)
+}
+
 // WalletSetDefault mocks base method.
 func (m *MockFullNode) WalletSetDefault(arg0 context.Context, arg1 address.Address) error {
 	m.ctrl.T.Helper()
@@ -3781,6 +4626,21 @@ func (mr *MockFullNodeMockRecorder) WalletSignMessage(arg0, arg1, arg2 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletSignMessage", reflect.TypeOf((*MockFullNode)(nil).WalletSignMessage), arg0, arg1, arg2)
 }
 
+// WalletSignMessageBytes mocks base method.
+func (m *MockFullNode) WalletSignMessageBytes(arg0 context.Context, arg1 address.Address, arg2 []byte) (*crypto.Signature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletSignMessageBytes", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*crypto.Signature)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletSignMessageBytes indicates an expected call of WalletSignMessageBytes.
+func (mr *MockFullNodeMockRecorder) WalletSignMessageBytes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletSignMessageBytes", reflect.TypeOf((*MockFullNode)(nil).WalletSignMessageBytes), arg0, arg1, arg2)
+}
+
 // WalletState mocks base method.
 func (m *MockFullNode) WalletState(arg0 context.Context) int {
 	m.ctrl.T.Helper()
@@ -3795,6 +4655,21 @@ func (mr *MockFullNodeMockRecorder) WalletState(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletState", reflect.TypeOf((*MockFullNode)(nil).WalletState), arg0)
 }
 
+// WalletVerify mocks base method.
+func (m *MockFullNode) WalletVerify(arg0 context.Context, arg1 address.Address, arg2 []byte, arg3 *crypto.Signature) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletVerify", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletVerify indicates an expected call of WalletVerify.
+func (mr *MockFullNodeMockRecorder) WalletVerify(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletVerify", reflect.TypeOf((*MockFullNode)(nil).WalletVerify), arg0, arg1, arg2, arg3)
+}
+
 // Web3ClientVersion mocks base method.
 func (m *MockFullNode) Web3ClientVersion(arg0 context.Context) (string, error) {
 	m.ctrl.T.Helper()
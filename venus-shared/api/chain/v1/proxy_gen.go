@@ -280,10 +280,15 @@ func (s *IMinerStateStruct) StateVerifiedClientStatus(p0 context.Context, p1 add
 type IChainInfoStruct struct {
 	Internal struct {
 		BlockTime                           func(ctx context.Context) time.Duration                                                                                                                      `perm:"read"`
+		ChainCheckBlockstore                func(ctx context.Context, params types.BlockstoreCheckParams) (*types.BlockstoreCheckResult, error)                                                          `perm:"admin"`
 		ChainExport                         func(context.Context, abi.ChainEpoch, bool, types.TipSetKey) (<-chan []byte, error)                                                                          `perm:"read"`
+		ChainExportJobCancel                func(ctx context.Context) error                                                                                                                              `perm:"admin"`
+		ChainExportJobStart                 func(ctx context.Context, params types.ExportJobParams) error                                                                                                `perm:"admin"`
+		ChainExportJobStatus                func(ctx context.Context) (*types.ExportJobStatus, error)                                                                                                    `perm:"read"`
 		ChainGetBlock                       func(ctx context.Context, id cid.Cid) (*types.BlockHeader, error)                                                                                            `perm:"read"`
 		ChainGetBlockMessages               func(ctx context.Context, bid cid.Cid) (*types.BlockMessages, error)                                                                                         `perm:"read"`
 		ChainGetEvents                      func(context.Context, cid.Cid) ([]types.Event, error)                                                                                                        `perm:"read"`
+		ChainGetFinalizedTipSet             func(ctx context.Context) (*types.TipSet, error)                                                                                                             `perm:"read"`
 		ChainGetGenesis                     func(context.Context) (*types.TipSet, error)                                                                                                                 `perm:"read"`
 		ChainGetMessage                     func(ctx context.Context, msgID cid.Cid) (*types.Message, error)                                                                                             `perm:"read"`
 		ChainGetMessagesInTipset            func(ctx context.Context, key types.TipSetKey) ([]types.MessageCID, error)                                                                                   `perm:"read"`
@@ -297,7 +302,10 @@ type IChainInfoStruct struct {
 		ChainHead                           func(ctx context.Context) (*types.TipSet, error)                                                                                                             `perm:"read"`
 		ChainList                           func(ctx context.Context, tsKey types.TipSetKey, count int) ([]types.TipSetKey, error)                                                                       `perm:"read"`
 		ChainNotify                         func(ctx context.Context) (<-chan []*types.HeadChange, error)                                                                                                `perm:"read"`
+		ChainPrune                          func(ctx context.Context, opts types.PruneOpts) error                                                                                                        `perm:"admin"`
+		ChainPruneStatus                    func(ctx context.Context) (*types.PruneStatus, error)                                                                                                        `perm:"read"`
 		ChainSetHead                        func(ctx context.Context, key types.TipSetKey) error                                                                                                         `perm:"admin"`
+		ChainValidateIndex                  func(ctx context.Context, epoch abi.ChainEpoch, backfill bool) (*types.IndexValidation, error)                                                               `perm:"write"`
 		GetActor                            func(ctx context.Context, addr address.Address) (*types.Actor, error)                                                                                        `perm:"read"`
 		GetEntry                            func(ctx context.Context, height abi.ChainEpoch, round uint64) (*types.BeaconEntry, error)                                                                   `perm:"read"`
 		GetFullBlock                        func(ctx context.Context, id cid.Cid) (*types.FullBlock, error)                                                                                              `perm:"read"`
@@ -329,9 +337,21 @@ type IChainInfoStruct struct {
 func (s *IChainInfoStruct) BlockTime(p0 context.Context) time.Duration {
 	return s.Internal.BlockTime(p0)
 }
+func (s *IChainInfoStruct) ChainCheckBlockstore(p0 context.Context, p1 types.BlockstoreCheckParams) (*types.BlockstoreCheckResult, error) {
+	return s.Internal.ChainCheckBlockstore(p0, p1)
+}
 func (s *IChainInfoStruct) ChainExport(p0 context.Context, p1 abi.ChainEpoch, p2 bool, p3 types.TipSetKey) (<-chan []byte, error) {
 	return s.Internal.ChainExport(p0, p1, p2, p3)
 }
+func (s *IChainInfoStruct) ChainExportJobCancel(p0 context.Context) error {
+	return s.Internal.ChainExportJobCancel(p0)
+}
+func (s *IChainInfoStruct) ChainExportJobStart(p0 context.Context, p1 types.ExportJobParams) error {
+	return s.Internal.ChainExportJobStart(p0, p1)
+}
+func (s *IChainInfoStruct) ChainExportJobStatus(p0 context.Context) (*types.ExportJobStatus, error) {
+	return s.Internal.ChainExportJobStatus(p0)
+}
 func (s *IChainInfoStruct) ChainGetBlock(p0 context.Context, p1 cid.Cid) (*types.BlockHeader, error) {
 	return s.Internal.ChainGetBlock(p0, p1)
 }
@@ -341,6 +361,9 @@ func (s *IChainInfoStruct) ChainGetBlockMessages(p0 context.Context, p1 cid.Cid)
 func (s *IChainInfoStruct) ChainGetEvents(p0 context.Context, p1 cid.Cid) ([]types.Event, error) {
 	return s.Internal.ChainGetEvents(p0, p1)
 }
+func (s *IChainInfoStruct) ChainGetFinalizedTipSet(p0 context.Context) (*types.TipSet, error) {
+	return s.Internal.ChainGetFinalizedTipSet(p0)
+}
 func (s *IChainInfoStruct) ChainGetGenesis(p0 context.Context) (*types.TipSet, error) {
 	return s.Internal.ChainGetGenesis(p0)
 }
@@ -380,9 +403,18 @@ func (s *IChainInfoStruct) ChainList(p0 context.Context, p1 types.TipSetKey, p2
 func (s *IChainInfoStruct) ChainNotify(p0 context.Context) (<-chan []*types.HeadChange, error) {
 	return s.Internal.ChainNotify(p0)
 }
+func (s *IChainInfoStruct) ChainPrune(p0 context.Context, p1 types.PruneOpts) error {
+	return s.Internal.ChainPrune(p0, p1)
+}
+func (s *IChainInfoStruct) ChainPruneStatus(p0 context.Context) (*types.PruneStatus, error) {
+	return s.Internal.ChainPruneStatus(p0)
+}
 func (s *IChainInfoStruct) ChainSetHead(p0 context.Context, p1 types.TipSetKey) error {
 	return s.Internal.ChainSetHead(p0, p1)
 }
+func (s *IChainInfoStruct) ChainValidateIndex(p0 context.Context, p1 abi.ChainEpoch, p2 bool) (*types.IndexValidation, error) {
+	return s.Internal.ChainValidateIndex(p0, p1, p2)
+}
 func (s *IChainInfoStruct) GetActor(p0 context.Context, p1 address.Address) (*types.Actor, error) {
 	return s.Internal.GetActor(p0, p1)
 }
@@ -494,6 +526,7 @@ type IMessagePoolStruct struct {
 	Internal struct {
 		GasBatchEstimateMessageGas func(ctx context.Context, estimateMessages []*types.EstimateMessage, fromNonce uint64, tsk types.TipSetKey) ([]*types.EstimateResult, error) `perm:"read"`
 		GasEstimateFeeCap          func(ctx context.Context, msg *types.Message, maxqueueblks int64, tsk types.TipSetKey) (big.Int, error)                                      `perm:"read"`
+		GasEstimateFeeHistory      func(ctx context.Context, epochs uint64, percentiles []float64, tsk types.TipSetKey) (*types.FeeHistory, error)                              `perm:"read"`
 		GasEstimateGasLimit        func(ctx context.Context, msgIn *types.Message, tsk types.TipSetKey) (int64, error)                                                          `perm:"read"`
 		GasEstimateGasPremium      func(ctx context.Context, nblocksincl uint64, sender address.Address, gaslimit int64, tsk types.TipSetKey) (big.Int, error)                  `perm:"read"`
 		GasEstimateMessageGas      func(ctx context.Context, msg *types.Message, spec *types.MessageSendSpec, tsk types.TipSetKey) (*types.Message, error)                      `perm:"read"`
@@ -505,6 +538,7 @@ type IMessagePoolStruct struct {
 		MpoolCheckReplaceMessages  func(ctx context.Context, msg []*types.Message) ([][]types.MessageCheckStatus, error)                                                        `perm:"read"`
 		MpoolClear                 func(ctx context.Context, local bool) error                                                                                                  `perm:"write"`
 		MpoolDeleteByAdress        func(ctx context.Context, addr address.Address) error                                                                                        `perm:"admin"`
+		MpoolFeeBumpStatus         func(ctx context.Context) (*types.MpoolFeeBumpStatus, error)                                                                                 `perm:"read"`
 		MpoolGetConfig             func(context.Context) (*types.MpoolConfig, error)                                                                                            `perm:"read"`
 		MpoolGetNonce              func(ctx context.Context, addr address.Address) (uint64, error)                                                                              `perm:"read"`
 		MpoolPending               func(ctx context.Context, tsk types.TipSetKey) ([]*types.SignedMessage, error)                                                               `perm:"read"`
@@ -526,6 +560,9 @@ func (s *IMessagePoolStruct) GasBatchEstimateMessageGas(p0 context.Context, p1 [
 func (s *IMessagePoolStruct) GasEstimateFeeCap(p0 context.Context, p1 *types.Message, p2 int64, p3 types.TipSetKey) (big.Int, error) {
 	return s.Internal.GasEstimateFeeCap(p0, p1, p2, p3)
 }
+func (s *IMessagePoolStruct) GasEstimateFeeHistory(p0 context.Context, p1 uint64, p2 []float64, p3 types.TipSetKey) (*types.FeeHistory, error) {
+	return s.Internal.GasEstimateFeeHistory(p0, p1, p2, p3)
+}
 func (s *IMessagePoolStruct) GasEstimateGasLimit(p0 context.Context, p1 *types.Message, p2 types.TipSetKey) (int64, error) {
 	return s.Internal.GasEstimateGasLimit(p0, p1, p2)
 }
@@ -559,6 +596,9 @@ func (s *IMessagePoolStruct) MpoolClear(p0 context.Context, p1 bool) error {
 func (s *IMessagePoolStruct) MpoolDeleteByAdress(p0 context.Context, p1 address.Address) error {
 	return s.Internal.MpoolDeleteByAdress(p0, p1)
 }
+func (s *IMessagePoolStruct) MpoolFeeBumpStatus(p0 context.Context) (*types.MpoolFeeBumpStatus, error) {
+	return s.Internal.MpoolFeeBumpStatus(p0)
+}
 func (s *IMessagePoolStruct) MpoolGetConfig(p0 context.Context) (*types.MpoolConfig, error) {
 	return s.Internal.MpoolGetConfig(p0)
 }
@@ -596,6 +636,80 @@ func (s *IMessagePoolStruct) MpoolSub(p0 context.Context) (<-chan types.MpoolUpd
 	return s.Internal.MpoolSub(p0)
 }
 
+type IMultiSigStruct struct {
+	Internal struct {
+		MsigAddApprove          func(ctx context.Context, msig address.Address, src address.Address, txID uint64, proposer address.Address, newAdd address.Address, inc bool) (*types.MessagePrototype, error)                                   `perm:"sign"`
+		MsigAddCancel           func(ctx context.Context, msig address.Address, src address.Address, txID uint64, newAdd address.Address, inc bool) (*types.MessagePrototype, error)                                                             `perm:"sign"`
+		MsigAddPropose          func(ctx context.Context, msig address.Address, src address.Address, newAdd address.Address, inc bool) (*types.MessagePrototype, error)                                                                          `perm:"sign"`
+		MsigApprove             func(ctx context.Context, msig address.Address, txID uint64, src address.Address) (*types.MessagePrototype, error)                                                                                               `perm:"sign"`
+		MsigApproveTxnHash      func(ctx context.Context, msig address.Address, txID uint64, proposer address.Address, to address.Address, amt types.BigInt, src address.Address, method uint64, params []byte) (*types.MessagePrototype, error) `perm:"sign"`
+		MsigCancel              func(ctx context.Context, msig address.Address, txID uint64, src address.Address) (*types.MessagePrototype, error)                                                                                               `perm:"sign"`
+		MsigCancelTxnHash       func(ctx context.Context, msig address.Address, txID uint64, to address.Address, amt types.BigInt, src address.Address, method uint64, params []byte) (*types.MessagePrototype, error)                           `perm:"sign"`
+		MsigCreate              func(ctx context.Context, req uint64, addrs []address.Address, duration abi.ChainEpoch, val types.BigInt, src address.Address, gp types.BigInt) (*types.MessagePrototype, error)                                 `perm:"sign"`
+		MsigGetAvailableBalance func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.BigInt, error)                                                                                                                       `perm:"read"`
+		MsigGetPending          func(ctx context.Context, addr address.Address, tsk types.TipSetKey) ([]*types.MsigTransaction, error)                                                                                                           `perm:"read"`
+		MsigGetVested           func(ctx context.Context, addr address.Address, start types.TipSetKey, end types.TipSetKey) (types.BigInt, error)                                                                                                `perm:"read"`
+		MsigGetVestingSchedule  func(ctx context.Context, addr address.Address, tsk types.TipSetKey) (types.MsigVesting, error)                                                                                                                  `perm:"read"`
+		MsigPropose             func(ctx context.Context, msig address.Address, to address.Address, amt types.BigInt, src address.Address, method uint64, params []byte) (*types.MessagePrototype, error)                                        `perm:"sign"`
+		MsigRemoveSigner        func(ctx context.Context, msig address.Address, proposer address.Address, toRemove address.Address, decrease bool) (*types.MessagePrototype, error)                                                              `perm:"sign"`
+		MsigSwapApprove         func(ctx context.Context, msig address.Address, src address.Address, txID uint64, proposer address.Address, oldAdd address.Address, newAdd address.Address) (*types.MessagePrototype, error)                     `perm:"sign"`
+		MsigSwapCancel          func(ctx context.Context, msig address.Address, src address.Address, txID uint64, oldAdd address.Address, newAdd address.Address) (*types.MessagePrototype, error)                                               `perm:"sign"`
+		MsigSwapPropose         func(ctx context.Context, msig address.Address, src address.Address, oldAdd address.Address, newAdd address.Address) (*types.MessagePrototype, error)                                                            `perm:"sign"`
+	}
+}
+
+func (s *IMultiSigStruct) MsigAddApprove(p0 context.Context, p1 address.Address, p2 address.Address, p3 uint64, p4 address.Address, p5 address.Address, p6 bool) (*types.MessagePrototype, error) {
+	return s.Internal.MsigAddApprove(p0, p1, p2, p3, p4, p5, p6)
+}
+func (s *IMultiSigStruct) MsigAddCancel(p0 context.Context, p1 address.Address, p2 address.Address, p3 uint64, p4 address.Address, p5 bool) (*types.MessagePrototype, error) {
+	return s.Internal.MsigAddCancel(p0, p1, p2, p3, p4, p5)
+}
+func (s *IMultiSigStruct) MsigAddPropose(p0 context.Context, p1 address.Address, p2 address.Address, p3 address.Address, p4 bool) (*types.MessagePrototype, error) {
+	return s.Internal.MsigAddPropose(p0, p1, p2, p3, p4)
+}
+func (s *IMultiSigStruct) MsigApprove(p0 context.Context, p1 address.Address, p2 uint64, p3 address.Address) (*types.MessagePrototype, error) {
+	return s.Internal.MsigApprove(p0, p1, p2, p3)
+}
+func (s *IMultiSigStruct) MsigApproveTxnHash(p0 context.Context, p1 address.Address, p2 uint64, p3 address.Address, p4 address.Address, p5 types.BigInt, p6 address.Address, p7 uint64, p8 []byte) (*types.MessagePrototype, error) {
+	return s.Internal.MsigApproveTxnHash(p0, p1, p2, p3, p4, p5, p6, p7, p8)
+}
+func (s *IMultiSigStruct) MsigCancel(p0 context.Context, p1 address.Address, p2 uint64, p3 address.Address) (*types.MessagePrototype, error) {
+	return s.Internal.MsigCancel(p0, p1, p2, p3)
+}
+func (s *IMultiSigStruct) MsigCancelTxnHash(p0 context.Context, p1 address.Address, p2 uint64, p3 address.Address, p4 types.BigInt, p5 address.Address, p6 uint64, p7 []byte) (*types.MessagePrototype, error) {
+	return s.Internal.MsigCancelTxnHash(p0, p1, p2, p3, p4, p5, p6, p7)
+}
+func (s *IMultiSigStruct) MsigCreate(p0 context.Context, p1 uint64, p2 []address.Address, p3 abi.ChainEpoch, p4 types.BigInt, p5 address.Address, p6 types.BigInt) (*types.MessagePrototype, error) {
+	return s.Internal.MsigCreate(p0, p1, p2, p3, p4, p5, p6)
+}
+func (s *IMultiSigStruct) MsigGetAvailableBalance(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (types.BigInt, error) {
+	return s.Internal.MsigGetAvailableBalance(p0, p1, p2)
+}
+func (s *IMultiSigStruct) MsigGetPending(p0 context.Context, p1 address.Address, p2 types.TipSetKey) ([]*types.MsigTransaction, error) {
+	return s.Internal.MsigGetPending(p0, p1, p2)
+}
+func (s *IMultiSigStruct) MsigGetVested(p0 context.Context, p1 address.Address, p2 types.TipSetKey, p3 types.TipSetKey) (types.BigInt, error) {
+	return s.Internal.MsigGetVested(p0, p1, p2, p3)
+}
+func (s *IMultiSigStruct) MsigGetVestingSchedule(p0 context.Context, p1 address.Address, p2 types.TipSetKey) (types.MsigVesting, error) {
+	return s.Internal.MsigGetVestingSchedule(p0, p1, p2)
+}
+func (s *IMultiSigStruct) MsigPropose(p0 context.Context, p1 address.Address, p2 address.Address, p3 types.BigInt, p4 address.Address, p5 uint64, p6 []byte) (*types.MessagePrototype, error) {
+	return s.Internal.MsigPropose(p0, p1, p2, p3, p4, p5, p6)
+}
+func (s *IMultiSigStruct) MsigRemoveSigner(p0 context.Context, p1 address.Address, p2 address.Address, p3 address.Address, p4 bool) (*types.MessagePrototype, error) {
+	return s.Internal.MsigRemoveSigner(p0, p1, p2, p3, p4)
+}
+func (s *IMultiSigStruct) MsigSwapApprove(p0 context.Context, p1 address.Address, p2 address.Address, p3 uint64, p4 address.Address, p5 address.Address, p6 address.Address) (*types.MessagePrototype, error) {
+	return s.Internal.MsigSwapApprove(p0, p1, p2, p3, p4, p5, p6)
+}
+func (s *IMultiSigStruct) MsigSwapCancel(p0 context.Context, p1 address.Address, p2 address.Address, p3 uint64, p4 address.Address, p5 address.Address) (*types.MessagePrototype, error) {
+	return s.Internal.MsigSwapCancel(p0, p1, p2, p3, p4, p5)
+}
+func (s *IMultiSigStruct) MsigSwapPropose(p0 context.Context, p1 address.Address, p2 address.Address, p3 address.Address, p4 address.Address) (*types.MessagePrototype, error) {
+	return s.Internal.MsigSwapPropose(p0, p1, p2, p3, p4)
+}
+
 type INetworkStruct struct {
 	Internal struct {
 		ID                          func(ctx context.Context) (peer.ID, error)                             `perm:"read"`
@@ -605,12 +719,16 @@ type INetworkStruct struct {
 		NetBandwidthStats           func(ctx context.Context) (metrics.Stats, error)                       `perm:"read"`
 		NetBandwidthStatsByPeer     func(ctx context.Context) (map[string]metrics.Stats, error)            `perm:"read"`
 		NetBandwidthStatsByProtocol func(ctx context.Context) (map[protocol.ID]metrics.Stats, error)       `perm:"read"`
+		NetBlockAdd                 func(ctx context.Context, acl types.NetBlockList) error                `perm:"admin"`
+		NetBlockList                func(ctx context.Context) (types.NetBlockList, error)                  `perm:"read"`
+		NetBlockRemove              func(ctx context.Context, acl types.NetBlockList) error                `perm:"admin"`
 		NetConnect                  func(ctx context.Context, pi peer.AddrInfo) error                      `perm:"admin"`
 		NetConnectedness            func(context.Context, peer.ID) (network2.Connectedness, error)         `perm:"read"`
 		NetDisconnect               func(ctx context.Context, p peer.ID) error                             `perm:"admin"`
 		NetFindPeer                 func(ctx context.Context, p peer.ID) (peer.AddrInfo, error)            `perm:"read"`
 		NetFindProvidersAsync       func(ctx context.Context, key cid.Cid, count int) <-chan peer.AddrInfo `perm:"read"`
 		NetGetClosestPeers          func(ctx context.Context, key string) ([]peer.ID, error)               `perm:"read"`
+		NetLimit                    func(ctx context.Context, scope string) (types.NetLimit, error)        `perm:"read"`
 		NetPeerInfo                 func(ctx context.Context, p peer.ID) (*types.ExtendedPeerInfo, error)  `perm:"read"`
 		NetPeers                    func(ctx context.Context) ([]peer.AddrInfo, error)                     `perm:"read"`
 		NetPing                     func(ctx context.Context, p peer.ID) (time.Duration, error)            `perm:"read"`
@@ -618,6 +736,8 @@ type INetworkStruct struct {
 		NetProtectList              func(ctx context.Context) ([]peer.ID, error)                           `perm:"read"`
 		NetProtectRemove            func(ctx context.Context, acl []peer.ID) error                         `perm:"admin"`
 		NetPubsubScores             func(context.Context) ([]types.PubsubScore, error)                     `perm:"read"`
+		NetSetLimit                 func(ctx context.Context, scope string, limit types.NetLimit) error    `perm:"admin"`
+		NetStat                     func(ctx context.Context, scope string) (types.NetStat, error)         `perm:"read"`
 	}
 }
 
@@ -640,6 +760,15 @@ func (s *INetworkStruct) NetBandwidthStatsByPeer(p0 context.Context) (map[string
 func (s *INetworkStruct) NetBandwidthStatsByProtocol(p0 context.Context) (map[protocol.ID]metrics.Stats, error) {
 	return s.Internal.NetBandwidthStatsByProtocol(p0)
 }
+func (s *INetworkStruct) NetBlockAdd(p0 context.Context, p1 types.NetBlockList) error {
+	return s.Internal.NetBlockAdd(p0, p1)
+}
+func (s *INetworkStruct) NetBlockList(p0 context.Context) (types.NetBlockList, error) {
+	return s.Internal.NetBlockList(p0)
+}
+func (s *INetworkStruct) NetBlockRemove(p0 context.Context, p1 types.NetBlockList) error {
+	return s.Internal.NetBlockRemove(p0, p1)
+}
 func (s *INetworkStruct) NetConnect(p0 context.Context, p1 peer.AddrInfo) error {
 	return s.Internal.NetConnect(p0, p1)
 }
@@ -658,6 +787,9 @@ func (s *INetworkStruct) NetFindProvidersAsync(p0 context.Context, p1 cid.Cid, p
 func (s *INetworkStruct) NetGetClosestPeers(p0 context.Context, p1 string) ([]peer.ID, error) {
 	return s.Internal.NetGetClosestPeers(p0, p1)
 }
+func (s *INetworkStruct) NetLimit(p0 context.Context, p1 string) (types.NetLimit, error) {
+	return s.Internal.NetLimit(p0, p1)
+}
 func (s *INetworkStruct) NetPeerInfo(p0 context.Context, p1 peer.ID) (*types.ExtendedPeerInfo, error) {
 	return s.Internal.NetPeerInfo(p0, p1)
 }
@@ -679,6 +811,12 @@ func (s *INetworkStruct) NetProtectRemove(p0 context.Context, p1 []peer.ID) erro
 func (s *INetworkStruct) NetPubsubScores(p0 context.Context) ([]types.PubsubScore, error) {
 	return s.Internal.NetPubsubScores(p0)
 }
+func (s *INetworkStruct) NetSetLimit(p0 context.Context, p1 string, p2 types.NetLimit) error {
+	return s.Internal.NetSetLimit(p0, p1, p2)
+}
+func (s *INetworkStruct) NetStat(p0 context.Context, p1 string) (types.NetStat, error) {
+	return s.Internal.NetStat(p0, p1)
+}
 
 type IPaychanStruct struct {
 	Internal struct {
@@ -760,10 +898,14 @@ type ISyncerStruct struct {
 		ChainTipSetWeight        func(ctx context.Context, tsk types.TipSetKey) (big.Int, error) `perm:"read"`
 		Concurrent               func(ctx context.Context) int64                                 `perm:"read"`
 		SetConcurrent            func(ctx context.Context, concurrent int64) error               `perm:"admin"`
+		SyncCheckBad             func(ctx context.Context, bcid cid.Cid) (string, error)         `perm:"read"`
 		SyncCheckpoint           func(ctx context.Context, tsk types.TipSetKey) error            `perm:"admin"`
 		SyncIncomingBlocks       func(ctx context.Context) (<-chan *types.BlockHeader, error)    `perm:"read"`
+		SyncMarkBad              func(ctx context.Context, bcid cid.Cid) error                   `perm:"admin"`
 		SyncState                func(ctx context.Context) (*types.SyncState, error)             `perm:"read"`
 		SyncSubmitBlock          func(ctx context.Context, blk *types.BlockMsg) error            `perm:"write"`
+		SyncUnmarkAllBad         func(ctx context.Context) error                                 `perm:"admin"`
+		SyncUnmarkBad            func(ctx context.Context, bcid cid.Cid) error                   `perm:"admin"`
 		SyncerTracker            func(ctx context.Context) *types.TargetTracker                  `perm:"read"`
 	}
 }
@@ -778,40 +920,60 @@ func (s *ISyncerStruct) Concurrent(p0 context.Context) int64 { return s.Internal
 func (s *ISyncerStruct) SetConcurrent(p0 context.Context, p1 int64) error {
 	return s.Internal.SetConcurrent(p0, p1)
 }
+func (s *ISyncerStruct) SyncCheckBad(p0 context.Context, p1 cid.Cid) (string, error) {
+	return s.Internal.SyncCheckBad(p0, p1)
+}
 func (s *ISyncerStruct) SyncCheckpoint(p0 context.Context, p1 types.TipSetKey) error {
 	return s.Internal.SyncCheckpoint(p0, p1)
 }
 func (s *ISyncerStruct) SyncIncomingBlocks(p0 context.Context) (<-chan *types.BlockHeader, error) {
 	return s.Internal.SyncIncomingBlocks(p0)
 }
+func (s *ISyncerStruct) SyncMarkBad(p0 context.Context, p1 cid.Cid) error {
+	return s.Internal.SyncMarkBad(p0, p1)
+}
 func (s *ISyncerStruct) SyncState(p0 context.Context) (*types.SyncState, error) {
 	return s.Internal.SyncState(p0)
 }
 func (s *ISyncerStruct) SyncSubmitBlock(p0 context.Context, p1 *types.BlockMsg) error {
 	return s.Internal.SyncSubmitBlock(p0, p1)
 }
+func (s *ISyncerStruct) SyncUnmarkAllBad(p0 context.Context) error {
+	return s.Internal.SyncUnmarkAllBad(p0)
+}
+func (s *ISyncerStruct) SyncUnmarkBad(p0 context.Context, p1 cid.Cid) error {
+	return s.Internal.SyncUnmarkBad(p0, p1)
+}
 func (s *ISyncerStruct) SyncerTracker(p0 context.Context) *types.TargetTracker {
 	return s.Internal.SyncerTracker(p0)
 }
 
 type IWalletStruct struct {
 	Internal struct {
-		HasPassword          func(ctx context.Context) bool                                                                          `perm:"admin"`
-		LockWallet           func(ctx context.Context) error                                                                         `perm:"admin"`
-		SetPassword          func(ctx context.Context, password []byte) error                                                        `perm:"admin"`
-		UnLockWallet         func(ctx context.Context, password []byte) error                                                        `perm:"admin"`
-		WalletAddresses      func(ctx context.Context) []address.Address                                                             `perm:"admin"`
-		WalletBalance        func(ctx context.Context, addr address.Address) (abi.TokenAmount, error)                                `perm:"read"`
-		WalletDefaultAddress func(ctx context.Context) (address.Address, error)                                                      `perm:"write"`
-		WalletDelete         func(ctx context.Context, addr address.Address) error                                                   `perm:"admin"`
-		WalletExport         func(ctx context.Context, addr address.Address, password string) (*types.KeyInfo, error)                `perm:"admin"`
-		WalletHas            func(ctx context.Context, addr address.Address) (bool, error)                                           `perm:"write"`
-		WalletImport         func(ctx context.Context, key *types.KeyInfo) (address.Address, error)                                  `perm:"admin"`
-		WalletNewAddress     func(ctx context.Context, protocol address.Protocol) (address.Address, error)                           `perm:"write"`
-		WalletSetDefault     func(ctx context.Context, addr address.Address) error                                                   `perm:"write"`
-		WalletSign           func(ctx context.Context, k address.Address, msg []byte, meta types.MsgMeta) (*crypto.Signature, error) `perm:"sign"`
-		WalletSignMessage    func(ctx context.Context, k address.Address, msg *types.Message) (*types.SignedMessage, error)          `perm:"sign"`
-		WalletState          func(ctx context.Context) int                                                                           `perm:"admin"`
+		HasPassword            func(ctx context.Context) bool                                                                          `perm:"admin"`
+		LockWallet             func(ctx context.Context) error                                                                         `perm:"admin"`
+		SetPassword            func(ctx context.Context, password []byte) error                                                        `perm:"admin"`
+		UnLockWallet           func(ctx context.Context, password []byte) error                                                        `perm:"admin"`
+		WalletAddresses        func(ctx context.Context) []address.Address                                                             `perm:"admin"`
+		WalletBalance          func(ctx context.Context, addr address.Address) (abi.TokenAmount, error)                                `perm:"read"`
+		WalletDefaultAddress   func(ctx context.Context) (address.Address, error)                                                      `perm:"write"`
+		WalletDelete           func(ctx context.Context, addr address.Address) error                                                   `perm:"admin"`
+		WalletDeriveHDAddress  func(ctx context.Context, protocol address.Protocol, index uint32) (*types.WalletHDAddress, error)      `perm:"admin"`
+		WalletExport           func(ctx context.Context, addr address.Address, password string) (*types.KeyInfo, error)                `perm:"admin"`
+		WalletHas              func(ctx context.Context, addr address.Address) (bool, error)                                           `perm:"write"`
+		WalletImport           func(ctx context.Context, key *types.KeyInfo) (address.Address, error)                                  `perm:"admin"`
+		WalletImportMnemonic   func(ctx context.Context, mnemonic string, protocol address.Protocol) (*types.WalletHDAddress, error)   `perm:"admin"`
+		WalletNewAddress       func(ctx context.Context, protocol address.Protocol) (address.Address, error)                           `perm:"write"`
+		WalletNewHDAddress     func(ctx context.Context, protocol address.Protocol) (*types.WalletHDAddress, error)                    `perm:"admin"`
+		WalletPolicyGet        func(ctx context.Context, addr address.Address) (*types.WalletPolicy, error)                            `perm:"admin"`
+		WalletPolicyList       func(ctx context.Context) ([]*types.WalletPolicy, error)                                                `perm:"admin"`
+		WalletPolicySet        func(ctx context.Context, policy *types.WalletPolicy) error                                             `perm:"admin"`
+		WalletSetDefault       func(ctx context.Context, addr address.Address) error                                                   `perm:"write"`
+		WalletSign             func(ctx context.Context, k address.Address, msg []byte, meta types.MsgMeta) (*crypto.Signature, error) `perm:"sign"`
+		WalletSignMessage      func(ctx context.Context, k address.Address, msg *types.Message) (*types.SignedMessage, error)          `perm:"sign"`
+		WalletSignMessageBytes func(ctx context.Context, k address.Address, msg []byte) (*crypto.Signature, error)                     `perm:"sign"`
+		WalletState            func(ctx context.Context) int                                                                           `perm:"admin"`
+		WalletVerify           func(ctx context.Context, k address.Address, msg []byte, sig *crypto.Signature) (bool, error)           `perm:"read"`
 	}
 }
 
@@ -835,6 +997,9 @@ func (s *IWalletStruct) WalletDefaultAddress(p0 context.Context) (address.Addres
 func (s *IWalletStruct) WalletDelete(p0 context.Context, p1 address.Address) error {
 	return s.Internal.WalletDelete(p0, p1)
 }
+func (s *IWalletStruct) WalletDeriveHDAddress(p0 context.Context, p1 address.Protocol, p2 uint32) (*types.WalletHDAddress, error) {
+	return s.Internal.WalletDeriveHDAddress(p0, p1, p2)
+}
 func (s *IWalletStruct) WalletExport(p0 context.Context, p1 address.Address, p2 string) (*types.KeyInfo, error) {
 	return s.Internal.WalletExport(p0, p1, p2)
 }
@@ -844,9 +1009,24 @@ func (s *IWalletStruct) WalletHas(p0 context.Context, p1 address.Address) (bool,
 func (s *IWalletStruct) WalletImport(p0 context.Context, p1 *types.KeyInfo) (address.Address, error) {
 	return s.Internal.WalletImport(p0, p1)
 }
+func (s *IWalletStruct) WalletImportMnemonic(p0 context.Context, p1 string, p2 address.Protocol) (*types.WalletHDAddress, error) {
+	return s.Internal.WalletImportMnemonic(p0, p1, p2)
+}
 func (s *IWalletStruct) WalletNewAddress(p0 context.Context, p1 address.Protocol) (address.Address, error) {
 	return s.Internal.WalletNewAddress(p0, p1)
 }
+func (s *IWalletStruct) WalletNewHDAddress(p0 context.Context, p1 address.Protocol) (*types.WalletHDAddress, error) {
+	return s.Internal.WalletNewHDAddress(p0, p1)
+}
+func (s *IWalletStruct) WalletPolicyGet(p0 context.Context, p1 address.Address) (*types.WalletPolicy, error) {
+	return s.Internal.WalletPolicyGet(p0, p1)
+}
+func (s *IWalletStruct) WalletPolicyList(p0 context.Context) ([]*types.WalletPolicy, error) {
+	return s.Internal.WalletPolicyList(p0)
+}
+func (s *IWalletStruct) WalletPolicySet(p0 context.Context, p1 *types.WalletPolicy) error {
+	return s.Internal.WalletPolicySet(p0, p1)
+}
 func (s *IWalletStruct) WalletSetDefault(p0 context.Context, p1 address.Address) error {
 	return s.Internal.WalletSetDefault(p0, p1)
 }
@@ -856,7 +1036,13 @@ func (s *IWalletStruct) WalletSign(p0 context.Context, p1 address.Address, p2 []
 func (s *IWalletStruct) WalletSignMessage(p0 context.Context, p1 address.Address, p2 *types.Message) (*types.SignedMessage, error) {
 	return s.Internal.WalletSignMessage(p0, p1, p2)
 }
+func (s *IWalletStruct) WalletSignMessageBytes(p0 context.Context, p1 address.Address, p2 []byte) (*crypto.Signature, error) {
+	return s.Internal.WalletSignMessageBytes(p0, p1, p2)
+}
 func (s *IWalletStruct) WalletState(p0 context.Context) int { return s.Internal.WalletState(p0) }
+func (s *IWalletStruct) WalletVerify(p0 context.Context, p1 address.Address, p2 []byte, p3 *crypto.Signature) (bool, error) {
+	return s.Internal.WalletVerify(p0, p1, p2, p3)
+}
 
 type ICommonStruct struct {
 	Internal struct {
@@ -878,44 +1064,54 @@ func (s *ICommonStruct) Version(p0 context.Context) (types.Version, error) {
 
 type IETHStruct struct {
 	Internal struct {
-		EthAccounts                            func(ctx context.Context) ([]types.EthAddress, error)                                                                                     `perm:"read"`
-		EthAddressToFilecoinAddress            func(ctx context.Context, ethAddress types.EthAddress) (address.Address, error)                                                           `perm:"read"`
-		EthBlockNumber                         func(ctx context.Context) (types.EthUint64, error)                                                                                        `perm:"read"`
-		EthCall                                func(ctx context.Context, tx types.EthCall, blkParam types.EthBlockNumberOrHash) (types.EthBytes, error)                                  `perm:"read"`
-		EthChainId                             func(ctx context.Context) (types.EthUint64, error)                                                                                        `perm:"read"`
-		EthEstimateGas                         func(ctx context.Context, p jsonrpc.RawParams) (types.EthUint64, error)                                                                   `perm:"read"`
-		EthFeeHistory                          func(ctx context.Context, p jsonrpc.RawParams) (types.EthFeeHistory, error)                                                               `perm:"read"`
-		EthGasPrice                            func(ctx context.Context) (types.EthBigInt, error)                                                                                        `perm:"read"`
-		EthGetBalance                          func(ctx context.Context, address types.EthAddress, blkParam types.EthBlockNumberOrHash) (types.EthBigInt, error)                         `perm:"read"`
-		EthGetBlockByHash                      func(ctx context.Context, blkHash types.EthHash, fullTxInfo bool) (types.EthBlock, error)                                                 `perm:"read"`
-		EthGetBlockByNumber                    func(ctx context.Context, blkNum string, fullTxInfo bool) (types.EthBlock, error)                                                         `perm:"read"`
-		EthGetBlockReceipts                    func(ctx context.Context, blkParam types.EthBlockNumberOrHash) ([]*types.EthTxReceipt, error)                                             `perm:"read"`
-		EthGetBlockReceiptsLimited             func(ctx context.Context, blkParam types.EthBlockNumberOrHash, limit abi.ChainEpoch) ([]*types.EthTxReceipt, error)                       `perm:"read"`
-		EthGetBlockTransactionCountByHash      func(ctx context.Context, blkHash types.EthHash) (types.EthUint64, error)                                                                 `perm:"read"`
-		EthGetBlockTransactionCountByNumber    func(ctx context.Context, blkNum types.EthUint64) (types.EthUint64, error)                                                                `perm:"read"`
-		EthGetCode                             func(ctx context.Context, address types.EthAddress, blkParam types.EthBlockNumberOrHash) (types.EthBytes, error)                          `perm:"read"`
-		EthGetMessageCidByTransactionHash      func(ctx context.Context, txHash *types.EthHash) (*cid.Cid, error)                                                                        `perm:"read"`
-		EthGetStorageAt                        func(ctx context.Context, address types.EthAddress, position types.EthBytes, blkParam types.EthBlockNumberOrHash) (types.EthBytes, error) `perm:"read"`
-		EthGetTransactionByBlockHashAndIndex   func(ctx context.Context, blkHash types.EthHash, txIndex types.EthUint64) (types.EthTx, error)                                            `perm:"read"`
-		EthGetTransactionByBlockNumberAndIndex func(ctx context.Context, blkNum types.EthUint64, txIndex types.EthUint64) (types.EthTx, error)                                           `perm:"read"`
-		EthGetTransactionByHash                func(ctx context.Context, txHash *types.EthHash) (*types.EthTx, error)                                                                    `perm:"read"`
-		EthGetTransactionByHashLimited         func(ctx context.Context, txHash *types.EthHash, limit abi.ChainEpoch) (*types.EthTx, error)                                              `perm:"read"`
-		EthGetTransactionCount                 func(ctx context.Context, sender types.EthAddress, blkParam types.EthBlockNumberOrHash) (types.EthUint64, error)                          `perm:"read"`
-		EthGetTransactionHashByCid             func(ctx context.Context, cid cid.Cid) (*types.EthHash, error)                                                                            `perm:"read"`
-		EthGetTransactionReceipt               func(ctx context.Context, txHash types.EthHash) (*types.EthTxReceipt, error)                                                              `perm:"read"`
-		EthGetTransactionReceiptLimited        func(ctx context.Context, txHash types.EthHash, limit abi.ChainEpoch) (*types.EthTxReceipt, error)                                        `perm:"read"`
-		EthMaxPriorityFeePerGas                func(ctx context.Context) (types.EthBigInt, error)                                                                                        `perm:"read"`
-		EthProtocolVersion                     func(ctx context.Context) (types.EthUint64, error)                                                                                        `perm:"read"`
-		EthSendRawTransaction                  func(ctx context.Context, rawTx types.EthBytes) (types.EthHash, error)                                                                    `perm:"read"`
-		EthSyncing                             func(ctx context.Context) (types.EthSyncingResult, error)                                                                                 `perm:"read"`
-		EthTraceBlock                          func(ctx context.Context, blkNum string) ([]*types.EthTraceBlock, error)                                                                  `perm:"read"`
-		EthTraceFilter                         func(ctx context.Context, filter types.EthTraceFilterCriteria) ([]*types.EthTraceFilterResult, error)                                     `perm:"read"`
-		EthTraceReplayBlockTransactions        func(ctx context.Context, blkNum string, traceTypes []string) ([]*types.EthTraceReplayBlockTransaction, error)                            `perm:"read"`
-		EthTraceTransaction                    func(ctx context.Context, txHash string) ([]*types.EthTraceTransaction, error)                                                            `perm:"read"`
-		FilecoinAddressToEthAddress            func(ctx context.Context, filecoinAddress address.Address) (types.EthAddress, error)                                                      `perm:"read"`
-		NetListening                           func(ctx context.Context) (bool, error)                                                                                                   `perm:"read"`
-		NetVersion                             func(ctx context.Context) (string, error)                                                                                                 `perm:"read"`
-		Web3ClientVersion                      func(ctx context.Context) (string, error)                                                                                                 `perm:"read"`
+		EthAccounts                            func(ctx context.Context) ([]types.EthAddress, error)                                                                                           `perm:"read"`
+		EthAddressToFilecoinAddress            func(ctx context.Context, ethAddress types.EthAddress) (address.Address, error)                                                                 `perm:"read"`
+		EthBlockNumber                         func(ctx context.Context) (types.EthUint64, error)                                                                                              `perm:"read"`
+		EthCall                                func(ctx context.Context, tx types.EthCall, blkParam types.EthBlockNumberOrHash) (types.EthBytes, error)                                        `perm:"read"`
+		EthChainId                             func(ctx context.Context) (types.EthUint64, error)                                                                                              `perm:"read"`
+		EthDebugTraceBlockByNumber             func(ctx context.Context, p jsonrpc.RawParams) ([]*types.EthDebugTraceBlockResult, error)                                                       `perm:"read"`
+		EthDebugTraceCall                      func(ctx context.Context, p jsonrpc.RawParams) (*types.EthDebugTrace, error)                                                                    `perm:"read"`
+		EthDebugTraceTransaction               func(ctx context.Context, p jsonrpc.RawParams) (*types.EthDebugTrace, error)                                                                    `perm:"read"`
+		EthEstimateGas                         func(ctx context.Context, p jsonrpc.RawParams) (types.EthUint64, error)                                                                         `perm:"read"`
+		EthFeeHistory                          func(ctx context.Context, p jsonrpc.RawParams) (types.EthFeeHistory, error)                                                                     `perm:"read"`
+		EthGasPrice                            func(ctx context.Context) (types.EthBigInt, error)                                                                                              `perm:"read"`
+		EthGetBalance                          func(ctx context.Context, address types.EthAddress, blkParam types.EthBlockNumberOrHash) (types.EthBigInt, error)                               `perm:"read"`
+		EthGetBlockByHash                      func(ctx context.Context, blkHash types.EthHash, fullTxInfo bool) (types.EthBlock, error)                                                       `perm:"read"`
+		EthGetBlockByNumber                    func(ctx context.Context, blkNum string, fullTxInfo bool) (types.EthBlock, error)                                                               `perm:"read"`
+		EthGetBlockReceipts                    func(ctx context.Context, blkParam types.EthBlockNumberOrHash) ([]*types.EthTxReceipt, error)                                                   `perm:"read"`
+		EthGetBlockReceiptsLimited             func(ctx context.Context, blkParam types.EthBlockNumberOrHash, limit abi.ChainEpoch) ([]*types.EthTxReceipt, error)                             `perm:"read"`
+		EthGetBlockTransactionCountByHash      func(ctx context.Context, blkHash types.EthHash) (types.EthUint64, error)                                                                       `perm:"read"`
+		EthGetBlockTransactionCountByNumber    func(ctx context.Context, blkNum types.EthUint64) (types.EthUint64, error)                                                                      `perm:"read"`
+		EthGetCode                             func(ctx context.Context, address types.EthAddress, blkParam types.EthBlockNumberOrHash) (types.EthBytes, error)                                `perm:"read"`
+		EthGetMessageCidByTransactionHash      func(ctx context.Context, txHash *types.EthHash) (*cid.Cid, error)                                                                              `perm:"read"`
+		EthGetProof                            func(ctx context.Context, address types.EthAddress, storageKeys []types.EthBytes, blkParam types.EthBlockNumberOrHash) (*types.EthProof, error) `perm:"read"`
+		EthGetStorageAt                        func(ctx context.Context, address types.EthAddress, position types.EthBytes, blkParam types.EthBlockNumberOrHash) (types.EthBytes, error)       `perm:"read"`
+		EthGetTransactionByBlockHashAndIndex   func(ctx context.Context, blkHash types.EthHash, txIndex types.EthUint64) (types.EthTx, error)                                                  `perm:"read"`
+		EthGetTransactionByBlockNumberAndIndex func(ctx context.Context, blkNum types.EthUint64, txIndex types.EthUint64) (types.EthTx, error)                                                 `perm:"read"`
+		EthGetTransactionByHash                func(ctx context.Context, txHash *types.EthHash) (*types.EthTx, error)                                                                          `perm:"read"`
+		EthGetTransactionByHashLimited         func(ctx context.Context, txHash *types.EthHash, limit abi.ChainEpoch) (*types.EthTx, error)                                                    `perm:"read"`
+		EthGetTransactionCount                 func(ctx context.Context, sender types.EthAddress, blkParam types.EthBlockNumberOrHash) (types.EthUint64, error)                                `perm:"read"`
+		EthGetTransactionHashByCid             func(ctx context.Context, cid cid.Cid) (*types.EthHash, error)                                                                                  `perm:"read"`
+		EthGetTransactionReceipt               func(ctx context.Context, txHash types.EthHash) (*types.EthTxReceipt, error)                                                                    `perm:"read"`
+		EthGetTransactionReceiptLimited        func(ctx context.Context, txHash types.EthHash, limit abi.ChainEpoch) (*types.EthTxReceipt, error)                                              `perm:"read"`
+		EthMaxPriorityFeePerGas                func(ctx context.Context) (types.EthBigInt, error)                                                                                              `perm:"read"`
+		EthProtocolVersion                     func(ctx context.Context) (types.EthUint64, error)                                                                                              `perm:"read"`
+		EthSendRawTransaction                  func(ctx context.Context, rawTx types.EthBytes) (types.EthHash, error)                                                                          `perm:"read"`
+		EthSimulateV1                          func(ctx context.Context, p jsonrpc.RawParams) ([]types.EthSimulatedBlock, error)                                                               `perm:"read"`
+		EthSyncing                             func(ctx context.Context) (types.EthSyncingResult, error)                                                                                       `perm:"read"`
+		EthTraceBlock                          func(ctx context.Context, blkNum string) ([]*types.EthTraceBlock, error)                                                                        `perm:"read"`
+		EthTraceCall                           func(ctx context.Context, p jsonrpc.RawParams) (*types.EthTraceResults, error)                                                                  `perm:"read"`
+		EthTraceFilter                         func(ctx context.Context, filter types.EthTraceFilterCriteria) ([]*types.EthTraceFilterResult, error)                                           `perm:"read"`
+		EthTraceReplayBlockTransactions        func(ctx context.Context, blkNum string, traceTypes []string) ([]*types.EthTraceReplayBlockTransaction, error)                                  `perm:"read"`
+		EthTraceTransaction                    func(ctx context.Context, txHash string) ([]*types.EthTraceTransaction, error)                                                                  `perm:"read"`
+		EthTxPoolContent                       func(ctx context.Context) (*types.EthTxPoolContent, error)                                                                                      `perm:"read"`
+		EthTxPoolContentFrom                   func(ctx context.Context, address types.EthAddress) (*types.EthTxPoolContentFrom, error)                                                        `perm:"read"`
+		EthTxPoolInspect                       func(ctx context.Context) (*types.EthTxPoolInspect, error)                                                                                      `perm:"read"`
+		EthTxPoolStatus                        func(ctx context.Context) (types.EthTxPoolStatus, error)                                                                                        `perm:"read"`
+		FilecoinAddressToEthAddress            func(ctx context.Context, filecoinAddress address.Address) (types.EthAddress, error)                                                            `perm:"read"`
+		NetListening                           func(ctx context.Context) (bool, error)                                                                                                         `perm:"read"`
+		NetVersion                             func(ctx context.Context) (string, error)                                                                                                       `perm:"read"`
+		Web3ClientVersion                      func(ctx context.Context) (string, error)                                                                                                       `perm:"read"`
 	}
 }
 
@@ -934,6 +1130,15 @@ func (s *IETHStruct) EthCall(p0 context.Context, p1 types.EthCall, p2 types.EthB
 func (s *IETHStruct) EthChainId(p0 context.Context) (types.EthUint64, error) {
 	return s.Internal.EthChainId(p0)
 }
+func (s *IETHStruct) EthDebugTraceBlockByNumber(p0 context.Context, p1 jsonrpc.RawParams) ([]*types.EthDebugTraceBlockResult, error) {
+	return s.Internal.EthDebugTraceBlockByNumber(p0, p1)
+}
+func (s *IETHStruct) EthDebugTraceCall(p0 context.Context, p1 jsonrpc.RawParams) (*types.EthDebugTrace, error) {
+	return s.Internal.EthDebugTraceCall(p0, p1)
+}
+func (s *IETHStruct) EthDebugTraceTransaction(p0 context.Context, p1 jsonrpc.RawParams) (*types.EthDebugTrace, error) {
+	return s.Internal.EthDebugTraceTransaction(p0, p1)
+}
 func (s *IETHStruct) EthEstimateGas(p0 context.Context, p1 jsonrpc.RawParams) (types.EthUint64, error) {
 	return s.Internal.EthEstimateGas(p0, p1)
 }
@@ -970,6 +1175,9 @@ func (s *IETHStruct) EthGetCode(p0 context.Context, p1 types.EthAddress, p2 type
 func (s *IETHStruct) EthGetMessageCidByTransactionHash(p0 context.Context, p1 *types.EthHash) (*cid.Cid, error) {
 	return s.Internal.EthGetMessageCidByTransactionHash(p0, p1)
 }
+func (s *IETHStruct) EthGetProof(p0 context.Context, p1 types.EthAddress, p2 []types.EthBytes, p3 types.EthBlockNumberOrHash) (*types.EthProof, error) {
+	return s.Internal.EthGetProof(p0, p1, p2, p3)
+}
 func (s *IETHStruct) EthGetStorageAt(p0 context.Context, p1 types.EthAddress, p2 types.EthBytes, p3 types.EthBlockNumberOrHash) (types.EthBytes, error) {
 	return s.Internal.EthGetStorageAt(p0, p1, p2, p3)
 }
@@ -1006,12 +1214,18 @@ func (s *IETHStruct) EthProtocolVersion(p0 context.Context) (types.EthUint64, er
 func (s *IETHStruct) EthSendRawTransaction(p0 context.Context, p1 types.EthBytes) (types.EthHash, error) {
 	return s.Internal.EthSendRawTransaction(p0, p1)
 }
+func (s *IETHStruct) EthSimulateV1(p0 context.Context, p1 jsonrpc.RawParams) ([]types.EthSimulatedBlock, error) {
+	return s.Internal.EthSimulateV1(p0, p1)
+}
 func (s *IETHStruct) EthSyncing(p0 context.Context) (types.EthSyncingResult, error) {
 	return s.Internal.EthSyncing(p0)
 }
 func (s *IETHStruct) EthTraceBlock(p0 context.Context, p1 string) ([]*types.EthTraceBlock, error) {
 	return s.Internal.EthTraceBlock(p0, p1)
 }
+func (s *IETHStruct) EthTraceCall(p0 context.Context, p1 jsonrpc.RawParams) (*types.EthTraceResults, error) {
+	return s.Internal.EthTraceCall(p0, p1)
+}
 func (s *IETHStruct) EthTraceFilter(p0 context.Context, p1 types.EthTraceFilterCriteria) ([]*types.EthTraceFilterResult, error) {
 	return s.Internal.EthTraceFilter(p0, p1)
 }
@@ -1021,6 +1235,18 @@ func (s *IETHStruct) EthTraceReplayBlockTransactions(p0 context.Context, p1 stri
 func (s *IETHStruct) EthTraceTransaction(p0 context.Context, p1 string) ([]*types.EthTraceTransaction, error) {
 	return s.Internal.EthTraceTransaction(p0, p1)
 }
+func (s *IETHStruct) EthTxPoolContent(p0 context.Context) (*types.EthTxPoolContent, error) {
+	return s.Internal.EthTxPoolContent(p0)
+}
+func (s *IETHStruct) EthTxPoolContentFrom(p0 context.Context, p1 types.EthAddress) (*types.EthTxPoolContentFrom, error) {
+	return s.Internal.EthTxPoolContentFrom(p0, p1)
+}
+func (s *IETHStruct) EthTxPoolInspect(p0 context.Context) (*types.EthTxPoolInspect, error) {
+	return s.Internal.EthTxPoolInspect(p0)
+}
+func (s *IETHStruct) EthTxPoolStatus(p0 context.Context) (types.EthTxPoolStatus, error) {
+	return s.Internal.EthTxPoolStatus(p0)
+}
 func (s *IETHStruct) FilecoinAddressToEthAddress(p0 context.Context, p1 address.Address) (types.EthAddress, error) {
 	return s.Internal.FilecoinAddressToEthAddress(p0, p1)
 }
@@ -1081,8 +1307,9 @@ type FullETHStruct struct {
 
 type IActorEventStruct struct {
 	Internal struct {
-		GetActorEventsRaw       func(ctx context.Context, filter *types.ActorEventFilter) ([]*types.ActorEvent, error)      `perm:"read"`
-		SubscribeActorEventsRaw func(ctx context.Context, filter *types.ActorEventFilter) (<-chan *types.ActorEvent, error) `perm:"read"`
+		GetActorEventsRaw             func(ctx context.Context, filter *types.ActorEventFilter) ([]*types.ActorEvent, error)                                                  `perm:"read"`
+		SubscribeActorEventsRaw       func(ctx context.Context, filter *types.ActorEventFilter) (<-chan *types.ActorEvent, error)                                             `perm:"read"`
+		SubscribeActorEventsResumable func(ctx context.Context, filter *types.ActorEventFilter, cursor *types.ActorEventCursor) (<-chan *types.ActorEventNotification, error) `perm:"read"`
 	}
 }
 
@@ -1092,6 +1319,9 @@ func (s *IActorEventStruct) GetActorEventsRaw(p0 context.Context, p1 *types.Acto
 func (s *IActorEventStruct) SubscribeActorEventsRaw(p0 context.Context, p1 *types.ActorEventFilter) (<-chan *types.ActorEvent, error) {
 	return s.Internal.SubscribeActorEventsRaw(p0, p1)
 }
+func (s *IActorEventStruct) SubscribeActorEventsResumable(p0 context.Context, p1 *types.ActorEventFilter, p2 *types.ActorEventCursor) (<-chan *types.ActorEventNotification, error) {
+	return s.Internal.SubscribeActorEventsResumable(p0, p1, p2)
+}
 
 type IF3Struct struct {
 	Internal struct {
@@ -1137,12 +1367,31 @@ func (s *IF3Struct) F3Participate(p0 context.Context, p1 types.F3ParticipationTi
 	return s.Internal.F3Participate(p0, p1)
 }
 
+type IConfigStruct struct {
+	Internal struct {
+		ConfigDiff func(ctx context.Context) ([]types.ConfigChange, error)                                        `perm:"admin"`
+		ConfigGet  func(ctx context.Context, dottedPath string) (interface{}, error)                              `perm:"admin"`
+		ConfigSet  func(ctx context.Context, dottedPath string, paramJSON string) (*types.ConfigSetResult, error) `perm:"admin"`
+	}
+}
+
+func (s *IConfigStruct) ConfigDiff(p0 context.Context) ([]types.ConfigChange, error) {
+	return s.Internal.ConfigDiff(p0)
+}
+func (s *IConfigStruct) ConfigGet(p0 context.Context, p1 string) (interface{}, error) {
+	return s.Internal.ConfigGet(p0, p1)
+}
+func (s *IConfigStruct) ConfigSet(p0 context.Context, p1 string, p2 string) (*types.ConfigSetResult, error) {
+	return s.Internal.ConfigSet(p0, p1, p2)
+}
+
 type FullNodeStruct struct {
 	IBlockStoreStruct
 	IChainStruct
 	IMarketStruct
 	IMiningStruct
 	IMessagePoolStruct
+	IMultiSigStruct
 	INetworkStruct
 	IPaychanStruct
 	ISyncerStruct
@@ -1151,4 +1400,5 @@ type FullNodeStruct struct {
 	FullETHStruct
 	IActorEventStruct
 	IF3Struct
+	IConfigStruct
 }
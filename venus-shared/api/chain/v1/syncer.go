@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/filecoin-project/go-state-types/big"
+	"github.com/ipfs/go-cid"
 
 	"github.com/filecoin-project/venus/venus-shared/types"
 )
@@ -21,4 +22,14 @@ type ISyncer interface {
 	SyncIncomingBlocks(ctx context.Context) (<-chan *types.BlockHeader, error) //perm:read
 	// SyncCheckpoint marks a blocks as checkpointed, meaning that it won't ever fork away from it.
 	SyncCheckpoint(ctx context.Context, tsk types.TipSetKey) error //perm:admin
+	// SyncMarkBad marks a block as bad, meaning that it won't ever be synced, nor any chain including it.
+	// The mark is persisted until it is removed with SyncUnmarkBad or SyncUnmarkAllBad.
+	SyncMarkBad(ctx context.Context, bcid cid.Cid) error //perm:admin
+	// SyncUnmarkBad unmarks a block as bad, making it possible to be validated and synced again.
+	SyncUnmarkBad(ctx context.Context, bcid cid.Cid) error //perm:admin
+	// SyncUnmarkAllBad purges the bad block cache, making it possible to sync to chains previously marked as bad.
+	SyncUnmarkAllBad(ctx context.Context) error //perm:admin
+	// SyncCheckBad checks if a block was marked as bad, and if it was, returns the reason, including the
+	// offending ancestor when the block descends from a bad block.
+	SyncCheckBad(ctx context.Context, bcid cid.Cid) (string, error) //perm:read
 }
@@ -27,4 +27,24 @@ type IWallet interface {
 	SetPassword(ctx context.Context, password []byte) error                                                       //perm:admin
 	HasPassword(ctx context.Context) bool                                                                         //perm:admin
 	WalletState(ctx context.Context) int                                                                          //perm:admin
+	// WalletSignMessageBytes signs msg prefixed with types.SignedMessageBytes, so that the signature proves the
+	// ownership of the address but can't be used for anything else
+	WalletSignMessageBytes(ctx context.Context, k address.Address, msg []byte) (*crypto.Signature, error) //perm:sign
+	// WalletVerify returns whether sig is a signature of msg by the key of k
+	WalletVerify(ctx context.Context, k address.Address, msg []byte, sig *crypto.Signature) (bool, error) //perm:read
+	// WalletNewHDAddress derives the next address of protocol from the seed of the HD wallet, a seed is
+	// created first if the wallet has none and its mnemonic is returned along
+	WalletNewHDAddress(ctx context.Context, protocol address.Protocol) (*types.WalletHDAddress, error) //perm:admin
+	// WalletImportMnemonic sets the seed of the HD wallet from mnemonic and derives the first address of protocol
+	WalletImportMnemonic(ctx context.Context, mnemonic string, protocol address.Protocol) (*types.WalletHDAddress, error) //perm:admin
+	// WalletDeriveHDAddress derives the index-th address of protocol from the seed of the HD wallet
+	WalletDeriveHDAddress(ctx context.Context, protocol address.Protocol, index uint32) (*types.WalletHDAddress, error) //perm:admin
+	// WalletPolicySet sets the signing policy of an address, replacing the previous one. The policy covers
+	// everything the node signs with the address: the wallet API, the message pool, the block signer and
+	// the wallet gateway.
+	WalletPolicySet(ctx context.Context, policy *types.WalletPolicy) error //perm:admin
+	// WalletPolicyGet returns the signing policy of an address
+	WalletPolicyGet(ctx context.Context, addr address.Address) (*types.WalletPolicy, error) //perm:admin
+	// WalletPolicyList returns the signing policies of all the addresses
+	WalletPolicyList(ctx context.Context) ([]*types.WalletPolicy, error) //perm:admin
 }
@@ -132,6 +132,7 @@ var (
 	_ blockstore.Blockstore = (*BadgerBlockstore)(nil)
 	_ blockstore.Viewer     = (*BadgerBlockstore)(nil)
 	_ io.Closer             = (*BadgerBlockstore)(nil)
+	_ BlockstoreGC          = (*BadgerBlockstore)(nil)
 )
 
 // Open creates a new badger-backed blockstore, with the supplied options.
@@ -453,9 +454,12 @@ func (b *BadgerBlockstore) AllKeysChan(ctx context.Context) (<-chan cid.Cid, err
 				// open iterators will run even after the database is closed...
 				return // closing, yield.
 			}
-			k := iter.Item().Key()
+			k := datastore.RawKey(string(iter.Item().Key()))
+			if b.keyTransform.Prefix.String() != "/" && !b.keyTransform.Prefix.IsAncestorOf(k) {
+				continue
+			}
 			// need to convert to key.Key using key.KeyFromDsKey.
-			bk, err := dshelp.BinaryFromDsKey(datastore.RawKey(string(k)))
+			bk, err := dshelp.BinaryFromDsKey(b.keyTransform.InvertKey(k))
 			if err != nil {
 				log.Warnf("error parsing key from binary: %s", err)
 				continue
@@ -472,6 +476,36 @@ func (b *BadgerBlockstore) AllKeysChan(ctx context.Context) (<-chan cid.Cid, err
 	return ch, nil
 }
 
+// CollectGarbage implements BlockstoreGC, it runs the badger value log GC
+// until there is nothing left to rewrite.
+func (b *BadgerBlockstore) CollectGarbage(options ...BlockstoreGCOption) error {
+	if atomic.LoadInt64(&b.state) != stateOpen {
+		return ErrBlockstoreClosed
+	}
+
+	var opts BlockstoreGCOptions
+	for _, opt := range options {
+		if err := opt(&opts); err != nil {
+			return err
+		}
+	}
+
+	// a full gc rewrites every value log file that has anything to reclaim.
+	threshold := 0.125
+	if opts.FullGC {
+		threshold = 0.01
+	}
+
+	var err error
+	for err == nil {
+		err = b.DB.RunValueLogGC(threshold)
+	}
+	if err == badger.ErrNoRewrite {
+		return nil
+	}
+	return err
+}
+
 // HashOnRead implements blockstore.HashOnRead. It is not supported by this
 // blockstore.
 func (b *BadgerBlockstore) HashOnRead(_ bool) {
@@ -0,0 +1,137 @@
+package blockstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	ipld "github.com/ipfs/go-ipld-format"
+)
+
+var ErrReadOnly = errors.New("readonly blockstore")
+
+// FetchFunc fetches a block missing from a blockstore from somewhere else
+type FetchFunc func(context.Context, cid.Cid) (blocks.Block, error)
+
+var _ Blockstore = (*FallbackStore)(nil)
+
+// FallbackStore reads the blocks missing from the wrapped blockstore, such as the state pruned by the
+// splitstore, from a fallback. The fetched blocks are kept in a bounded side store in memory rather than
+// written to the wrapped blockstore, Has only reports the blocks of the wrapped blockstore.
+type FallbackStore struct {
+	Blockstore
+
+	lk    sync.RWMutex
+	fetch FetchFunc
+	side  *lru.Cache[cid.Cid, blocks.Block]
+}
+
+// NewFallbackStore wraps bs, keeping up to cacheSize fetched blocks. It reads from bs only until a fallback
+// is set.
+func NewFallbackStore(bs Blockstore, cacheSize int) (*FallbackStore, error) {
+	side, err := lru.New[cid.Cid, blocks.Block](cacheSize)
+	if err != nil {
+		return nil, err
+	}
+	return &FallbackStore{Blockstore: bs, side: side}, nil
+}
+
+// SetFallback sets the function fetching the missing blocks
+func (fbs *FallbackStore) SetFallback(fetch FetchFunc) {
+	fbs.lk.Lock()
+	defer fbs.lk.Unlock()
+	fbs.fetch = fetch
+}
+
+func (fbs *FallbackStore) getFallback(ctx context.Context, c cid.Cid) (blocks.Block, error) {
+	if blk, ok := fbs.side.Get(c); ok {
+		return blk, nil
+	}
+
+	fbs.lk.RLock()
+	fetch := fbs.fetch
+	fbs.lk.RUnlock()
+	if fetch == nil {
+		return nil, ipld.ErrNotFound{Cid: c}
+	}
+
+	blk, err := fetch(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("fetch block %s from fallback: %w", c, err)
+	}
+	// the fallback isn't trusted
+	sum, err := c.Prefix().Sum(blk.RawData())
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(sum.Hash(), c.Hash()) {
+		return nil, fmt.Errorf("block %s fetched from fallback doesn't match its cid", c)
+	}
+
+	fbs.side.Add(c, blk)
+	return blk, nil
+}
+
+func (fbs *FallbackStore) Get(ctx context.Context, c cid.Cid) (blocks.Block, error) {
+	blk, err := fbs.Blockstore.Get(ctx, c)
+	if ipld.IsNotFound(err) {
+		return fbs.getFallback(ctx, c)
+	}
+	return blk, err
+}
+
+func (fbs *FallbackStore) View(ctx context.Context, c cid.Cid, callback func([]byte) error) error {
+	err := fbs.Blockstore.View(ctx, c, callback)
+	if ipld.IsNotFound(err) {
+		blk, err := fbs.getFallback(ctx, c)
+		if err != nil {
+			return err
+		}
+		return callback(blk.RawData())
+	}
+	return err
+}
+
+func (fbs *FallbackStore) GetSize(ctx context.Context, c cid.Cid) (int, error) {
+	size, err := fbs.Blockstore.GetSize(ctx, c)
+	if ipld.IsNotFound(err) {
+		blk, err := fbs.getFallback(ctx, c)
+		if err != nil {
+			return 0, err
+		}
+		return len(blk.RawData()), nil
+	}
+	return size, err
+}
+
+var _ Blockstore = (*readOnlyStore)(nil)
+
+type readOnlyStore struct {
+	Blockstore
+}
+
+// NewReadOnlyStore returns bs rejecting the writes, to serve it to untrusted clients
+func NewReadOnlyStore(bs Blockstore) Blockstore {
+	return &readOnlyStore{Blockstore: bs}
+}
+
+func (ro *readOnlyStore) Put(context.Context, blocks.Block) error {
+	return ErrReadOnly
+}
+
+func (ro *readOnlyStore) PutMany(context.Context, []blocks.Block) error {
+	return ErrReadOnly
+}
+
+func (ro *readOnlyStore) DeleteBlock(context.Context, cid.Cid) error {
+	return ErrReadOnly
+}
+
+func (ro *readOnlyStore) DeleteMany(context.Context, []cid.Cid) error {
+	return ErrReadOnly
+}
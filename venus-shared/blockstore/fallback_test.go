@@ -0,0 +1,91 @@
+package blockstore
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+	block "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/libp2p/go-msgio"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFallbackStore(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	cr, sw := io.Pipe()
+	sr, cw := io.Pipe()
+	cm := msgio.Combine(msgio.NewWriter(cw), msgio.NewReader(cr))
+	sm := msgio.Combine(msgio.NewWriter(sw), msgio.NewReader(sr))
+
+	archive := NewTemporarySync()
+	_ = HandleNetBstoreStream(ctx, NewReadOnlyStore(archive), sm)
+	upstream := NewNetworkStore(cm)
+
+	local := NewTemporarySync()
+	fbs, err := NewFallbackStore(local, 1)
+	require.NoError(t, err)
+
+	tb1 := block.NewBlock([]byte("aoeu"))
+	tb2 := block.NewBlock([]byte("snth"))
+	require.NoError(t, archive.PutMany(ctx, []block.Block{tb1, tb2}))
+
+	// nothing is fetched until the fallback is set
+	_, err = fbs.Get(ctx, tb1.Cid())
+	require.True(t, ipld.IsNotFound(err))
+
+	fetched := 0
+	fbs.SetFallback(func(ctx context.Context, c cid.Cid) (block.Block, error) {
+		fetched++
+		return upstream.Get(ctx, c)
+	})
+
+	b, err := fbs.Get(ctx, tb1.Cid())
+	require.NoError(t, err)
+	require.Equal(t, tb1.RawData(), b.RawData())
+	sz, err := fbs.GetSize(ctx, tb1.Cid())
+	require.NoError(t, err)
+	require.Equal(t, 4, sz)
+	require.Equal(t, 1, fetched)
+
+	// the fetched blocks are kept aside
+	h, err := fbs.Has(ctx, tb1.Cid())
+	require.NoError(t, err)
+	require.False(t, h)
+
+	// the side store is bounded
+	require.NoError(t, fbs.View(ctx, tb2.Cid(), func(data []byte) error {
+		require.Equal(t, tb2.RawData(), data)
+		return nil
+	}))
+	_, err = fbs.Get(ctx, tb1.Cid())
+	require.NoError(t, err)
+	require.Equal(t, 3, fetched)
+
+	// nothing fetched is written to the wrapped blockstore
+	keys, err := local.AllKeysChan(ctx)
+	require.NoError(t, err)
+	for k := range keys {
+		t.Fatalf("unexpected block %s in the local blockstore", k)
+	}
+
+	// the local blocks are read first
+	require.NoError(t, local.Put(ctx, tb2))
+	_, err = fbs.Get(ctx, tb2.Cid())
+	require.NoError(t, err)
+	require.Equal(t, 3, fetched)
+
+	// the served store is read-only and the fetched blocks are checked against their cid
+	require.ErrorContains(t, upstream.Put(ctx, block.NewBlock([]byte("fake"))), ErrReadOnly.Error())
+	fake, err := block.NewBlockWithCid([]byte("fake"), tb1.Cid())
+	require.NoError(t, err)
+	fbs.SetFallback(func(context.Context, cid.Cid) (block.Block, error) {
+		return fake, nil
+	})
+	_, err = fbs.Get(ctx, block.NewBlock([]byte("htns")).Cid())
+	require.ErrorContains(t, err, "doesn't match its cid")
+}
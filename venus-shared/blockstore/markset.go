@@ -0,0 +1,141 @@
+package blockstore
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/dgraph-io/badger/v2/options"
+	"github.com/ipfs/go-cid"
+)
+
+// markSetBatchSize is the number of marks kept in memory before they are written to disk.
+const markSetBatchSize = 16384
+
+// markSet is the set of objects marked live by a compaction. The reachable objects of a chain are
+// counted by tens of millions, the set is kept in a temporary badger database so that its memory
+// stays bounded by a batch of pending marks.
+type markSet struct {
+	path string
+	db   *badger.DB
+
+	lk      sync.RWMutex
+	pending map[string]struct{}
+}
+
+// openMarkSet creates an empty mark set in a new temporary directory under dir.
+func openMarkSet(dir string) (*markSet, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating mark set directory: %w", err)
+	}
+	path, err := os.MkdirTemp(dir, "markset-")
+	if err != nil {
+		return nil, fmt.Errorf("creating mark set directory: %w", err)
+	}
+
+	opts := badger.DefaultOptions(path)
+	opts.SyncWrites = false
+	opts.CompactL0OnClose = false
+	opts.Compression = options.None
+	opts.TableLoadingMode = options.FileIO
+	opts.ValueLogLoadingMode = options.FileIO
+	opts.Logger = nil
+	db, err := badger.Open(opts)
+	if err != nil {
+		_ = os.RemoveAll(path)
+		return nil, fmt.Errorf("opening mark set: %w", err)
+	}
+
+	return &markSet{
+		path:    path,
+		db:      db,
+		pending: make(map[string]struct{}, markSetBatchSize),
+	}, nil
+}
+
+// Mark adds c to the set.
+func (m *markSet) Mark(c cid.Cid) error {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+
+	m.pending[string(c.Hash())] = struct{}{}
+	if len(m.pending) < markSetBatchSize {
+		return nil
+	}
+	return m.flush()
+}
+
+// Has returns whether c was marked.
+func (m *markSet) Has(c cid.Cid) (bool, error) {
+	key := c.Hash()
+
+	m.lk.RLock()
+	_, ok := m.pending[string(key)]
+	m.lk.RUnlock()
+	if ok {
+		return true, nil
+	}
+
+	err := m.db.View(func(txn *badger.Txn) error {
+		_, err := txn.Get(key)
+		return err
+	})
+	switch err {
+	case nil:
+		return true, nil
+	case badger.ErrKeyNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("reading mark set: %w", err)
+	}
+}
+
+// Flush writes the pending marks to disk.
+func (m *markSet) Flush() error {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+	return m.flush()
+}
+
+func (m *markSet) flush() error {
+	if len(m.pending) == 0 {
+		return nil
+	}
+
+	batch := m.db.NewWriteBatch()
+	defer batch.Cancel()
+	for key := range m.pending {
+		if err := batch.Set([]byte(key), nil); err != nil {
+			return fmt.Errorf("writing mark set: %w", err)
+		}
+	}
+	if err := batch.Flush(); err != nil {
+		return fmt.Errorf("writing mark set: %w", err)
+	}
+
+	m.pending = make(map[string]struct{}, markSetBatchSize)
+	return nil
+}
+
+// Size returns the size of the set on disk, in bytes.
+func (m *markSet) Size() int64 {
+	var size int64
+	_ = filepath.Walk(m.path, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+// Close closes the set and removes it from disk.
+func (m *markSet) Close() error {
+	err := m.db.Close()
+	if rerr := os.RemoveAll(m.path); err == nil {
+		err = rerr
+	}
+	return err
+}
@@ -9,6 +9,9 @@ import (
 	"golang.org/x/xerrors"
 )
 
+// NetBstoreWSPath is where a venus node serves its blockstore over websocket
+const NetBstoreWSPath = "/rpc/streams/blockstore"
+
 type wsWrapper struct {
 	wc *websocket.Conn
 
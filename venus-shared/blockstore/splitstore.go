@@ -0,0 +1,416 @@
+package blockstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	ipld "github.com/ipfs/go-ipld-format"
+)
+
+// Compaction stages reported by SplitStore.Progress.
+const (
+	CompactionIdle     = "idle"
+	CompactionMarking  = "marking"
+	CompactionSweeping = "sweeping"
+	CompactionGC       = "gc"
+)
+
+// sweepBatchSize is the number of objects moved or deleted at once while sweeping.
+const sweepBatchSize = 16384
+
+// ErrCompactionInProgress is returned when a compaction is requested while another one is running.
+var ErrCompactionInProgress = fmt.Errorf("compaction already in progress")
+
+// MarkFunc walks every object that has to stay in the hot store and passes its cid to visit.
+type MarkFunc func(ctx context.Context, visit func(cid.Cid) error) error
+
+// CompactionProgress reports the state of the running (or last) compaction.
+type CompactionProgress struct {
+	Stage   string
+	Marked  int64
+	Scanned int64
+	Moved   int64
+	Removed int64
+	// MarkSetSize is the size on disk of the set of marked objects, in bytes.
+	MarkSetSize int64
+}
+
+// SplitStore is a blockstore made of a hot store, which receives all writes, and an
+// optional cold store that reads fall through to. Compact keeps the objects reachable
+// from the mark function in the hot store and moves the others to the cold store, or
+// deletes them when keepCold is false.
+//
+// Compaction runs online: objects written while a compaction is running are protected
+// and never swept by it.
+type SplitStore struct {
+	hot        Blockstore
+	cold       Blockstore
+	keepCold   bool
+	markSetDir string
+
+	compacting int32
+
+	markSetLk   sync.Mutex
+	markSet     *markSet
+	markSetSize int64
+
+	protectLk sync.Mutex
+	protect   map[string]struct{}
+
+	stage                           atomic.Value
+	marked, scanned, moved, removed int64
+}
+
+var (
+	_ Blockstore = (*SplitStore)(nil)
+	_ io.Closer  = (*SplitStore)(nil)
+)
+
+// NewSplitStore creates a splitstore on top of hot and cold, cold may be nil.
+// When keepCold is set objects swept out of the hot store are moved to cold.
+// The objects marked by a compaction are recorded on disk under markSetDir, the sets left there by
+// an interrupted compaction are removed.
+func NewSplitStore(hot, cold Blockstore, keepCold bool, markSetDir string) (*SplitStore, error) {
+	if keepCold && cold == nil {
+		return nil, fmt.Errorf("a cold store is required to keep cold objects")
+	}
+	if markSetDir == "" {
+		return nil, fmt.Errorf("a directory is required for the mark set")
+	}
+	if err := os.RemoveAll(markSetDir); err != nil {
+		return nil, fmt.Errorf("removing stale mark sets: %w", err)
+	}
+
+	s := &SplitStore{
+		hot:        hot,
+		cold:       cold,
+		keepCold:   keepCold,
+		markSetDir: markSetDir,
+	}
+	s.stage.Store(CompactionIdle)
+	return s, nil
+}
+
+// Hot returns the hot store.
+func (s *SplitStore) Hot() Blockstore {
+	return s.hot
+}
+
+// Cold returns the cold store, it is nil when the splitstore has none.
+func (s *SplitStore) Cold() Blockstore {
+	return s.cold
+}
+
+// Compacting returns whether a compaction is running.
+func (s *SplitStore) Compacting() bool {
+	return atomic.LoadInt32(&s.compacting) == 1
+}
+
+// Progress returns the progress of the running compaction, or the result of the last one.
+func (s *SplitStore) Progress() CompactionProgress {
+	s.markSetLk.Lock()
+	markSetSize := s.markSetSize
+	if s.markSet != nil {
+		markSetSize = s.markSet.Size()
+	}
+	s.markSetLk.Unlock()
+
+	return CompactionProgress{
+		Stage:       s.stage.Load().(string),
+		Marked:      atomic.LoadInt64(&s.marked),
+		Scanned:     atomic.LoadInt64(&s.scanned),
+		Moved:       atomic.LoadInt64(&s.moved),
+		Removed:     atomic.LoadInt64(&s.removed),
+		MarkSetSize: markSetSize,
+	}
+}
+
+// Compact marks the objects reachable through mark and sweeps every other object
+// out of the hot store. Only one compaction can run at a time.
+func (s *SplitStore) Compact(ctx context.Context, mark MarkFunc) error {
+	// the protection set is allocated under protectLk with the compacting flag, so that a
+	// writer seeing the flag always finds the set to record its object in.
+	s.protectLk.Lock()
+	if !atomic.CompareAndSwapInt32(&s.compacting, 0, 1) {
+		s.protectLk.Unlock()
+		return ErrCompactionInProgress
+	}
+	s.protect = make(map[string]struct{})
+	s.protectLk.Unlock()
+	defer func() {
+		s.protectLk.Lock()
+		s.protect = nil
+		atomic.StoreInt32(&s.compacting, 0)
+		s.protectLk.Unlock()
+	}()
+	defer s.stage.Store(CompactionIdle)
+
+	atomic.StoreInt64(&s.marked, 0)
+	atomic.StoreInt64(&s.scanned, 0)
+	atomic.StoreInt64(&s.moved, 0)
+	atomic.StoreInt64(&s.removed, 0)
+
+	marked, err := openMarkSet(s.markSetDir)
+	if err != nil {
+		return err
+	}
+	s.markSetLk.Lock()
+	s.markSet = marked
+	s.markSetLk.Unlock()
+	defer func() {
+		s.markSetLk.Lock()
+		s.markSetSize = marked.Size()
+		s.markSet = nil
+		s.markSetLk.Unlock()
+		if err := marked.Close(); err != nil {
+			log.Warnf("failed to remove the mark set: %s", err)
+		}
+	}()
+
+	s.stage.Store(CompactionMarking)
+	err = mark(ctx, func(c cid.Cid) error {
+		has, err := marked.Has(c)
+		if err != nil || has {
+			return err
+		}
+		atomic.AddInt64(&s.marked, 1)
+		return marked.Mark(c)
+	})
+	if err != nil {
+		return fmt.Errorf("marking live objects: %w", err)
+	}
+	if err := marked.Flush(); err != nil {
+		return err
+	}
+
+	s.stage.Store(CompactionSweeping)
+	keys, err := s.hot.AllKeysChan(ctx)
+	if err != nil {
+		return fmt.Errorf("listing hot objects: %w", err)
+	}
+
+	batch := make([]cid.Cid, 0, sweepBatchSize)
+	for c := range keys {
+		atomic.AddInt64(&s.scanned, 1)
+		has, err := marked.Has(c)
+		if err != nil {
+			return err
+		}
+		if has {
+			continue
+		}
+
+		batch = append(batch, c)
+		if len(batch) < sweepBatchSize {
+			continue
+		}
+		if err := s.sweep(ctx, batch); err != nil {
+			return err
+		}
+		batch = batch[:0]
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := s.sweep(ctx, batch); err != nil {
+		return err
+	}
+
+	s.stage.Store(CompactionGC)
+	if gc, ok := s.hot.(BlockstoreGC); ok {
+		if err := gc.CollectGarbage(); err != nil {
+			log.Warnf("hot store garbage collection failed: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// sweep moves or deletes a batch of unmarked hot objects, skipping the protected ones.
+func (s *SplitStore) sweep(ctx context.Context, batch []cid.Cid) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	if s.keepCold {
+		blks := make([]blocks.Block, 0, len(batch))
+		for _, c := range batch {
+			blk, err := s.hot.Get(ctx, c)
+			if err != nil {
+				if ipld.IsNotFound(err) {
+					continue
+				}
+				return fmt.Errorf("reading hot object %s: %w", c, err)
+			}
+			blks = append(blks, blk)
+		}
+		if err := s.cold.PutMany(ctx, blks); err != nil {
+			return fmt.Errorf("moving objects to the cold store: %w", err)
+		}
+		atomic.AddInt64(&s.moved, int64(len(blks)))
+	}
+
+	// holding protectLk keeps writers from re-adding an object between the protection
+	// check and its deletion.
+	s.protectLk.Lock()
+	defer s.protectLk.Unlock()
+
+	toDelete := make([]cid.Cid, 0, len(batch))
+	for _, c := range batch {
+		if _, ok := s.protect[string(c.Hash())]; !ok {
+			toDelete = append(toDelete, c)
+		}
+	}
+	if err := s.hot.DeleteMany(ctx, toDelete); err != nil {
+		return fmt.Errorf("deleting hot objects: %w", err)
+	}
+	atomic.AddInt64(&s.removed, int64(len(toDelete)))
+
+	return nil
+}
+
+// track protects c from the running compaction, if any.
+func (s *SplitStore) track(c cid.Cid) {
+	if atomic.LoadInt32(&s.compacting) == 0 {
+		return
+	}
+
+	s.protectLk.Lock()
+	if s.protect != nil {
+		s.protect[string(c.Hash())] = struct{}{}
+	}
+	s.protectLk.Unlock()
+}
+
+func (s *SplitStore) Has(ctx context.Context, c cid.Cid) (bool, error) {
+	has, err := s.hot.Has(ctx, c)
+	if err != nil || has || s.cold == nil {
+		return has, err
+	}
+	return s.cold.Has(ctx, c)
+}
+
+func (s *SplitStore) Get(ctx context.Context, c cid.Cid) (blocks.Block, error) {
+	blk, err := s.hot.Get(ctx, c)
+	if ipld.IsNotFound(err) && s.cold != nil {
+		return s.cold.Get(ctx, c)
+	}
+	return blk, err
+}
+
+func (s *SplitStore) GetSize(ctx context.Context, c cid.Cid) (int, error) {
+	size, err := s.hot.GetSize(ctx, c)
+	if ipld.IsNotFound(err) && s.cold != nil {
+		return s.cold.GetSize(ctx, c)
+	}
+	return size, err
+}
+
+func (s *SplitStore) View(ctx context.Context, c cid.Cid, callback func([]byte) error) error {
+	err := s.hot.View(ctx, c, callback)
+	if ipld.IsNotFound(err) && s.cold != nil {
+		return s.cold.View(ctx, c, callback)
+	}
+	return err
+}
+
+func (s *SplitStore) Put(ctx context.Context, blk blocks.Block) error {
+	s.track(blk.Cid())
+
+	return s.hot.Put(ctx, blk)
+}
+
+func (s *SplitStore) PutMany(ctx context.Context, blks []blocks.Block) error {
+	for _, blk := range blks {
+		s.track(blk.Cid())
+	}
+
+	return s.hot.PutMany(ctx, blks)
+}
+
+func (s *SplitStore) DeleteBlock(ctx context.Context, c cid.Cid) error {
+	if err := s.hot.DeleteBlock(ctx, c); err != nil {
+		return err
+	}
+	if s.cold != nil {
+		return s.cold.DeleteBlock(ctx, c)
+	}
+	return nil
+}
+
+func (s *SplitStore) DeleteMany(ctx context.Context, cids []cid.Cid) error {
+	if err := s.hot.DeleteMany(ctx, cids); err != nil {
+		return err
+	}
+	if s.cold != nil {
+		return s.cold.DeleteMany(ctx, cids)
+	}
+	return nil
+}
+
+// AllKeysChan returns the keys of the hot store followed by the keys of the cold store.
+func (s *SplitStore) AllKeysChan(ctx context.Context) (<-chan cid.Cid, error) {
+	hotCh, err := s.hot.AllKeysChan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.cold == nil {
+		return hotCh, nil
+	}
+
+	coldCh, err := s.cold.AllKeysChan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan cid.Cid)
+	go func() {
+		defer close(ch)
+		for _, in := range []<-chan cid.Cid{hotCh, coldCh} {
+			for c := range in {
+				select {
+				case ch <- c:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+func (s *SplitStore) HashOnRead(enabled bool) {
+	s.hot.HashOnRead(enabled)
+	if s.cold != nil {
+		s.cold.HashOnRead(enabled)
+	}
+}
+
+func (s *SplitStore) Flush(ctx context.Context) error {
+	if err := s.hot.Flush(ctx); err != nil {
+		return err
+	}
+	if s.cold != nil {
+		return s.cold.Flush(ctx)
+	}
+	return nil
+}
+
+// Close closes the hot and cold stores if they can be closed.
+func (s *SplitStore) Close() error {
+	for _, bs := range []Blockstore{s.hot, s.cold} {
+		if closer, ok := bs.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package blockstore
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBlocks(n int) []blocks.Block {
+	out := make([]blocks.Block, n)
+	for i := range out {
+		out[i] = blocks.NewBlock([]byte(fmt.Sprintf("block %d", i)))
+	}
+	return out
+}
+
+func markBlocks(blks ...blocks.Block) MarkFunc {
+	return func(ctx context.Context, visit func(cid.Cid) error) error {
+		for _, blk := range blks {
+			if err := visit(blk.Cid()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func TestSplitStoreCompactKeepCold(t *testing.T) {
+	ctx := context.Background()
+	hot, cold := NewMemory(), NewMemory()
+	markSetDir := t.TempDir()
+	ss, err := NewSplitStore(hot, cold, true, markSetDir)
+	require.NoError(t, err)
+
+	blks := newTestBlocks(4)
+	require.NoError(t, ss.PutMany(ctx, blks))
+
+	require.NoError(t, ss.Compact(ctx, markBlocks(blks[0], blks[1])))
+
+	for i, blk := range blks {
+		inHot, err := hot.Has(ctx, blk.Cid())
+		require.NoError(t, err)
+		inCold, err := cold.Has(ctx, blk.Cid())
+		require.NoError(t, err)
+		require.Equal(t, i < 2, inHot)
+		require.Equal(t, i >= 2, inCold)
+
+		// reads fall through to the cold store
+		got, err := ss.Get(ctx, blk.Cid())
+		require.NoError(t, err)
+		require.Equal(t, blk.RawData(), got.RawData())
+	}
+
+	progress := ss.Progress()
+	require.Equal(t, CompactionIdle, progress.Stage)
+	require.EqualValues(t, 2, progress.Marked)
+	require.EqualValues(t, 4, progress.Scanned)
+	require.EqualValues(t, 2, progress.Moved)
+	require.EqualValues(t, 2, progress.Removed)
+	require.Positive(t, progress.MarkSetSize)
+
+	// the mark set is removed once the compaction is done
+	entries, err := os.ReadDir(markSetDir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestSplitStoreCompactDiscard(t *testing.T) {
+	ctx := context.Background()
+	hot := NewMemory()
+	ss, err := NewSplitStore(hot, nil, false, t.TempDir())
+	require.NoError(t, err)
+
+	blks := newTestBlocks(3)
+	require.NoError(t, ss.PutMany(ctx, blks))
+
+	require.NoError(t, ss.Compact(ctx, markBlocks(blks[0])))
+
+	_, err = ss.Get(ctx, blks[0].Cid())
+	require.NoError(t, err)
+	for _, blk := range blks[1:] {
+		_, err := ss.Get(ctx, blk.Cid())
+		require.True(t, ipld.IsNotFound(err))
+	}
+}
+
+func TestSplitStoreProtectsWrites(t *testing.T) {
+	ctx := context.Background()
+	ss, err := NewSplitStore(NewMemory(), nil, false, t.TempDir())
+	require.NoError(t, err)
+
+	blks := newTestBlocks(2)
+	require.NoError(t, ss.Put(ctx, blks[0]))
+
+	// blks[1] is written while marking, it is not reachable from the mark function
+	// but must survive the compaction.
+	err = ss.Compact(ctx, func(ctx context.Context, visit func(cid.Cid) error) error {
+		require.Equal(t, ErrCompactionInProgress, ss.Compact(ctx, markBlocks()))
+		return ss.Put(ctx, blks[1])
+	})
+	require.NoError(t, err)
+
+	has, err := ss.Has(ctx, blks[0].Cid())
+	require.NoError(t, err)
+	require.False(t, has)
+
+	has, err = ss.Has(ctx, blks[1].Cid())
+	require.NoError(t, err)
+	require.True(t, has)
+}
@@ -65,3 +65,35 @@ type ActorEvent struct {
 	// CID of message that produced this event.
 	MsgCid cid.Cid `json:"msgCid"`
 }
+
+// ActorEventCursor is the position of a client of a resumable actor event subscription.
+//
+// When Reverted is false, the client applied the events of the tipset up to EventIdx. When Reverted is true,
+// the client reverted the events of the tipset from the last one it applied down to EventIdx, the ones
+// before it remain applied: the events of a tipset are always reverted from the last one.
+type ActorEventCursor struct {
+	// The tipset that contained the messages that produced the events.
+	TipSetKey TipSetKey `json:"tipsetKey"`
+
+	// Index of the event within all the events emitted by the messages of the tipset.
+	EventIdx int `json:"eventIdx"`
+
+	Reverted bool `json:"reverted,omitempty"`
+}
+
+type ActorEventNotificationType string
+
+const (
+	ActorEventApplied  ActorEventNotificationType = "applied"
+	ActorEventReverted ActorEventNotificationType = "reverted"
+)
+
+type ActorEventNotification struct {
+	// Whether the event was applied or reverted because of a network re-org.
+	Type ActorEventNotificationType `json:"type"`
+
+	Event *ActorEvent `json:"event"`
+
+	// The position of the client once it handled this notification, to resume the subscription from.
+	Cursor ActorEventCursor `json:"cursor"`
+}
@@ -292,6 +292,29 @@ type MessageSendSpec struct {
 	MaxFee            abi.TokenAmount
 	GasOverEstimation float64
 	GasOverPremium    float64
+	// FeePolicy selects how the gas premium and fee cap are estimated: default, urgent, economy or
+	// percentile:<N>[:<epochs>], the default policy is used when it is empty.
+	FeePolicy string `json:",omitempty"`
+}
+
+// FeeHistory is the history of the base fee and the gas premiums of the messages included in recent tipsets.
+type FeeHistory struct {
+	Percentiles []float64
+	// Tipsets are the tipsets of the history, oldest first.
+	Tipsets []FeeHistoryTipSet
+	// Premiums are the percentiles of the gas premiums over all the tipsets of the history.
+	Premiums []big.Int
+}
+
+// FeeHistoryTipSet is the fee history of a tipset.
+type FeeHistoryTipSet struct {
+	Epoch         abi.ChainEpoch
+	ParentBaseFee big.Int
+	// GasLimit is the gas limit of the messages included in the tipset.
+	GasLimit int64
+	// Premiums are the percentiles of the gas premiums of the messages included in the tipset, weighted
+	// by their gas limit. They are zero when the tipset has no messages.
+	Premiums []big.Int
 }
 
 // Version provides various build-time information
@@ -0,0 +1,44 @@
+package types
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+)
+
+// BlockstoreCheckParams are the parameters of a blockstore integrity check.
+type BlockstoreCheckParams struct {
+	// TipSetKey is the tipset to walk the chain from, the head when empty.
+	TipSetKey TipSetKey
+	// Epochs is the number of epochs of headers and messages checked below the tipset, 0 walks
+	// to genesis.
+	Epochs abi.ChainEpoch
+	// RecentStateRoots is the number of recent epochs whose state trees and receipts are checked,
+	// 0 only checks the genesis state when it is reached.
+	RecentStateRoots abi.ChainEpoch
+	// Repair refetches the missing or corrupt headers and messages from peers.
+	Repair bool
+}
+
+// BlockstoreFault is an object the blockstore check found missing or corrupt.
+type BlockstoreFault struct {
+	Cid cid.Cid
+	// Object is the kind of object the block belongs to: header, messages, receipts or state.
+	Object string
+	// Epoch is the height of the header the object was reached from.
+	Epoch abi.ChainEpoch
+	// Corrupt is set when the block does not hash to its cid, the block is missing otherwise.
+	Corrupt  bool
+	Repaired bool
+	// Error is the error reading the block, or the reason the repair failed.
+	Error string `json:",omitempty"`
+}
+
+// BlockstoreCheckResult reports the result of a blockstore integrity check.
+type BlockstoreCheckResult struct {
+	TipSetKey TipSetKey
+	Height    abi.ChainEpoch
+	// LowestEpoch is the lowest epoch reached by the walk of the chain.
+	LowestEpoch   abi.ChainEpoch
+	BlocksChecked int64
+	Faults        []BlockstoreFault
+}
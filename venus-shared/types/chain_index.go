@@ -0,0 +1,18 @@
+package types
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// IndexValidation is the result of validating the chain index at an epoch.
+type IndexValidation struct {
+	// TipSetKey is the key of the canonical tipset at Height, it is empty for a null round.
+	TipSetKey TipSetKey
+	Height    abi.ChainEpoch
+
+	IndexedMessagesCount uint64
+	// Backfilled is true when the tipset was missing in the index, or didn't match the chain, and has been
+	// re-indexed by the validation.
+	Backfilled  bool
+	IsNullRound bool
+}
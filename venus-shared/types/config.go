@@ -0,0 +1,25 @@
+package types
+
+import "encoding/json"
+
+// ConfigSetResult reports how the fields changed by a config update take effect, the fields are listed by
+// their dotted keys, e.g. mpool.maxFee.
+type ConfigSetResult struct {
+	// Changed are the fields whose values were changed by the update
+	Changed []string
+	// Applied are the changed fields which the node applied without a restart
+	Applied []string
+	// RestartRequired are the changed fields which only take effect after the node restarts
+	RestartRequired []string
+}
+
+// ConfigChange is a field of the config file whose value differs from the one the node runs with, it takes
+// effect after the node restarts.
+type ConfigChange struct {
+	// Key is the dotted key of the field
+	Key string
+	// Running is the json value the node runs with, it's empty when the field was added to the config
+	Running json.RawMessage
+	// Current is the json value of the config file, it's empty when the field was removed from the config
+	Current json.RawMessage
+}
@@ -6,13 +6,16 @@ import (
 )
 
 const (
-	EthAddressLength = types.EthAddressLength
-	EthBloomSize     = types.EthBloomSize
-	EthHashLength    = types.EthHashLength
-	SafeEpochDelay   = types.SafeEpochDelay
+	EthAddressLength  = types.EthAddressLength
+	EthBloomSize      = types.EthBloomSize
+	EthCallTracer     = types.EthCallTracer
+	EthHashLength     = types.EthHashLength
+	EthPrestateTracer = types.EthPrestateTracer
+	SafeEpochDelay    = types.SafeEpochDelay
 )
 
 var (
+	EmptyCodeHash     = types.EmptyCodeHash
 	EmptyEthBloom     = types.EmptyEthBloom
 	EmptyEthHash      = types.EmptyEthHash
 	EmptyEthInt       = types.EmptyEthInt
@@ -25,17 +28,25 @@ var (
 )
 
 type (
+	EthAccountOverride             = types.EthAccountOverride
 	EthAddress                     = types.EthAddress
 	EthAddressList                 = types.EthAddressList
 	EthBigInt                      = types.EthBigInt
 	EthBlock                       = types.EthBlock
 	EthBlockNumberOrHash           = types.EthBlockNumberOrHash
+	EthBlockStateCalls             = types.EthBlockStateCalls
 	EthBytes                       = types.EthBytes
 	EthCall                        = types.EthCall
+	EthCallFrame                   = types.EthCallFrame
 	EthCallTraceAction             = types.EthCallTraceAction
 	EthCallTraceResult             = types.EthCallTraceResult
 	EthCreateTraceAction           = types.EthCreateTraceAction
 	EthCreateTraceResult           = types.EthCreateTraceResult
+	EthDebugTrace                  = types.EthDebugTrace
+	EthDebugTraceBlockParams       = types.EthDebugTraceBlockParams
+	EthDebugTraceBlockResult       = types.EthDebugTraceBlockResult
+	EthDebugTraceCallParams        = types.EthDebugTraceCallParams
+	EthDebugTraceTransactionParams = types.EthDebugTraceTransactionParams
 	EthEstimateGasParams           = types.EthEstimateGasParams
 	EthFeeHistory                  = types.EthFeeHistory
 	EthFeeHistoryParams            = types.EthFeeHistoryParams
@@ -46,6 +57,17 @@ type (
 	EthHashList                    = types.EthHashList
 	EthLog                         = types.EthLog
 	EthNonce                       = types.EthNonce
+	EthPrestate                    = types.EthPrestate
+	EthPrestateAccount             = types.EthPrestateAccount
+	EthPrestateDiff                = types.EthPrestateDiff
+	EthProof                       = types.EthProof
+	EthSimulateError               = types.EthSimulateError
+	EthSimulateParams              = types.EthSimulateParams
+	EthSimulatePayload             = types.EthSimulatePayload
+	EthSimulatedBlock              = types.EthSimulatedBlock
+	EthSimulatedCall               = types.EthSimulatedCall
+	EthStateOverride               = types.EthStateOverride
+	EthStorageProof                = types.EthStorageProof
 	EthSubscribeParams             = types.EthSubscribeParams
 	EthSubscriptionID              = types.EthSubscriptionID
 	EthSubscriptionParams          = types.EthSubscriptionParams
@@ -54,10 +76,18 @@ type (
 	EthTopicSpec                   = types.EthTopicSpec
 	EthTrace                       = types.EthTrace
 	EthTraceBlock                  = types.EthTraceBlock
+	EthTraceCallParams             = types.EthTraceCallParams
+	EthTraceConfig                 = types.EthTraceConfig
 	EthTraceFilterCriteria         = types.EthTraceFilterCriteria
 	EthTraceFilterResult           = types.EthTraceFilterResult
 	EthTraceReplayBlockTransaction = types.EthTraceReplayBlockTransaction
+	EthTraceResults                = types.EthTraceResults
 	EthTraceTransaction            = types.EthTraceTransaction
+	EthTracerConfig                = types.EthTracerConfig
+	EthTxPoolContent               = types.EthTxPoolContent
+	EthTxPoolContentFrom           = types.EthTxPoolContentFrom
+	EthTxPoolInspect               = types.EthTxPoolInspect
+	EthTxPoolStatus                = types.EthTxPoolStatus
 	EthTxReceipt                   = types.EthTxReceipt
 	EthUint64                      = types.EthUint64
 )
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/ipfs/go-cid"
 )
 
@@ -52,3 +54,23 @@ type MpoolUpdate struct {
 	Type    MpoolChange
 	Message *SignedMessage
 }
+
+// MpoolFeeBumpStatus is the status of the replacement of the stuck local messages.
+type MpoolFeeBumpStatus struct {
+	Enabled bool
+	// Messages are the local pending messages tracked.
+	Messages []MpoolFeeBump
+}
+
+// MpoolFeeBump is the fee bump status of a local pending message.
+type MpoolFeeBump struct {
+	From  address.Address
+	Nonce uint64
+	// Cid is the cid of the pending message, the one of the last replacement when its fee was bumped.
+	Cid          cid.Cid
+	PendingSince abi.ChainEpoch
+	Bumps        int
+	LastBump     abi.ChainEpoch
+	// Error is why the fee of the message could not be bumped the last time it was stuck.
+	Error string `json:",omitempty"`
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
 )
 
 type MpoolConfig struct {
@@ -13,4 +14,23 @@ type MpoolConfig struct {
 	ReplaceByFeeRatio      Percent
 	PruneCooldown          time.Duration
 	GasLimitOverestimation float64
+	PriorityLanes          []MpoolPriorityLane
+}
+
+// MpoolPriorityLane reserves a share of the block gas for the messages calling some methods of an actor or sent
+// from some senders, a message must match both when both are set. The lanes are selected in order, after the messages
+// of the priority addresses.
+type MpoolPriorityLane struct {
+	Name string
+	// Methods are the methods called by the messages of the lane, they are methods of Actor
+	Methods []abi.MethodNum
+	// Actor is the name of the builtin actor receiving the messages of the lane, e.g. storageminer, it is
+	// required with Methods
+	Actor string
+	// Senders are the senders of the messages of the lane
+	Senders []address.Address
+	// MinPremium is the minimum gas premium of the messages of the lane
+	MinPremium BigInt
+	// MaxShare is the maximum share of the block gas limit taken by the lane, in (0, 1]
+	MaxShare float64
 }
@@ -40,6 +40,9 @@ const (
 	MTVerifyAddress = MsgType("verifyaddress")
 
 	MTF3 = MsgType("f3")
+
+	// Signing the bytes of MsgMeta.Extra prefixed by SignedMessageBytes, to prove the ownership of an address
+	MTMessageBytes = MsgType("messagebytes")
 )
 
 type MsgMeta struct {
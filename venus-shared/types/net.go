@@ -29,3 +29,29 @@ type NatInfo struct {
 	Reachability network.Reachability
 	PublicAddrs  []string
 }
+
+// NetBlockList is a set of peers, IP addresses and CIDR subnets the connection gater refuses.
+type NetBlockList struct {
+	Peers     []peer.ID
+	IPAddrs   []string
+	IPSubnets []string
+}
+
+// NetStat is the resource usage of the scopes of the libp2p resource manager, only the scopes
+// that were queried are set.
+type NetStat struct {
+	System    *network.ScopeStat           `json:",omitempty"`
+	Transient *network.ScopeStat           `json:",omitempty"`
+	Services  map[string]network.ScopeStat `json:",omitempty"`
+	Protocols map[string]network.ScopeStat `json:",omitempty"`
+	Peers     map[string]network.ScopeStat `json:",omitempty"`
+}
+
+// NetLimit is the limit of a scope of the libp2p resource manager.
+type NetLimit struct {
+	Memory int64 `json:",omitempty"`
+
+	Streams, StreamsInbound, StreamsOutbound int
+	Conns, ConnsInbound, ConnsOutbound       int
+	FD                                       int
+}
@@ -0,0 +1,30 @@
+package types
+
+import (
+	"strconv"
+
+	"github.com/filecoin-project/go-address"
+)
+
+const (
+	// FilecoinSignedMessagePrefix separates the messages signed off chain from the other data signed by
+	// the wallets, see FRC-0102
+	FilecoinSignedMessagePrefix = "\x19Filecoin Signed Message:\n"
+	// EthereumSignedMessagePrefix is the prefix of the messages signed with personal_sign, see EIP-191
+	EthereumSignedMessagePrefix = "\x19Ethereum Signed Message:\n"
+)
+
+// SignedMessageBytes returns the bytes signed for msg by a key of protocol: the message is prefixed with
+// its length and a prefix which no chain message, block or other data signed by a wallet starts with. The
+// delegated keys use the prefix of EIP-191, their signatures are the ones of personal_sign.
+func SignedMessageBytes(protocol address.Protocol, msg []byte) []byte {
+	prefix := FilecoinSignedMessagePrefix
+	if protocol == address.Delegated {
+		prefix = EthereumSignedMessagePrefix
+	}
+
+	out := make([]byte, 0, len(prefix)+20+len(msg))
+	out = append(out, prefix...)
+	out = strconv.AppendInt(out, int64(len(msg)), 10)
+	return append(out, msg...)
+}
@@ -0,0 +1,20 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/venus/pkg/testhelpers/testflags"
+)
+
+func TestSignedMessageBytes(t *testing.T) {
+	tf.UnitTest(t)
+
+	msg := []byte("login 1234")
+	require.Equal(t, "\x19Filecoin Signed Message:\n10login 1234", string(SignedMessageBytes(address.SECP256K1, msg)))
+	require.Equal(t, "\x19Filecoin Signed Message:\n10login 1234", string(SignedMessageBytes(address.BLS, msg)))
+	require.Equal(t, "\x19Ethereum Signed Message:\n10login 1234", string(SignedMessageBytes(address.Delegated, msg)))
+	require.Equal(t, "\x19Filecoin Signed Message:\n0", string(SignedMessageBytes(address.SECP256K1, nil)))
+}
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// ExportJobParams are the parameters of a background snapshot export.
+type ExportJobParams struct {
+	// Path is the file on the node the snapshot is written to.
+	Path string
+	// TipSetKey is the tipset to export from, the head when empty.
+	TipSetKey TipSetKey
+	// RecentStateRoots is the number of recent state roots included in the snapshot, 0 only
+	// includes the genesis state.
+	RecentStateRoots abi.ChainEpoch
+	SkipOldMsgs      bool
+	// Compress writes a zstd compressed CAR file.
+	Compress bool
+	// Workers is the number of goroutines walking the chain DAG, 0 walks it with a single one.
+	Workers int
+}
+
+// ExportJobStatus reports the progress of the running, or last, snapshot export.
+type ExportJobStatus struct {
+	Running    bool
+	Path       string
+	Compressed bool
+	TipSetKey  TipSetKey
+	// Height is the epoch of the exported tipset.
+	Height           abi.ChainEpoch
+	RecentStateRoots abi.ChainEpoch
+	// CurrentEpoch is the lowest epoch reached by the walk of the chain.
+	CurrentEpoch abi.ChainEpoch
+	StartTime    time.Time
+	EndTime      time.Time
+
+	BlocksWritten int64
+	// BytesWritten is the size of the snapshot file, after compression.
+	BytesWritten int64
+
+	Canceled  bool
+	LastError string
+}
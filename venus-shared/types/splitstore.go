@@ -0,0 +1,43 @@
+package types
+
+import (
+	"time"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// PruneOpts are the options of a manually requested chain prune.
+type PruneOpts struct {
+	// RetainFinalities overrides the configured number of finality windows kept in the
+	// hot store, 0 uses the configured value.
+	RetainFinalities int64
+	// Wait makes the prune return once the compaction finished, cancelling the request then
+	// cancels the compaction.
+	Wait bool
+}
+
+// PruneStatus reports the state of the splitstore compaction.
+type PruneStatus struct {
+	// Enabled is false when the node does not run a splitstore.
+	Enabled bool
+	Mode    string
+	// Compacting is true while a compaction is running.
+	Compacting bool
+	// Stage is one of idle, marking, sweeping or gc.
+	Stage string
+	// BaseEpoch is the head epoch the running, or last, compaction started from.
+	BaseEpoch abi.ChainEpoch
+	// Boundary is the epoch below which state and messages are swept out of the hot store.
+	Boundary  abi.ChainEpoch
+	StartTime time.Time
+	EndTime   time.Time
+
+	Marked  int64
+	Scanned int64
+	Moved   int64
+	Removed int64
+	// MarkSetSize is the size on disk of the objects marked by the compaction, in bytes.
+	MarkSetSize int64
+
+	LastError string
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/filecoin-project/go-address"
 	cborutil "github.com/filecoin-project/go-cbor-util"
 	"github.com/filecoin-project/go-fil-markets/storagemarket"
 	"github.com/filecoin-project/go-fil-markets/storagemarket/migrations"
@@ -192,6 +193,21 @@ var SupportedMsgTypes = map[types.MsgType]*Types{
 			return nil, fmt.Errorf("un-expected MsgType:%s", meta.Type)
 		},
 	},
+	// 'toSign' is `meta.Extra` prefixed by types.SignedMessageBytes, the prefix of the ethereum wallets for
+	// the delegated addresses and the one of filecoin for the others
+	types.MTMessageBytes: {
+		Type: reflect.TypeOf([]byte{}),
+		SignBytes: func(in interface{}) ([]byte, error) {
+			return in.([]byte), nil
+		},
+		ParseObj: func(in []byte, meta types.MsgMeta) (interface{}, error) {
+			if !bytes.Equal(in, types.SignedMessageBytes(address.SECP256K1, meta.Extra)) &&
+				!bytes.Equal(in, types.SignedMessageBytes(address.Delegated, meta.Extra)) {
+				return nil, fmt.Errorf("sign data isn't the prefixed message")
+			}
+			return in, nil
+		},
+	},
 }
 
 // GetSignBytesAndObj Matches the type and returns the data that needs to be signed
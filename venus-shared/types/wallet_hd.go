@@ -0,0 +1,12 @@
+package types
+
+import "github.com/filecoin-project/go-address"
+
+// WalletHDAddress is an address derived by the HD wallet
+type WalletHDAddress struct {
+	Address address.Address
+	// Path is the BIP-44 derivation path of the key
+	Path string
+	// Mnemonic is only returned once, when a new seed is created for the HD wallet
+	Mnemonic string `json:",omitempty"`
+}
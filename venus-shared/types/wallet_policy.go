@@ -0,0 +1,33 @@
+package types
+
+import (
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// WalletPolicy holds the rules the messages signed by an address must follow, the rules left empty don't
+// restrict anything.
+type WalletPolicy struct {
+	Address address.Address
+	// AllowedTo are the receivers the messages may be sent to, an ID address and the robust address of the same
+	// actor are the same receiver
+	AllowedTo []address.Address
+	// AllowedActors are the actor types of the receivers, e.g. account, multisig or storageminer
+	AllowedActors []string
+	// AllowedMethods are the methods the messages may call
+	AllowedMethods []abi.MethodNum
+	// MaxValue caps the value of a message
+	MaxValue *abi.TokenAmount `json:",omitempty"`
+	// MaxDailyValue caps the value of the messages signed over the last 24 hours
+	MaxDailyValue *abi.TokenAmount `json:",omitempty"`
+	// MaxFee caps the fee of a message, GasFeeCap * GasLimit
+	MaxFee *abi.TokenAmount `json:",omitempty"`
+	// MaxGasFeeCap caps the GasFeeCap of a message
+	MaxGasFeeCap *abi.TokenAmount `json:",omitempty"`
+	// AllowRawBytes allows signing the bytes of unknown type, which the policy can't check
+	AllowRawBytes bool
+	// AllowedTypes are the other types of data the address may sign, e.g. block or signedvoucher, which the
+	// policy can't check. The chain messages are always checked against the policy, the prefixed messages
+	// proving the ownership of the address are always allowed, the other types not listed are denied.
+	AllowedTypes []MsgType
+}
@@ -0,0 +1,297 @@
+// Package ethproof builds and verifies the proofs returned by eth_getProof.
+//
+// The state of Filecoin isn't a Merkle-Patricia trie, so a proof is the list of the IPLD blocks read while
+// looking up the actor from the state root, and the slots from the storage root of the contract. Verifying a
+// proof runs the same lookups over the blocks of the proof only, the CIDs of the blocks being derived from
+// their content, a proof which doesn't match the state root fails to load.
+package ethproof
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	gstbuiltin "github.com/filecoin-project/go-state-types/builtin"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	mh "github.com/multiformats/go-multihash"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/venus/venus-shared/actors/adt"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	builtinevm "github.com/filecoin-project/venus/venus-shared/actors/builtin/evm"
+	builtininit "github.com/filecoin-project/venus/venus-shared/actors/builtin/init"
+	"github.com/filecoin-project/venus/venus-shared/actors/types"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+)
+
+// minStateTreeVersion is the first version of the state tree with delegated addresses
+const minStateTreeVersion = 5
+
+// blockPrefix is the prefix of the CIDs of the state blocks
+var blockPrefix = cid.Prefix{
+	Version:  1,
+	Codec:    cid.DagCBOR,
+	MhType:   uint64(mh.BLAKE2B_MIN + 31),
+	MhLength: 32,
+}
+
+// Account is the state of an account as seen by eth_getProof
+type Account struct {
+	Balance  types.EthBigInt
+	Nonce    types.EthUint64
+	CodeHash types.EthHash
+	// StorageRoot is the root of the storage of the contract, undefined when the account isn't a contract
+	StorageRoot cid.Cid
+}
+
+// StorageHash returns the hash of the storage root, EmptyRootHash when the account isn't a contract
+func (a *Account) StorageHash() (types.EthHash, error) {
+	if !a.StorageRoot.Defined() {
+		return types.EmptyRootHash, nil
+	}
+	return types.EthHashFromCid(a.StorageRoot)
+}
+
+// ReadAccount reads the account addr in the state stateRoot. An account which doesn't exist is returned
+// with a zero balance, nonce and code hash.
+func ReadAccount(ctx context.Context, store ipldcbor.IpldStore, stateRoot cid.Cid, addr types.EthAddress) (*Account, error) {
+	adtStore := adt.WrapStore(ctx, store)
+
+	var root stateRootHeader
+	if err := store.Get(ctx, stateRoot, &root); err != nil {
+		return nil, fmt.Errorf("failed to load state root %s: %w", stateRoot, err)
+	}
+	if root.version < minStateTreeVersion {
+		return nil, fmt.Errorf("unsupported state tree version %d", root.version)
+	}
+	tree, err := gstbuiltin.LoadTree(adtStore, root.actors)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load actors tree: %w", err)
+	}
+
+	empty := &Account{Balance: types.EthBigIntZero}
+	filAddr, err := addr.ToFilecoinAddress()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get Filecoin address: %w", err)
+	}
+	if filAddr.Protocol() != address.ID {
+		initActor, found, err := tree.GetActorV5(builtin.InitActorAddr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load init actor: %w", err)
+		}
+		if !found {
+			return nil, fmt.Errorf("init actor not found")
+		}
+		initState, err := builtininit.Load(adtStore, &types.Actor{Code: initActor.Code, Head: initActor.Head})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load init actor state: %w", err)
+		}
+		if filAddr, found, err = initState.ResolveAddress(filAddr); err != nil {
+			return nil, fmt.Errorf("failed to resolve address %s: %w", addr, err)
+		} else if !found {
+			return empty, nil
+		}
+	}
+
+	actor, found, err := tree.GetActorV5(filAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load actor %s: %w", filAddr, err)
+	}
+	if !found {
+		return empty, nil
+	}
+
+	account := &Account{
+		Balance:  types.EthBigInt(actor.Balance),
+		Nonce:    types.EthUint64(actor.CallSeqNum),
+		CodeHash: types.EmptyCodeHash,
+	}
+	if !builtin.IsEvmActor(actor.Code) {
+		return account, nil
+	}
+
+	evmState, err := builtinevm.Load(adtStore, &types.Actor{Code: actor.Code, Head: actor.Head})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load evm state: %w", err)
+	}
+	nonce, err := evmState.Nonce()
+	if err != nil {
+		return nil, err
+	}
+	codeHash, err := evmState.GetBytecodeHash()
+	if err != nil {
+		return nil, err
+	}
+	if account.StorageRoot, err = evmState.GetContractStateCID(); err != nil {
+		return nil, err
+	}
+	account.Nonce = types.EthUint64(nonce)
+	account.CodeHash = codeHash
+
+	return account, nil
+}
+
+// Prove returns the proof of the account addr and of the storage slots keys in the state stateRoot.
+func Prove(ctx context.Context, bs ipldcbor.IpldBlockstore, stateRoot cid.Cid, addr types.EthAddress, keys []types.EthHash) (*types.EthProof, error) {
+	rec := newRecorder(bs)
+	account, err := ReadAccount(ctx, ipldcbor.NewCborStore(rec), stateRoot, addr)
+	if err != nil {
+		return nil, err
+	}
+	storageHash, err := account.StorageHash()
+	if err != nil {
+		return nil, err
+	}
+
+	proof := &types.EthProof{
+		Address:      addr,
+		Balance:      account.Balance,
+		Nonce:        account.Nonce,
+		CodeHash:     account.CodeHash,
+		StorageHash:  storageHash,
+		AccountProof: rec.blocks,
+		StorageProof: make([]types.EthStorageProof, 0, len(keys)),
+	}
+	for _, key := range keys {
+		storageProof := types.EthStorageProof{Key: key, Value: types.EthBigIntZero, Proof: []types.EthBytes{}}
+		if account.StorageRoot.Defined() {
+			// each slot is proven on its own, from the storage root
+			rec := newRecorder(bs)
+			value, _, err := builtinevm.GetStorage(ctx, ipldcbor.NewCborStore(rec), account.StorageRoot, key)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read storage slot %s: %w", key, err)
+			}
+			storageProof.Value = slotValue(value)
+			storageProof.Proof = rec.blocks
+		}
+		proof.StorageProof = append(proof.StorageProof, storageProof)
+	}
+
+	return proof, nil
+}
+
+// Verify checks proof against the state root stateRoot, which is usually the parent state of the tipset
+// the proof was requested at.
+func Verify(ctx context.Context, stateRoot cid.Cid, proof *types.EthProof) error {
+	accountStore, err := proofStore(proof.AccountProof)
+	if err != nil {
+		return fmt.Errorf("invalid account proof: %w", err)
+	}
+	account, err := ReadAccount(ctx, accountStore, stateRoot, proof.Address)
+	if err != nil {
+		return fmt.Errorf("invalid account proof: %w", err)
+	}
+	storageHash, err := account.StorageHash()
+	if err != nil {
+		return err
+	}
+	switch {
+	case !big.Int(account.Balance).Equals(big.Int(proof.Balance)):
+		return fmt.Errorf("balance mismatch: proven %s, claimed %s", account.Balance, proof.Balance)
+	case account.Nonce != proof.Nonce:
+		return fmt.Errorf("nonce mismatch: proven %d, claimed %d", account.Nonce, proof.Nonce)
+	case account.CodeHash != proof.CodeHash:
+		return fmt.Errorf("code hash mismatch: proven %s, claimed %s", account.CodeHash, proof.CodeHash)
+	case storageHash != proof.StorageHash:
+		return fmt.Errorf("storage hash mismatch: proven %s, claimed %s", storageHash, proof.StorageHash)
+	}
+
+	for _, storageProof := range proof.StorageProof {
+		value := types.EthHash{}
+		if account.StorageRoot.Defined() {
+			storageStore, err := proofStore(storageProof.Proof)
+			if err != nil {
+				return fmt.Errorf("invalid proof of slot %s: %w", storageProof.Key, err)
+			}
+			if value, _, err = builtinevm.GetStorage(ctx, storageStore, account.StorageRoot, storageProof.Key); err != nil {
+				return fmt.Errorf("invalid proof of slot %s: %w", storageProof.Key, err)
+			}
+		}
+		if proven := slotValue(value); !big.Int(proven).Equals(big.Int(storageProof.Value)) {
+			return fmt.Errorf("value mismatch for slot %s: proven %s, claimed %s", storageProof.Key, proven, storageProof.Value)
+		}
+	}
+
+	return nil
+}
+
+func slotValue(value types.EthHash) types.EthBigInt {
+	return types.EthBigInt(types.BigFromBytes(value[:]))
+}
+
+// proofStore returns a store holding the blocks of a proof
+func proofStore(proof []types.EthBytes) (ipldcbor.IpldStore, error) {
+	bs := blockstoreutil.NewMemory()
+	for _, data := range proof {
+		c, err := blockPrefix.Sum(data)
+		if err != nil {
+			return nil, err
+		}
+		blk, err := blocks.NewBlockWithCid(data, c)
+		if err != nil {
+			return nil, err
+		}
+		if err := bs.Put(context.Background(), blk); err != nil {
+			return nil, err
+		}
+	}
+	return ipldcbor.NewCborStore(bs), nil
+}
+
+// recorder records the blocks read from a blockstore, each block is recorded once
+type recorder struct {
+	bs     ipldcbor.IpldBlockstore
+	seen   map[cid.Cid]struct{}
+	blocks []types.EthBytes
+}
+
+func newRecorder(bs ipldcbor.IpldBlockstore) *recorder {
+	return &recorder{bs: bs, seen: make(map[cid.Cid]struct{}), blocks: []types.EthBytes{}}
+}
+
+func (r *recorder) Get(ctx context.Context, c cid.Cid) (blocks.Block, error) {
+	blk, err := r.bs.Get(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := r.seen[c]; !ok {
+		r.seen[c] = struct{}{}
+		r.blocks = append(r.blocks, blk.RawData())
+	}
+	return blk, nil
+}
+
+func (r *recorder) Put(context.Context, blocks.Block) error {
+	return fmt.Errorf("the state is read only")
+}
+
+// stateRootHeader is the state root, [version, actors, info]
+type stateRootHeader struct {
+	version uint64
+	actors  cid.Cid
+}
+
+func (s *stateRootHeader) UnmarshalCBOR(r io.Reader) error {
+	cr := cbg.NewCborReader(r)
+	maj, extra, err := cr.ReadHeader()
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray || extra != 3 {
+		return fmt.Errorf("expected the state root to be an array of 3 elements")
+	}
+	if maj, s.version, err = cr.ReadHeader(); err != nil {
+		return err
+	} else if maj != cbg.MajUnsignedInt {
+		return fmt.Errorf("expected the version of the state tree")
+	}
+	if s.actors, err = cbg.ReadCid(cr); err != nil {
+		return fmt.Errorf("reading actors root: %w", err)
+	}
+	var info cbg.Deferred
+	return info.UnmarshalCBOR(cr)
+}
@@ -0,0 +1,198 @@
+package ethproof
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+	gstbuiltin "github.com/filecoin-project/go-state-types/builtin"
+	evm16 "github.com/filecoin-project/go-state-types/builtin/v16/evm"
+	init16 "github.com/filecoin-project/go-state-types/builtin/v16/init"
+	"github.com/filecoin-project/go-state-types/manifest"
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/venus/venus-shared/actors"
+	"github.com/filecoin-project/venus/venus-shared/actors/adt"
+	"github.com/filecoin-project/venus/venus-shared/actors/builtin"
+	"github.com/filecoin-project/venus/venus-shared/actors/types"
+	blockstoreutil "github.com/filecoin-project/venus/venus-shared/blockstore"
+)
+
+// rawNode writes its cbor encoding as is
+type rawNode []byte
+
+func (n rawNode) MarshalCBOR(w io.Writer) error {
+	_, err := w.Write(n)
+	return err
+}
+
+type testState struct {
+	bs        blockstoreutil.MemBlockstore
+	root      cid.Cid
+	contract  types.EthAddress
+	account   types.EthAddress
+	slot      types.EthHash
+	slotValue types.EthHash
+}
+
+// newTestState builds a state with a contract holding a single slot and an account
+func newTestState(t *testing.T) *testState {
+	ctx := context.Background()
+	s := &testState{
+		bs:        blockstoreutil.NewMemory(),
+		contract:  types.EthAddress{0: 0xaa, 19: 1},
+		slot:      types.EthHash{31: 1},
+		slotValue: types.EthHash{30: 1, 31: 2},
+	}
+	store := ipldcbor.NewCborStore(s.bs)
+	adtStore := adt.WrapStore(ctx, store)
+	codeID := func(name string) cid.Cid {
+		c, ok := actors.GetActorCodeID(actorstypes.Version16, name)
+		require.True(t, ok)
+		return c
+	}
+
+	tree, err := gstbuiltin.NewTree(adtStore)
+	require.NoError(t, err)
+
+	initState, err := init16.ConstructState(adtStore, "test")
+	require.NoError(t, err)
+	delegated, err := s.contract.ToFilecoinAddress()
+	require.NoError(t, err)
+	contractID, err := initState.MapAddressToNewID(adtStore, delegated)
+	require.NoError(t, err)
+	initHead, err := store.Put(ctx, initState)
+	require.NoError(t, err)
+	require.NoError(t, tree.SetActorV5(builtin.InitActorAddr, &gstbuiltin.ActorV5{Code: codeID(manifest.InitKey), Head: initHead, Balance: big.Zero()}))
+
+	// the storage is a single node holding the slot at the index 0
+	storage := cbg.CborEncodeMajorType(cbg.MajArray, 2)
+	storage = append(storage, cbg.CborEncodeMajorType(cbg.MajByteString, 1)...)
+	storage = append(storage, 1)
+	storage = append(storage, cbg.CborEncodeMajorType(cbg.MajArray, 1)...)
+	storage = append(storage, cbg.CborEncodeMajorType(cbg.MajArray, 1)...)
+	storage = append(storage, cbg.CborEncodeMajorType(cbg.MajArray, 2)...)
+	for _, b := range [][]byte{s.slot[:], s.slotValue[:]} {
+		storage = append(storage, cbg.CborEncodeMajorType(cbg.MajByteString, uint64(len(b)))...)
+		storage = append(storage, b...)
+	}
+	storageRoot, err := store.Put(ctx, rawNode(storage))
+	require.NoError(t, err)
+	evmHead, err := store.Put(ctx, &evm16.State{
+		Bytecode:      types.EthHash{1}.ToCid(),
+		BytecodeHash:  types.EthHash{2},
+		ContractState: storageRoot,
+		Nonce:         3,
+	})
+	require.NoError(t, err)
+	require.NoError(t, tree.SetActorV5(contractID, &gstbuiltin.ActorV5{
+		Code:             codeID(manifest.EvmKey),
+		Head:             evmHead,
+		CallSeqNum:       1,
+		Balance:          big.NewInt(100),
+		DelegatedAddress: &delegated,
+	}))
+
+	accountID, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	s.account, err = types.EthAddressFromFilecoinAddress(accountID)
+	require.NoError(t, err)
+	require.NoError(t, tree.SetActorV5(accountID, &gstbuiltin.ActorV5{
+		Code:       codeID(manifest.AccountKey),
+		Head:       initHead,
+		CallSeqNum: 2,
+		Balance:    big.NewInt(7),
+	}))
+
+	actorsRoot, err := tree.Flush()
+	require.NoError(t, err)
+	info, err := store.Put(ctx, rawNode(cbg.CborEncodeMajorType(cbg.MajArray, 0)))
+	require.NoError(t, err)
+	root := cbg.CborEncodeMajorType(cbg.MajArray, 3)
+	root = append(root, cbg.CborEncodeMajorType(cbg.MajUnsignedInt, 5)...)
+	root = append(root, cidBytes(t, actorsRoot)...)
+	root = append(root, cidBytes(t, info)...)
+	s.root, err = store.Put(ctx, rawNode(root))
+	require.NoError(t, err)
+
+	return s
+}
+
+func cidBytes(t *testing.T, c cid.Cid) []byte {
+	var buf bytesWriter
+	require.NoError(t, cbg.WriteCid(&buf, c))
+	return buf
+}
+
+type bytesWriter []byte
+
+func (w *bytesWriter) Write(b []byte) (int, error) {
+	*w = append(*w, b...)
+	return len(b), nil
+}
+
+func TestProveContract(t *testing.T) {
+	ctx := context.Background()
+	s := newTestState(t)
+	missing := types.EthHash{0: 0x80}
+
+	proof, err := Prove(ctx, s.bs, s.root, s.contract, []types.EthHash{s.slot, missing})
+	require.NoError(t, err)
+	require.Equal(t, types.EthBigInt(big.NewInt(100)), proof.Balance)
+	require.Equal(t, types.EthUint64(3), proof.Nonce)
+	require.Equal(t, types.EthHash{2}, proof.CodeHash)
+	require.NotEqual(t, types.EmptyRootHash, proof.StorageHash)
+	require.Len(t, proof.StorageProof, 2)
+	require.Equal(t, types.EthBigInt(big.NewInt(0x102)), proof.StorageProof[0].Value)
+	require.Len(t, proof.StorageProof[0].Proof, 1)
+	require.Zero(t, proof.StorageProof[1].Value.Sign())
+	require.NoError(t, Verify(ctx, s.root, proof))
+
+	// the proof is checked against the state root
+	require.Error(t, Verify(ctx, types.EthHash{3}.ToCid(), proof))
+
+	tampered := *proof
+	tampered.Balance = types.EthBigInt(big.NewInt(101))
+	require.ErrorContains(t, Verify(ctx, s.root, &tampered), "balance mismatch")
+
+	tampered = *proof
+	tampered.AccountProof = proof.AccountProof[1:]
+	require.ErrorContains(t, Verify(ctx, s.root, &tampered), "invalid account proof")
+
+	tampered = *proof
+	tampered.StorageProof = []types.EthStorageProof{{Key: s.slot, Value: types.EthBigInt(big.NewInt(1)), Proof: proof.StorageProof[0].Proof}}
+	require.ErrorContains(t, Verify(ctx, s.root, &tampered), "value mismatch")
+
+	tampered.StorageProof = []types.EthStorageProof{{Key: s.slot, Value: proof.StorageProof[0].Value}}
+	require.ErrorContains(t, Verify(ctx, s.root, &tampered), "invalid proof of slot")
+}
+
+func TestProveAccount(t *testing.T) {
+	ctx := context.Background()
+	s := newTestState(t)
+
+	proof, err := Prove(ctx, s.bs, s.root, s.account, []types.EthHash{s.slot})
+	require.NoError(t, err)
+	require.Equal(t, types.EthBigInt(big.NewInt(7)), proof.Balance)
+	require.Equal(t, types.EthUint64(2), proof.Nonce)
+	require.Equal(t, types.EmptyCodeHash, proof.CodeHash)
+	require.Equal(t, types.EmptyRootHash, proof.StorageHash)
+	require.Zero(t, proof.StorageProof[0].Value.Sign())
+	require.NoError(t, Verify(ctx, s.root, proof))
+
+	// an account which doesn't exist is proven to be empty
+	proof, err = Prove(ctx, s.bs, s.root, types.EthAddress{0: 0xbb}, nil)
+	require.NoError(t, err)
+	require.Zero(t, proof.Balance.Sign())
+	require.Equal(t, types.EmptyEthHash, proof.CodeHash)
+	require.NoError(t, Verify(ctx, s.root, proof))
+
+	proof.Nonce = 1
+	require.ErrorContains(t, Verify(ctx, s.root, proof), "nonce mismatch")
+}